@@ -47,7 +47,10 @@ func GenerateProtobuf() error {
 	if err := sh.Run("protoc", "-I=.", "--go_out=./pb", "./pb/session.proto"); err != nil {
 		return err
 	}
-	return sh.Run("protoc", "-I=.", "--go_out=./pb", "./pb/payment.proto")
+	if err := sh.Run("protoc", "-I=.", "--go_out=./pb", "./pb/payment.proto"); err != nil {
+		return err
+	}
+	return sh.Run("protoc", "-I=.", "--go_out=./pb", "./pb/price.proto")
 }
 
 // GetProtobuf installs protobuf golang compiler.
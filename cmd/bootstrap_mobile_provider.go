@@ -75,6 +75,12 @@ func (di *Dependencies) bootstrapTequilapi(nodeOptions node.Options, listener ne
 			tequilapi_endpoints.AddRoutesForIdentities(di.IdentityManager, di.IdentitySelector, di.IdentityRegistry, di.ConsumerBalanceTracker, di.AddressProvider, di.HermesChannelRepository, di.BCHelper, di.Transactor, di.BeneficiaryProvider, di.IdentityMover, di.BeneficiaryAddressStorage, di.HermesMigrator),
 			tequilapi_endpoints.AddRoutesForConnection(di.MultiConnectionManager, di.StateKeeper, di.ProposalRepository, di.IdentityRegistry, di.EventBus, di.AddressProvider),
 			tequilapi_endpoints.AddRoutesForSessions(di.SessionStorage),
+			func(e *gin.Engine) error {
+				if di.ServiceSessions == nil {
+					return nil
+				}
+				return tequilapi_endpoints.AddRoutesForSessionPrice(di.ServiceSessions)(e)
+			},
 			tequilapi_endpoints.AddRoutesForConnectionLocation(di.IPResolver, di.LocationResolver, di.LocationResolver),
 			tequilapi_endpoints.AddRoutesForProposals(di.ProposalRepository, di.PricingHelper, di.LocationResolver, di.FilterPresetStorage, di.NATProber),
 			tequilapi_endpoints.AddRoutesForService(di.ServicesManager, services.JSONParsersByType, di.ProposalRepository, tequilaApiClient),
@@ -90,8 +96,10 @@ func (di *Dependencies) bootstrapTequilapi(nodeOptions node.Options, listener ne
 			tequilapi_endpoints.AddRoutesForConnectivityStatus(di.SessionConnectivityStatusStorage),
 			tequilapi_endpoints.AddRoutesForDocs,
 			tequilapi_endpoints.AddRoutesForCurrencyExchange(di.PilvytisAPI),
-			tequilapi_endpoints.AddRoutesForPilvytis(di.PilvytisAPI, di.PilvytisOrderIssuer, di.LocationResolver),
+			tequilapi_endpoints.AddRoutesForPilvytis(di.PilvytisAPI, di.PilvytisOrderIssuer, di.LocationResolver, di.PilvytisAutoTopUp),
 			tequilapi_endpoints.AddRoutesForTerms,
+			tequilapi_endpoints.AddRoutesForBackup(di.NodeBackup),
+			tequilapi_endpoints.AddRoutesForProviderLists(di.ProviderListStorage),
 			tequilapi_endpoints.AddEntertainmentRoutes(entertainment.NewEstimator(
 				config.FlagPaymentPriceGiB.Value,
 				config.FlagPaymentPriceHour.Value,
@@ -0,0 +1,165 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package backup
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/mysteriumnetwork/node/cmd/commands/cli/clio"
+	"github.com/mysteriumnetwork/node/config"
+	"github.com/mysteriumnetwork/node/config/urfavecli/clicontext"
+	"github.com/mysteriumnetwork/node/core/backup"
+	"github.com/mysteriumnetwork/node/core/node"
+	"github.com/mysteriumnetwork/node/tequilapi/contract"
+)
+
+// CommandName is the name of the backup command.
+const CommandName = "backup"
+
+var (
+	flagPassphrase = cli.StringFlag{
+		Name:     "passphrase",
+		Usage:    "Passphrase used to encrypt or decrypt the backup",
+		EnvVars:  []string{"MYST_BACKUP_PASSPHRASE"},
+		Required: true,
+	}
+
+	flagOutput = cli.StringFlag{
+		Name:     "output",
+		Usage:    "File to write the backup to",
+		Required: true,
+	}
+
+	flagInput = cli.StringFlag{
+		Name:     "input",
+		Usage:    "Backup file to restore",
+		Required: true,
+	}
+)
+
+type localBackup interface {
+	Create(passphrase string) ([]byte, backup.Manifest, error)
+	Restore(passphrase string, sealed []byte) (backup.Manifest, error)
+}
+
+type remoteBackup interface {
+	BackupCreate(passphrase string) ([]byte, error)
+	BackupRestore(passphrase string, archive []byte) (contract.BackupManifestDTO, error)
+}
+
+// NewCommand function creates backup command.
+func NewCommand() *cli.Command {
+	remote := func(ctx *cli.Context) func() (remoteBackup, error) {
+		return func() (remoteBackup, error) {
+			return clio.NewTequilApiClient(ctx)
+		}
+	}
+
+	return &cli.Command{
+		Name:        CommandName,
+		Usage:       "Create and restore encrypted node backups",
+		Description: "Backs up identity keys, database with promises and settlement history, user config and UI credentials into a single passphrase encrypted file",
+		Before:      clicontext.LoadUserConfigQuietly,
+		Subcommands: []*cli.Command{
+			{
+				Name:      "create",
+				Usage:     "Create node backup",
+				ArgsUsage: " ",
+				Flags:     []cli.Flag{&flagPassphrase, &flagOutput},
+				Action: func(ctx *cli.Context) error {
+					return create(ctx, newLocalBackup(ctx), remote(ctx))
+				},
+			},
+			{
+				Name:        "restore",
+				Usage:       "Restore node backup",
+				Description: "Restores node state from a backup. A running node restores it on the next start. Backups older than promises held by the node are refused",
+				ArgsUsage:   " ",
+				Flags:       []cli.Flag{&flagPassphrase, &flagInput},
+				Action: func(ctx *cli.Context) error {
+					return restore(ctx, newLocalBackup(ctx), remote(ctx))
+				},
+			},
+		},
+	}
+}
+
+func newLocalBackup(ctx *cli.Context) *backup.Backup {
+	config.ParseFlagsNode(ctx)
+	dirs := node.GetOptions().Directories
+	return backup.NewBackup(backup.Paths{
+		Data:       dirs.Data,
+		Keystore:   dirs.Keystore,
+		Storage:    dirs.Storage,
+		UserConfig: config.Current.UserConfigLocation(),
+	}, nil)
+}
+
+func create(ctx *cli.Context, local localBackup, remote func() (remoteBackup, error)) error {
+	passphrase := ctx.String(flagPassphrase.Name)
+
+	archive, _, err := local.Create(passphrase)
+	if errors.Is(err, backup.ErrNodeRunning) {
+		var api remoteBackup
+		if api, err = remote(); err == nil {
+			archive, err = api.BackupCreate(passphrase)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("could not create backup: %w", err)
+	}
+
+	output := ctx.String(flagOutput.Name)
+	if err := os.WriteFile(output, archive, 0600); err != nil {
+		return fmt.Errorf("could not write backup: %w", err)
+	}
+	fmt.Fprintf(ctx.App.Writer, "Backup written to %s\n", output)
+	return nil
+}
+
+func restore(ctx *cli.Context, local localBackup, remote func() (remoteBackup, error)) error {
+	passphrase := ctx.String(flagPassphrase.Name)
+	archive, err := os.ReadFile(ctx.String(flagInput.Name))
+	if err != nil {
+		return fmt.Errorf("could not read backup: %w", err)
+	}
+
+	manifest, err := local.Restore(passphrase, archive)
+	if errors.Is(err, backup.ErrNodeRunning) {
+		api, err := remote()
+		if err != nil {
+			return fmt.Errorf("could not restore backup: %w", err)
+		}
+		staged, err := api.BackupRestore(passphrase, archive)
+		if err != nil {
+			return fmt.Errorf("could not restore backup: %w", err)
+		}
+		fmt.Fprintf(ctx.App.Writer, "Backup created at %s by node %s will be restored on the next node start\n", staged.CreatedAt, staged.NodeVersion)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("could not restore backup: %w", err)
+	}
+
+	fmt.Fprintf(ctx.App.Writer, "Restored backup created at %s by node %s\n", manifest.CreatedAt, manifest.NodeVersion)
+	return nil
+}
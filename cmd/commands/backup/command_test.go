@@ -0,0 +1,104 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package backup
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+
+	"github.com/mysteriumnetwork/node/core/backup"
+	"github.com/mysteriumnetwork/node/tequilapi/contract"
+)
+
+type mockLocalBackup struct {
+	err error
+}
+
+func (m *mockLocalBackup) Create(passphrase string) ([]byte, backup.Manifest, error) {
+	return []byte("local"), backup.Manifest{}, m.err
+}
+
+func (m *mockLocalBackup) Restore(passphrase string, sealed []byte) (backup.Manifest, error) {
+	return backup.Manifest{NodeVersion: "1.0.0"}, m.err
+}
+
+type mockRemoteBackup struct {
+	restored []byte
+}
+
+func (m *mockRemoteBackup) BackupCreate(passphrase string) ([]byte, error) {
+	return []byte("remote"), nil
+}
+
+func (m *mockRemoteBackup) BackupRestore(passphrase string, archive []byte) (contract.BackupManifestDTO, error) {
+	m.restored = archive
+	return contract.BackupManifestDTO{NodeVersion: "1.0.0"}, nil
+}
+
+func newContext(t *testing.T, output *bytes.Buffer, file string) *cli.Context {
+	set := flag.NewFlagSet("test", 0)
+	set.String(flagPassphrase.Name, "secret", "")
+	set.String(flagOutput.Name, file, "")
+	set.String(flagInput.Name, file, "")
+	return cli.NewContext(&cli.App{Writer: output}, set, nil)
+}
+
+func TestCreate(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "backup.bin")
+	remote := &mockRemoteBackup{}
+	connect := func() (remoteBackup, error) { return remote, nil }
+
+	output := bytes.NewBufferString("")
+	require.NoError(t, create(newContext(t, output, file), &mockLocalBackup{}, connect))
+	content, err := os.ReadFile(file)
+	require.NoError(t, err)
+	assert.Equal(t, "local", string(content))
+	assert.Equal(t, "Backup written to "+file+"\n", output.String())
+
+	require.NoError(t, create(newContext(t, output, file), &mockLocalBackup{err: backup.ErrNodeRunning}, connect))
+	content, err = os.ReadFile(file)
+	require.NoError(t, err)
+	assert.Equal(t, "remote", string(content))
+}
+
+func TestRestore(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "backup.bin")
+	require.NoError(t, os.WriteFile(file, []byte("archive"), 0600))
+	remote := &mockRemoteBackup{}
+	connect := func() (remoteBackup, error) { return remote, nil }
+
+	output := bytes.NewBufferString("")
+	require.NoError(t, restore(newContext(t, output, file), &mockLocalBackup{}, connect))
+	assert.Contains(t, output.String(), "Restored backup")
+	assert.Nil(t, remote.restored)
+
+	output.Reset()
+	require.NoError(t, restore(newContext(t, output, file), &mockLocalBackup{err: backup.ErrNodeRunning}, connect))
+	assert.Contains(t, output.String(), "will be restored on the next node start")
+	assert.Equal(t, "archive", string(remote.restored))
+
+	err := restore(newContext(t, output, file), &mockLocalBackup{err: backup.ErrStalePromises}, connect)
+	assert.ErrorIs(t, err, backup.ErrStalePromises)
+}
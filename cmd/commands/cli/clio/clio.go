@@ -18,7 +18,10 @@
 package clio
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
 	"unicode"
 )
 
@@ -27,46 +30,85 @@ const warningColor = "\033[31m"
 const successColor = "\033[32m"
 const infoColor = "\033[93m"
 
+var (
+	// out receives human readable messages.
+	out io.Writer = os.Stdout
+	// jsonOutput enables machine readable command results.
+	jsonOutput bool
+)
+
+// SetJSONOutput switches to machine readable output.
+// Command results are written to stdout as JSON, while human readable messages are moved to stderr.
+func SetJSONOutput() {
+	jsonOutput = true
+	out = os.Stderr
+}
+
+// JSONOutput checks if machine readable output is enabled.
+func JSONOutput() bool {
+	return jsonOutput
+}
+
+// Result prints a command result as JSON if machine readable output is enabled.
+func Result(v interface{}) {
+	if !jsonOutput {
+		return
+	}
+	if err := json.NewEncoder(os.Stdout).Encode(v); err != nil {
+		Error("Failed to encode result:", err)
+	}
+}
+
+// Print prints raw human readable text.
+func Print(items ...interface{}) {
+	fmt.Fprint(out, items...)
+}
+
+// Println prints raw human readable text followed by a new line.
+func Println(items ...interface{}) {
+	fmt.Fprintln(out, items...)
+}
+
 // Status prints a message with a given status.
 func Status(label string, items ...interface{}) {
-	fmt.Printf(statusColor+"[%s] \033[0m", label)
-	fmt.Println(sentenceCase(fmt.Sprintln(items...)))
+	fmt.Fprintf(out, statusColor+"[%s] \033[0m", label)
+	fmt.Fprintln(out, sentenceCase(fmt.Sprintln(items...)))
 }
 
 // Warn prints a warning.
 func Warn(items ...interface{}) {
-	fmt.Printf(warningColor + "[WARNING] \033[0m")
-	fmt.Println(sentenceCase(fmt.Sprint(items...)))
+	fmt.Fprintf(out, warningColor+"[WARNING] \033[0m")
+	fmt.Fprintln(out, sentenceCase(fmt.Sprint(items...)))
 }
 
 // Warnf prints a warning using fmt.Printf.
 func Warnf(format string, items ...interface{}) {
-	fmt.Printf(warningColor + "[WARNING] \033[0m")
-	fmt.Print(sentenceCase(fmt.Sprintf(format, items...)))
+	fmt.Fprintf(out, warningColor+"[WARNING] \033[0m")
+	fmt.Fprint(out, sentenceCase(fmt.Sprintf(format, items...)))
 }
 
 // Success prints a success message.
 func Success(items ...interface{}) {
-	fmt.Printf(successColor + "[SUCCESS] \033[0m")
-	fmt.Println(sentenceCase(fmt.Sprint(items...)))
+	fmt.Fprintf(out, successColor+"[SUCCESS] \033[0m")
+	fmt.Fprintln(out, sentenceCase(fmt.Sprint(items...)))
 }
 
 // Info prints an information message.
 func Info(items ...interface{}) {
-	fmt.Printf(infoColor + "[INFO] \033[0m")
-	fmt.Println(sentenceCase(fmt.Sprint(items...)))
+	fmt.Fprintf(out, infoColor+"[INFO] \033[0m")
+	fmt.Fprintln(out, sentenceCase(fmt.Sprint(items...)))
 }
 
 // Error prints an error message
 func Error(items ...interface{}) {
-	fmt.Printf(warningColor + "[ERROR] \033[0m")
-	fmt.Println(sentenceCase(fmt.Sprint(items...)))
+	fmt.Fprintf(out, warningColor+"[ERROR] \033[0m")
+	fmt.Fprintln(out, sentenceCase(fmt.Sprint(items...)))
 }
 
 // Infof prints an information message using fmt.Printf.
 func Infof(format string, items ...interface{}) {
-	fmt.Printf(infoColor + "[INFO] \033[0m")
-	fmt.Print(sentenceCase(fmt.Sprintf(format, items...)))
+	fmt.Fprintf(out, infoColor+"[INFO] \033[0m")
+	fmt.Fprint(out, sentenceCase(fmt.Sprintf(format, items...)))
 }
 
 // sentenceCase capitalizes the first letter.
@@ -18,19 +18,17 @@
 package clio
 
 import (
-	"fmt"
-
 	"github.com/mysteriumnetwork/node/metadata"
 )
 
 // PrintTOSError prints TOS together with a given error
 // asking user to accept them.
 func PrintTOSError(err error) {
-	fmt.Println(metadata.VersionAsSummary(metadata.LicenseCopyright(
+	Println(metadata.VersionAsSummary(metadata.LicenseCopyright(
 		"type 'license --warranty'",
 		"type 'license --conditions'",
 	)))
-	fmt.Println()
+	Println()
 	Error(err)
 	Info("If you agree with these Terms & Conditions, run program again with '--agreed-terms-and-conditions' flag")
 }
@@ -61,13 +61,37 @@ const serviceHelp = `service <action> [args]
 
 	example: service start 0x7d5ee3557775aed0b85d691b036769c17349db23 openvpn --openvpn.port=1194 --openvpn.proto=UDP`
 
+const (
+	outputText = "text"
+	outputJSON = "json"
+)
+
+var flagOutput = cli.StringFlag{
+	Name:    "output",
+	Aliases: []string{"o"},
+	Usage:   "Output format of one-shot commands: text or json",
+	Value:   outputText,
+}
+
 // NewCommand constructs CLI based Mysterium UI with possibility to control quiting
 func NewCommand() *cli.Command {
 	return &cli.Command{
-		Name:  CommandName,
-		Usage: "Starts a CLI client with a Tequilapi",
-		Flags: []cli.Flag{&config.FlagAgreedTermsConditions, &config.FlagTequilapiAddress, &config.FlagTequilapiPort},
+		Name:      CommandName,
+		Usage:     "Starts a CLI client with a Tequilapi",
+		ArgsUsage: "[command] [args]",
+		Flags:     []cli.Flag{&config.FlagAgreedTermsConditions, &config.FlagTequilapiAddress, &config.FlagTequilapiPort, &flagOutput},
 		Action: func(ctx *cli.Context) error {
+			output := ctx.String(flagOutput.Name)
+			if output != outputText && output != outputJSON {
+				return cli.Exit(fmt.Sprintf("unknown output format %q, expected %s or %s", output, outputText, outputJSON), exitCodeUsage)
+			}
+			if ctx.Args().Len() > 0 {
+				return runOnce(ctx, output == outputJSON)
+			}
+			if output == outputJSON {
+				return cli.Exit("JSON output is supported only for one-shot commands, e.g. 'cli --output json status'", exitCodeUsage)
+			}
+
 			client, err := clio.NewTequilApiClient(ctx)
 			if err != nil {
 				return err
@@ -87,6 +111,41 @@ func NewCommand() *cli.Command {
 	}
 }
 
+// runOnce executes a single command given in arguments and exits with a code describing the result.
+func runOnce(ctx *cli.Context, jsonOutput bool) error {
+	if jsonOutput {
+		clio.SetJSONOutput()
+	}
+
+	err := func() error {
+		client, err := clio.NewTequilApiClient(ctx)
+		if err != nil {
+			return withExitCode(err, exitCodeNodeUnreachable)
+		}
+
+		cfg, err := remote.NewConfig(client)
+		if err != nil {
+			clio.Error(formatForHuman(err))
+			return withExitCode(err, exitCodeNodeUnreachable)
+		}
+
+		cmdCLI := newCliApp(cfg, client)
+		if err := cmdCLI.handleTOS(ctx); err != nil {
+			clio.PrintTOSError(err)
+			return withExitCode(err, exitCodeTermsNotAgreed)
+		}
+		cmdCLI.completer = newAutocompleter(client, nil)
+
+		return cmdCLI.handleActions(ctx.Args().Slice())
+	}()
+	if err == nil {
+		return nil
+	}
+
+	clio.Result(newErrorResult(err))
+	return cli.Exit("", exitCode(err))
+}
+
 func describeQuit(err error) error {
 	if err == nil || err == io.EOF || err == readline.ErrInterrupt {
 		log.Info().Msg("Stopping application")
@@ -177,10 +236,6 @@ func (c *cliApp) Run(ctx *cli.Context) (err error) {
 	c.completer = newAutocompleter(c.tequilapi, c.fetchedProposals)
 	c.fetchedProposals = c.fetchProposals()
 
-	if ctx.Args().Len() > 0 {
-		return c.handleActions(ctx.Args().Slice())
-	}
-
 	c.reader, err = readline.NewEx(&readline.Config{
 		Prompt:          fmt.Sprintf(redColor, "» "),
 		HistoryFile:     c.historyFile,
@@ -213,6 +268,9 @@ func (c *cliApp) Run(ctx *cli.Context) (err error) {
 
 // Kill stops cli
 func (c *cliApp) Kill() error {
+	if c.reader == nil {
+		return nil
+	}
 	c.reader.Clean()
 	return c.reader.Close()
 }
@@ -253,6 +311,7 @@ func (c *cliApp) handleActions(args []string) error {
 		{"orders", c.order},
 		{"license", c.license},
 		{"proposals", c.proposals},
+		{"providers", c.providers},
 		{"service", c.service},
 		{"stake", c.stake},
 		{"mmn", c.mmnApiKey},
@@ -279,7 +338,8 @@ func (c *cliApp) handleActions(args []string) error {
 	}
 
 	// Command matched nothing
-	return c.help()
+	c.help()
+	return errUnknownCommand
 }
 
 func (c *cliApp) connect(args []string) (err error) {
@@ -300,6 +360,7 @@ func (c *cliApp) connect(args []string) (err error) {
 		}
 		clio.Info("Migration finished successfully")
 		clio.Info("Try to reconnect")
+		clio.Result(messageResult{Message: "Hermes migration finished, try to reconnect"})
 		return nil
 	}
 
@@ -346,7 +407,7 @@ func (c *cliApp) connect(args []string) (err error) {
 	// if identity it locked, it will notify us anyway.
 	_ = c.tequilapi.Unlock(consumerID, "")
 
-	_, err = c.tequilapi.ConnectionCreate(consumerID, providerID, hermesID, serviceType, connectOptions)
+	conn, err := c.tequilapi.ConnectionCreate(consumerID, providerID, hermesID, serviceType, connectOptions)
 	if err != nil {
 		return err
 	}
@@ -354,6 +415,7 @@ func (c *cliApp) connect(args []string) (err error) {
 	c.currentConsumerID = consumerID
 
 	clio.Success("Connected.")
+	clio.Result(conn)
 	return nil
 }
 
@@ -376,6 +438,7 @@ func (c *cliApp) mmnApiKey(args []string) (err error) {
 	}
 
 	clio.Success("MMN API key configured.")
+	clio.Result(messageResult{Message: "MMN API key configured"})
 	return nil
 }
 
@@ -386,16 +449,34 @@ func (c *cliApp) disconnect() (err error) {
 	}
 	c.currentConsumerID = ""
 	clio.Success("Disconnected.")
+	clio.Result(messageResult{Message: "Disconnected"})
 	return nil
 }
 
+// statusResult is a machine readable result of the status command.
+type statusResult struct {
+	Connection *contract.ConnectionInfoDTO       `json:"connection,omitempty"`
+	IP         string                            `json:"ip,omitempty"`
+	Location   *contract.LocationDTO             `json:"location,omitempty"`
+	Statistics *contract.ConnectionStatisticsDTO `json:"statistics,omitempty"`
+}
+
+// messageResult is a machine readable result of commands which do not return any data.
+type messageResult struct {
+	Message string `json:"message"`
+}
+
+// status prints every section it could fetch and fails only if none of them could be fetched.
 func (c *cliApp) status() (err error) {
-	status, err := c.tequilapi.ConnectionStatus(0)
-	if err != nil {
-		clio.Warn(err)
+	var result statusResult
+
+	status, statusErr := c.tequilapi.ConnectionStatus(0)
+	if statusErr != nil {
+		clio.Warn(statusErr)
 	} else {
 		clio.Info("Status:", status.Status)
 		clio.Info("SID:", status.SessionID)
+		result.Connection = &status
 	}
 
 	ip, err := c.tequilapi.ConnectionIP()
@@ -403,6 +484,7 @@ func (c *cliApp) status() (err error) {
 		clio.Warn(err)
 	} else {
 		clio.Info("IP:", ip.IP)
+		result.IP = ip.IP
 	}
 
 	location, err := c.tequilapi.ConnectionLocation()
@@ -410,6 +492,7 @@ func (c *cliApp) status() (err error) {
 		clio.Warn(err)
 	} else {
 		clio.Info(fmt.Sprintf("Location: %s, %s (%s - %s)", location.City, location.Country, location.IPType, location.ISP))
+		result.Location = &location
 	}
 
 	if status.Status == statusConnected {
@@ -423,8 +506,14 @@ func (c *cliApp) status() (err error) {
 			clio.Info(fmt.Sprintf("Data: %s/%s", datasize.FromBytes(statistics.BytesReceived), datasize.FromBytes(statistics.BytesSent)))
 			clio.Info(fmt.Sprintf("Throughput: %s/%s", datasize.BitSpeed(statistics.ThroughputReceived), datasize.BitSpeed(statistics.ThroughputSent)))
 			clio.Info(fmt.Sprintf("Spent: %s", money.New(statistics.TokensSpent)))
+			result.Statistics = &statistics
 		}
 	}
+
+	if result == (statusResult{}) {
+		return withExitCode(fmt.Errorf("could not fetch connection status: %w", statusErr), exitCodeFailure)
+	}
+	clio.Result(result)
 	return nil
 }
 
@@ -439,9 +528,16 @@ func (c *cliApp) healthcheck() (err error) {
 	clio.Info(fmt.Sprintf("Version: %v", healthcheck.Version))
 	buildString := metadata.FormatString(healthcheck.BuildInfo.Commit, healthcheck.BuildInfo.Branch, healthcheck.BuildInfo.BuildNumber)
 	clio.Info(buildString)
+	clio.Result(healthcheck)
 	return nil
 }
 
+// natResult is a machine readable result of the nat command.
+type natResult struct {
+	Status string `json:"status"`
+	Type   string `json:"type,omitempty"`
+}
+
 func (c *cliApp) nodeMonitoringStatus() (err error) {
 	status, err := c.tequilapi.NATStatus()
 	if err != nil {
@@ -450,6 +546,9 @@ func (c *cliApp) nodeMonitoringStatus() (err error) {
 
 	clio.Infof("Node Monitoring Status: %q\n", status.Status)
 
+	result := natResult{Status: string(status.Status)}
+	defer func() { clio.Result(result) }()
+
 	connStatus, err := c.tequilapi.ConnectionStatus(0)
 	if err != nil {
 		clio.Warn(err)
@@ -471,12 +570,17 @@ func (c *cliApp) nodeMonitoringStatus() (err error) {
 			displayedNATType = string(natType.Type)
 		}
 		clio.Info("NAT type:", displayedNATType)
+		result.Type = string(natType.Type)
 	}
 
 	return nil
 }
 
 func (c *cliApp) proposals(args []string) (err error) {
+	if len(args) > 0 && args[0] == "query" {
+		return c.proposalsByQuery(args[1:])
+	}
+
 	proposals := c.fetchProposals()
 	c.fetchedProposals = proposals
 
@@ -490,6 +594,8 @@ func (c *cliApp) proposals(args []string) (err error) {
 	}
 	clio.Info(fmt.Sprintf("Found %v proposals %s", len(proposals), filterMsg))
 
+	filtered := make([]contract.ProposalDTO, 0, len(proposals))
+
 	for _, proposal := range proposals {
 		country := proposal.Location.Country
 		if country == "" {
@@ -509,9 +615,32 @@ func (c *cliApp) proposals(args []string) (err error) {
 			strings.Contains(proposal.ProviderID, filter) ||
 			strings.Contains(country, filter) {
 			clio.Info(msg)
+			filtered = append(filtered, proposal)
 		}
 	}
 
+	clio.Result(filtered)
+	return nil
+}
+
+func (c *cliApp) proposalsByQuery(args []string) error {
+	if len(args) == 0 {
+		clio.Info(`Please type in the proposal query. proposals query <query>, e.g. proposals query country in (DE, NL) and not isp ~ "hosting" and quality >= 2`)
+		return errWrongArgumentCount
+	}
+
+	query := strings.Join(args, " ")
+	proposals, err := c.tequilapi.ProposalsByQuery(query)
+	if err != nil {
+		return err
+	}
+
+	clio.Info(fmt.Sprintf("Found %v proposals (query: '%s')", len(proposals), query))
+	for _, proposal := range proposals {
+		clio.Info(fmt.Sprintf("- provider id: %v\ttype: %v\tcountry: %v\tcity: %v\tisp: %v\tquality: %.1f", proposal.ProviderID, proposal.ServiceType, proposal.Location.Country, proposal.Location.City, proposal.Location.ISP, proposal.Quality.Quality))
+	}
+
+	clio.Result(proposals)
 	return nil
 }
 
@@ -531,12 +660,13 @@ func (c *cliApp) location() (err error) {
 	}
 
 	clio.Info(fmt.Sprintf("Location: %s, %s (%s - %s)", location.City, location.Country, location.IPType, location.ISP))
+	clio.Result(location)
 	return nil
 }
 
 func (c *cliApp) help() (err error) {
 	clio.Info("Mysterium CLI commands:")
-	fmt.Println(c.completer.Tree("  "))
+	clio.Println(c.completer.Tree("  "))
 	return nil
 }
 
@@ -553,11 +683,15 @@ func (c *cliApp) stopClient() (err error) {
 		return fmt.Errorf("cannot stop the client: %w", err)
 	}
 	clio.Success("Client stopped")
+	clio.Result(messageResult{Message: "Client stopped"})
 	return nil
 }
 
 func (c *cliApp) version() (err error) {
-	fmt.Println(versionSummary)
+	clio.Println(versionSummary)
+	clio.Result(struct {
+		Version string `json:"version"`
+	}{Version: metadata.VersionAsString()})
 	return nil
 }
 
@@ -567,9 +701,9 @@ func (c *cliApp) license(args []string) (err error) {
 		arg = args[0]
 	}
 	if arg == "warranty" {
-		fmt.Print(metadata.LicenseWarranty)
+		clio.Print(metadata.LicenseWarranty)
 	} else if arg == "conditions" {
-		fmt.Print(metadata.LicenseConditions)
+		clio.Print(metadata.LicenseConditions)
 	} else {
 		clio.Info("identities command:\n    warranty\n    conditions")
 	}
@@ -668,7 +802,16 @@ func newAutocompleter(tequilapi *tequilapi_client.Client, proposals []contract.P
 		),
 		readline.PcItem("healthcheck"),
 		readline.PcItem("nat"),
-		readline.PcItem("proposals"),
+		readline.PcItem("proposals",
+			readline.PcItem("query"),
+		),
+		readline.PcItem("providers",
+			readline.PcItem("list", readline.PcItemDynamic(getIdentityOptionList(tequilapi))),
+			readline.PcItem("favourite", readline.PcItemDynamic(getIdentityOptionList(tequilapi))),
+			readline.PcItem("unfavourite", readline.PcItemDynamic(getIdentityOptionList(tequilapi))),
+			readline.PcItem("block", readline.PcItemDynamic(getIdentityOptionList(tequilapi))),
+			readline.PcItem("unblock", readline.PcItemDynamic(getIdentityOptionList(tequilapi))),
+		),
 		readline.PcItem("location"),
 		readline.PcItem("disconnect"),
 		readline.PcItem("mmn"),
@@ -103,7 +103,7 @@ func (c *cliApp) identities(args []string) (err error) {
 	case "migrate-hermes-status":
 		return c.migrateHermesStatus(actionArgs)
 	default:
-		fmt.Println(usage)
+		clio.Println(usage)
 		return errUnknownSubCommand(args[0])
 	}
 }
@@ -123,6 +123,7 @@ func (c *cliApp) listIdentities(args []string) (err error) {
 	for _, id := range ids {
 		clio.Status("+", id.Address)
 	}
+	clio.Result(ids)
 	return nil
 }
 
@@ -141,6 +142,7 @@ func (c *cliApp) getBalance(actionArgs []string) (err error) {
 	}
 
 	clio.Info(fmt.Sprintf("Balance: %s MYST", balance.BalanceTokens))
+	clio.Result(balance)
 	return nil
 }
 
@@ -162,6 +164,7 @@ func (c *cliApp) getIdentity(actionArgs []string) (err error) {
 	clio.Info(fmt.Sprintf("Balance: %s MYST", identityStatus.BalanceTokens))
 	clio.Info(fmt.Sprintf("Earnings: %s", money.New(identityStatus.Earnings)))
 	clio.Info(fmt.Sprintf("Earnings total: %s", money.New(identityStatus.EarningsTotal)))
+	clio.Result(identityStatus)
 	return nil
 }
 
@@ -182,6 +185,7 @@ func (c *cliApp) newIdentity(args []string) (err error) {
 		return err
 	}
 	clio.Success("New identity created:", id.Address)
+	clio.Result(id)
 	return nil
 }
 
@@ -206,6 +210,7 @@ func (c *cliApp) unlockIdentity(actionArgs []string) (err error) {
 	}
 
 	clio.Success(fmt.Sprintf("Identity %s unlocked.", address))
+	clio.Result(messageResult{Message: "Identity unlocked"})
 	return nil
 }
 
@@ -237,6 +242,7 @@ func (c *cliApp) registerIdentity(actionArgs []string) error {
 
 	clio.Info(msg)
 	clio.Info(fmt.Sprintf("To explore additional information about the identity use: identities %s", usageGetIdentity))
+	clio.Result(messageResult{Message: msg})
 	return nil
 }
 
@@ -286,16 +292,17 @@ func (c *cliApp) settle(args []string) (err error) {
 	for {
 		select {
 		case <-timeout:
-			fmt.Println()
+			clio.Println()
 			return errTimeout
 		case <-time.After(time.Millisecond * 500):
-			fmt.Print(".")
+			clio.Print(".")
 		case err := <-errChan:
-			fmt.Println()
+			clio.Println()
 			if err != nil {
 				return fmt.Errorf("settlement failed: %w", err)
 			}
 			clio.Info("settlement succeeded")
+			clio.Result(messageResult{Message: "Settlement succeeded"})
 			return nil
 		}
 	}
@@ -327,6 +334,7 @@ func (c *cliApp) setBeneficiary(actionArgs []string) error {
 		case <-timeout:
 			clio.Info("Beneficiary change in progress")
 			clio.Info(fmt.Sprintf("To get additional information use command: \"%s\"", usageSetBeneficiaryStatus))
+			clio.Result(messageResult{Message: "Beneficiary change in progress"})
 			return nil
 		case <-time.After(time.Second):
 			st, err := c.tequilapi.SettleWithBeneficiaryStatus(address)
@@ -349,6 +357,7 @@ func (c *cliApp) setBeneficiary(actionArgs []string) error {
 
 			if strings.EqualFold(data.Beneficiary, benef) {
 				clio.Success("New beneficiary address set")
+				clio.Result(messageResult{Message: "New beneficiary address set"})
 				return nil
 			}
 		}
@@ -387,6 +396,10 @@ func (c *cliApp) getBeneficiaryStatus(actionArgs []string) error {
 		clio.Warn(fmt.Sprintf("Error: %s", st.Error))
 	}
 
+	clio.Result(struct {
+		Beneficiary  interface{} `json:"beneficiary"`
+		ChangeStatus interface{} `json:"change_status"`
+	}{Beneficiary: data, ChangeStatus: st})
 	return nil
 }
 
@@ -452,15 +465,16 @@ func (c *cliApp) withdraw(args []string) error {
 	for {
 		select {
 		case <-timeout:
-			return errors.New("withdrawal timed out")
+			return fmt.Errorf("withdrawal: %w", errTimeout)
 		case <-time.After(time.Millisecond * 500):
-			fmt.Print(".")
+			clio.Print(".")
 		case err := <-errChan:
-			fmt.Println()
+			clio.Println()
 			if err != nil {
 				return fmt.Errorf("withdrawal failed: %w", err)
 			}
 			clio.Info("withdrawal succeeded")
+			clio.Result(messageResult{Message: "Withdrawal succeeded"})
 			return nil
 		}
 	}
@@ -481,6 +495,7 @@ func (c *cliApp) getReferralCode(actionArgs []string) error {
 	}
 
 	clio.Success(fmt.Sprintf("Your referral token is: %q", res.Token))
+	clio.Result(res)
 	return nil
 }
 
@@ -529,13 +544,21 @@ func (c *cliApp) exportIdentity(actionsArgs []string) (err error) {
 		}
 
 		clio.Success("Identity exported to file:", filepath)
+		clio.Result(messageResult{Message: "Identity exported to file: " + filepath})
+		return nil
+	}
+
+	if clio.JSONOutput() {
+		clio.Result(struct {
+			Key string `json:"key"`
+		}{Key: string(blob)})
 		return nil
 	}
 
 	clio.Success("Private key exported: ")
 
 	quoted := strconv.Quote(string(blob))
-	fmt.Println(quoted[1 : len(quoted)-1])
+	clio.Println(quoted[1 : len(quoted)-1])
 
 	return nil
 }
@@ -566,6 +589,7 @@ func (c *cliApp) importIdentity(actionsArgs []string) (err error) {
 	}
 
 	clio.Success("Identity imported:", id.Address)
+	clio.Result(id)
 	return nil
 }
 
@@ -584,6 +608,7 @@ func (c *cliApp) lastWithdrawal(actionArgs []string) error {
 	}
 	if history.TotalItems == 0 {
 		clio.Info("No withdrawals found")
+		clio.Result(nil)
 		return nil
 	}
 
@@ -600,6 +625,7 @@ func (c *cliApp) lastWithdrawal(actionArgs []string) error {
 	} else {
 		clio.Info("Error: none")
 	}
+	clio.Result(lastWithdrawal)
 	return nil
 }
 
@@ -618,6 +644,7 @@ func (c *cliApp) migrateHermesStatus(actionArgs []string) error {
 	}
 
 	clio.Info("Migration status: ", response.Status)
+	clio.Result(response)
 
 	return nil
 }
@@ -637,6 +664,7 @@ func (c *cliApp) migrateHermes(actionArgs []string) error {
 		return err
 	}
 	clio.Info("Migration finished successfully")
+	clio.Result(messageResult{Message: "Migration finished successfully"})
 
 	return nil
 }
@@ -64,7 +64,7 @@ func (c *cliApp) order(args []string) (err error) {
 	case "gateways":
 		return c.gateways(actionArgs)
 	default:
-		fmt.Println(usage)
+		clio.Println(usage)
 		return errUnknownSubCommand(args[0])
 	}
 }
@@ -74,7 +74,7 @@ const usageOrderGateways = "gateways"
 func (c *cliApp) gateways(args []string) (err error) {
 	if len(args) > 0 {
 		clio.Info("Usage: " + usageOrderGateways)
-		return
+		return errWrongArgumentCount
 	}
 
 	resp, err := c.tequilapi.PaymentOrderGateways(exchange.CurrencyMYST)
@@ -88,6 +88,7 @@ func (c *cliApp) gateways(args []string) (err error) {
 		clio.Info("Supported currencies:", strings.Join(gw.Currencies, ", "))
 	}
 
+	clio.Result(resp)
 	return nil
 }
 
@@ -119,19 +120,16 @@ func (c *cliApp) orderCreate(args []string) (err error) {
 
 	gws, err := c.tequilapi.PaymentOrderGateways(exchange.CurrencyMYST)
 	if err != nil {
-		clio.Info("Failed to get enabled gateways and their information")
-		return
+		return fmt.Errorf("failed to get enabled gateways and their information: %w", err)
 	}
 
 	if len(gws) == 0 {
-		clio.Warn("No payment gateways are enabled, can't create new orders")
-		return
+		return errors.New("no payment gateways are enabled, can't create new orders")
 	}
 
 	gw, ok := findGateway(argGateway, gws)
 	if !ok {
-		clio.Error("Can't continue, no such gateway:", argGateway)
-		return
+		return fmt.Errorf("can't continue, no such gateway: %s", argGateway)
 	}
 	if gw.OrderOptions.Minimum != 0 && f <= gw.OrderOptions.Minimum {
 		return fmt.Errorf(
@@ -145,8 +143,8 @@ func (c *cliApp) orderCreate(args []string) (err error) {
 	for _, part := range parts {
 		kv := strings.Split(part, "=")
 		if len(kv) != 2 {
-			clio.Error("Gateway data wrong, example: lightning_network=true,custom_id=\"123 11\"")
-			return
+			clio.Info("Gateway data example: lightning_network=true,custom_id=\"123 11\"")
+			return fmt.Errorf("wrong gateway data: %w", errUnknownArgument)
 		}
 
 		if b, err := strconv.ParseBool(kv[1]); err == nil {
@@ -164,8 +162,7 @@ func (c *cliApp) orderCreate(args []string) (err error) {
 
 	callerData, err := json.Marshal(data)
 	if err != nil {
-		clio.Error("Failed to make caller data")
-		return
+		return fmt.Errorf("failed to make caller data: %w", err)
 	}
 
 	resp, err := c.tequilapi.OrderCreate(
@@ -182,6 +179,7 @@ func (c *cliApp) orderCreate(args []string) (err error) {
 	}
 
 	printOrder(resp, c.config)
+	clio.Result(resp)
 	return nil
 }
 
@@ -190,7 +188,7 @@ const usageOrderGet = "get <identity> <orderID>"
 func (c *cliApp) orderGet(args []string) (err error) {
 	if len(args) != 2 {
 		clio.Info("Usage: " + usageOrderGet)
-		return
+		return errWrongArgumentCount
 	}
 
 	resp, err := c.tequilapi.OrderGet(identity.FromAddress(args[0]), args[1])
@@ -198,6 +196,7 @@ func (c *cliApp) orderGet(args []string) (err error) {
 		return fmt.Errorf("could not get an order: %w", err)
 	}
 	printOrder(resp, c.config)
+	clio.Result(resp)
 	return nil
 }
 
@@ -214,6 +213,7 @@ func (c *cliApp) orderGetAll(args []string) (err error) {
 		return fmt.Errorf("could not get orders: %w", err)
 	}
 
+	clio.Result(resp)
 	if len(resp) == 0 {
 		clio.Info("No orders found")
 		return nil
@@ -233,7 +233,7 @@ const usageOrderInvoice = "invoice <identity> <orderID>"
 func (c *cliApp) invoice(args []string) (err error) {
 	if len(args) != 2 {
 		clio.Info("Usage: " + usageOrderInvoice)
-		return
+		return errWrongArgumentCount
 	}
 
 	resp, err := c.tequilapi.OrderInvoice(identity.FromAddress(args[0]), args[1])
@@ -242,7 +242,13 @@ func (c *cliApp) invoice(args []string) (err error) {
 	}
 	filename := fmt.Sprintf("invoice-%v.pdf", args[1])
 	clio.Info("Writing invoice to", filename)
-	return os.WriteFile(filename, resp, 0644)
+	if err := os.WriteFile(filename, resp, 0644); err != nil {
+		return err
+	}
+	clio.Result(struct {
+		File string `json:"file"`
+	}{File: filename})
+	return nil
 }
 
 func printOrder(o contract.PaymentOrderResponse, rc *remote.Config) {
@@ -0,0 +1,107 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package cli
+
+import (
+	"strings"
+
+	"github.com/mysteriumnetwork/node/cmd/commands/cli/clio"
+	"github.com/mysteriumnetwork/node/identity"
+	"github.com/mysteriumnetwork/node/tequilapi/contract"
+)
+
+const (
+	usageProvidersList        = "list <identity>"
+	usageProvidersFavourite   = "favourite <identity> <provider>"
+	usageProvidersUnfavourite = "unfavourite <identity> <provider>"
+	usageProvidersBlock       = "block <identity> <provider>"
+	usageProvidersUnblock     = "unblock <identity> <provider>"
+)
+
+func (c *cliApp) providers(args []string) (err error) {
+	usage := strings.Join([]string{
+		"Usage: providers <action> [args]",
+		"Available actions:",
+		"  " + usageProvidersList,
+		"  " + usageProvidersFavourite,
+		"  " + usageProvidersUnfavourite,
+		"  " + usageProvidersBlock,
+		"  " + usageProvidersUnblock,
+	}, "\n")
+
+	if len(args) == 0 {
+		clio.Info(usage)
+		return errWrongArgumentCount
+	}
+
+	action := args[0]
+	actionArgs := args[1:]
+
+	switch action {
+	case "list":
+		if len(actionArgs) != 1 {
+			clio.Info("Usage: " + usageProvidersList)
+			return errWrongArgumentCount
+		}
+		lists, err := c.tequilapi.ProviderLists(identity.FromAddress(actionArgs[0]))
+		if err != nil {
+			return err
+		}
+		printProviderLists(lists)
+		return nil
+	case "favourite":
+		return c.providersSet(actionArgs, usageProvidersFavourite, func(id identity.Identity, provider string) (contract.ProviderListsDTO, error) {
+			return c.tequilapi.ProviderFavouriteSet(id, provider, true)
+		})
+	case "unfavourite":
+		return c.providersSet(actionArgs, usageProvidersUnfavourite, func(id identity.Identity, provider string) (contract.ProviderListsDTO, error) {
+			return c.tequilapi.ProviderFavouriteSet(id, provider, false)
+		})
+	case "block":
+		return c.providersSet(actionArgs, usageProvidersBlock, func(id identity.Identity, provider string) (contract.ProviderListsDTO, error) {
+			return c.tequilapi.ProviderBlockedSet(id, provider, true)
+		})
+	case "unblock":
+		return c.providersSet(actionArgs, usageProvidersUnblock, func(id identity.Identity, provider string) (contract.ProviderListsDTO, error) {
+			return c.tequilapi.ProviderBlockedSet(id, provider, false)
+		})
+	default:
+		clio.Println(usage)
+		return errUnknownSubCommand(args[0])
+	}
+}
+
+func (c *cliApp) providersSet(args []string, usage string, set func(id identity.Identity, provider string) (contract.ProviderListsDTO, error)) error {
+	if len(args) != 2 {
+		clio.Info("Usage: " + usage)
+		return errWrongArgumentCount
+	}
+
+	lists, err := set(identity.FromAddress(args[0]), args[1])
+	if err != nil {
+		return err
+	}
+	printProviderLists(lists)
+	return nil
+}
+
+func printProviderLists(lists contract.ProviderListsDTO) {
+	clio.Info("Favourite providers:", strings.Join(lists.Favourites, ", "))
+	clio.Info("Blocked providers:", strings.Join(lists.Blocked, ", "))
+	clio.Result(lists)
+}
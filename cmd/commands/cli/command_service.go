@@ -28,7 +28,7 @@ import (
 
 func (c *cliApp) service(args []string) (err error) {
 	if len(args) == 0 {
-		fmt.Println(serviceHelp)
+		clio.Println(serviceHelp)
 		return errWrongArgumentCount
 	}
 
@@ -36,19 +36,19 @@ func (c *cliApp) service(args []string) (err error) {
 	switch action {
 	case "start":
 		if len(args) < 3 {
-			fmt.Println(serviceHelp)
+			clio.Println(serviceHelp)
 			return errWrongArgumentCount
 		}
 		return c.serviceStart(args[1], args[2], args[3:]...)
 	case "stop":
 		if len(args) < 2 {
-			fmt.Println(serviceHelp)
+			clio.Println(serviceHelp)
 			return errWrongArgumentCount
 		}
 		return c.serviceStop(args[1])
 	case "status":
 		if len(args) < 2 {
-			fmt.Println(serviceHelp)
+			clio.Println(serviceHelp)
 			return errWrongArgumentCount
 		}
 		return c.serviceGet(args[1])
@@ -57,7 +57,7 @@ func (c *cliApp) service(args []string) (err error) {
 	case "sessions":
 		return c.serviceSessions()
 	default:
-		fmt.Println(serviceHelp)
+		clio.Println(serviceHelp)
 		return errUnknownSubCommand(args[0])
 	}
 }
@@ -82,6 +82,7 @@ func (c *cliApp) serviceStart(providerID, serviceType string, args ...string) (e
 		"ID: "+service.ID,
 		"ProviderID: "+service.Proposal.ProviderID,
 		"Type: "+service.Proposal.ServiceType)
+	clio.Result(service)
 	return nil
 }
 
@@ -91,6 +92,7 @@ func (c *cliApp) serviceStop(id string) (err error) {
 	}
 
 	clio.Status("Stopping", "ID: "+id)
+	clio.Result(messageResult{Message: "Stopping service " + id})
 	return nil
 }
 
@@ -106,6 +108,7 @@ func (c *cliApp) serviceList() (err error) {
 			"ProviderID: "+service.Proposal.ProviderID,
 			"Type: "+service.Proposal.ServiceType)
 	}
+	clio.Result(services)
 	return nil
 }
 
@@ -124,6 +127,7 @@ func (c *cliApp) serviceSessions() (err error) {
 			fmt.Sprintf("Tokens: %s", money.New(session.Tokens)),
 		)
 	}
+	clio.Result(sessions.Items)
 	return nil
 }
 
@@ -137,5 +141,6 @@ func (c *cliApp) serviceGet(id string) (err error) {
 		"ID: "+service.ID,
 		"ProviderID: "+service.Proposal.ProviderID,
 		"Type: "+service.Proposal.ServiceType)
+	clio.Result(service)
 	return nil
 }
@@ -49,7 +49,7 @@ func (c *cliApp) stake(args []string) (err error) {
 	case "decrease":
 		return c.decreaseStake(actionArgs)
 	default:
-		fmt.Println(usage)
+		clio.Println(usage)
 		return errUnknownSubCommand(args[0])
 	}
 }
@@ -72,6 +72,7 @@ func (c *cliApp) decreaseStake(args []string) (err error) {
 	if err != nil {
 		return fmt.Errorf("could not decrease stake: %w", err)
 	}
+	clio.Result(messageResult{Message: "Stake decreased"})
 	return nil
 }
 
@@ -96,16 +97,17 @@ func (c *cliApp) increaseStake(args []string) (err error) {
 	for {
 		select {
 		case <-timeout:
-			fmt.Println()
+			clio.Println()
 			return errTimeout
 		case <-time.After(time.Millisecond * 500):
-			fmt.Print(".")
+			clio.Print(".")
 		case err := <-errChan:
-			fmt.Println()
+			clio.Println()
 			if err != nil {
 				return fmt.Errorf("settlement failed: %w", err)
 			}
 			clio.Info("settlement succeeded")
+			clio.Result(messageResult{Message: "Settlement into stake succeeded"})
 			return nil
 		}
 	}
@@ -0,0 +1,66 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package cli
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tequilapi_client "github.com/mysteriumnetwork/node/tequilapi/client"
+)
+
+func newStatusTestApp(t *testing.T, handler http.HandlerFunc) *cliApp {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	host, port, err := net.SplitHostPort(server.Listener.Addr().String())
+	require.NoError(t, err)
+	portNum, err := strconv.Atoi(port)
+	require.NoError(t, err)
+
+	return &cliApp{tequilapi: tequilapi_client.NewClient(host, portNum)}
+}
+
+func TestStatus_FailsWhenNoSectionIsFetched(t *testing.T) {
+	app := newStatusTestApp(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	err := app.status()
+	require.Error(t, err)
+	assert.Equal(t, exitCodeFailure, exitCode(err))
+}
+
+func TestStatus_SucceedsWithSomeSections(t *testing.T) {
+	app := newStatusTestApp(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/connection/ip" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ip": "1.2.3.4"}`))
+	})
+
+	assert.NoError(t, app.status())
+}
@@ -28,11 +28,81 @@ import (
 var (
 	errWrongArgumentCount = errors.New("wrong number of arguments")
 	errUnknownArgument    = errors.New("unknown argument")
+	errUnknownCommand     = errors.New("unknown command")
 	errTimeout            = errors.New("operation timed out")
+	errUsage              = errors.New("invalid usage")
+)
+
+// Exit codes of one-shot commands.
+const (
+	exitCodeFailure         = 1
+	exitCodeUsage           = 2
+	exitCodeTermsNotAgreed  = 3
+	exitCodeNodeUnreachable = 4
+	exitCodeTimeout         = 5
 )
 
 func errUnknownSubCommand(cmd string) error {
-	return fmt.Errorf("unknown sub-command '%s'", cmd)
+	return &unknownSubCommandError{cmd: cmd}
+}
+
+type unknownSubCommandError struct {
+	cmd string
+}
+
+func (e *unknownSubCommandError) Error() string {
+	return fmt.Sprintf("unknown sub-command '%s'", e.cmd)
+}
+
+// codedError attaches an exit code to an error.
+type codedError struct {
+	error
+	code int
+}
+
+func (e *codedError) Unwrap() error {
+	return e.error
+}
+
+func withExitCode(err error, code int) error {
+	return &codedError{error: err, code: code}
+}
+
+func exitCode(err error) int {
+	var coded *codedError
+	if errors.As(err, &coded) {
+		return coded.code
+	}
+
+	var subCmdErr *unknownSubCommandError
+	switch {
+	case errors.As(err, &subCmdErr),
+		errors.Is(err, errWrongArgumentCount),
+		errors.Is(err, errUnknownArgument),
+		errors.Is(err, errUnknownCommand),
+		errors.Is(err, errUsage):
+		return exitCodeUsage
+	case errors.Is(err, errTermsNotAgreed):
+		return exitCodeTermsNotAgreed
+	case errors.Is(err, errTimeout):
+		return exitCodeTimeout
+	}
+	return exitCodeFailure
+}
+
+// errorResult is a machine readable error of a one-shot command.
+type errorResult struct {
+	Error struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func newErrorResult(err error) errorResult {
+	var res errorResult
+	res.Error.Code = exitCode(err)
+	res.Error.Message = formatForHuman(err)
+	return res
 }
 
 func formatForHuman(err error) string {
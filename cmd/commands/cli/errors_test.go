@@ -0,0 +1,54 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		err  error
+		code int
+	}{
+		{errors.New("boom"), exitCodeFailure},
+		{errWrongArgumentCount, exitCodeUsage},
+		{fmt.Errorf("wrong gateway data: %w", errUnknownArgument), exitCodeUsage},
+		{errUnknownSubCommand("fly"), exitCodeUsage},
+		{errUnknownCommand, exitCodeUsage},
+		{errTermsNotAgreed, exitCodeTermsNotAgreed},
+		{fmt.Errorf("withdrawal: %w", errTimeout), exitCodeTimeout},
+		{withExitCode(errors.New("connection refused"), exitCodeNodeUnreachable), exitCodeNodeUnreachable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.err.Error(), func(t *testing.T) {
+			assert.Equal(t, tt.code, exitCode(tt.err))
+		})
+	}
+}
+
+func TestNewErrorResult(t *testing.T) {
+	res := newErrorResult(errUnknownSubCommand("fly"))
+
+	assert.Equal(t, exitCodeUsage, res.Error.Code)
+	assert.Equal(t, "Unknown sub-command 'fly'", res.Error.Message)
+}
@@ -32,6 +32,11 @@ import (
 // CommandName is the name which is used to call this command
 const CommandName = "config"
 
+var flagDryRun = cli.BoolFlag{
+	Name:  "dry-run",
+	Usage: "Validate the value and show how the config would change without applying it",
+}
+
 // NewCommand function creates license command.
 func NewCommand() *cli.Command {
 	cmd := &command{}
@@ -55,9 +60,11 @@ func NewCommand() *cli.Command {
 				},
 			},
 			{
-				Name:   "set",
-				Usage:  "Set node config value",
-				Action: cmd.set,
+				Name:      "set",
+				Usage:     "Set node config value",
+				ArgsUsage: "<key> <value>",
+				Flags:     []cli.Flag{&flagDryRun},
+				Action:    cmd.set,
 			},
 		},
 	}
@@ -93,6 +100,16 @@ func (c *command) set(ctx *cli.Context) error {
 
 	config := map[string]interface{}{ctx.Args().Get(0): ctx.Args().Get(1)}
 
+	if ctx.Bool(flagDryRun.Name) {
+		changes, err := c.tc.DiffConfig(config)
+		if err != nil {
+			clio.Error("Invalid config value", err)
+			return err
+		}
+		printChanges(changes)
+		return nil
+	}
+
 	err := c.tc.SetConfig(config)
 	if err != nil {
 		clio.Error("Failed to set user config", err)
@@ -102,6 +119,23 @@ func (c *command) set(ctx *cli.Context) error {
 	return nil
 }
 
+func printChanges(changes []config.Change) {
+	if len(changes) == 0 {
+		clio.Info("No changes")
+		return
+	}
+	for _, change := range changes {
+		note := "applied live"
+		if change.RestartRequired {
+			note = "restart required"
+		}
+		if change.OverriddenByCLI {
+			note = "overridden by CLI flag"
+		}
+		fmt.Printf("%s: %v -> %v (%s)\n", change.Key, change.Old, change.New, note)
+	}
+}
+
 // Orders keys alphabetically and prints a given map.
 func printMapOrdered(m map[string]string) {
 	keys := make([]string, 0, len(m))
@@ -71,6 +71,11 @@ var (
 		Value: "quality",
 	}
 
+	flagQuery = cli.StringFlag{
+		Name:  "query",
+		Usage: `Proposal query to filter by eg. 'country in (DE, NL) and not isp ~ "hosting" and quality >= 2'`,
+	}
+
 	flagIncludeFailed = cli.BoolFlag{
 		Name:  "include-failed",
 		Usage: "Include proposals marked as test failed by monitoring agent",
@@ -120,7 +125,7 @@ func NewCommand() *cli.Command {
 				Name:      "up",
 				ArgsUsage: "[ProviderIdentityAddress]",
 				Usage:     "Create a new connection",
-				Flags:     []cli.Flag{&config.FlagAgreedTermsConditions, &flagCountry, &flagLocationType, &flagSortType, &flagIncludeFailed, &flagProxyPort, &flagServiceType},
+				Flags:     []cli.Flag{&config.FlagAgreedTermsConditions, &flagCountry, &flagLocationType, &flagSortType, &flagQuery, &flagIncludeFailed, &flagProxyPort, &flagServiceType},
 				Action: func(ctx *cli.Context) error {
 					cmd.up(ctx)
 					return nil
@@ -295,6 +300,7 @@ func (c *command) up(ctx *cli.Context) {
 		IPType:                  ctx.String(flagLocationType.Name),
 		SortBy:                  ctx.String(flagSortType.Name),
 		IncludeMonitoringFailed: ctx.Bool(flagIncludeFailed.Name),
+		Query:                   ctx.String(flagQuery.Name),
 	}
 
 	_, err = c.tequilapi.SmartConnectionCreate(id.Address, hermesID, ctx.String(flagServiceType.Name), filter, connectOptions)
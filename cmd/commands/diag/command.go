@@ -0,0 +1,93 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package diag
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/mysteriumnetwork/node/cmd/commands/cli/clio"
+	"github.com/mysteriumnetwork/node/config"
+	"github.com/mysteriumnetwork/node/core/diagnostics"
+	"github.com/mysteriumnetwork/node/tequilapi/contract"
+)
+
+// CommandName is the name of the diag command.
+const CommandName = "diag"
+
+var flagJSON = cli.BoolFlag{
+	Name:  "json",
+	Usage: "Print machine readable report",
+}
+
+// errFailed is returned when at least one check has failed, so the command exits with non zero code.
+var errFailed = errors.New("diagnostics found problems")
+
+type reportProvider interface {
+	Diagnostics() (contract.DiagnosticsReportDTO, error)
+}
+
+// NewCommand function creates diag command.
+func NewCommand() *cli.Command {
+	return &cli.Command{
+		Name:        CommandName,
+		Usage:       "Run connectivity and health diagnostics of a running node",
+		Description: "Checks NAT type, port reachability, broker and discovery availability, DNS, clock skew, identity registration, hermes channel and firewall state",
+		ArgsUsage:   " ",
+		Flags:       []cli.Flag{&config.FlagTequilapiAddress, &config.FlagTequilapiPort, &flagJSON},
+		Action: func(ctx *cli.Context) error {
+			tc, err := clio.NewTequilApiClient(ctx)
+			if err != nil {
+				return err
+			}
+			return run(ctx, tc)
+		},
+	}
+}
+
+func run(ctx *cli.Context, api reportProvider) error {
+	report, err := api.Diagnostics()
+	if err != nil {
+		return fmt.Errorf("could not run diagnostics: %w", err)
+	}
+
+	if ctx.Bool(flagJSON.Name) {
+		out, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(ctx.App.Writer, string(out))
+	} else {
+		for _, check := range report.Checks {
+			fmt.Fprintf(ctx.App.Writer, "[%s] %s: %s\n", strings.ToUpper(check.Status), check.Name, check.Message)
+			if check.Remediation != "" {
+				fmt.Fprintf(ctx.App.Writer, "    hint: %s\n", check.Remediation)
+			}
+		}
+		fmt.Fprintf(ctx.App.Writer, "Overall status: %s\n", report.Status)
+	}
+
+	if report.Status == string(diagnostics.StatusFailed) {
+		return errFailed
+	}
+	return nil
+}
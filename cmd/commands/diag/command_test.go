@@ -0,0 +1,56 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package diag
+
+import (
+	"bytes"
+	"flag"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/urfave/cli/v2"
+
+	"github.com/mysteriumnetwork/node/tequilapi/contract"
+)
+
+type mockReportProvider struct {
+	report contract.DiagnosticsReportDTO
+}
+
+func (m *mockReportProvider) Diagnostics() (contract.DiagnosticsReportDTO, error) {
+	return m.report, nil
+}
+
+func TestRun(t *testing.T) {
+	output := bytes.NewBufferString("")
+	ctx := cli.NewContext(&cli.App{Writer: output}, flag.NewFlagSet("test", 0), nil)
+
+	err := run(ctx, &mockReportProvider{report: contract.DiagnosticsReportDTO{
+		Status: "failed",
+		Checks: []contract.DiagnosticsCheckDTO{
+			{Name: "dns", Status: "ok", Message: "2 host(s) resolved"},
+			{Name: "clock_skew", Status: "failed", Message: "clock skew is 1m0s", Remediation: "Synchronize the system clock"},
+		},
+	}})
+
+	assert.Equal(t, errFailed, err)
+	assert.Equal(t, "[OK] dns: 2 host(s) resolved\n"+
+		"[FAILED] clock_skew: clock skew is 1m0s\n"+
+		"    hint: Synchronize the system clock\n"+
+		"Overall status: failed\n", output.String())
+}
@@ -34,6 +34,7 @@ import (
 	"github.com/mysteriumnetwork/node/config/urfavecli/clicontext"
 	"github.com/mysteriumnetwork/node/core/control"
 	"github.com/mysteriumnetwork/node/core/node"
+	"github.com/mysteriumnetwork/node/identity"
 	"github.com/mysteriumnetwork/node/metadata"
 	"github.com/mysteriumnetwork/node/services"
 	"github.com/mysteriumnetwork/node/tequilapi/client"
@@ -56,6 +57,7 @@ func NewCommand(licenseCommandName string) *cli.Command {
 			config.ParseFlagsServiceWireguard(ctx)
 			config.ParseFlagsServiceQuic(ctx)
 			config.ParseFlagsServiceNoop(ctx)
+			config.ParseFlagsControlPlane(ctx)
 			config.ParseFlagsNode(ctx)
 
 			if err := config.ValidateWireguardMTUFlag(); err != nil {
@@ -70,6 +72,7 @@ func NewCommand(licenseCommandName string) *cli.Command {
 
 			nodeOptions := node.GetOptions()
 			nodeOptions.Discovery.FetchEnabled = false
+			nodeOptions.Discovery.Snapshot = false
 			if err := di.Bootstrap(*nodeOptions); err != nil {
 				return err
 			}
@@ -82,7 +85,16 @@ func NewCommand(licenseCommandName string) *cli.Command {
 				errorChannel: quit,
 			}
 			go func() {
-				cp := control.NewControlPlane(di.BrokerConnection, cmdService.tequilapi)
+				cp := control.NewControlPlane(
+					di.BrokerConnection,
+					cmdService.tequilapi,
+					di.SignerFactory,
+					identity.NewVerifierSigned(),
+					control.Config{
+						Operators: config.GetStringSlice(config.FlagControlPlaneOperators),
+						MaxSkew:   config.GetDuration(config.FlagControlPlaneMaxSkew),
+					},
+				)
 				quit <- cmdService.Run(ctx, cp)
 				cp.Stop()
 			}()
@@ -99,6 +111,7 @@ func NewCommand(licenseCommandName string) *cli.Command {
 	config.RegisterFlagsServiceWireguard(&command.Flags)
 	config.RegisterFlagsServiceQuic(&command.Flags)
 	config.RegisterFlagsServiceNoop(&command.Flags)
+	config.RegisterFlagsControlPlane(&command.Flags)
 
 	return command
 }
@@ -19,26 +19,32 @@ package cmd
 
 import (
 	"fmt"
+	"math/big"
 	"net"
 	"net/http"
 	"net/url"
 	"path/filepath"
 	"reflect"
+	"strings"
 	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
+	"github.com/spf13/cast"
 
 	"github.com/mysteriumnetwork/node/communication/nats"
 	"github.com/mysteriumnetwork/node/config"
 	"github.com/mysteriumnetwork/node/consumer/migration"
 	consumer_session "github.com/mysteriumnetwork/node/consumer/session"
 	"github.com/mysteriumnetwork/node/core/auth"
+	"github.com/mysteriumnetwork/node/core/backup"
 	"github.com/mysteriumnetwork/node/core/beneficiary"
 	"github.com/mysteriumnetwork/node/core/connection"
 	"github.com/mysteriumnetwork/node/core/connection/connectionstate"
+	"github.com/mysteriumnetwork/node/core/diagnostics"
 	"github.com/mysteriumnetwork/node/core/discovery"
 	"github.com/mysteriumnetwork/node/core/discovery/proposal"
 	"github.com/mysteriumnetwork/node/core/ip"
@@ -55,6 +61,7 @@ import (
 	"github.com/mysteriumnetwork/node/core/storage/boltdb"
 	"github.com/mysteriumnetwork/node/core/storage/boltdb/migrations/history"
 	"github.com/mysteriumnetwork/node/core/storage/boltdb/migrator"
+	"github.com/mysteriumnetwork/node/core/webhook"
 	"github.com/mysteriumnetwork/node/dns"
 	"github.com/mysteriumnetwork/node/eventbus"
 	"github.com/mysteriumnetwork/node/feedback"
@@ -78,6 +85,7 @@ import (
 	"github.com/mysteriumnetwork/node/router"
 	service_noop "github.com/mysteriumnetwork/node/services/noop"
 	service_openvpn "github.com/mysteriumnetwork/node/services/openvpn"
+	service_relay "github.com/mysteriumnetwork/node/services/relay"
 	"github.com/mysteriumnetwork/node/services/wireguard/endpoint"
 	"github.com/mysteriumnetwork/node/session/connectivity"
 	"github.com/mysteriumnetwork/node/session/pingpong"
@@ -121,7 +129,9 @@ type Dependencies struct {
 
 	NATService       nat.NATService
 	NATProber        natprobe.NATProber
+	STUNServer       *natprobe.Server
 	Storage          *boltdb.Bolt
+	NodeBackup       *backup.Backup
 	Keystore         *identity.Keystore
 	IdentityManager  identity.Manager
 	SignerFactory    identity.SignerFactory
@@ -133,6 +143,7 @@ type Dependencies struct {
 	DiscoveryFactory    service.DiscoveryFactory
 	ProposalRepository  *discovery.PricedServiceProposalRepository
 	FilterPresetStorage *proposal.FilterPresetStorage
+	ProviderListStorage *proposal.ProviderListStorage
 	DiscoveryWorker     discovery.Worker
 
 	QualityClient *quality.MysteriumMORQA
@@ -140,7 +151,8 @@ type Dependencies struct {
 	IPResolver       ip.Resolver
 	LocationResolver *location.Cache
 
-	dnsProxy *dns.Proxy
+	dnsProxy   *dns.Proxy
+	dnsHandler *dns.ProviderHandler
 
 	PolicyOracle   *localcopy.Oracle
 	PolicyProvider policy.Provider
@@ -152,6 +164,7 @@ type Dependencies struct {
 
 	MultiConnectionManager connection.MultiManager
 	ConnectionRegistry     *connection.Registry
+	Relayer                *service_relay.Relayer
 
 	ServicesManager *service.Manager
 	ServiceRegistry *service.Registry
@@ -198,9 +211,14 @@ type Dependencies struct {
 
 	MMN *mmn.MMN
 
+	WebhookDispatcher *webhook.Dispatcher
+
+	Diagnostics *diagnostics.Diagnostics
+
 	PilvytisAPI         *pilvytis.API
 	PilvytisTracker     *pilvytis.StatusTracker
 	PilvytisOrderIssuer *pilvytis.OrderIssuer
+	PilvytisAutoTopUp   *pilvytis.AutoTopUp
 
 	ObserverAPI *observer.API
 
@@ -209,6 +227,7 @@ type Dependencies struct {
 	BeneficiaryAddressStorage beneficiary.BeneficiaryStorage
 	NodeStatusTracker         *monitoring.StatusTracker
 	NodeStatsTracker          *node.StatsTracker
+	UptimeTracker             *node.UptimeTracker
 	uiVersionConfig           versionmanager.NodeUIVersionConfig
 }
 
@@ -238,9 +257,18 @@ func (di *Dependencies) Bootstrap(nodeOptions node.Options) error {
 
 	di.bootstrapEventBus()
 
+	backupPaths := backup.Paths{
+		Data:       nodeOptions.Directories.Data,
+		Keystore:   nodeOptions.Directories.Keystore,
+		Storage:    nodeOptions.Directories.Storage,
+		UserConfig: config.Current.UserConfigLocation(),
+	}
+	di.applyStagedBackup(backupPaths)
+
 	if err := di.bootstrapStorage(nodeOptions.Directories.Storage); err != nil {
 		return err
 	}
+	di.NodeBackup = backup.NewBackup(backupPaths, di.Storage)
 
 	if err := di.bootstrapNetworkComponents(nodeOptions); err != nil {
 		return err
@@ -280,7 +308,10 @@ func (di *Dependencies) Bootstrap(nodeOptions node.Options) error {
 
 	di.PortPool = port.NewFixedRangePool(portRange)
 
-	di.bootstrapP2P()
+	di.bootstrapP2P(nodeOptions)
+	if err := di.bootstrapSTUNServer(); err != nil {
+		return err
+	}
 	di.SessionConnectivityStatusStorage = connectivity.NewStatusStorage()
 
 	if err := di.bootstrapServices(nodeOptions); err != nil {
@@ -291,6 +322,10 @@ func (di *Dependencies) Bootstrap(nodeOptions node.Options) error {
 		return err
 	}
 
+	if err := di.bootstrapWebhooks(nodeOptions.Webhook); err != nil {
+		return err
+	}
+
 	if err := di.bootstrapNodeComponents(nodeOptions, tequilaListener); err != nil {
 		return err
 	}
@@ -304,6 +339,9 @@ func (di *Dependencies) Bootstrap(nodeOptions node.Options) error {
 	}
 
 	config.Current.EnableEventPublishing(di.EventBus)
+	if err := di.EventBus.SubscribeAsync(config.AppTopicConfig(config.FlagLogLevel.Name), applyLogLevel); err != nil {
+		return err
+	}
 
 	di.handleNATStatusForPublicIP()
 
@@ -350,12 +388,30 @@ func (di *Dependencies) bootstrapAddressProvider(nodeOptions node.Options) {
 	di.AddressProvider = paymentClient.NewMultiChainAddressProvider(keeper, di.BCHelper)
 }
 
-func (di *Dependencies) bootstrapP2P() {
+func (di *Dependencies) bootstrapP2P(nodeOptions node.Options) {
 	verifierFactory := func(id identity.Identity) identity.Verifier {
 		return identity.NewVerifierIdentity(id)
 	}
 
-	di.P2PListener = p2p.NewListener(di.BrokerConnection, di.SignerFactory, identity.NewVerifierSigned(), di.IPResolver, di.EventBus)
+	// Relay sessions use their own connection registry, managers and event bus, so their
+	// connection, session and invoice events do not reach consumer state, UI or webhooks.
+	// Spending still reaches the balance tracker through the consumer totals storage.
+	service_relay.Bootstrap()
+	relayConnections := connection.NewRegistry()
+	relayBus := eventbus.New()
+	di.Relayer = service_relay.NewRelayer(
+		connection.NewMultiConnectionManager(func() connection.Manager {
+			return di.newConnectionManager(nodeOptions, relayConnections, relayBus)
+		}),
+		di.ProposalRepository,
+		func() (common.Address, error) {
+			return di.AddressProvider.GetActiveHermes(nodeOptions.ChainID)
+		},
+		service_relay.DefaultKeepAlive,
+	)
+	relayConnections.Register(service_relay.ServiceType, di.Relayer.NewConnection)
+
+	di.P2PListener = p2p.NewListener(di.BrokerConnection, di.SignerFactory, identity.NewVerifierSigned(), di.IPResolver, di.EventBus, di.Relayer)
 	di.P2PDialer = p2p.NewDialer(di.BrokerConnector, di.SignerFactory, verifierFactory, di.IPResolver, di.PortPool, di.EventBus)
 }
 
@@ -412,6 +468,36 @@ func (di *Dependencies) registerNoopConnection() {
 	di.ConnectionRegistry.Register(service_noop.ServiceType, service_noop.NewConnection)
 }
 
+func (di *Dependencies) newConnectionManager(nodeOptions node.Options, registry *connection.Registry, bus eventbus.EventBus) connection.Manager {
+	connectionConfig := connection.DefaultConfig()
+	connectionConfig.PriceChange.AcceptIncrease = config.GetBool(config.FlagPaymentsConsumerAcceptPriceIncrease)
+
+	return connection.NewManager(
+		pingpong.ExchangeFactoryFunc(
+			di.Keystore,
+			di.SignerFactory,
+			di.ConsumerTotalsStorage,
+			di.AddressProvider,
+			bus,
+			nodeOptions.Payments.ConsumerDataLeewayMegabytes,
+		),
+		registry.CreateConnection,
+		bus,
+		di.IPResolver,
+		di.LocationResolver,
+		connectionConfig,
+		config.GetDuration(config.FlagStatsReportInterval),
+		connection.NewValidator(
+			di.ConsumerBalanceTracker,
+			di.IdentityManager,
+		),
+		di.P2PDialer,
+		di.allowTrustedDomainBypassTunnel,
+		di.disallowTrustedDomainBypassTunnel,
+		di.PricingHelper,
+	)
+}
+
 // Shutdown stops container
 func (di *Dependencies) Shutdown() (err error) {
 	var errs []error
@@ -441,6 +527,10 @@ func (di *Dependencies) Shutdown() (err error) {
 		di.PolicyOracle.Stop()
 	}
 
+	if di.dnsHandler != nil {
+		di.dnsHandler.Stop()
+	}
+
 	if di.NATService != nil {
 		if err := di.NATService.Disable(); err != nil {
 			errs = append(errs, err)
@@ -475,6 +565,20 @@ func (di *Dependencies) Shutdown() (err error) {
 		di.QualityClient.Stop()
 	}
 
+	if di.WebhookDispatcher != nil {
+		di.WebhookDispatcher.Stop()
+	}
+
+	if di.UptimeTracker != nil {
+		di.UptimeTracker.Stop()
+	}
+
+	if di.STUNServer != nil {
+		if err := di.STUNServer.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
 	if di.ServiceFirewall != nil {
 		di.ServiceFirewall.Teardown()
 	}
@@ -491,6 +595,33 @@ func (di *Dependencies) Shutdown() (err error) {
 	return nil
 }
 
+// promisedEarnings returns hourly amounts promised to the provider by Hermes since the given time.
+func (di *Dependencies) promisedEarnings(id identity.Identity, from time.Time) (map[time.Time]*big.Int, error) {
+	list, err := di.HermesPromiseStorage.ListEarnings(id, from)
+	if err != nil {
+		return nil, err
+	}
+
+	earnings := make(map[time.Time]*big.Int, len(list))
+	for _, e := range list {
+		earnings[e.Hour] = e.Amount
+	}
+	return earnings, nil
+}
+
+// applyStagedBackup restores backup staged via Tequilapi. Node options are already parsed at this point,
+// so restored user config takes effect on the next start.
+func (di *Dependencies) applyStagedBackup(paths backup.Paths) {
+	manifest, err := backup.NewBackup(paths, nil).ApplyPending()
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to restore staged backup, it was discarded")
+		return
+	}
+	if manifest != nil {
+		log.Info().Msgf("Restored backup created at %s by node %s", manifest.CreatedAt, manifest.NodeVersion)
+	}
+}
+
 func (di *Dependencies) bootstrapStorage(path string) error {
 	localStorage, err := boltdb.NewStorage(path)
 	if err != nil {
@@ -514,6 +645,84 @@ func (di *Dependencies) bootstrapStorage(path string) error {
 	return di.SessionStorage.Subscribe(di.EventBus)
 }
 
+func (di *Dependencies) bootstrapWebhooks(options node.OptionsWebhook) error {
+	if len(options.URLs) == 0 {
+		return nil
+	}
+
+	di.WebhookDispatcher = webhook.NewDispatcher(
+		di.Storage,
+		requests.NewHTTPClientWithTransport(di.HTTPTransport, 30*time.Second),
+		webhook.Config{
+			URLs:        options.URLs,
+			Secret:      options.Secret,
+			Events:      options.Events,
+			MaxAttempts: options.MaxAttempts,
+			MaxQueued:   options.MaxQueued,
+		},
+	)
+	if err := di.WebhookDispatcher.Subscribe(di.EventBus); err != nil {
+		return err
+	}
+	di.WebhookDispatcher.Start()
+	return nil
+}
+
+func (di *Dependencies) bootstrapSTUNServer() error {
+	primary := config.GetString(config.FlagSTUNServerPrimary)
+	if primary == "" {
+		return nil
+	}
+
+	server, err := natprobe.ListenServer(primary, config.GetString(config.FlagSTUNServerAlternate))
+	if err != nil {
+		return fmt.Errorf("could not start STUN server: %w", err)
+	}
+	log.Info().Msgf("STUN server listening on %s", primary)
+	di.STUNServer = server
+	return nil
+}
+
+func applyLogLevel(e config.AppEventConfigChanged) {
+	level, err := zerolog.ParseLevel(cast.ToString(e.Value))
+	if err != nil {
+		log.Warn().Err(err).Msg("Could not apply log level")
+		return
+	}
+	logconfig.SetLogLevel(level)
+	log.Info().Msgf("Log level changed to %s", level)
+}
+
+// bootstrapDiagnostics has to run once the components checked by diagnostics are bootstrapped,
+// checks capture them at construction.
+func (di *Dependencies) bootstrapDiagnostics(nodeOptions node.Options) error {
+	if di.NATProber == nil || di.PortPool == nil || di.BrokerConnection == nil || di.HTTPClient == nil ||
+		di.IdentityManager == nil || di.IdentityRegistry == nil || di.AddressProvider == nil || di.HermesChannelRepository == nil {
+		return errors.New("diagnostics depend on components which are not bootstrapped yet")
+	}
+
+	var echoServers []string
+	for _, address := range strings.Split(config.GetString(config.FlagPortCheckServers), ",") {
+		if address = strings.TrimSpace(address); address != "" {
+			echoServers = append(echoServers, address)
+		}
+	}
+
+	di.Diagnostics = diagnostics.NewDiagnostics(
+		diagnostics.DefaultCheckTimeout,
+		diagnostics.NATCheck(di.NATProber),
+		diagnostics.PortCheck(di.PortPool, echoServers),
+		diagnostics.BrokerCheck(di.BrokerConnection),
+		diagnostics.DiscoveryCheck(di.HTTPClient, nodeOptions.Discovery.Address),
+		diagnostics.DNSCheck(diagnostics.Hosts(append([]string{nodeOptions.Discovery.Address}, di.NetworkDefinition.BrokerAddresses...)...)),
+		diagnostics.ClockSkewCheck(di.HTTPClient, nodeOptions.Discovery.Address),
+		diagnostics.RegistrationCheck(di.IdentityManager, di.IdentityRegistry, nodeOptions.ChainID),
+		diagnostics.HermesChannelCheck(di.IdentityManager, di.AddressProvider, di.HermesChannelRepository, nodeOptions.ChainID),
+		diagnostics.FirewallCheck(diagnostics.FirewallProbe),
+	)
+	return nil
+}
+
 func (di *Dependencies) getHermesURL(nodeOptions node.Options) (string, error) {
 	log.Info().Msgf("Node chain id %v", nodeOptions.ChainID)
 	addr := common.HexToAddress(nodeOptions.Chains.Chain2.HermesID)
@@ -582,33 +791,10 @@ func (di *Dependencies) bootstrapNodeComponents(nodeOptions node.Options, tequil
 
 	di.ConnectionRegistry = connection.NewRegistry()
 	di.MultiConnectionManager = connection.NewMultiConnectionManager(func() connection.Manager {
-		return connection.NewManager(
-			pingpong.ExchangeFactoryFunc(
-				di.Keystore,
-				di.SignerFactory,
-				di.ConsumerTotalsStorage,
-				di.AddressProvider,
-				di.EventBus,
-				nodeOptions.Payments.ConsumerDataLeewayMegabytes,
-			),
-			di.ConnectionRegistry.CreateConnection,
-			di.EventBus,
-			di.IPResolver,
-			di.LocationResolver,
-			connection.DefaultConfig(),
-			config.GetDuration(config.FlagStatsReportInterval),
-			connection.NewValidator(
-				di.ConsumerBalanceTracker,
-				di.IdentityManager,
-			),
-			di.P2PDialer,
-			di.allowTrustedDomainBypassTunnel,
-			di.disallowTrustedDomainBypassTunnel,
-			di.PricingHelper,
-		)
+		return di.newConnectionManager(nodeOptions, di.ConnectionRegistry, di.EventBus)
 	})
 
-	di.NATProber = natprobe.NewNATProber(di.MultiConnectionManager, di.EventBus)
+	di.NATProber = natprobe.NewNATProber(di.MultiConnectionManager, di.EventBus, config.GetStringSlice(config.FlagNATBehaviorServers))
 
 	di.LogCollector = logconfig.NewCollector(&logconfig.CurrentLogOptions)
 	reporter, err := feedback.NewReporter(di.LogCollector, di.IdentityManager, di.LocationResolver, nodeOptions.FeedbackURL)
@@ -628,17 +814,31 @@ func (di *Dependencies) bootstrapNodeComponents(nodeOptions node.Options, tequil
 		di.QualityClient,
 	)
 
+	di.UptimeTracker = node.NewUptimeTracker(di.Storage)
+	di.UptimeTracker.Start()
+
+	providerStats := node.SelectProviderStats(
+		nodeOptions.Quality.StatsSource,
+		di.QualityClient,
+		node.NewLocalStats(
+			di.SessionStorage,
+			di.promisedEarnings,
+			di.SessionConnectivityStatusStorage,
+			di.UptimeTracker,
+			nodeOptions.Quality.StatsBucket,
+		),
+	)
 	di.NodeStatsTracker = node.NewNodeStatsTracker(
 		di.QualityClient.ProviderStatuses,
-		di.QualityClient.ProviderSessionsList,
-		di.QualityClient.ProviderTransferredData,
-		di.QualityClient.ProviderSessionsCount,
-		di.QualityClient.ProviderConsumersCount,
-		di.QualityClient.ProviderEarningsSeries,
-		di.QualityClient.ProviderSessionsSeries,
-		di.QualityClient.ProviderTransferredDataSeries,
-		di.QualityClient.ProviderActivityStats,
-		di.QualityClient.ProviderQuality,
+		providerStats.ProviderSessionsList,
+		providerStats.ProviderTransferredData,
+		providerStats.ProviderSessionsCount,
+		providerStats.ProviderConsumersCount,
+		providerStats.ProviderEarningsSeries,
+		providerStats.ProviderSessionsSeries,
+		providerStats.ProviderTransferredDataSeries,
+		providerStats.ProviderActivityStats,
+		providerStats.ProviderQuality,
 		di.QualityClient.ProviderServiceEarnings,
 		di.IdentityManager,
 	)
@@ -648,6 +848,10 @@ func (di *Dependencies) bootstrapNodeComponents(nodeOptions node.Options, tequil
 		return fmt.Errorf("error during subscribe: %w", err)
 	}
 
+	if err := di.bootstrapDiagnostics(nodeOptions); err != nil {
+		return err
+	}
+
 	tequilapiHTTPServer, err := di.bootstrapTequilapi(nodeOptions, tequilaListener)
 	if err != nil {
 		return err
@@ -861,7 +1065,21 @@ func (di *Dependencies) bootstrapIdentityComponents(options node.Options) error
 		ks = keystore.NewKeyStore(options.Directories.Keystore, keystore.StandardScryptN, keystore.StandardScryptP)
 	}
 
-	di.Keystore = identity.NewKeystoreFilesystem(options.Directories.Keystore, ks)
+	if options.Keystore.RemoteSigner != "" {
+		log.Info().Msgf("Using remote signer %s", options.Keystore.RemoteSigner)
+		if strings.HasPrefix(options.Keystore.RemoteSigner, "http") {
+			if err := di.AllowURLAccess(options.Keystore.RemoteSigner); err != nil {
+				return err
+			}
+		}
+		remote, err := identity.NewRemoteSigner(options.Keystore.RemoteSigner, identity.RemoteSignerMode(options.Keystore.RemoteSignerMode))
+		if err != nil {
+			return err
+		}
+		di.Keystore = identity.NewKeystoreWithRemoteSigner(options.Directories.Keystore, ks, remote)
+	} else {
+		di.Keystore = identity.NewKeystoreFilesystem(options.Directories.Keystore, ks)
+	}
 	if di.ResidentCountry == nil {
 		return errMissingDependency("di.residentCountry")
 	}
@@ -988,9 +1206,13 @@ func (di *Dependencies) bootstrapPilvytis(options node.Options) {
 	di.PilvytisAPI = pilvytis.NewAPI(di.HTTPClient, options.PilvytisAddress, di.SignerFactory, di.LocationResolver, di.AddressProvider)
 	di.PilvytisTracker = pilvytis.NewStatusTracker(di.PilvytisAPI, di.IdentityManager, di.EventBus, time.Minute)
 	di.PilvytisOrderIssuer = pilvytis.NewOrderIssuer(di.PilvytisAPI, di.PilvytisTracker)
+	di.PilvytisAutoTopUp = pilvytis.NewAutoTopUp(di.PilvytisOrderIssuer, di.Storage, di.EventBus)
 
 	go di.PilvytisTracker.Track()
 	di.PilvytisTracker.SubscribeAsync(di.EventBus)
+	if err := di.PilvytisAutoTopUp.Subscribe(di.EventBus); err != nil {
+		log.Warn().Err(err).Msg("Failed to subscribe auto top-up to balance changes")
+	}
 }
 
 func (di *Dependencies) bootstrapFirewall(options node.OptionsFirewall) error {
@@ -43,6 +43,7 @@ import (
 	"github.com/mysteriumnetwork/node/services/quic"
 	quic_connection "github.com/mysteriumnetwork/node/services/quic/connection"
 	quic_service "github.com/mysteriumnetwork/node/services/quic/service"
+	service_relay "github.com/mysteriumnetwork/node/services/relay"
 	"github.com/mysteriumnetwork/node/services/scraping"
 	"github.com/mysteriumnetwork/node/services/wireguard"
 	wireguard_connection "github.com/mysteriumnetwork/node/services/wireguard/connection"
@@ -70,15 +71,16 @@ func (di *Dependencies) bootstrapServices(nodeOptions node.Options) error {
 	}
 	di.bootstrapServiceOpenvpn(nodeOptions)
 	di.bootstrapServiceNoop(nodeOptions)
+	di.bootstrapServiceRelay(nodeOptions)
 	resourcesAllocator := resources.NewAllocator(di.PortPool, wireguard_service.GetOptions().Subnet)
 
-	dnsHandler, err := dns.ResolveViaSystem()
+	di.dnsHandler, err = dns.NewProviderHandler()
 	if err != nil {
 		log.Error().Err(err).Msg("Provider DNS are not available")
 		return err
 	}
 
-	di.dnsProxy = dns.NewProxy("", config.GetInt(config.FlagDNSListenPort), dnsHandler)
+	di.dnsProxy = dns.NewProxy("", config.GetInt(config.FlagDNSListenPort), di.dnsHandler)
 
 	// disable for mobile
 	if !nodeOptions.Mobile {
@@ -265,6 +267,20 @@ func (di *Dependencies) bootstrapServiceNoop(nodeOptions node.Options) {
 	)
 }
 
+func (di *Dependencies) bootstrapServiceRelay(nodeOptions node.Options) {
+	di.ServiceRegistry.Register(
+		service_relay.ServiceType,
+		func(serviceOptions service.Options) (service.Service, error) {
+			return service_relay.NewManager(
+				di.IPResolver,
+				di.PortPool,
+				di.EventBus,
+				config.GetDuration(config.FlagStatsReportInterval),
+			), nil
+		},
+	)
+}
+
 func (di *Dependencies) bootstrapHermesPromiseSettler(nodeOptions node.Options) error {
 	di.HermesChannelRepository = pingpong.NewHermesChannelRepository(
 		di.HermesPromiseStorage,
@@ -308,6 +324,7 @@ func (di *Dependencies) bootstrapHermesPromiseSettler(nodeOptions node.Options)
 			SettlementCheckInterval: nodeOptions.Payments.SettlementRecheckInterval,
 			L1ChainID:               nodeOptions.Chains.Chain1.ChainID,
 			L2ChainID:               nodeOptions.Chains.Chain2.ChainID,
+			Scheduler:               settlementSchedulerConfig(nodeOptions.Payments),
 		},
 	)
 	if err := settler.Subscribe(di.EventBus); err != nil {
@@ -496,3 +513,14 @@ func (di *Dependencies) bootstrapMMN() error {
 	di.MMN = mmn.NewMMN(di.IPResolver, client)
 	return di.MMN.Subscribe(di.EventBus)
 }
+
+func settlementSchedulerConfig(options node.OptionsPayments) pingpong.SettlementSchedulerConfig {
+	if !options.SettlementScheduler {
+		return pingpong.SettlementSchedulerConfig{}
+	}
+	return pingpong.SettlementSchedulerConfig{
+		CheckInterval:   options.SettlementSchedulerInterval,
+		TargetFeeRatio:  options.SettlementTargetFeeRatio,
+		MaxUnsettledAge: options.SettlementMaxUnsettledAge,
+	}
+}
@@ -0,0 +1,72 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package cmd
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mysteriumnetwork/node/communication/nats"
+	"github.com/mysteriumnetwork/node/core/connection"
+	"github.com/mysteriumnetwork/node/core/node"
+	"github.com/mysteriumnetwork/node/core/port"
+	"github.com/mysteriumnetwork/node/eventbus"
+	"github.com/mysteriumnetwork/node/identity"
+	"github.com/mysteriumnetwork/node/identity/registry"
+	"github.com/mysteriumnetwork/node/nat/behavior"
+	"github.com/mysteriumnetwork/node/requests"
+	"github.com/mysteriumnetwork/node/session/pingpong"
+	paymentClient "github.com/mysteriumnetwork/payments/client"
+)
+
+func TestBootstrapDiagnostics_RequiresCheckedComponents(t *testing.T) {
+	di := &Dependencies{}
+	assert.Error(t, di.bootstrapDiagnostics(node.Options{}))
+	assert.Nil(t, di.Diagnostics)
+}
+
+func TestBootstrapDiagnostics_RunsChecks(t *testing.T) {
+	di := &Dependencies{EventBus: eventbus.New()}
+	di.MultiConnectionManager = connection.NewMultiConnectionManager(func() connection.Manager { return nil })
+	di.NATProber = behavior.NewNATProber(di.MultiConnectionManager, di.EventBus, []string{"127.0.0.1:1"})
+	di.PortPool = port.NewFixedRangePool(port.Range{Start: 50000, End: 50010})
+	di.BrokerConnection = nats.NewConnectionMock()
+	di.HTTPClient = requests.NewHTTPClient("0.0.0.0", time.Second)
+	di.IdentityManager = identity.NewIdentityManager(identity.NewMockKeystore(), di.EventBus, nil)
+	di.IdentityRegistry = &registry.FakeRegistry{}
+	di.AddressProvider = paymentClient.NewMultiChainAddressProvider(&paymentClient.MultiChainAddressKeeper{}, nil)
+	di.HermesChannelRepository = &pingpong.HermesChannelRepository{}
+
+	options := node.Options{}
+	options.Discovery.Address = "http://127.0.0.1:1/api/v4"
+	require.NoError(t, di.bootstrapDiagnostics(options))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	report := di.Diagnostics.Run(ctx)
+
+	assert.NotEmpty(t, report.Checks)
+	for _, check := range report.Checks {
+		assert.False(t, strings.HasPrefix(check.Message, "check panicked"), "%s: %s", check.Name, check.Message)
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/mysteriumnetwork/node/core/connection"
 	"github.com/mysteriumnetwork/node/core/discovery"
 	"github.com/mysteriumnetwork/node/core/discovery/apidiscovery"
 	"github.com/mysteriumnetwork/node/core/discovery/brokerdiscovery"
@@ -33,7 +34,13 @@ import (
 
 func (di *Dependencies) bootstrapDiscoveryComponents(options node.OptionsDiscovery) error {
 	di.FilterPresetStorage = proposal.NewFilterPresetStorage(di.Storage)
-	proposalRepository := discovery.NewRepository()
+	di.ProviderListStorage = proposal.NewProviderListStorage(di.Storage)
+	if options.AutoBlockFailures > 0 {
+		if err := connection.NewProviderAutoBlocker(di.ProviderListStorage, options.AutoBlockFailures).Subscribe(di.EventBus); err != nil {
+			return err
+		}
+	}
+	proposalRepository := discovery.NewRepository(di.EventBus, options.RequireSignature)
 	proposalRegistry := discovery.NewRegistry()
 	discoveryWorker := discovery.NewWorker()
 
@@ -72,12 +79,18 @@ func (di *Dependencies) bootstrapDiscoveryComponents(options node.OptionsDiscove
 		}
 	}
 
+	var snapshot *discovery.ProposalSnapshot
+	if options.Snapshot {
+		snapshot = discovery.NewProposalSnapshot(di.Storage, options.SnapshotInterval)
+		discoveryWorker.AddWorker(snapshot)
+	}
+	di.ProposalRepository = discovery.NewPricedServiceProposalRepository(proposalRepository, di.PricingHelper, di.FilterPresetStorage, di.ProviderListStorage, snapshot)
+
 	di.DiscoveryWorker = discoveryWorker
 	if err := di.DiscoveryWorker.Start(); err != nil {
 		return errors.Wrap(err, "failed to start discovery")
 	}
 
-	di.ProposalRepository = discovery.NewPricedServiceProposalRepository(proposalRepository, di.PricingHelper, di.FilterPresetStorage)
 	di.DiscoveryFactory = func() service.Discovery {
 		return discovery.NewService(di.IdentityRegistry, proposalRegistry, options.PingInterval, di.SignerFactory, di.EventBus)
 	}
@@ -22,10 +22,12 @@ import (
 	"sync"
 
 	"github.com/mysteriumnetwork/node/cmd/commands/account"
+	"github.com/mysteriumnetwork/node/cmd/commands/backup"
 	command_cli "github.com/mysteriumnetwork/node/cmd/commands/cli"
 	command_cfg "github.com/mysteriumnetwork/node/cmd/commands/config"
 	"github.com/mysteriumnetwork/node/cmd/commands/connection"
 	"github.com/mysteriumnetwork/node/cmd/commands/daemon"
+	"github.com/mysteriumnetwork/node/cmd/commands/diag"
 	"github.com/mysteriumnetwork/node/cmd/commands/license"
 	"github.com/mysteriumnetwork/node/cmd/commands/reset"
 	"github.com/mysteriumnetwork/node/cmd/commands/service"
@@ -53,6 +55,8 @@ var (
 	accountCommand    = account.NewCommand()
 	connectionCommand = connection.NewCommand()
 	configCommand     = command_cfg.NewCommand()
+	diagCommand       = diag.NewCommand()
+	backupCommand     = backup.NewCommand()
 )
 
 func main() {
@@ -99,6 +103,8 @@ func NewCommand() (*cli.App, error) {
 		accountCommand,
 		connectionCommand,
 		configCommand,
+		diagCommand,
+		backupCommand,
 	}
 
 	return app, nil
@@ -117,6 +123,8 @@ var uiCommands = map[string]struct{}{
 	connection.CommandName:  {},
 	command_cfg.CommandName: {},
 	reset.CommandName:       {},
+	diag.CommandName:        {},
+	backup.CommandName:      {},
 }
 
 // configureLogging returns a func which configures global
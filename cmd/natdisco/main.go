@@ -36,6 +36,7 @@ var (
 	addressList  = flag.String("servers", "stun.mysterium.network:3478,stun.stunprotocol.org:3478,stun.sip.us:3478", "comma-separated list of STUN servers")
 	reqTimeout   = flag.Duration("req-timeout", 1*time.Second, "timeout to wait for each STUN server response")
 	totalTimeout = flag.Duration("total-timeout", 10*time.Second, "overall operation deadline")
+	server       = flag.String("server", "", "single RFC 5780 STUN server to query, e.g. a node started with --stun-server.primary; overrides -servers")
 	raw          = flag.Bool("raw", false, "print raw NAT_TYPE_* value")
 )
 
@@ -52,7 +53,15 @@ func run() int {
 
 	ctx, cl := context.WithTimeout(context.Background(), *totalTimeout)
 	defer cl()
-	res, err := behavior.RacingDiscoverNATBehavior(ctx, addresses, *reqTimeout)
+	var (
+		res nat.NATType
+		err error
+	)
+	if *server != "" {
+		res, err = behavior.DiscoverNATBehavior(ctx, *server, *reqTimeout)
+	} else {
+		res, err = behavior.RacingDiscoverNATBehavior(ctx, addresses, *reqTimeout)
+	}
 	if err != nil {
 		fmt.Fprintln(os.Stderr, "error:", err)
 		return 1
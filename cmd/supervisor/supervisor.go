@@ -90,7 +90,7 @@ func main() {
 		if err != nil {
 			log.Fatal().Err(err).Msg("Failed to read configuration")
 		}
-		supervisor := daemon.New()
+		supervisor := daemon.New(daemon.Options{AllowLegacyCommands: *svflags.FlagAllowLegacy})
 		if err := supervisor.Start(transport.Options{WinService: *svflags.FlagWinService, Uid: cfg.Uid}); err != nil {
 			log.Fatal().Err(err).Msg("Error running supervisor")
 		}
@@ -0,0 +1,83 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Change describes how a user configuration change affects the effective value of a key.
+type Change struct {
+	Key string      `json:"key"`
+	Old interface{} `json:"old"`
+	New interface{} `json:"new"`
+	// RestartRequired is true if the node has to be restarted for the change to take effect.
+	RestartRequired bool `json:"restart_required"`
+	// OverriddenByCLI is true if the key is set by a CLI flag, so the change has no effect until the flag is removed.
+	OverriddenByCLI bool `json:"overridden_by_cli,omitempty"`
+}
+
+// DiffUser validates user configuration changes and returns how they would change
+// the effective configuration without applying them. Nil value removes the key from user configuration.
+func (cfg *Config) DiffUser(changes map[string]interface{}) ([]Change, error) {
+	keys := make([]string, 0, len(changes))
+	for key := range changes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	diff := make([]Change, 0, len(keys))
+	for _, key := range keys {
+		value := changes[key]
+		if value != nil {
+			if err := cfg.Validate(key, value); err != nil {
+				return nil, err
+			}
+		}
+
+		segments := strings.Split(strings.ToLower(key), ".")
+		cfg.mu.RLock()
+		cliValue := SearchMap(cfg.cli, segments)
+		newValue := value
+		if newValue == nil {
+			newValue = SearchMap(cfg.defaults, segments)
+		}
+		cfg.mu.RUnlock()
+
+		change := Change{
+			Key:             strings.ToLower(key),
+			Old:             cfg.Get(key),
+			New:             newValue,
+			RestartRequired: true,
+		}
+		if cliValue != nil {
+			change.New = cliValue
+			change.OverriddenByCLI = true
+		}
+		if fmt.Sprint(change.Old) == fmt.Sprint(change.New) && !change.OverriddenByCLI {
+			continue
+		}
+		if schema, ok := cfg.Describe(key); ok {
+			change.RestartRequired = schema.RestartRequired
+		}
+		diff = append(diff, change)
+	}
+	return diff, nil
+}
@@ -69,6 +69,13 @@ func (cfg *Config) userConfigLoaded() bool {
 	return cfg.userConfigLocation != ""
 }
 
+// UserConfigLocation returns location of the loaded user config file, empty if none was loaded.
+func (cfg *Config) UserConfigLocation() string {
+	cfg.mu.RLock()
+	defer cfg.mu.RUnlock()
+	return cfg.userConfigLocation
+}
+
 // EnableEventPublishing enables config event publishing to the event bus.
 func (cfg *Config) EnableEventPublishing(eb eventbus.EventBus) {
 	cfg.mu.Lock()
@@ -151,14 +158,8 @@ func (cfg *Config) SetDefault(key string, value interface{}) {
 
 // SetUser sets user configuration value for key.
 func (cfg *Config) SetUser(key string, value interface{}) {
-	func() {
-		cfg.mu.RLock()
-		defer cfg.mu.RUnlock()
-		if cfg.eventBus != nil {
-			cfg.eventBus.Publish(AppTopicConfig(key), value)
-		}
-	}()
 	cfg.set(cfg.user, key, value)
+	cfg.publishChange(key)
 }
 
 // SetCLI sets value passed via CLI flag for key.
@@ -169,6 +170,19 @@ func (cfg *Config) SetCLI(key string, value interface{}) {
 // RemoveUser removes user configuration value for key.
 func (cfg *Config) RemoveUser(key string) {
 	cfg.remove(cfg.user, key)
+	cfg.publishChange(key)
+}
+
+// publishChange notifies subscribers of the key about its current value.
+func (cfg *Config) publishChange(key string) {
+	cfg.mu.RLock()
+	eb := cfg.eventBus
+	cfg.mu.RUnlock()
+	if eb == nil {
+		return
+	}
+	key = strings.ToLower(key)
+	eb.Publish(AppTopicConfig(key), AppEventConfigChanged{Key: key, Value: cfg.Get(key)})
 }
 
 // RemoveCLI removes configured CLI flag value by key.
@@ -475,3 +489,10 @@ func GetFloat64(flag cli.Float64Flag) float64 {
 func AppTopicConfig(configKey string) string {
 	return "config:" + configKey
 }
+
+// AppEventConfigChanged is published to AppTopicConfig once user configuration of the key changes.
+type AppEventConfigChanged struct {
+	Key string
+	// Value is the effective value of the key after the change.
+	Value interface{}
+}
@@ -0,0 +1,53 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package config
+
+import (
+	"time"
+
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	// FlagControlPlaneOperators addresses allowed to send control plane commands.
+	FlagControlPlaneOperators = cli.StringSliceFlag{
+		Name:  "control-plane.operators",
+		Usage: "Addresses of operator keys allowed to send control plane commands in addition to the node identity",
+		Value: cli.NewStringSlice(),
+	}
+	// FlagControlPlaneMaxSkew allowed age of control plane commands.
+	FlagControlPlaneMaxSkew = cli.DurationFlag{
+		Name:  "control-plane.max-skew",
+		Usage: "Maximum difference between control plane command timestamp and local time",
+		Value: 5 * time.Minute,
+	}
+)
+
+// RegisterFlagsControlPlane function register control plane flags to flag list
+func RegisterFlagsControlPlane(flags *[]cli.Flag) {
+	*flags = append(*flags,
+		&FlagControlPlaneOperators,
+		&FlagControlPlaneMaxSkew,
+	)
+}
+
+// ParseFlagsControlPlane parses CLI flags and registers value to configuration
+func ParseFlagsControlPlane(ctx *cli.Context) {
+	Current.ParseStringSliceFlag(ctx, FlagControlPlaneOperators)
+	Current.ParseDurationFlag(ctx, FlagControlPlaneMaxSkew)
+}
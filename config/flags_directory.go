@@ -76,6 +76,11 @@ func RegisterFlagsDirectory(flags *[]cli.Flag) error {
 	FlagScriptDir.Value = filepath.Join(currentDir, "config")
 	FlagNodeUIDir.Value = filepath.Join(FlagDataDir.Value, "nodeui")
 
+	registerFlagsDirectory(flags)
+	return nil
+}
+
+func registerFlagsDirectory(flags *[]cli.Flag) {
 	*flags = append(*flags,
 		&FlagConfigDir,
 		&FlagDataDir,
@@ -84,7 +89,6 @@ func RegisterFlagsDirectory(flags *[]cli.Flag) error {
 		&FlagScriptDir,
 		&FlagNodeUIDir,
 	)
-	return nil
 }
 
 // ParseFlagsDirectory function fills in directory options from CLI context
@@ -108,12 +108,36 @@ var (
 		Usage: "Restore connection automatically once it failed",
 		Value: false,
 	}
+	// FlagFailover keeps a standby connection to switch to once the active one fails.
+	FlagFailover = cli.BoolFlag{
+		Name:  "failover",
+		Usage: "Keep a standby session with a different provider and switch to it once the active connection fails. The standby session is paid for like the active one",
+		Value: false,
+	}
 	// FlagSTUNservers list of STUN server to be used to detect NAT type.
 	FlagSTUNservers = cli.StringSliceFlag{
 		Name:  "stun-servers",
 		Usage: "Comma separated list of STUN server to be used to detect NAT type",
 		Value: cli.NewStringSlice("stun.l.google.com:19302", "stun1.l.google.com:19302", "stun2.l.google.com:19302"),
 	}
+	// FlagNATBehaviorServers list of RFC 5780 compatible STUN servers used to detect NAT behavior.
+	FlagNATBehaviorServers = cli.StringSliceFlag{
+		Name:  "nat-behavior-servers",
+		Usage: "Comma separated list of RFC 5780 compatible STUN servers to be used to detect NAT behavior",
+		Value: cli.NewStringSlice("stun.mysterium.network:3478", "stun.stunprotocol.org:3478", "stun.sip.us:3478"),
+	}
+	// FlagSTUNServerPrimary primary address of the embedded STUN server.
+	FlagSTUNServerPrimary = cli.StringFlag{
+		Name:  "stun-server.primary",
+		Usage: "Primary IP:port of the embedded RFC 5780 STUN server, e.g. 203.0.113.1:3478. The server is not started if empty",
+		Value: "",
+	}
+	// FlagSTUNServerAlternate alternate address of the embedded STUN server.
+	FlagSTUNServerAlternate = cli.StringFlag{
+		Name:  "stun-server.alternate",
+		Usage: "Alternate IP:port of the embedded STUN server, both IP and port must differ from the primary one, e.g. 203.0.113.2:3479",
+		Value: "",
+	}
 	// FlagLocalServiceDiscovery enables SSDP and Bonjour local service discovery.
 	FlagLocalServiceDiscovery = cli.BoolFlag{
 		Name:  "local-service-discovery",
@@ -129,8 +153,8 @@ var (
 	// FlagTraversal order of NAT traversal methods to be used for providing service.
 	FlagTraversal = cli.StringFlag{
 		Name:  "traversal",
-		Usage: "Comma separated order of NAT traversal methods to be used for providing service",
-		Value: "manual,upnp,holepunching",
+		Usage: "Comma separated order of NAT traversal methods to be used for providing service. Relay is used only when listed, e.g. --traversal=manual,upnp,pcp,holepunching,relay, and its sessions are paid from the balance of the provider identity",
+		Value: "manual,upnp,pcp,holepunching",
 	}
 	// FlagPortCheckServers list of asymmetric UDP echo servers for checking port availability
 	FlagPortCheckServers = cli.StringFlag{
@@ -154,6 +178,23 @@ var (
 		Usage: "DNS listen port for services",
 		Value: 11253,
 	}
+	// FlagDNSCacheSize sets the number of answers cached by the provider DNS proxy.
+	FlagDNSCacheSize = cli.IntFlag{
+		Name:  "dns.cache-size",
+		Usage: "Maximum number of answers cached by the provider DNS proxy, 0 disables caching",
+		Value: 5000,
+	}
+	// FlagDNSBlocklist sets the files with domains which are not resolved by the provider DNS proxy.
+	FlagDNSBlocklist = cli.StringSliceFlag{
+		Name:  "dns.blocklist",
+		Usage: "Comma separated list of files with domains (one per line or hosts file format) blocked by the provider DNS proxy",
+	}
+	// FlagDNSBlocklistReload sets how often blocklist files are checked for changes.
+	FlagDNSBlocklistReload = cli.DurationFlag{
+		Name:  "dns.blocklist-reload",
+		Usage: "How often DNS blocklist files are checked for changes, 0 disables reloading",
+		Value: 10 * time.Minute,
+	}
 )
 
 // RegisterFlagsNetwork function register network flags to flag list
@@ -172,13 +213,20 @@ func RegisterFlagsNetwork(flags *[]cli.Flag) {
 		&FlagChainID,
 		&FlagKeepConnectedOnFail,
 		&FlagAutoReconnect,
+		&FlagFailover,
 		&FlagSTUNservers,
+		&FlagNATBehaviorServers,
+		&FlagSTUNServerPrimary,
+		&FlagSTUNServerAlternate,
 		&FlagLocalServiceDiscovery,
 		&FlagUDPListenPorts,
 		&FlagTraversal,
 		&FlagPortCheckServers,
 		&FlagStatsReportInterval,
 		&FlagDNSListenPort,
+		&FlagDNSCacheSize,
+		&FlagDNSBlocklist,
+		&FlagDNSBlocklistReload,
 	)
 }
 
@@ -196,13 +244,20 @@ func ParseFlagsNetwork(ctx *cli.Context) {
 	Current.ParseInt64Flag(ctx, FlagChainID)
 	Current.ParseBoolFlag(ctx, FlagKeepConnectedOnFail)
 	Current.ParseBoolFlag(ctx, FlagAutoReconnect)
+	Current.ParseBoolFlag(ctx, FlagFailover)
 	Current.ParseStringSliceFlag(ctx, FlagSTUNservers)
+	Current.ParseStringSliceFlag(ctx, FlagNATBehaviorServers)
+	Current.ParseStringFlag(ctx, FlagSTUNServerPrimary)
+	Current.ParseStringFlag(ctx, FlagSTUNServerAlternate)
 	Current.ParseBoolFlag(ctx, FlagLocalServiceDiscovery)
 	Current.ParseStringFlag(ctx, FlagUDPListenPorts)
 	Current.ParseStringFlag(ctx, FlagTraversal)
 	Current.ParseStringFlag(ctx, FlagPortCheckServers)
 	Current.ParseDurationFlag(ctx, FlagStatsReportInterval)
 	Current.ParseIntFlag(ctx, FlagDNSListenPort)
+	Current.ParseIntFlag(ctx, FlagDNSCacheSize)
+	Current.ParseStringSliceFlag(ctx, FlagDNSBlocklist)
+	Current.ParseDurationFlag(ctx, FlagDNSBlocklistReload)
 }
 
 // BlockchainNetwork defines a blockchain network
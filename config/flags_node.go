@@ -52,6 +52,30 @@ var (
 		Usage: `Proposal fetch interval { "30s", "3m", "1h20m30s" }`,
 		Value: 180 * time.Second,
 	}
+	// FlagDiscoveryRequireSignature rejects proposals without provider signature.
+	FlagDiscoveryRequireSignature = cli.BoolFlag{
+		Name:  "discovery.require-signature",
+		Usage: "Reject proposals which are not signed by their provider. Proposals with an invalid signature are always rejected",
+		Value: true,
+	}
+	// FlagDiscoverySnapshot keeps the last good set of proposals on disk.
+	FlagDiscoverySnapshot = cli.BoolFlag{
+		Name:  "discovery.snapshot",
+		Usage: "Keep the last good set of proposals on disk and use it while discovery is unreachable",
+		Value: true,
+	}
+	// FlagDiscoverySnapshotInterval proposal snapshot refresh interval.
+	FlagDiscoverySnapshotInterval = cli.DurationFlag{
+		Name:  "discovery.snapshot.interval",
+		Usage: `Proposal snapshot refresh interval { "15m", "1h" }`,
+		Value: 30 * time.Minute,
+	}
+	// FlagDiscoveryAutoBlockFailures blocks providers after consecutive failed connects.
+	FlagDiscoveryAutoBlockFailures = cli.IntFlag{
+		Name:  "discovery.auto-block-failures",
+		Usage: "Block a provider for the consumer after this many consecutive failed connects, 0 disables",
+		Value: 0,
+	}
 	// FlagDHTAddress IP address of interface to listen for DHT connections.
 	FlagDHTAddress = cli.StringFlag{
 		Name:  "discovery.dht.address",
@@ -123,6 +147,18 @@ var (
 		Usage: "Determines the scrypt memory complexity. If set to true, will use 4MB blocks instead of the standard 256MB ones",
 		Value: true,
 	}
+	// FlagKeystoreRemoteSigner address of the remote signer holding identity keys.
+	FlagKeystoreRemoteSigner = cli.StringFlag{
+		Name:  "keystore.remote-signer",
+		Usage: "HTTP URL or Unix socket path of a remote signer holding identity keys, e.g. Clef. New identities are created there and their keys never leave it",
+		Value: "",
+	}
+	// FlagKeystoreRemoteSignerMode tells which signatures the remote signer makes.
+	FlagKeystoreRemoteSignerMode = cli.StringFlag{
+		Name:  "keystore.remote-signer-mode",
+		Usage: "Signatures made by the remote signer: 'hash' for signers which sign plain hashes deterministically, 'clef' for stock Clef, which signs EIP-191 prefixed data only and can not sign payment promises",
+		Value: "hash",
+	}
 	// FlagLogHTTP enables HTTP payload logging.
 	FlagLogHTTP = cli.BoolFlag{
 		Name:  "log.http",
@@ -172,6 +208,18 @@ var (
 		),
 		Value: "https://quality.mysterium.network/api/v3",
 	}
+	// FlagProviderStatsSource source of provider statistics.
+	FlagProviderStatsSource = cli.StringFlag{
+		Name:  "provider.stats-source",
+		Usage: "Source of provider statistics. Options: (local - recorded by this node, remote - Quality Oracle, merged - both)",
+		Value: "remote",
+	}
+	// FlagProviderStatsBucket bucket size of locally computed provider statistics series.
+	FlagProviderStatsBucket = cli.DurationFlag{
+		Name:  "provider.stats-bucket",
+		Usage: "Bucket size of locally computed provider statistics series, 0 picks it by the requested range. Earnings are recorded hourly",
+		Value: 0,
+	}
 	// FlagTequilapiAddress IP address of interface to listen for incoming connections.
 	FlagTequilapiAddress = cli.StringFlag{
 		Name:  "tequilapi.address",
@@ -312,6 +360,12 @@ func RegisterFlagsNode(flags *[]cli.Flag) error {
 		return err
 	}
 
+	registerFlagsNode(flags)
+	return nil
+}
+
+// registerFlagsNode registers node flags, except the directory ones which need their defaults resolved.
+func registerFlagsNode(flags *[]cli.Flag) {
 	RegisterFlagsLocation(flags)
 	RegisterFlagsNetwork(flags)
 	RegisterFlagsTransactor(flags)
@@ -324,6 +378,7 @@ func RegisterFlagsNode(flags *[]cli.Flag) error {
 	RegisterFlagsUI(flags)
 	RegisterFlagsBlockchainNetwork(flags)
 	RegisterFlagsSSE(flags)
+	RegisterFlagsWebhook(flags)
 	RegisterFlagsServiceQuic(flags)
 
 	*flags = append(*flags,
@@ -332,6 +387,10 @@ func RegisterFlagsNode(flags *[]cli.Flag) error {
 		&FlagDiscoveryType,
 		&FlagDiscoveryPingInterval,
 		&FlagDiscoveryFetchInterval,
+		&FlagDiscoveryRequireSignature,
+		&FlagDiscoverySnapshot,
+		&FlagDiscoverySnapshotInterval,
+		&FlagDiscoveryAutoBlockFailures,
 		&FlagDHTAddress,
 		&FlagDHTPort,
 		&FlagDHTProtocol,
@@ -342,12 +401,16 @@ func RegisterFlagsNode(flags *[]cli.Flag) error {
 		&FlagShaperEnabled,
 		&FlagShaperBandwidth,
 		&FlagKeystoreLightweight,
+		&FlagKeystoreRemoteSigner,
+		&FlagKeystoreRemoteSignerMode,
 		&FlagLogHTTP,
 		&FlagLogLevel,
 		&FlagVerbose,
 		&FlagOpenvpnBinary,
 		&FlagQualityType,
 		&FlagQualityAddress,
+		&FlagProviderStatsSource,
+		&FlagProviderStatsBucket,
 		&FlagTequilapiAddress,
 		&FlagTequilapiAllowedHostnames,
 		&FlagTequilapiPort,
@@ -368,8 +431,6 @@ func RegisterFlagsNode(flags *[]cli.Flag) error {
 		&FlagResidentCountry,
 		&FlagWireguardMTU,
 	)
-
-	return nil
 }
 
 // ParseFlagsNode function fills in node options from CLI context
@@ -387,6 +448,7 @@ func ParseFlagsNode(ctx *cli.Context) {
 	ParseFlagsChains(ctx)
 	ParseFlagsUI(ctx)
 	ParseFlagsSSE(ctx)
+	ParseFlagsWebhook(ctx)
 	// it is important to have this one at the end so it overwrites defaults correctly
 	ParseFlagsServiceQuic(ctx)
 	ParseFlagsBlockchainNetwork(ctx)
@@ -396,6 +458,10 @@ func ParseFlagsNode(ctx *cli.Context) {
 	Current.ParseStringSliceFlag(ctx, FlagDiscoveryType)
 	Current.ParseDurationFlag(ctx, FlagDiscoveryPingInterval)
 	Current.ParseDurationFlag(ctx, FlagDiscoveryFetchInterval)
+	Current.ParseBoolFlag(ctx, FlagDiscoveryRequireSignature)
+	Current.ParseBoolFlag(ctx, FlagDiscoverySnapshot)
+	Current.ParseDurationFlag(ctx, FlagDiscoverySnapshotInterval)
+	Current.ParseIntFlag(ctx, FlagDiscoveryAutoBlockFailures)
 	Current.ParseStringFlag(ctx, FlagDHTAddress)
 	Current.ParseIntFlag(ctx, FlagDHTPort)
 	Current.ParseStringFlag(ctx, FlagDHTProtocol)
@@ -406,12 +472,16 @@ func ParseFlagsNode(ctx *cli.Context) {
 	Current.ParseBoolFlag(ctx, FlagShaperEnabled)
 	Current.ParseUInt64Flag(ctx, FlagShaperBandwidth)
 	Current.ParseBoolFlag(ctx, FlagKeystoreLightweight)
+	Current.ParseStringFlag(ctx, FlagKeystoreRemoteSigner)
+	Current.ParseStringFlag(ctx, FlagKeystoreRemoteSignerMode)
 	Current.ParseBoolFlag(ctx, FlagLogHTTP)
 	Current.ParseBoolFlag(ctx, FlagVerbose)
 	Current.ParseStringFlag(ctx, FlagLogLevel)
 	Current.ParseStringFlag(ctx, FlagOpenvpnBinary)
 	Current.ParseStringFlag(ctx, FlagQualityAddress)
 	Current.ParseStringFlag(ctx, FlagQualityType)
+	Current.ParseStringFlag(ctx, FlagProviderStatsSource)
+	Current.ParseDurationFlag(ctx, FlagProviderStatsBucket)
 	Current.ParseStringFlag(ctx, FlagTequilapiAddress)
 	Current.ParseStringFlag(ctx, FlagTequilapiAllowedHostnames)
 	Current.ParseIntFlag(ctx, FlagTequilapiPort)
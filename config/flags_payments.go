@@ -91,6 +91,31 @@ var (
 		Value: 0.05,
 		Usage: "The max percentage we allow to pay in fees when automatically settling promises.",
 	}
+	// FlagPaymentsSettleScheduler enables fee aware settlement scheduling.
+	FlagPaymentsSettleScheduler = cli.BoolFlag{
+		Name:  "payments.settle.scheduler",
+		Value: false,
+		Usage: "Settle zero stake channels periodically only when fees are low enough, instead of on every promise. Channels worth settling are settled together in a batch.",
+	}
+	// FlagPaymentsSettleSchedulerInterval determines how often the settlement scheduler evaluates channels.
+	FlagPaymentsSettleSchedulerInterval = cli.DurationFlag{
+		Name:   "payments.settle.scheduler-interval",
+		Value:  time.Hour,
+		Usage:  "The duration between settlement scheduler checks.",
+		Hidden: true,
+	}
+	// FlagPaymentsSettleTargetFeeRatio represents the max share of unsettled earnings the settlement scheduler pays in fees.
+	FlagPaymentsSettleTargetFeeRatio = cli.Float64Flag{
+		Name:  "payments.settle.target-fee-ratio",
+		Value: 0.02,
+		Usage: "The settlement scheduler settles once transactor and hermes fees are below this share of unsettled earnings.",
+	}
+	// FlagPaymentsSettleMaxUnsettledAge determines how long earnings can stay unsettled when the settlement scheduler is used.
+	FlagPaymentsSettleMaxUnsettledAge = cli.DurationFlag{
+		Name:  "payments.settle.max-unsettled-age",
+		Value: time.Hour * 24 * 30,
+		Usage: "The settlement scheduler settles earnings unsettled for this long regardless of the fee ratio, 0 disables the limit.",
+	}
 	// FlagPaymentsUnsettledMaxAmount determines the maximum amount of myst for which we will consider the fee threshold.
 	FlagPaymentsUnsettledMaxAmount = cli.Float64Flag{
 		Name:  "payments.unsettled.max-amount",
@@ -117,6 +142,12 @@ var (
 		Usage: "sets the data amount the consumer agrees to pay before establishing a session",
 		Value: metadata.MainnetDefinition.Payments.DataLeewayMegabytes,
 	}
+	// FlagPaymentsConsumerAcceptPriceIncrease allows providers to raise the price of an ongoing session.
+	FlagPaymentsConsumerAcceptPriceIncrease = cli.BoolFlag{
+		Name:  "payments.consumer.accept-price-increase",
+		Usage: "Accept session price increases proposed by the provider as long as the price stays within the current network price. Only decreases are accepted otherwise.",
+		Value: false,
+	}
 	// FlagPaymentsHermesStatusRecheckInterval sets how often we re-check the hermes status on bc. Higher values allow for less bc lookups but increase the risk for provider.
 	FlagPaymentsHermesStatusRecheckInterval = cli.DurationFlag{
 		Hidden: true,
@@ -193,6 +224,10 @@ func RegisterFlagsPayments(flags *[]cli.Flag) {
 		&FlagPaymentsHermesPromiseSettleThreshold,
 		&FlagPaymentsPromiseSettleMaxFeeThreshold,
 		&FlagPaymentsUnsettledMaxAmount,
+		&FlagPaymentsSettleScheduler,
+		&FlagPaymentsSettleSchedulerInterval,
+		&FlagPaymentsSettleTargetFeeRatio,
+		&FlagPaymentsSettleMaxUnsettledAge,
 		&FlagPaymentsHermesPromiseSettleTimeout,
 		&FlagPaymentsHermesPromiseSettleCheckInterval,
 		&FlagPaymentsLongBalancePollInterval,
@@ -201,6 +236,7 @@ func RegisterFlagsPayments(flags *[]cli.Flag) {
 		&FlagPaymentsRegistryTransactorPollTimeout,
 		&FlagPaymentsRegistryTransactorPollInterval,
 		&FlagPaymentsConsumerDataLeewayMegabytes,
+		&FlagPaymentsConsumerAcceptPriceIncrease,
 		&FlagPaymentsHermesStatusRecheckInterval,
 		&FlagOffchainBalanceExpiration,
 		&FlagPaymentsZeroStakeUnsettledAmount,
@@ -223,6 +259,10 @@ func ParseFlagsPayments(ctx *cli.Context) {
 	Current.ParseFloat64Flag(ctx, FlagPaymentsHermesPromiseSettleThreshold)
 	Current.ParseFloat64Flag(ctx, FlagPaymentsPromiseSettleMaxFeeThreshold)
 	Current.ParseFloat64Flag(ctx, FlagPaymentsUnsettledMaxAmount)
+	Current.ParseBoolFlag(ctx, FlagPaymentsSettleScheduler)
+	Current.ParseDurationFlag(ctx, FlagPaymentsSettleSchedulerInterval)
+	Current.ParseFloat64Flag(ctx, FlagPaymentsSettleTargetFeeRatio)
+	Current.ParseDurationFlag(ctx, FlagPaymentsSettleMaxUnsettledAge)
 	Current.ParseDurationFlag(ctx, FlagPaymentsHermesPromiseSettleTimeout)
 	Current.ParseDurationFlag(ctx, FlagPaymentsHermesPromiseSettleCheckInterval)
 	Current.ParseDurationFlag(ctx, FlagPaymentsFastBalancePollInterval)
@@ -232,6 +272,7 @@ func ParseFlagsPayments(ctx *cli.Context) {
 	Current.ParseDurationFlag(ctx, FlagPaymentsRegistryTransactorPollInterval)
 	Current.ParseDurationFlag(ctx, FlagPaymentsRegistryTransactorPollTimeout)
 	Current.ParseUInt64Flag(ctx, FlagPaymentsConsumerDataLeewayMegabytes)
+	Current.ParseBoolFlag(ctx, FlagPaymentsConsumerAcceptPriceIncrease)
 	Current.ParseDurationFlag(ctx, FlagPaymentsHermesStatusRecheckInterval)
 	Current.ParseDurationFlag(ctx, FlagOffchainBalanceExpiration)
 	Current.ParseFloat64Flag(ctx, FlagPaymentsZeroStakeUnsettledAmount)
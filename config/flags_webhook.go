@@ -0,0 +1,76 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package config
+
+import (
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	// FlagWebhookURL endpoints which receive node events.
+	FlagWebhookURL = cli.StringSliceFlag{
+		Name:  "webhook.url",
+		Usage: "HTTP endpoint to deliver node events to. Can be repeated to deliver to several endpoints",
+		Value: cli.NewStringSlice(),
+	}
+	// FlagWebhookSecret shared secret used to sign webhook payloads.
+	FlagWebhookSecret = cli.StringFlag{
+		Name:  "webhook.secret",
+		Usage: "Shared secret used to sign webhook payloads with HMAC-SHA256",
+		Value: "",
+	}
+	// FlagWebhookEvents events delivered to webhooks.
+	FlagWebhookEvents = cli.StringSliceFlag{
+		Name:  "webhook.events",
+		Usage: "Webhook events to deliver, e.g. session.created,invoice.paid. All events are delivered if empty",
+		Value: cli.NewStringSlice(),
+	}
+	// FlagWebhookMaxAttempts delivery attempts before an event is dropped.
+	FlagWebhookMaxAttempts = cli.IntFlag{
+		Name:  "webhook.max-attempts",
+		Usage: "Number of delivery attempts before a webhook event is dropped",
+		Value: 10,
+	}
+	// FlagWebhookMaxQueued pending events kept per endpoint.
+	FlagWebhookMaxQueued = cli.IntFlag{
+		Name:  "webhook.max-queued",
+		Usage: "Number of pending webhook events kept per endpoint, the oldest events are dropped past it",
+		Value: 1000,
+	}
+)
+
+// RegisterFlagsWebhook function register webhook flags to flag list
+func RegisterFlagsWebhook(flags *[]cli.Flag) {
+	*flags = append(
+		*flags,
+		&FlagWebhookURL,
+		&FlagWebhookSecret,
+		&FlagWebhookEvents,
+		&FlagWebhookMaxAttempts,
+		&FlagWebhookMaxQueued,
+	)
+}
+
+// ParseFlagsWebhook function fills in webhook options from CLI context
+func ParseFlagsWebhook(ctx *cli.Context) {
+	Current.ParseStringSliceFlag(ctx, FlagWebhookURL)
+	Current.ParseStringFlag(ctx, FlagWebhookSecret)
+	Current.ParseStringSliceFlag(ctx, FlagWebhookEvents)
+	Current.ParseIntFlag(ctx, FlagWebhookMaxAttempts)
+	Current.ParseIntFlag(ctx, FlagWebhookMaxQueued)
+}
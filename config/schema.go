@@ -0,0 +1,288 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/rs/zerolog"
+	"github.com/spf13/cast"
+	"github.com/urfave/cli/v2"
+)
+
+// ValueType is a type of configuration value.
+type ValueType string
+
+// Configuration value types.
+const (
+	TypeBool        ValueType = "bool"
+	TypeInt         ValueType = "int"
+	TypeUint        ValueType = "uint"
+	TypeFloat       ValueType = "float"
+	TypeDuration    ValueType = "duration"
+	TypeString      ValueType = "string"
+	TypeStringSlice ValueType = "string_slice"
+)
+
+// FlagSchema describes a configuration value backed by a CLI flag.
+type FlagSchema struct {
+	Key   string    `json:"key"`
+	Type  ValueType `json:"type"`
+	Usage string    `json:"usage,omitempty"`
+	Min   *float64  `json:"min,omitempty"`
+	Max   *float64  `json:"max,omitempty"`
+	Enum  []string  `json:"enum,omitempty"`
+	// RestartRequired is false for values which are applied live.
+	RestartRequired bool `json:"restart_required"`
+}
+
+type constraint struct {
+	min, max *float64
+	enum     []string
+}
+
+func bound(v float64) *float64 {
+	return &v
+}
+
+var portRange = constraint{min: bound(0), max: bound(65535)}
+
+// constraints limit values of flags further than their type does.
+var constraints = map[string]constraint{
+	FlagTequilapiPort.Name:                        portRange,
+	FlagUIPort.Name:                               portRange,
+	FlagOpenvpnPort.Name:                          portRange,
+	FlagDNSListenPort.Name:                        portRange,
+	FlagDHTPort.Name:                              portRange,
+	FlagDNSCacheSize.Name:                         {min: bound(0)},
+	FlagWireguardMTU.Name:                         {min: bound(0), max: bound(9000)},
+	FlagShaperBandwidth.Name:                      {min: bound(1)},
+	FlagWebhookMaxAttempts.Name:                   {min: bound(1)},
+	FlagDiscoveryAutoBlockFailures.Name:           {min: bound(0)},
+	FlagPaymentsHermesPromiseSettleThreshold.Name: {min: bound(0), max: bound(1)},
+	FlagPaymentsPromiseSettleMaxFeeThreshold.Name: {min: bound(0), max: bound(1)},
+	FlagPaymentsSettleTargetFeeRatio.Name:         {min: bound(0), max: bound(1)},
+	FlagOpenvpnProtocol.Name:                      {enum: []string{"udp", "tcp"}},
+	FlagProviderStatsSource.Name:                  {enum: []string{"local", "remote", "merged"}},
+	FlagLogLevel.Name: {enum: []string{
+		zerolog.TraceLevel.String(),
+		zerolog.DebugLevel.String(),
+		zerolog.InfoLevel.String(),
+		zerolog.WarnLevel.String(),
+		zerolog.ErrorLevel.String(),
+		zerolog.FatalLevel.String(),
+		zerolog.PanicLevel.String(),
+	}},
+}
+
+// reloadable flags are either read on every use or have a subscriber
+// to their AppTopicConfig topic, so they are applied without restart.
+var reloadable = map[string]struct{}{
+	FlagShaperEnabled.Name:                    {},
+	FlagShaperBandwidth.Name:                  {},
+	FlagLogLevel.Name:                         {},
+	FlagKeepConnectedOnFail.Name:              {},
+	FlagFailover.Name:                         {},
+	FlagPaymentsDuringSessionDebug.Name:       {},
+	FlagPaymentsAmountDuringSessionDebug.Name: {},
+}
+
+// ValidationError is returned for configuration values not matching their schema.
+type ValidationError struct {
+	Key string
+	Err error
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid value of %q: %v", e.Key, e.Err)
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// ErrUnknownKey is returned for configuration keys not backed by any registered flag.
+var ErrUnknownKey = errors.New("unknown configuration key")
+
+var (
+	schemaOnce sync.Once
+	schema     map[string]FlagSchema
+)
+
+// registeredFlags returns flags registered by the node and its service command.
+func registeredFlags() []cli.Flag {
+	var flags []cli.Flag
+	registerFlagsDirectory(&flags)
+	registerFlagsNode(&flags)
+	RegisterFlagsServiceStart(&flags)
+	RegisterFlagsServiceOpenvpn(&flags)
+	RegisterFlagsServiceWireguard(&flags)
+	RegisterFlagsServiceNoop(&flags)
+	RegisterFlagsControlPlane(&flags)
+	RegisterFlagNodeVersion(&flags)
+	return flags
+}
+
+// flagSchema returns schema of all registered flags by key.
+func flagSchema() map[string]FlagSchema {
+	schemaOnce.Do(func() {
+		schema = make(map[string]FlagSchema)
+		for _, flag := range registeredFlags() {
+			if s, ok := describe(flag); ok {
+				schema[s.Key] = s
+			}
+		}
+	})
+	return schema
+}
+
+// describe returns schema of the flag, flags of unsupported types are not described.
+func describe(flag cli.Flag) (FlagSchema, bool) {
+	var s FlagSchema
+	switch f := flag.(type) {
+	case *cli.BoolFlag:
+		s = FlagSchema{Type: TypeBool, Usage: f.Usage}
+	case *cli.IntFlag:
+		s = FlagSchema{Type: TypeInt, Usage: f.Usage}
+	case *cli.Int64Flag:
+		s = FlagSchema{Type: TypeInt, Usage: f.Usage}
+	case *cli.Uint64Flag:
+		s = FlagSchema{Type: TypeUint, Usage: f.Usage}
+	case *cli.Float64Flag:
+		s = FlagSchema{Type: TypeFloat, Usage: f.Usage}
+	case *cli.DurationFlag:
+		s = FlagSchema{Type: TypeDuration, Usage: f.Usage}
+	case *cli.StringFlag:
+		s = FlagSchema{Type: TypeString, Usage: f.Usage}
+	case *cli.StringSliceFlag:
+		s = FlagSchema{Type: TypeStringSlice, Usage: f.Usage}
+	default:
+		return FlagSchema{}, false
+	}
+
+	s.Key = strings.ToLower(flag.Names()[0])
+	s.RestartRequired = true
+	if c, ok := constraints[s.Key]; ok {
+		s.Min, s.Max, s.Enum = c.min, c.max, c.enum
+	}
+	if _, ok := reloadable[s.Key]; ok {
+		s.RestartRequired = false
+	}
+	return s, true
+}
+
+// Schema returns schema of all registered flags ordered by key.
+func (cfg *Config) Schema() []FlagSchema {
+	all := flagSchema()
+	res := make([]FlagSchema, 0, len(all))
+	for _, s := range all {
+		res = append(res, s)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Key < res[j].Key
+	})
+	return res
+}
+
+// Describe returns schema of the given key.
+func (cfg *Config) Describe(key string) (FlagSchema, bool) {
+	s, ok := flagSchema()[strings.ToLower(key)]
+	return s, ok
+}
+
+// Validate checks the value against schema of the key.
+// Keys not backed by a registered flag are rejected with ErrUnknownKey.
+func (cfg *Config) Validate(key string, value interface{}) error {
+	s, ok := cfg.Describe(key)
+	if !ok {
+		return &ValidationError{Key: strings.ToLower(key), Err: ErrUnknownKey}
+	}
+	if err := s.validate(value); err != nil {
+		return &ValidationError{Key: s.Key, Err: err}
+	}
+	return nil
+}
+
+func (s FlagSchema) validate(value interface{}) error {
+	var (
+		number float64
+		err    error
+	)
+	switch s.Type {
+	case TypeBool:
+		_, err = cast.ToBoolE(value)
+		return err
+	case TypeInt:
+		var v int64
+		v, err = cast.ToInt64E(value)
+		number = float64(v)
+	case TypeUint:
+		if v, _ := cast.ToInt64E(value); v < 0 {
+			return fmt.Errorf("must not be negative")
+		}
+		var v uint64
+		v, err = cast.ToUint64E(value)
+		number = float64(v)
+	case TypeFloat:
+		number, err = cast.ToFloat64E(value)
+	case TypeDuration:
+		_, err = cast.ToDurationE(value)
+		return err
+	case TypeString:
+		var v string
+		if v, err = cast.ToStringE(value); err != nil {
+			return err
+		}
+		return s.validateEnum(v)
+	case TypeStringSlice:
+		if _, ok := value.(string); ok {
+			return nil
+		}
+		_, err = cast.ToStringSliceE(value)
+		return err
+	default:
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if s.Min != nil && number < *s.Min {
+		return fmt.Errorf("must be at least %v", *s.Min)
+	}
+	if s.Max != nil && number > *s.Max {
+		return fmt.Errorf("must be at most %v", *s.Max)
+	}
+	return nil
+}
+
+func (s FlagSchema) validateEnum(value string) error {
+	if len(s.Enum) == 0 {
+		return nil
+	}
+	for _, allowed := range s.Enum {
+		if strings.EqualFold(allowed, value) {
+			return nil
+		}
+	}
+	return fmt.Errorf("must be one of %s", strings.Join(s.Enum, ", "))
+}
@@ -0,0 +1,159 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package config
+
+import (
+	"flag"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+
+	"github.com/mysteriumnetwork/node/eventbus"
+)
+
+func parsedConfig(t *testing.T) *Config {
+	cfg := NewConfig()
+	flagSet := flag.NewFlagSet("", flag.ContinueOnError)
+	ctx := cli.NewContext(nil, flagSet, nil)
+
+	cfg.ParseUInt64Flag(ctx, FlagShaperBandwidth)
+	cfg.ParseBoolFlag(ctx, FlagShaperEnabled)
+	cfg.ParseIntFlag(ctx, FlagTequilapiPort)
+	cfg.ParseStringFlag(ctx, FlagLogLevel)
+	cfg.ParseDurationFlag(ctx, FlagDiscoveryPingInterval)
+	cfg.ParseFloat64Flag(ctx, FlagPaymentsSettleTargetFeeRatio)
+	return cfg
+}
+
+func TestConfig_Schema(t *testing.T) {
+	cfg := NewConfig()
+
+	schema, ok := cfg.Describe(FlagShaperBandwidth.Name)
+	require.True(t, ok)
+	assert.Equal(t, TypeUint, schema.Type)
+	assert.False(t, schema.RestartRequired)
+	assert.Equal(t, 1.0, *schema.Min)
+
+	schema, ok = cfg.Describe(FlagTequilapiPort.Name)
+	require.True(t, ok)
+	assert.Equal(t, TypeInt, schema.Type)
+	assert.True(t, schema.RestartRequired)
+	assert.Equal(t, 65535.0, *schema.Max)
+
+	// Flags are described without being parsed, including the ones of the service command.
+	schema, ok = cfg.Describe(FlagOpenvpnProtocol.Name)
+	require.True(t, ok)
+	assert.Equal(t, TypeString, schema.Type)
+	assert.Equal(t, []string{"udp", "tcp"}, schema.Enum)
+
+	_, ok = cfg.Describe("not.a.flag")
+	assert.False(t, ok)
+
+	all := cfg.Schema()
+	assert.Len(t, all, len(flagSchema()))
+	for i := 1; i < len(all); i++ {
+		assert.Less(t, all[i-1].Key, all[i].Key)
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	cfg := parsedConfig(t)
+
+	var tests = []struct {
+		key   string
+		value interface{}
+		valid bool
+	}{
+		{FlagShaperBandwidth.Name, "5000", true},
+		{FlagShaperBandwidth.Name, 5000.0, true},
+		{FlagShaperBandwidth.Name, "0", false},
+		{FlagShaperBandwidth.Name, -1.0, false},
+		{FlagShaperEnabled.Name, "true", true},
+		{FlagShaperEnabled.Name, "yes please", false},
+		{FlagTequilapiPort.Name, 70000.0, false},
+		{FlagLogLevel.Name, "DEBUG", true},
+		{FlagLogLevel.Name, "loud", false},
+		{FlagDiscoveryPingInterval.Name, "3m", true},
+		{FlagDiscoveryPingInterval.Name, "soon", false},
+		{FlagPaymentsSettleTargetFeeRatio.Name, 1.5, false},
+		{"not.a.flag", "anything", false},
+	}
+	for _, tc := range tests {
+		err := cfg.Validate(tc.key, tc.value)
+		if tc.valid {
+			assert.NoError(t, err, "%s=%v", tc.key, tc.value)
+		} else {
+			assert.Error(t, err, "%s=%v", tc.key, tc.value)
+		}
+	}
+}
+
+func TestConfig_DiffUser(t *testing.T) {
+	cfg := parsedConfig(t)
+	cfg.SetUser(FlagLogLevel.Name, "trace")
+	cfg.SetCLI(FlagTequilapiPort.Name, 5000)
+
+	changes, err := cfg.DiffUser(map[string]interface{}{
+		FlagShaperBandwidth.Name: "100",
+		FlagShaperEnabled.Name:   false,
+		FlagLogLevel.Name:        nil,
+		FlagTequilapiPort.Name:   4100,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []Change{
+		{Key: FlagLogLevel.Name, Old: "trace", New: FlagLogLevel.Value},
+		{Key: FlagShaperBandwidth.Name, Old: FlagShaperBandwidth.Value, New: "100"},
+		{Key: FlagTequilapiPort.Name, Old: 5000, New: 5000, RestartRequired: true, OverriddenByCLI: true},
+	}, changes)
+	assert.Equal(t, "trace", cfg.GetString(FlagLogLevel.Name), "diff must not apply changes")
+
+	_, err = cfg.DiffUser(map[string]interface{}{FlagTequilapiPort.Name: -1})
+	var verr *ValidationError
+	require.ErrorAs(t, err, &verr)
+	assert.Equal(t, FlagTequilapiPort.Name, verr.Key)
+
+	_, err = cfg.DiffUser(map[string]interface{}{"Shaper.Bandwith": 100})
+	require.ErrorAs(t, err, &verr)
+	assert.ErrorIs(t, err, ErrUnknownKey)
+	assert.Equal(t, "shaper.bandwith", verr.Key)
+
+	// Stale keys can still be removed.
+	_, err = cfg.DiffUser(map[string]interface{}{"not.a.flag": nil})
+	assert.NoError(t, err)
+}
+
+func TestConfig_PublishesChanges(t *testing.T) {
+	cfg := parsedConfig(t)
+	bus := eventbus.New()
+	cfg.EnableEventPublishing(bus)
+
+	var events []AppEventConfigChanged
+	require.NoError(t, bus.Subscribe(AppTopicConfig(FlagShaperBandwidth.Name), func(e AppEventConfigChanged) {
+		events = append(events, e)
+	}))
+
+	cfg.SetUser(FlagShaperBandwidth.Name, 100)
+	cfg.RemoveUser(FlagShaperBandwidth.Name)
+
+	assert.Equal(t, []AppEventConfigChanged{
+		{Key: FlagShaperBandwidth.Name, Value: 100},
+		{Key: FlagShaperBandwidth.Name, Value: FlagShaperBandwidth.Value},
+	}, events)
+}
@@ -0,0 +1,225 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package backup
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+// FormatVersion is the archive format version produced by this node.
+const FormatVersion = 1
+
+const (
+	manifestName = "manifest.json"
+	saltSize     = 16
+)
+
+var magic = []byte("MYSTBAK\x01")
+
+// ErrBadPassphrase is returned when archive can not be decrypted with the given passphrase.
+var ErrBadPassphrase = errors.New("wrong passphrase or corrupted backup")
+
+// Manifest describes contents of a backup archive.
+type Manifest struct {
+	FormatVersion int         `json:"format_version"`
+	NodeVersion   string      `json:"node_version"`
+	CreatedAt     time.Time   `json:"created_at"`
+	Files         []FileEntry `json:"files"`
+}
+
+// FileEntry describes a single file of a backup archive.
+type FileEntry struct {
+	Path   string `json:"path"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// archive is a decrypted backup.
+type archive struct {
+	manifest Manifest
+	files    map[string][]byte
+}
+
+func (a *archive) add(path string, content []byte) {
+	sum := sha256.Sum256(content)
+	a.manifest.Files = append(a.manifest.Files, FileEntry{
+		Path:   path,
+		Size:   int64(len(content)),
+		SHA256: hex.EncodeToString(sum[:]),
+	})
+	a.files[path] = content
+}
+
+// pack writes archive as gzipped tar with the manifest first.
+func (a *archive) pack() ([]byte, error) {
+	manifest, err := json.MarshalIndent(a.manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	write := func(name string, content []byte) error {
+		if err := tw.WriteHeader(&tar.Header{
+			Name:    name,
+			Mode:    0600,
+			Size:    int64(len(content)),
+			ModTime: a.manifest.CreatedAt,
+		}); err != nil {
+			return err
+		}
+		_, err := tw.Write(content)
+		return err
+	}
+
+	if err := write(manifestName, manifest); err != nil {
+		return nil, err
+	}
+	for _, f := range a.manifest.Files {
+		if err := write(f.Path, a.files[f.Path]); err != nil {
+			return nil, err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// unpack reads gzipped tar and verifies files against the manifest.
+func unpack(packed []byte) (*archive, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(packed))
+	if err != nil {
+		return nil, fmt.Errorf("could not read backup: %w", err)
+	}
+	tr := tar.NewReader(gz)
+
+	a := &archive{files: make(map[string][]byte)}
+	var manifestFound bool
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not read backup: %w", err)
+		}
+		content, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("could not read backup: %w", err)
+		}
+		if hdr.Name == manifestName {
+			if err := json.Unmarshal(content, &a.manifest); err != nil {
+				return nil, fmt.Errorf("could not parse backup manifest: %w", err)
+			}
+			manifestFound = true
+			continue
+		}
+		a.files[hdr.Name] = content
+	}
+	if !manifestFound {
+		return nil, errors.New("backup manifest is missing")
+	}
+
+	for _, f := range a.manifest.Files {
+		content, ok := a.files[f.Path]
+		if !ok {
+			return nil, fmt.Errorf("backup file %s is missing", f.Path)
+		}
+		sum := sha256.Sum256(content)
+		if hex.EncodeToString(sum[:]) != f.SHA256 || int64(len(content)) != f.Size {
+			return nil, fmt.Errorf("backup file %s is corrupted", f.Path)
+		}
+	}
+	return a, nil
+}
+
+// encrypt seals packed archive with a key derived from the passphrase.
+func encrypt(passphrase string, packed []byte) ([]byte, error) {
+	salt := make([]byte, saltSize)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, err
+	}
+	gcm, err := newCipher(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	header := append(append(append([]byte{}, magic...), salt...), nonce...)
+	return gcm.Seal(header, nonce, packed, header[:len(magic)+saltSize]), nil
+}
+
+// decrypt opens archive sealed by encrypt.
+func decrypt(passphrase string, sealed []byte) ([]byte, error) {
+	if len(sealed) < len(magic)+saltSize || !bytes.Equal(sealed[:len(magic)], magic) {
+		return nil, errors.New("not a node backup")
+	}
+	salt := sealed[len(magic) : len(magic)+saltSize]
+	gcm, err := newCipher(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	rest := sealed[len(magic)+saltSize:]
+	if len(rest) < gcm.NonceSize() {
+		return nil, ErrBadPassphrase
+	}
+	nonce, ciphertext := rest[:gcm.NonceSize()], rest[gcm.NonceSize():]
+	packed, err := gcm.Open(nil, nonce, ciphertext, sealed[:len(magic)+saltSize])
+	if err != nil {
+		return nil, ErrBadPassphrase
+	}
+	return packed, nil
+}
+
+func newCipher(passphrase string, salt []byte) (cipher.AEAD, error) {
+	if passphrase == "" {
+		return nil, errors.New("backup passphrase is required")
+	}
+	key, err := scrypt.Key([]byte(passphrase), salt, 1<<15, 8, 1, 32)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
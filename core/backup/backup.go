@@ -0,0 +1,381 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package backup
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.etcd.io/bbolt"
+
+	"github.com/mysteriumnetwork/node/core/storage/boltdb"
+	"github.com/mysteriumnetwork/node/metadata"
+)
+
+const (
+	dbFile            = "myst.db"
+	credentialsFile   = "nodeui-pass" // maintained by auth.CredentialsManager
+	pendingFile       = "backup-restore.pending"
+	dbOpenTimeout     = time.Second
+	archiveDB         = "db/" + dbFile
+	archiveKeystore   = "keystore/"
+	archiveUserConfig = "config/user.toml"
+	archiveCredential = "data/" + credentialsFile
+)
+
+// ErrNodeRunning is returned when database is locked by a running node.
+var ErrNodeRunning = errors.New("node is running, use the node API to backup or restore")
+
+// Paths points to node state included into a backup.
+type Paths struct {
+	// Data directory holding UI credentials.
+	Data string
+	// Keystore directory holding identity keys.
+	Keystore string
+	// Storage directory holding the database.
+	Storage string
+	// UserConfig file, optional.
+	UserConfig string
+}
+
+// Backup creates and restores encrypted node backups.
+type Backup struct {
+	paths Paths
+	db    *boltdb.Bolt
+}
+
+// NewBackup returns a new Backup of node state at given paths.
+// Database of a running node should be passed in, otherwise the database file is opened directly.
+func NewBackup(paths Paths, db *boltdb.Bolt) *Backup {
+	return &Backup{
+		paths: paths,
+		db:    db,
+	}
+}
+
+// Create returns an archive of node state encrypted with the given passphrase.
+func (b *Backup) Create(passphrase string) ([]byte, Manifest, error) {
+	if passphrase == "" {
+		return nil, Manifest{}, errors.New("backup passphrase is required")
+	}
+
+	a := &archive{
+		manifest: Manifest{
+			FormatVersion: FormatVersion,
+			NodeVersion:   metadata.VersionAsString(),
+			CreatedAt:     time.Now().UTC(),
+		},
+		files: make(map[string][]byte),
+	}
+
+	db, err := b.snapshotDB()
+	if err != nil {
+		return nil, Manifest{}, err
+	}
+	if db != nil {
+		a.add(archiveDB, db)
+	}
+
+	keys, err := os.ReadDir(b.paths.Keystore)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, Manifest{}, fmt.Errorf("could not read keystore: %w", err)
+	}
+	for _, key := range keys {
+		if !key.Type().IsRegular() {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(b.paths.Keystore, key.Name()))
+		if err != nil {
+			return nil, Manifest{}, fmt.Errorf("could not read keystore: %w", err)
+		}
+		a.add(archiveKeystore+key.Name(), content)
+	}
+
+	for name, file := range map[string]string{
+		archiveUserConfig: b.paths.UserConfig,
+		archiveCredential: filepath.Join(b.paths.Data, credentialsFile),
+	} {
+		if file == "" {
+			continue
+		}
+		content, err := os.ReadFile(file)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, Manifest{}, fmt.Errorf("could not read %s: %w", file, err)
+		}
+		a.add(name, content)
+	}
+
+	packed, err := a.pack()
+	if err != nil {
+		return nil, Manifest{}, fmt.Errorf("could not pack backup: %w", err)
+	}
+	sealed, err := encrypt(passphrase, packed)
+	if err != nil {
+		return nil, Manifest{}, fmt.Errorf("could not encrypt backup: %w", err)
+	}
+	return sealed, a.manifest, nil
+}
+
+// Restore decrypts the archive and overwrites node state with it.
+// It must not be used while the node is running, see Stage.
+func (b *Backup) Restore(passphrase string, sealed []byte) (Manifest, error) {
+	a, _, err := b.open(passphrase, sealed)
+	if err != nil {
+		return Manifest{}, err
+	}
+	if err := b.restore(a); err != nil {
+		return Manifest{}, err
+	}
+	return a.manifest, nil
+}
+
+// Stage validates the archive and leaves it to be restored by ApplyPending on the next node start.
+func (b *Backup) Stage(passphrase string, sealed []byte) (Manifest, error) {
+	a, packed, err := b.open(passphrase, sealed)
+	if err != nil {
+		return Manifest{}, err
+	}
+	if err := writeFileAtomic(filepath.Join(b.paths.Data, pendingFile), packed); err != nil {
+		return Manifest{}, fmt.Errorf("could not stage backup: %w", err)
+	}
+	return a.manifest, nil
+}
+
+// ApplyPending restores a backup staged by Stage, if any. It must be called before the database is opened.
+// Staged backup is discarded even if it fails to apply, so a bad backup does not block node start.
+func (b *Backup) ApplyPending() (*Manifest, error) {
+	file := filepath.Join(b.paths.Data, pendingFile)
+	packed, err := os.ReadFile(file)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not read staged backup: %w", err)
+	}
+	defer os.Remove(file)
+
+	a, err := unpack(packed)
+	if err != nil {
+		return nil, err
+	}
+	if err := b.check(a); err != nil {
+		return nil, err
+	}
+	if err := b.restore(a); err != nil {
+		return nil, err
+	}
+	return &a.manifest, nil
+}
+
+func (b *Backup) open(passphrase string, sealed []byte) (*archive, []byte, error) {
+	packed, err := decrypt(passphrase, sealed)
+	if err != nil {
+		return nil, nil, err
+	}
+	a, err := unpack(packed)
+	if err != nil {
+		return nil, nil, err
+	}
+	return a, packed, b.check(a)
+}
+
+// check refuses archives this node can not restore safely.
+func (b *Backup) check(a *archive) error {
+	if a.manifest.FormatVersion > FormatVersion {
+		return fmt.Errorf("backup format version %d is not supported, upgrade the node", a.manifest.FormatVersion)
+	}
+	if newerVersion(a.manifest.NodeVersion, metadata.VersionAsString()) {
+		return fmt.Errorf("backup was created by newer node version %s, upgrade the node", a.manifest.NodeVersion)
+	}
+	for name := range a.files {
+		if !allowedPath(name) {
+			return fmt.Errorf("backup contains unexpected file %s", name)
+		}
+	}
+
+	archived, ok := a.files[archiveDB]
+	if !ok {
+		return nil
+	}
+	current, err := b.currentPromises()
+	if err != nil {
+		return err
+	}
+	restored, err := archivedPromises(archived)
+	if err != nil {
+		return err
+	}
+	return checkPromises(current, restored)
+}
+
+func (b *Backup) restore(a *archive) error {
+	for name, content := range a.files {
+		var file string
+		switch {
+		case name == archiveDB:
+			file = filepath.Join(b.paths.Storage, dbFile)
+		case name == archiveUserConfig:
+			if b.paths.UserConfig == "" {
+				continue
+			}
+			file = b.paths.UserConfig
+		case name == archiveCredential:
+			file = filepath.Join(b.paths.Data, credentialsFile)
+		default:
+			file = filepath.Join(b.paths.Keystore, strings.TrimPrefix(name, archiveKeystore))
+		}
+
+		if err := os.MkdirAll(filepath.Dir(file), 0700); err != nil {
+			return fmt.Errorf("could not restore %s: %w", name, err)
+		}
+		if err := writeFileAtomic(file, content); err != nil {
+			return fmt.Errorf("could not restore %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// snapshotDB returns a consistent copy of the database, or nil if there is none yet.
+func (b *Backup) snapshotDB() ([]byte, error) {
+	var buf bytes.Buffer
+	snapshot := func(db *bbolt.DB) error {
+		return db.View(func(tx *bbolt.Tx) error {
+			_, err := tx.WriteTo(&buf)
+			return err
+		})
+	}
+
+	if b.db != nil {
+		b.db.RLock()
+		defer b.db.RUnlock()
+		if err := snapshot(b.db.DB().Bolt); err != nil {
+			return nil, fmt.Errorf("could not read database: %w", err)
+		}
+		return buf.Bytes(), nil
+	}
+
+	db, err := openReadOnly(filepath.Join(b.paths.Storage, dbFile))
+	if db == nil || err != nil {
+		return nil, err
+	}
+	defer db.Close()
+	if err := snapshot(db); err != nil {
+		return nil, fmt.Errorf("could not read database: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func (b *Backup) currentPromises() (map[string]promiseState, error) {
+	if b.db != nil {
+		b.db.RLock()
+		defer b.db.RUnlock()
+		return readPromises(b.db.DB().Bolt)
+	}
+
+	db, err := openReadOnly(filepath.Join(b.paths.Storage, dbFile))
+	if db == nil || err != nil {
+		return nil, err
+	}
+	defer db.Close()
+	return readPromises(db)
+}
+
+// openReadOnly opens existing database file, returns nil if it does not exist.
+func openReadOnly(file string) (*bbolt.DB, error) {
+	if _, err := os.Stat(file); errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	db, err := bbolt.Open(file, 0600, &bbolt.Options{ReadOnly: true, Timeout: dbOpenTimeout})
+	if errors.Is(err, bbolt.ErrTimeout) {
+		return nil, ErrNodeRunning
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not open database: %w", err)
+	}
+	return db, nil
+}
+
+func allowedPath(name string) bool {
+	switch name {
+	case archiveDB, archiveUserConfig, archiveCredential:
+		return true
+	}
+	key := strings.TrimPrefix(name, archiveKeystore)
+	return key != name && key != "" && path.Base(key) == key && key != "." && key != ".."
+}
+
+func writeFileAtomic(file string, content []byte) error {
+	tmp := file + ".tmp"
+	if err := os.WriteFile(tmp, content, 0600); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, file); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+// newerVersion reports whether version a is newer than b. Versions which are not
+// semantic, e.g. development builds, are never considered newer.
+func newerVersion(a, b string) bool {
+	av, ok := parseVersion(a)
+	if !ok {
+		return false
+	}
+	bv, ok := parseVersion(b)
+	if !ok {
+		return false
+	}
+	for i := range av {
+		if av[i] != bv[i] {
+			return av[i] > bv[i]
+		}
+	}
+	return false
+}
+
+func parseVersion(v string) ([3]int, bool) {
+	var parsed [3]int
+	v = strings.TrimPrefix(v, "v")
+	if i := strings.IndexAny(v, "-+"); i >= 0 {
+		v = v[:i]
+	}
+	parts := strings.Split(v, ".")
+	if len(parts) != len(parsed) {
+		return parsed, false
+	}
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return parsed, false
+		}
+		parsed[i] = n
+	}
+	return parsed, true
+}
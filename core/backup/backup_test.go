@@ -0,0 +1,193 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package backup
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mysteriumnetwork/payments/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mysteriumnetwork/node/core/storage/boltdb"
+	"github.com/mysteriumnetwork/node/identity"
+	"github.com/mysteriumnetwork/node/metadata"
+	"github.com/mysteriumnetwork/node/session/pingpong"
+)
+
+const passphrase = "correct horse battery staple"
+
+func newPaths(t *testing.T) Paths {
+	dir := t.TempDir()
+	return Paths{
+		Data:       dir,
+		Keystore:   filepath.Join(dir, "keystore"),
+		Storage:    filepath.Join(dir, "mainnet", "db"),
+		UserConfig: filepath.Join(dir, "config-mainnet.toml"),
+	}
+}
+
+func newNode(t *testing.T, amount int64) Paths {
+	paths := newPaths(t)
+	require.NoError(t, os.MkdirAll(paths.Keystore, 0700))
+	require.NoError(t, os.MkdirAll(paths.Storage, 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(paths.Keystore, "UTC--key"), []byte("key"), 0600))
+	require.NoError(t, os.WriteFile(paths.UserConfig, []byte("[payments]\n"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(paths.Data, credentialsFile), []byte("hash"), 0600))
+
+	db, err := boltdb.NewStorage(paths.Storage)
+	require.NoError(t, err)
+	defer db.Close()
+	storePromise(t, db, amount)
+	return paths
+}
+
+func storePromise(t *testing.T, db *boltdb.Bolt, amount int64) {
+	err := pingpong.NewHermesPromiseStorage(db).Store(pingpong.HermesPromise{
+		ChannelID: "0x1",
+		Identity:  identity.FromAddress("0x2"),
+		Promise:   crypto.Promise{ChainID: 1, Amount: big.NewInt(amount)},
+	})
+	require.NoError(t, err)
+}
+
+func promiseAmount(t *testing.T, paths Paths) *big.Int {
+	db, err := boltdb.NewStorage(paths.Storage)
+	require.NoError(t, err)
+	defer db.Close()
+	promise, err := pingpong.NewHermesPromiseStorage(db).Get(1, "0x1")
+	require.NoError(t, err)
+	return promise.Promise.Amount
+}
+
+func TestBackup_CreateAndRestore(t *testing.T) {
+	source := newNode(t, 10)
+	archive, manifest, err := NewBackup(source, nil).Create(passphrase)
+	require.NoError(t, err)
+	assert.Equal(t, FormatVersion, manifest.FormatVersion)
+	assert.Len(t, manifest.Files, 4)
+
+	target := newPaths(t)
+	restored, err := NewBackup(target, nil).Restore(passphrase, archive)
+	require.NoError(t, err)
+	assert.Equal(t, manifest.Files, restored.Files)
+
+	for _, file := range []string{
+		filepath.Join("keystore", "UTC--key"),
+		"config-mainnet.toml",
+		credentialsFile,
+	} {
+		want, err := os.ReadFile(filepath.Join(source.Data, file))
+		require.NoError(t, err)
+		got, err := os.ReadFile(filepath.Join(target.Data, file))
+		require.NoError(t, err)
+		assert.Equal(t, want, got, file)
+	}
+	assert.Equal(t, big.NewInt(10), promiseAmount(t, target))
+}
+
+func TestBackup_RestoreRejectsWrongPassphrase(t *testing.T) {
+	archive, _, err := NewBackup(newNode(t, 10), nil).Create(passphrase)
+	require.NoError(t, err)
+
+	_, err = NewBackup(newPaths(t), nil).Restore("wrong", archive)
+	assert.ErrorIs(t, err, ErrBadPassphrase)
+
+	archive[len(archive)-1] ^= 1
+	_, err = NewBackup(newPaths(t), nil).Restore(passphrase, archive)
+	assert.ErrorIs(t, err, ErrBadPassphrase)
+}
+
+func TestBackup_RestoreRejectsStalePromises(t *testing.T) {
+	paths := newNode(t, 10)
+	archive, _, err := NewBackup(paths, nil).Create(passphrase)
+	require.NoError(t, err)
+
+	db, err := boltdb.NewStorage(paths.Storage)
+	require.NoError(t, err)
+	storePromise(t, db, 20)
+	require.NoError(t, db.Close())
+
+	_, err = NewBackup(paths, nil).Restore(passphrase, archive)
+	assert.ErrorIs(t, err, ErrStalePromises)
+	assert.Equal(t, big.NewInt(20), promiseAmount(t, paths))
+}
+
+func TestBackup_RestoreRejectsNewerNodeVersion(t *testing.T) {
+	defer func(version string) { metadata.Version = version }(metadata.Version)
+
+	metadata.Version = "1.2.0"
+	archive, _, err := NewBackup(newNode(t, 10), nil).Create(passphrase)
+	require.NoError(t, err)
+
+	metadata.Version = "1.1.9"
+	_, err = NewBackup(newPaths(t), nil).Restore(passphrase, archive)
+	assert.ErrorContains(t, err, "newer node version 1.2.0")
+
+	metadata.Version = "1.2.0-rc1"
+	_, err = NewBackup(newPaths(t), nil).Restore(passphrase, archive)
+	assert.NoError(t, err)
+}
+
+func TestBackup_OfflineRefusesRunningNode(t *testing.T) {
+	paths := newNode(t, 10)
+	db, err := boltdb.NewStorage(paths.Storage)
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, _, err = NewBackup(paths, nil).Create(passphrase)
+	assert.ErrorIs(t, err, ErrNodeRunning)
+
+	_, _, err = NewBackup(paths, db).Create(passphrase)
+	assert.NoError(t, err)
+}
+
+func TestBackup_StageAndApplyPending(t *testing.T) {
+	archive, _, err := NewBackup(newNode(t, 10), nil).Create(passphrase)
+	require.NoError(t, err)
+
+	paths := newPaths(t)
+	require.NoError(t, os.MkdirAll(paths.Storage, 0700))
+	db, err := boltdb.NewStorage(paths.Storage)
+	require.NoError(t, err)
+	_, err = NewBackup(paths, db).Stage(passphrase, archive)
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	manifest, err := NewBackup(paths, nil).ApplyPending()
+	require.NoError(t, err)
+	require.NotNil(t, manifest)
+	assert.Equal(t, big.NewInt(10), promiseAmount(t, paths))
+	assert.NoFileExists(t, filepath.Join(paths.Data, pendingFile))
+
+	manifest, err = NewBackup(paths, nil).ApplyPending()
+	assert.NoError(t, err)
+	assert.Nil(t, manifest)
+}
+
+func TestNewerVersion(t *testing.T) {
+	assert.True(t, newerVersion("1.10.0", "1.9.3"))
+	assert.True(t, newerVersion("v2.0.0", "1.99.99"))
+	assert.False(t, newerVersion("1.9.3", "1.9.3"))
+	assert.False(t, newerVersion("1.9.3", "1.10.0"))
+	assert.False(t, newerVersion("source.abcdef12", "1.0.0"))
+	assert.False(t, newerVersion("1.0.0", "source.abcdef12"))
+}
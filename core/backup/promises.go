@@ -0,0 +1,105 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package backup
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/asdine/storm/v3/codec/json"
+	"go.etcd.io/bbolt"
+)
+
+// hermesPromiseBucketPrefix matches buckets of pingpong.HermesPromiseStorage.
+const hermesPromiseBucketPrefix = "hermes_promises_"
+
+// ErrStalePromises is returned when restoring would roll back promises received after the backup was made.
+// Settling such an older promise after newer ones would be rejected or lose earnings.
+var ErrStalePromises = errors.New("node has newer hermes promises than the backup")
+
+type promiseState struct {
+	Promise struct {
+		Amount *big.Int
+	}
+}
+
+// readPromises returns hermes promises keyed by bucket and channel ID.
+func readPromises(db *bbolt.DB) (map[string]promiseState, error) {
+	result := make(map[string]promiseState)
+	err := db.View(func(tx *bbolt.Tx) error {
+		return tx.ForEach(func(name []byte, bucket *bbolt.Bucket) error {
+			if !strings.HasPrefix(string(name), hermesPromiseBucketPrefix) {
+				return nil
+			}
+			return bucket.ForEach(func(k, v []byte) error {
+				if v == nil || string(k) == "__storm_metadata" {
+					return nil
+				}
+				var entry promiseState
+				if err := json.Codec.Unmarshal(v, &entry); err != nil {
+					return err
+				}
+				result[string(name)+"/"+string(k)] = entry
+				return nil
+			})
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not read hermes promises: %w", err)
+	}
+	return result, nil
+}
+
+func archivedPromises(content []byte) (map[string]promiseState, error) {
+	tmp, err := os.CreateTemp("", "myst-backup-*.db")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(tmp.Name())
+	_, err = tmp.Write(content)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := openReadOnly(tmp.Name())
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+	return readPromises(db)
+}
+
+// checkPromises makes sure none of current promises would be replaced by a lower one.
+func checkPromises(current, restored map[string]promiseState) error {
+	for key, cur := range current {
+		if cur.Promise.Amount == nil || cur.Promise.Amount.Sign() == 0 {
+			continue
+		}
+		res, ok := restored[key]
+		if !ok || res.Promise.Amount == nil || res.Promise.Amount.Cmp(cur.Promise.Amount) < 0 {
+			return fmt.Errorf("%w: channel %s", ErrStalePromises, strings.TrimPrefix(key, hermesPromiseBucketPrefix))
+		}
+	}
+	return nil
+}
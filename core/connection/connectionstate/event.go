@@ -36,6 +36,8 @@ const (
 	AppTopicConnectionStatistics = "Statistics"
 	// AppTopicConnectionSession represents the session lifetime changes
 	AppTopicConnectionSession = "Session"
+	// AppTopicConnectionFailover represents the connection switch to a standby provider
+	AppTopicConnectionFailover = "Failover"
 )
 
 // AppEventConnectionState is the struct we'll emit on a AppEventConnectionState topic event
@@ -80,6 +82,10 @@ type Status struct {
 	State            State
 	SessionID        session.ID
 	Proposal         proposal.PricedServiceProposal
+	// Failovers is the number of times the connection was switched to a standby provider.
+	Failovers int
+	// StandbyProviderID is the provider kept ready for a failover, if any.
+	StandbyProviderID string
 }
 
 // Duration returns elapsed time from marked session start
@@ -104,6 +110,15 @@ type AppEventConnectionSession struct {
 	SessionInfo Status
 }
 
+// AppEventConnectionFailover represents the connection switch to a standby provider
+type AppEventConnectionFailover struct {
+	UUID           string
+	Reason         string
+	FromProviderID string
+	ToProviderID   string
+	SessionInfo    Status
+}
+
 // AppEventConnectionStatistics represents a session statistics event
 type AppEventConnectionStatistics struct {
 	UUID        string
@@ -0,0 +1,352 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package connection
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/mysteriumnetwork/node/config"
+	"github.com/mysteriumnetwork/node/core/connection/connectionstate"
+	"github.com/mysteriumnetwork/node/core/discovery/proposal"
+	"github.com/mysteriumnetwork/node/identity"
+	"github.com/mysteriumnetwork/node/p2p"
+	"github.com/mysteriumnetwork/node/pb"
+	"github.com/mysteriumnetwork/node/session"
+	"github.com/mysteriumnetwork/node/trace"
+)
+
+const (
+	failoverReasonKeepAlive  = "keep-alive"
+	failoverReasonThroughput = "throughput"
+
+	// standbyLookupAttempts limits proposal lookups while searching for a provider different from the active one.
+	standbyLookupAttempts = 3
+)
+
+var errNoStandbyProvider = errors.New("no standby provider available")
+
+// standbyConnection is a session with a different provider kept ready for a failover.
+// The session is created ahead of time, so the provider side of the tunnel is already set up and paid for,
+// and switching to it only reconfigures the consumer tunnel in place.
+type standbyConnection struct {
+	proposal proposal.PricedServiceProposal
+	channel  p2p.Channel
+	session  p2pSession
+	options  ConnectOptions
+}
+
+// maintainStandby keeps a healthy standby connection until the given context is done.
+func (m *connectionManager) maintainStandby(ctx context.Context, opts ConnectOptions) {
+	for {
+		if standby := m.currentStandby(); standby != nil && !m.standbyAlive(ctx, standby) {
+			log.Warn().Msgf("Standby provider %s is not reachable, dropping it", standby.proposal.ProviderID)
+			m.dropStandby()
+		}
+
+		if m.currentStandby() == nil && m.Status().State == connectionstate.Connected {
+			if err := m.prepareStandby(ctx, opts); err != nil {
+				log.Warn().Err(err).Msg("Could not prepare standby connection")
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(m.config.Failover.StandbyCheckInterval):
+		}
+	}
+}
+
+// prepareStandby dials a different provider and creates a session with it, which is not carrying any traffic yet.
+func (m *connectionManager) prepareStandby(ctx context.Context, opts ConnectOptions) (err error) {
+	activeProviderID := m.Status().Proposal.ProviderID
+
+	var standbyProposal *proposal.PricedServiceProposal
+	for i := 0; i < standbyLookupAttempts; i++ {
+		p, err := opts.ProposalLookup()
+		if err != nil {
+			return fmt.Errorf("failed to lookup proposal: %w", err)
+		}
+		if p.ProviderID != activeProviderID {
+			standbyProposal = p
+			break
+		}
+	}
+	if standbyProposal == nil {
+		return errNoStandbyProvider
+	}
+
+	contactDef, err := p2p.ParseContact(standbyProposal.Contacts)
+	if err != nil {
+		return fmt.Errorf("provider does not support p2p communication: %w", err)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, p2pDialTimeout)
+	defer cancel()
+
+	tracer := trace.NewTracer("Consumer standby session creation")
+	channel, err := m.p2pDialer.Dial(timeoutCtx, opts.ConsumerID, identity.FromAddress(standbyProposal.ProviderID), standbyProposal.ServiceType, contactDef, tracer)
+	if err != nil {
+		return fmt.Errorf("p2p dialer failed: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			channel.Close()
+		}
+	}()
+
+	// Provider pings the session as soon as it is created.
+	m.handleKeepAlive(channel)
+
+	opts.Proposal = *standbyProposal
+	opts.ProviderNATConn = channel.ServiceConn()
+	opts.ChannelConn = channel.Conn()
+	sess, err := m.createSession(channel, opts, tracer, m.priceFromProposal(*standbyProposal))
+	if err != nil {
+		if sess.payments != nil {
+			sess.payments.Stop()
+		}
+		return fmt.Errorf("could not create standby session: %w", err)
+	}
+	opts.SessionID = sess.id
+	opts.SessionConfig = sess.config
+
+	// Connection might have been closed while creating the session.
+	if ctx.Err() != nil {
+		sess.payments.Stop()
+		sess.release()
+		destroySession(channel, opts.ConsumerID, sess.id)
+		return ctx.Err()
+	}
+
+	log.Info().Msgf("Standby session %s with provider %s is ready", sess.id, standbyProposal.ProviderID)
+	m.setStandby(&standbyConnection{proposal: *standbyProposal, channel: channel, session: sess, options: opts})
+	return nil
+}
+
+// standbyAlive checks that the standby provider still answers keep alive pings of the standby session.
+func (m *connectionManager) standbyAlive(ctx context.Context, standby *standbyConnection) bool {
+	ctx, cancel := context.WithTimeout(ctx, m.config.KeepAlive.SendTimeout)
+	defer cancel()
+
+	_, err := standby.channel.Send(ctx, p2p.TopicKeepAlive, p2p.ProtoMessage(&pb.P2PKeepAlivePing{SessionID: string(standby.session.id)}))
+	return err == nil
+}
+
+func (m *connectionManager) currentStandby() *standbyConnection {
+	m.standbyLock.Lock()
+	defer m.standbyLock.Unlock()
+
+	return m.standby
+}
+
+func (m *connectionManager) setStandby(standby *standbyConnection) {
+	m.standbyLock.Lock()
+	m.standby = standby
+	m.standbyLock.Unlock()
+
+	m.setStatus(func(status *connectionstate.Status) {
+		status.StandbyProviderID = standby.proposal.ProviderID
+	})
+}
+
+// takeStandby removes the standby connection from the manager and returns it.
+func (m *connectionManager) takeStandby() *standbyConnection {
+	m.standbyLock.Lock()
+	standby := m.standby
+	m.standby = nil
+	m.standbyLock.Unlock()
+
+	m.setStatus(func(status *connectionstate.Status) {
+		status.StandbyProviderID = ""
+	})
+	return standby
+}
+
+func (m *connectionManager) dropStandby() {
+	if standby := m.takeStandby(); standby != nil {
+		standby.session.payments.Stop()
+		standby.session.release()
+		destroySession(standby.channel, standby.options.ConsumerID, standby.session.id)
+		if err := standby.channel.Close(); err != nil {
+			log.Warn().Err(err).Msg("Could not close standby channel")
+		}
+	}
+}
+
+// dropStandbyOf drops the standby connection if it uses the given channel.
+func (m *connectionManager) dropStandbyOf(channel p2p.Channel) {
+	if standby := m.currentStandby(); standby == nil || standby.channel != channel {
+		return
+	}
+
+	log.Warn().Msg("Standby session failed, dropping it")
+	m.dropStandby()
+}
+
+// tryFailover switches traffic to the standby provider if failover mode is enabled.
+// Returns false if the caller should fall back to the regular failure handling.
+func (m *connectionManager) tryFailover(reason string) bool {
+	if !config.GetBool(config.FlagFailover) {
+		return false
+	}
+	if !m.failoverLock.TryLock() {
+		log.Debug().Msgf("Failover is already in progress, skipping %s failover", reason)
+		return true
+	}
+	defer m.failoverLock.Unlock()
+
+	err := m.failover(reason)
+	if err == nil {
+		return true
+	}
+
+	log.Error().Err(err).Msgf("Failover (%s) failed", reason)
+	// Start errors are handled by cancelling the whole connection.
+	return errors.Is(err, ErrConnectionCancelled) || m.Status().State == connectionstate.NotConnected
+}
+
+// failover moves the tunnel to the standby session.
+// The tunnel is reconfigured in place, so the kill switch stays active all the time.
+func (m *connectionManager) failover(reason string) (err error) {
+	standby := m.takeStandby()
+	if standby == nil {
+		return errNoStandbyProvider
+	}
+
+	sessionID := standby.session.id
+	tracer := trace.NewTracer("Consumer failover")
+	defer func() {
+		traceResult := tracer.Finish(m.eventBus, string(sessionID))
+		log.Debug().Msgf("Consumer failover trace: %s", traceResult)
+	}()
+
+	prevStatus := m.Status()
+	prevChannel := m.currentChannel()
+	prevProposal := m.currentConnectOptions().Proposal
+	prevPayments := m.currentPayments()
+	prevRelease := m.currentReleaseSession()
+	log.Info().Msgf("Failing over (%s) from provider %s to %s", reason, prevProposal.ProviderID, standby.proposal.ProviderID)
+
+	m.statusReconnecting()
+	m.addCleanupAfterDisconnect(func() error {
+		log.Trace().Msg("Cleaning: closing failover P2P communication channel")
+		defer log.Trace().Msg("Cleaning: failover P2P communication channel DONE")
+
+		return standby.channel.Close()
+	})
+
+	m.updateConnectOptions(func(opts *ConnectOptions) {
+		*opts = standby.options
+	})
+	m.setStatus(func(status *connectionstate.Status) {
+		status.Proposal = standby.proposal
+	})
+	m.activateSession(standby.channel, standby.session, tracer)
+
+	if prevPayments != nil {
+		prevPayments.Stop()
+	}
+	if prevRelease != nil {
+		prevRelease()
+	}
+	if prevChannel != nil {
+		destroySession(prevChannel, prevStatus.ConsumerID, prevStatus.SessionID)
+		prevChannel.Close()
+	}
+
+	m.preReconnect()
+	m.clearIPCache()
+	err = m.startConnection(m.currentCtx(), m.activeConnection, m.activeConnection.Reconnect, m.currentConnectOptions(), tracer)
+	if err != nil {
+		return m.handleStartError(sessionID, err)
+	}
+	m.postReconnect()
+	if m.acknowledge != nil {
+		go m.acknowledge()
+	}
+
+	m.setStatus(func(status *connectionstate.Status) {
+		status.Failovers++
+	})
+	sessionInfo := m.Status()
+	// avoid printing IP address in logs
+	sessionInfo.ConsumerLocation.IP = ""
+	m.eventBus.Publish(connectionstate.AppTopicConnectionFailover, connectionstate.AppEventConnectionFailover{
+		UUID:           m.uuid,
+		Reason:         reason,
+		FromProviderID: prevProposal.ProviderID,
+		ToProviderID:   standby.proposal.ProviderID,
+		SessionInfo:    sessionInfo,
+	})
+
+	return nil
+}
+
+// destroySession asks the provider to destroy a session which no longer carries the traffic.
+func destroySession(channel p2p.Channel, consumerID identity.Identity, sessionID session.ID) {
+	sessionDestroy := &pb.SessionInfo{
+		ConsumerID: consumerID.Address,
+		SessionID:  string(sessionID),
+	}
+
+	log.Debug().Msgf("Sending P2P message to %q: %s", p2p.TopicSessionDestroy, sessionDestroy.String())
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	if _, err := channel.Send(ctx, p2p.TopicSessionDestroy, p2p.ProtoMessage(sessionDestroy)); err != nil {
+		log.Debug().Err(err).Msgf("Could not destroy session %s", sessionID)
+	}
+}
+
+// monitorThroughput fails over once traffic is being sent but nothing is received for several checks in a row.
+func (m *connectionManager) monitorThroughput(ctx context.Context, conn Connection) {
+	var last connectionstate.Statistics
+	var stalled int
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(m.config.Failover.ThroughputCheckInterval):
+		}
+
+		stats, err := conn.Statistics()
+		if err != nil || m.Status().State != connectionstate.Connected {
+			stalled = 0
+			continue
+		}
+
+		diff := last.Diff(stats)
+		last = stats
+		if diff.BytesSent > 0 && diff.BytesReceived == 0 {
+			stalled++
+		} else {
+			stalled = 0
+		}
+
+		if stalled >= m.config.Failover.MaxStalledChecks {
+			log.Warn().Msgf("No traffic received during %d checks", stalled)
+			stalled = 0
+			m.tryFailover(failoverReasonThroughput)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package connection
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mysteriumnetwork/node/core/connection/connectionstate"
+	"github.com/mysteriumnetwork/node/core/discovery/proposal"
+)
+
+func (tc *testContext) Test_FailoverSwitchesToStandbyProvider() {
+	// Tunnel is reconfigured in place, so connection is stopped once per start.
+	tc.fakeConnectionFactory.mockConnection.onStopReportStates = []fakeState{}
+
+	standbyProposal := activeProposal
+	standbyProposal.ProviderID = "fake-node-2"
+	lookups := 0
+	lookup := func() (*proposal.PricedServiceProposal, error) {
+		lookups++
+		if lookups == 1 {
+			return &activeProposal, nil
+		}
+		return &standbyProposal, nil
+	}
+
+	err := tc.connManager.Connect(consumerID, hermesID, lookup, ConnectParams{})
+	assert.NoError(tc.T(), err)
+	firstPayments := tc.MockPaymentIssuer
+
+	standbyChannel := &mockP2PChannel{}
+	tc.connManager.p2pDialer = &mockP2PDialer{standbyChannel}
+	err = tc.connManager.prepareStandby(context.Background(), tc.connManager.currentConnectOptions())
+	assert.NoError(tc.T(), err)
+	assert.Equal(tc.T(), "fake-node-2", tc.connManager.Status().StandbyProviderID)
+
+	// Standby session is created and paid for before the failover.
+	standbyPayments := tc.MockPaymentIssuer
+	assert.NotSame(tc.T(), firstPayments, standbyPayments)
+	assert.Equal(tc.T(), 1, standbyChannel.sessionsCreated())
+	waitABit()
+	assert.True(tc.T(), standbyPayments.StartCalled())
+
+	err = tc.connManager.failover(failoverReasonKeepAlive)
+	assert.NoError(tc.T(), err)
+	waitABit()
+	assert.Equal(tc.T(), 1, standbyChannel.sessionsCreated())
+	assert.Same(tc.T(), standbyChannel, tc.connManager.currentChannel())
+	assert.False(tc.T(), standbyPayments.StopCalled())
+
+	status := tc.connManager.Status()
+	assert.Equal(tc.T(), connectionstate.Connected, status.State)
+	assert.Equal(tc.T(), "fake-node-2", status.Proposal.ProviderID)
+	assert.Equal(tc.T(), 1, status.Failovers)
+	assert.Empty(tc.T(), status.StandbyProviderID)
+	assert.True(tc.T(), firstPayments.StopCalled())
+
+	var failoverEvent *connectionstate.AppEventConnectionFailover
+	for _, e := range tc.stubPublisher.GetEventHistory() {
+		if e.Topic == connectionstate.AppTopicConnectionFailover {
+			ev := e.Event.(connectionstate.AppEventConnectionFailover)
+			failoverEvent = &ev
+		}
+	}
+	if assert.NotNil(tc.T(), failoverEvent) {
+		assert.Equal(tc.T(), failoverReasonKeepAlive, failoverEvent.Reason)
+		assert.Equal(tc.T(), activeProposal.ProviderID, failoverEvent.FromProviderID)
+		assert.Equal(tc.T(), "fake-node-2", failoverEvent.ToProviderID)
+	}
+
+	assert.NoError(tc.T(), tc.connManager.Disconnect())
+	// Each session is destroyed once, over its own channel.
+	assert.Equal(tc.T(), 1, tc.mockP2P.ch.sessionsDestroyed())
+	assert.Equal(tc.T(), 1, standbyChannel.sessionsDestroyed())
+}
+
+func (tc *testContext) Test_FailoverDroppedStandbyLeavesNoCleanup() {
+	standbyProposal := activeProposal
+	standbyProposal.ProviderID = "fake-node-2"
+
+	err := tc.connManager.Connect(consumerID, hermesID, activeProposalLookup, ConnectParams{})
+	assert.NoError(tc.T(), err)
+	cleanups := len(tc.connManager.cleanupAfterDisconnect)
+
+	standbyChannel := &mockP2PChannel{}
+	tc.connManager.p2pDialer = &mockP2PDialer{standbyChannel}
+	opts := tc.connManager.currentConnectOptions()
+	opts.ProposalLookup = func() (*proposal.PricedServiceProposal, error) {
+		return &standbyProposal, nil
+	}
+	for i := 0; i < 3; i++ {
+		assert.NoError(tc.T(), tc.connManager.prepareStandby(context.Background(), opts))
+		tc.connManager.dropStandby()
+	}
+	assert.Len(tc.T(), tc.connManager.cleanupAfterDisconnect, cleanups)
+	assert.Equal(tc.T(), 3, standbyChannel.sessionsDestroyed())
+
+	assert.NoError(tc.T(), tc.connManager.Disconnect())
+	assert.Equal(tc.T(), 3, standbyChannel.sessionsDestroyed())
+	assert.Equal(tc.T(), 1, tc.mockP2P.ch.sessionsDestroyed())
+}
+
+func (tc *testContext) Test_FailoverStandbyIsDestroyedOnDisconnect() {
+	standbyProposal := activeProposal
+	standbyProposal.ProviderID = "fake-node-2"
+	lookup := func() (*proposal.PricedServiceProposal, error) {
+		return &standbyProposal, nil
+	}
+
+	err := tc.connManager.Connect(consumerID, hermesID, activeProposalLookup, ConnectParams{})
+	assert.NoError(tc.T(), err)
+
+	standbyChannel := &mockP2PChannel{}
+	tc.connManager.p2pDialer = &mockP2PDialer{standbyChannel}
+	opts := tc.connManager.currentConnectOptions()
+	opts.ProposalLookup = lookup
+	assert.NoError(tc.T(), tc.connManager.prepareStandby(context.Background(), opts))
+	standbyPayments := tc.MockPaymentIssuer
+
+	assert.NoError(tc.T(), tc.connManager.Disconnect())
+	assert.True(tc.T(), standbyPayments.StopCalled())
+	assert.Equal(tc.T(), 1, standbyChannel.sessionsDestroyed())
+	assert.Empty(tc.T(), tc.connManager.Status().StandbyProviderID)
+}
+
+func (tc *testContext) Test_FailoverWithoutStandbyFails() {
+	err := tc.connManager.Connect(consumerID, hermesID, activeProposalLookup, ConnectParams{})
+	assert.NoError(tc.T(), err)
+
+	err = tc.connManager.prepareStandby(context.Background(), tc.connManager.currentConnectOptions())
+	assert.ErrorIs(tc.T(), err, errNoStandbyProvider)
+
+	err = tc.connManager.failover(failoverReasonThroughput)
+	assert.ErrorIs(tc.T(), err, errNoStandbyProvider)
+	assert.Equal(tc.T(), connectionstate.Connected, tc.connManager.Status().State)
+	assert.Equal(tc.T(), activeProposal.ProviderID, tc.connManager.Status().Proposal.ProviderID)
+
+	assert.NoError(tc.T(), tc.connManager.Disconnect())
+}
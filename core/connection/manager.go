@@ -81,10 +81,25 @@ type KeepAliveConfig struct {
 	MaxSendErrCount int
 }
 
+// FailoverConfig contains warm standby failover options.
+type FailoverConfig struct {
+	StandbyCheckInterval    time.Duration
+	ThroughputCheckInterval time.Duration
+	MaxStalledChecks        int
+}
+
 // Config contains common configuration options for connection manager.
 type Config struct {
-	IPCheck   IPCheckConfig
-	KeepAlive KeepAliveConfig
+	IPCheck     IPCheckConfig
+	KeepAlive   KeepAliveConfig
+	Failover    FailoverConfig
+	PriceChange PriceChangeConfig
+}
+
+// PriceChangeConfig controls which session price changes proposed by the provider are accepted.
+type PriceChangeConfig struct {
+	// AcceptIncrease accepts price increases up to the current network price, only decreases are accepted otherwise.
+	AcceptIncrease bool
 }
 
 // DefaultConfig returns default params.
@@ -99,6 +114,11 @@ func DefaultConfig() Config {
 			SendTimeout:     5 * time.Second,
 			MaxSendErrCount: 3,
 		},
+		Failover: FailoverConfig{
+			StandbyCheckInterval:    30 * time.Second,
+			ThroughputCheckInterval: 10 * time.Second,
+			MaxStalledChecks:        3,
+		},
 	}
 }
 
@@ -112,6 +132,8 @@ type ConnectionStart func(context.Context, ConnectOptions) error
 type PaymentIssuer interface {
 	Start() error
 	SetSessionID(string)
+	PriceBoundary() market.PriceBoundary
+	ChangePrice(market.Price, market.PriceBoundary)
 	Stop()
 }
 
@@ -156,22 +178,29 @@ type connectionManager struct {
 	statusLock             sync.RWMutex
 	cleanupLock            sync.Mutex
 	cleanup                []func() error
-	cleanupAfterDisconnect []func() error
+	cleanupAfterDisconnect []*cleanupFunc
 	cleanupFinished        chan struct{}
 	cleanupFinishedLock    sync.Mutex
 	acknowledge            func()
 	cancel                 func()
 	channel                p2p.Channel
+	releaseSession         func()
+	// connectOptions are guarded by statusLock, as failover replaces them while the session goroutines run.
+	connectOptions ConnectOptions
 
 	preReconnect  func()
 	postReconnect func()
 
-	discoLock      sync.Mutex
-	connectOptions ConnectOptions
+	discoLock sync.Mutex
 
 	activeConnection Connection
 	statsTracker     statsTracker
 
+	standbyLock  sync.Mutex
+	standby      *standbyConnection
+	failoverLock sync.Mutex
+	payments     PaymentIssuer
+
 	uuid string
 }
 
@@ -269,13 +298,15 @@ func (m *connectionManager) Connect(consumerID identity.Identity, hermesID commo
 		}
 	}()
 
-	m.connectOptions = ConnectOptions{
-		ConsumerID:     consumerID,
-		HermesID:       hermesID,
-		Proposal:       *proposal,
-		ProposalLookup: proposalLookup,
-		Params:         params,
-	}
+	m.updateConnectOptions(func(opts *ConnectOptions) {
+		*opts = ConnectOptions{
+			ConsumerID:     consumerID,
+			HermesID:       hermesID,
+			Proposal:       *proposal,
+			ProposalLookup: proposalLookup,
+			Params:         params,
+		}
+	})
 
 	m.activeConnection, err = m.newConnection(proposal.ServiceType)
 	if err != nil {
@@ -289,7 +320,7 @@ func (m *connectionManager) Connect(consumerID identity.Identity, hermesID commo
 
 	originalPublicIP := m.getPublicIP()
 
-	err = m.startConnection(m.currentCtx(), m.activeConnection, m.activeConnection.Start, m.connectOptions, tracer)
+	err = m.startConnection(m.currentCtx(), m.activeConnection, m.activeConnection.Start, m.currentConnectOptions(), tracer)
 	if err != nil {
 		return m.handleStartError(sessionID, err)
 	}
@@ -309,8 +340,20 @@ func (m *connectionManager) Connect(consumerID identity.Identity, hermesID commo
 	})
 
 	go m.consumeConnectionStates(m.activeConnection.State())
-	go m.checkSessionIP(m.channel, m.connectOptions.ConsumerID, m.connectOptions.SessionID, originalPublicIP)
-	go m.monitorPrice(prc)
+	opts := m.currentConnectOptions()
+	go m.checkSessionIP(m.currentChannel(), opts.ConsumerID, opts.SessionID, originalPublicIP)
+	go m.monitorPrice()
+
+	m.addCleanup(func() error {
+		log.Trace().Msg("Cleaning: standby connection")
+		defer log.Trace().Msg("Cleaning: standby connection DONE")
+		m.dropStandby()
+		return nil
+	})
+	if config.GetBool(config.FlagFailover) {
+		go m.maintainStandby(m.currentCtx(), opts)
+		go m.monitorThroughput(m.currentCtx(), m.activeConnection)
+	}
 
 	return nil
 }
@@ -324,19 +367,21 @@ func (m *connectionManager) autoReconnect() (err error) {
 		log.Debug().Msgf("Consumer connection trace: %s", traceResult)
 	}()
 
-	proposal, err := m.connectOptions.ProposalLookup()
+	proposal, err := m.currentConnectOptions().ProposalLookup()
 	if err != nil {
 		return fmt.Errorf("failed to lookup proposal: %w", err)
 	}
 
-	m.connectOptions.Proposal = *proposal
+	m.updateConnectOptions(func(opts *ConnectOptions) {
+		opts.Proposal = *proposal
+	})
 
-	sessionID, err = m.initSession(tracer, m.priceFromProposal(m.connectOptions.Proposal))
+	sessionID, err = m.initSession(tracer, m.priceFromProposal(*proposal))
 	if err != nil {
 		return err
 	}
 
-	err = m.startConnection(m.currentCtx(), m.activeConnection, m.activeConnection.Reconnect, m.connectOptions, tracer)
+	err = m.startConnection(m.currentCtx(), m.activeConnection, m.activeConnection.Reconnect, m.currentConnectOptions(), tracer)
 	if err != nil {
 		return m.handleStartError(sessionID, err)
 	}
@@ -367,47 +412,137 @@ func (m *connectionManager) priceFromProposal(proposal proposal.PricedServicePro
 }
 
 func (m *connectionManager) initSession(tracer *trace.Tracer, prc market.Price) (sessionID session.ID, err error) {
-	err = m.createP2PChannel(m.connectOptions, tracer)
+	err = m.createP2PChannel(m.currentConnectOptions(), tracer)
 	if err != nil {
 		return sessionID, fmt.Errorf("could not create p2p channel during connect: %w", err)
 	}
 
-	m.connectOptions.ProviderNATConn = m.channel.ServiceConn()
-	m.connectOptions.ChannelConn = m.channel.Conn()
+	return m.startSession(tracer, prc)
+}
 
-	paymentSession, err := m.paymentLoop(m.connectOptions, prc)
+// p2pSession is a session created over a p2p channel, which does not necessarily carry the traffic yet.
+type p2pSession struct {
+	id          session.ID
+	config      []byte
+	payments    PaymentIssuer
+	acknowledge func()
+	// release forgets the session destroy request scheduled for the disconnect,
+	// it is used once the session is destroyed before that.
+	release func()
+}
+
+// startSession creates a new session over the already established p2p channel.
+func (m *connectionManager) startSession(tracer *trace.Tracer, prc market.Price) (sessionID session.ID, err error) {
+	channel := m.currentChannel()
+	m.updateConnectOptions(func(opts *ConnectOptions) {
+		opts.ProviderNATConn = channel.ServiceConn()
+		opts.ChannelConn = channel.Conn()
+	})
+
+	sess, err := m.createSession(channel, m.currentConnectOptions(), tracer, prc)
 	if err != nil {
-		return sessionID, err
+		return sess.id, err
 	}
 
-	sessionDTO, err := m.createP2PSession(m.activeConnection, m.connectOptions, tracer, prc)
-	sessionID = session.ID(sessionDTO.GetID())
+	m.activateSession(channel, sess, tracer)
+	return sess.id, nil
+}
+
+// createSession creates a session with its payments over the given channel.
+func (m *connectionManager) createSession(channel p2p.Channel, opts ConnectOptions, tracer *trace.Tracer, prc market.Price) (p2pSession, error) {
+	paymentSession, err := m.paymentLoop(channel, opts, prc)
+	if err != nil {
+		return p2pSession{}, err
+	}
+
+	sessionDTO, acknowledge, release, err := m.createP2PSession(m.activeConnection, channel, opts, tracer, prc)
+	sess := p2pSession{
+		id:          session.ID(sessionDTO.GetID()),
+		config:      sessionDTO.GetConfig(),
+		payments:    paymentSession,
+		acknowledge: acknowledge,
+		release:     release,
+	}
 	if err != nil {
-		m.sendSessionStatus(m.channel, m.connectOptions.ConsumerID, sessionID, connectivity.StatusSessionEstablishmentFailed, err)
-		return sessionID, err
+		m.sendSessionStatus(channel, opts.ConsumerID, sess.id, connectivity.StatusSessionEstablishmentFailed, err)
+		return sess, err
 	}
+	paymentSession.SetSessionID(string(sess.id))
 
+	return sess, nil
+}
+
+// activateSession makes the session created over the given channel the one carrying the traffic.
+func (m *connectionManager) activateSession(channel p2p.Channel, sess p2pSession, tracer *trace.Tracer) {
 	traceStart := tracer.StartStage("Consumer session creation (start)")
-	go m.keepAliveLoop(m.channel, sessionID)
+	m.statusLock.Lock()
+	m.channel = channel
+	m.payments = sess.payments
+	m.releaseSession = sess.release
+	m.statusLock.Unlock()
+	m.acknowledge = sess.acknowledge
+
+	go m.keepAliveLoop(channel, sess.id)
+	m.handlePriceChange(channel, sess.id)
 	m.setStatus(func(status *connectionstate.Status) {
-		status.SessionID = sessionID
+		status.SessionID = sess.id
 	})
 	m.publishSessionCreate()
-	paymentSession.SetSessionID(string(sessionID))
 	tracer.EndStage(traceStart)
 
-	m.connectOptions.SessionID = sessionID
-	m.connectOptions.SessionConfig = sessionDTO.GetConfig()
+	m.updateConnectOptions(func(opts *ConnectOptions) {
+		opts.SessionID = sess.id
+		opts.SessionConfig = sess.config
+	})
+}
+
+// currentConnectOptions returns the options of the session carrying the traffic.
+func (m *connectionManager) currentConnectOptions() ConnectOptions {
+	m.statusLock.RLock()
+	defer m.statusLock.RUnlock()
 
-	return sessionID, nil
+	return m.connectOptions
+}
+
+func (m *connectionManager) updateConnectOptions(delta func(opts *ConnectOptions)) {
+	m.statusLock.Lock()
+	defer m.statusLock.Unlock()
+
+	delta(&m.connectOptions)
+}
+
+// currentChannel returns the p2p channel of the session carrying the traffic.
+func (m *connectionManager) currentChannel() p2p.Channel {
+	m.statusLock.RLock()
+	defer m.statusLock.RUnlock()
+
+	return m.channel
+}
+
+// currentReleaseSession returns the function forgetting the scheduled destroy of the session carrying the traffic.
+func (m *connectionManager) currentReleaseSession() func() {
+	m.statusLock.RLock()
+	defer m.statusLock.RUnlock()
+
+	return m.releaseSession
+}
+
+// currentPayments returns the payments of the session carrying the traffic.
+func (m *connectionManager) currentPayments() PaymentIssuer {
+	m.statusLock.RLock()
+	defer m.statusLock.RUnlock()
+
+	return m.payments
 }
 
 func (m *connectionManager) handleStartError(sessionID session.ID, err error) error {
 	if errors.Is(err, context.Canceled) {
 		return ErrConnectionCancelled
 	}
+	channel := m.currentChannel()
+	consumerID := m.currentConnectOptions().ConsumerID
 	m.addCleanupAfterDisconnect(func() error {
-		return m.sendSessionStatus(m.channel, m.connectOptions.ConsumerID, sessionID, connectivity.StatusConnectionFailed, err)
+		return m.sendSessionStatus(channel, consumerID, sessionID, connectivity.StatusConnectionFailed, err)
 	})
 	m.publishStateEvent(connectionstate.StateConnectionFailed)
 
@@ -491,8 +626,8 @@ func (m *connectionManager) getPublicIP() string {
 	return currentPublicIP
 }
 
-func (m *connectionManager) paymentLoop(opts ConnectOptions, price market.Price) (PaymentIssuer, error) {
-	payments, err := m.paymentEngineFactory(m.uuid, m.channel, opts.ConsumerID, identity.FromAddress(opts.Proposal.ProviderID), opts.HermesID, opts.Proposal, price)
+func (m *connectionManager) paymentLoop(channel p2p.Channel, opts ConnectOptions, price market.Price) (PaymentIssuer, error) {
+	payments, err := m.paymentEngineFactory(m.uuid, channel, opts.ConsumerID, identity.FromAddress(opts.Proposal.ProviderID), opts.HermesID, opts.Proposal, price)
 	if err != nil {
 		return nil, err
 	}
@@ -508,6 +643,12 @@ func (m *connectionManager) paymentLoop(opts ConnectOptions, price market.Price)
 		if err != nil {
 			log.Error().Err(err).Msg("Payment error")
 
+			// Payments of a standby or a replaced session do not affect the traffic.
+			if m.currentChannel() != channel {
+				m.dropStandbyOf(channel)
+				return
+			}
+
 			if config.GetBool(config.FlagKeepConnectedOnFail) {
 				m.statusOnHold()
 			} else {
@@ -522,31 +663,34 @@ func (m *connectionManager) paymentLoop(opts ConnectOptions, price market.Price)
 }
 
 func (m *connectionManager) cleanConnection() {
+	// Cleanups run without the lock, as some of them unregister the cleanups after disconnect.
 	m.cleanupLock.Lock()
-	defer m.cleanupLock.Unlock()
+	cleanup := m.cleanup
+	m.cleanup = nil
+	m.cleanupLock.Unlock()
 
-	for i := len(m.cleanup) - 1; i >= 0; i-- {
-		log.Trace().Msgf("Connection cleaning up: (%v/%v)", i+1, len(m.cleanup))
-		err := m.cleanup[i]()
+	for i := len(cleanup) - 1; i >= 0; i-- {
+		log.Trace().Msgf("Connection cleaning up: (%v/%v)", i+1, len(cleanup))
+		err := cleanup[i]()
 		if err != nil {
 			log.Warn().Err(err).Msg("Cleanup error")
 		}
 	}
-	m.cleanup = nil
 }
 
 func (m *connectionManager) cleanAfterDisconnect() {
 	m.cleanupLock.Lock()
-	defer m.cleanupLock.Unlock()
+	cleanup := m.cleanupAfterDisconnect
+	m.cleanupAfterDisconnect = nil
+	m.cleanupLock.Unlock()
 
-	for i := len(m.cleanupAfterDisconnect) - 1; i >= 0; i-- {
-		log.Trace().Msgf("Connection cleaning up (after disconnect): (%v/%v)", i+1, len(m.cleanupAfterDisconnect))
-		err := m.cleanupAfterDisconnect[i]()
+	for i := len(cleanup) - 1; i >= 0; i-- {
+		log.Trace().Msgf("Connection cleaning up (after disconnect): (%v/%v)", i+1, len(cleanup))
+		err := cleanup[i].fn()
 		if err != nil {
 			log.Warn().Err(err).Msg("Cleanup error")
 		}
 	}
-	m.cleanupAfterDisconnect = nil
 }
 
 func (m *connectionManager) createP2PChannel(opts ConnectOptions, tracer *trace.Tracer) error {
@@ -573,14 +717,37 @@ func (m *connectionManager) createP2PChannel(opts ConnectOptions, tracer *trace.
 		return channel.Close()
 	})
 
+	m.statusLock.Lock()
 	m.channel = channel
+	m.statusLock.Unlock()
 	return nil
 }
 
-func (m *connectionManager) addCleanupAfterDisconnect(fn func() error) {
+// cleanupFunc wraps a cleanup function, so that its registration can be told apart from the others.
+type cleanupFunc struct {
+	fn func() error
+}
+
+// addCleanupAfterDisconnect registers a cleanup to run after disconnect.
+// The returned function unregisters it.
+func (m *connectionManager) addCleanupAfterDisconnect(fn func() error) (remove func()) {
 	m.cleanupLock.Lock()
 	defer m.cleanupLock.Unlock()
-	m.cleanupAfterDisconnect = append(m.cleanupAfterDisconnect, fn)
+
+	cleanup := &cleanupFunc{fn: fn}
+	m.cleanupAfterDisconnect = append(m.cleanupAfterDisconnect, cleanup)
+
+	return func() {
+		m.cleanupLock.Lock()
+		defer m.cleanupLock.Unlock()
+
+		for i, c := range m.cleanupAfterDisconnect {
+			if c == cleanup {
+				m.cleanupAfterDisconnect = append(m.cleanupAfterDisconnect[:i], m.cleanupAfterDisconnect[i+1:]...)
+				return
+			}
+		}
+	}
 }
 
 func (m *connectionManager) addCleanup(fn func() error) {
@@ -589,18 +756,20 @@ func (m *connectionManager) addCleanup(fn func() error) {
 	m.cleanup = append(m.cleanup, fn)
 }
 
-func (m *connectionManager) createP2PSession(c Connection, opts ConnectOptions, tracer *trace.Tracer, requestedPrice market.Price) (*pb.SessionResponse, error) {
+// createP2PSession requests a new session over the given channel.
+// Returns the function to acknowledge the session once the tunnel is up.
+func (m *connectionManager) createP2PSession(c Connection, channel p2p.Channel, opts ConnectOptions, tracer *trace.Tracer, requestedPrice market.Price) (*pb.SessionResponse, func(), func(), error) {
 	trace := tracer.StartStage("Consumer session creation")
 	defer tracer.EndStage(trace)
 
 	sessionCreateConfig, err := c.GetConfig()
 	if err != nil {
-		return nil, fmt.Errorf("could not get session config: %w", err)
+		return nil, nil, nil, fmt.Errorf("could not get session config: %w", err)
 	}
 
 	config, err := json.Marshal(sessionCreateConfig)
 	if err != nil {
-		return nil, fmt.Errorf("could not marshal session config: %w", err)
+		return nil, nil, nil, fmt.Errorf("could not marshal session config: %w", err)
 	}
 
 	sessionRequest := &pb.SessionRequest{
@@ -622,19 +791,18 @@ func (m *connectionManager) createP2PSession(c Connection, opts ConnectOptions,
 	log.Debug().Msgf("Sending P2P message to %q: %s", p2p.TopicSessionCreate, sessionRequest.String())
 	ctx, cancel := context.WithTimeout(m.currentCtx(), 20*time.Second)
 	defer cancel()
-	res, err := m.channel.Send(ctx, p2p.TopicSessionCreate, p2p.ProtoMessage(sessionRequest))
+	res, err := channel.Send(ctx, p2p.TopicSessionCreate, p2p.ProtoMessage(sessionRequest))
 	if err != nil {
-		return nil, fmt.Errorf("could not send p2p session create request: %w", err)
+		return nil, nil, nil, fmt.Errorf("could not send p2p session create request: %w", err)
 	}
 
 	var sessionResponse pb.SessionResponse
 	err = res.UnmarshalProto(&sessionResponse)
 	if err != nil {
-		return nil, fmt.Errorf("could not unmarshal session reply to proto: %w", err)
+		return nil, nil, nil, fmt.Errorf("could not unmarshal session reply to proto: %w", err)
 	}
 
-	channel := m.channel
-	m.acknowledge = func() {
+	acknowledge := func() {
 		pc := &pb.SessionInfo{
 			ConsumerID: opts.ConsumerID.Address,
 			SessionID:  sessionResponse.GetID(),
@@ -647,7 +815,7 @@ func (m *connectionManager) createP2PSession(c Connection, opts ConnectOptions,
 			log.Warn().Err(err).Msg("Acknowledge failed")
 		}
 	}
-	m.addCleanupAfterDisconnect(func() error {
+	release := m.addCleanupAfterDisconnect(func() error {
 		log.Trace().Msg("Cleaning: requesting session destroy")
 		defer log.Trace().Msg("Cleaning: requesting session destroy DONE")
 
@@ -659,7 +827,7 @@ func (m *connectionManager) createP2PSession(c Connection, opts ConnectOptions,
 		log.Debug().Msgf("Sending P2P message to %q: %s", p2p.TopicSessionDestroy, sessionDestroy.String())
 		ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
 		defer cancel()
-		_, err := m.channel.Send(ctx, p2p.TopicSessionDestroy, p2p.ProtoMessage(sessionDestroy))
+		_, err := channel.Send(ctx, p2p.TopicSessionDestroy, p2p.ProtoMessage(sessionDestroy))
 		if err != nil {
 			return fmt.Errorf("could not send session destroy request: %w", err)
 		}
@@ -667,7 +835,7 @@ func (m *connectionManager) createP2PSession(c Connection, opts ConnectOptions,
 		return nil
 	})
 
-	return &sessionResponse, nil
+	return &sessionResponse, acknowledge, release, nil
 }
 
 func (m *connectionManager) publishSessionCreate() {
@@ -831,7 +999,7 @@ func (m *connectionManager) Disconnect() error {
 }
 
 func (m *connectionManager) CheckChannel(ctx context.Context) error {
-	if err := m.sendKeepAlivePing(ctx, m.channel, m.Status().SessionID); err != nil {
+	if err := m.sendKeepAlivePing(ctx, m.currentChannel(), m.Status().SessionID); err != nil {
 		return fmt.Errorf("keep alive ping failed: %w", err)
 	}
 	return nil
@@ -930,8 +1098,8 @@ func (m *connectionManager) reconnectOnHold(state connectionstate.AppEventConnec
 		return
 	}
 
-	if m.channel != nil {
-		m.channel.Close()
+	if channel := m.currentChannel(); channel != nil {
+		channel.Close()
 	}
 
 	m.preReconnect()
@@ -961,8 +1129,8 @@ func (m *connectionManager) publishStateEvent(state connectionstate.State) {
 	})
 }
 
-func (m *connectionManager) keepAliveLoop(channel p2p.Channel, sessionID session.ID) {
-	// Register handler for handling p2p keep alive pings from provider.
+// handleKeepAlive registers handler for handling p2p keep alive pings from provider.
+func (m *connectionManager) handleKeepAlive(channel p2p.ChannelHandler) {
 	channel.Handle(p2p.TopicKeepAlive, func(c p2p.Context) error {
 		var ping pb.P2PKeepAlivePing
 		if err := c.Request().UnmarshalProto(&ping); err != nil {
@@ -972,6 +1140,10 @@ func (m *connectionManager) keepAliveLoop(channel p2p.Channel, sessionID session
 		log.Debug().Msgf("Received p2p keepalive ping with SessionID=%s from %s", ping.SessionID, c.PeerID().ToCommonAddress())
 		return c.OK()
 	})
+}
+
+func (m *connectionManager) keepAliveLoop(channel p2p.Channel, sessionID session.ID) {
+	m.handleKeepAlive(channel)
 
 	// Send pings to provider.
 	var errCount int
@@ -983,10 +1155,19 @@ func (m *connectionManager) keepAliveLoop(channel p2p.Channel, sessionID session
 		case <-time.After(m.config.KeepAlive.SendInterval):
 			ctx, cancel := context.WithTimeout(context.Background(), m.config.KeepAlive.SendTimeout)
 			if err := m.sendKeepAlivePing(ctx, channel, sessionID); err != nil {
+				if m.currentChannel() != channel {
+					log.Debug().Msgf("Stopping p2p keepalive, session was replaced. SessionID=%s", sessionID)
+					cancel()
+					return
+				}
 				log.Err(err).Msgf("Failed to send p2p keepalive ping. SessionID=%s", sessionID)
 				errCount++
 				if errCount == m.config.KeepAlive.MaxSendErrCount {
 					log.Error().Msgf("Max p2p keepalive err count reached, disconnecting. SessionID=%s", sessionID)
+					if m.tryFailover(failoverReasonKeepAlive) {
+						cancel()
+						return
+					}
 					if config.GetBool(config.FlagKeepConnectedOnFail) {
 						m.statusOnHold()
 					} else {
@@ -1043,7 +1224,8 @@ func (m *connectionManager) Reconnect() {
 	ch := m.cleanupFinished
 	m.cleanupFinishedLock.Unlock()
 	<-ch
-	err = m.Connect(m.connectOptions.ConsumerID, m.connectOptions.HermesID, m.connectOptions.ProposalLookup, m.connectOptions.Params)
+	opts := m.currentConnectOptions()
+	err = m.Connect(opts.ConsumerID, opts.HermesID, opts.ProposalLookup, opts.Params)
 	if err != nil {
 		log.Error().Err(err).Msgf("Failed to reconnect")
 	}
@@ -1055,13 +1237,15 @@ func logDisconnectError(err error) {
 	}
 }
 
-func (m *connectionManager) monitorPrice(currentPrice market.Price) {
+func (m *connectionManager) monitorPrice() {
 	t := time.NewTicker(m.priceCheckInterval)
 	for {
 		select {
 		case <-m.currentCtx().Done():
 			return
 		case <-t.C:
+			// Session price could have been changed by the provider since the last check.
+			currentPrice := m.Status().Proposal.Price
 			newPrice, err := m.pricer.GetCurrentPrice(m.status.Proposal.Location.IPType, m.status.Proposal.Location.Country, m.status.Proposal.ServiceType)
 			if err != nil {
 				log.Error().Err(err).Msg("Failed to lookup proposal")
@@ -1072,6 +1256,14 @@ func (m *connectionManager) monitorPrice(currentPrice market.Price) {
 			hourDrop := float64(currentPrice.PricePerHour.Int64()-newPrice.PricePerHour.Int64()) / float64(currentPrice.PricePerHour.Int64())
 
 			if giBDrop*100 >= m.priceDropPercent || hourDrop*100 >= m.priceDropPercent {
+				// Try to keep the tunnel with the lower price first.
+				err := m.proposePrice(newPrice)
+				if err == nil {
+					log.Info().Msgf("Price dropped from %q to %q, continuing session with the new price", currentPrice.String(), newPrice.String())
+					continue
+				}
+				log.Warn().Err(err).Msg("Could not renegotiate session price")
+
 				log.Info().Msgf("Price dropped significantly from %q to %q, disconnecting", currentPrice.String(), newPrice.String())
 				m.Disconnect()
 				return
@@ -423,6 +423,39 @@ func (tc *testContext) TestDisconnectDueToPriceDrop() {
 	assert.Equal(tc.T(), connectionstate.NotConnected, tc.connManager.Status().State)
 }
 
+func (tc *testContext) TestRenegotiatePriceOnPriceDrop() {
+	tc.fakeConnectionFactory.mockConnection.onStartReportStates = []fakeState{
+		connectedState,
+	}
+	tc.connManager.priceCheckInterval = time.Millisecond
+	tc.mockP2P.ch.priceAccepted = true
+
+	expensiveProposal := activeProposal
+	expensiveProposal.Price = market.Price{
+		PricePerHour: big.NewInt(1),
+		PricePerGiB:  big.NewInt(2),
+	}
+	proposalLookup := func() (*proposal.PricedServiceProposal, error) {
+		return &expensiveProposal, nil
+	}
+
+	err := tc.connManager.Connect(consumerID, hermesID, proposalLookup, ConnectParams{})
+	assert.NoError(tc.T(), err)
+
+	waitABit()
+
+	newPrice := market.Price{
+		PricePerHour: big.NewInt(1),
+		PricePerGiB:  big.NewInt(1),
+	}
+	status := tc.connManager.Status()
+	assert.Equal(tc.T(), connectionstate.Connected, status.State)
+	assert.Equal(tc.T(), newPrice, status.Proposal.Price)
+	assert.Equal(tc.T(), &newPrice, tc.MockPaymentIssuer.ChangedPrice())
+
+	assert.NoError(tc.T(), tc.connManager.Disconnect())
+}
+
 func (tc *testContext) Test_PaymentManager_WhenManagerMadeConnectionIsStarted() {
 	err := tc.connManager.Connect(consumerID, hermesID, activeProposalLookup, ConnectParams{})
 	waitABit()
@@ -614,6 +647,9 @@ func waitABit() {
 type MockPaymentIssuer struct {
 	startCalled bool
 	stopCalled  bool
+	price       *market.Price
+	priceAt     market.PriceBoundary
+	boundary    market.PriceBoundary
 	MockError   error
 	stopChan    chan struct{}
 	sync.Mutex
@@ -642,6 +678,9 @@ func (mpm *MockPaymentIssuer) StopCalled() bool {
 func (mpm *MockPaymentIssuer) Stop() {
 	mpm.Lock()
 	defer mpm.Unlock()
+	if mpm.stopCalled {
+		return
+	}
 	mpm.stopCalled = true
 	close(mpm.stopChan)
 }
@@ -649,6 +688,31 @@ func (mpm *MockPaymentIssuer) Stop() {
 func (mpm *MockPaymentIssuer) SetSessionID(string) {
 }
 
+func (mpm *MockPaymentIssuer) PriceBoundary() market.PriceBoundary {
+	mpm.Lock()
+	defer mpm.Unlock()
+	return mpm.boundary
+}
+
+func (mpm *MockPaymentIssuer) ChangePrice(price market.Price, at market.PriceBoundary) {
+	mpm.Lock()
+	defer mpm.Unlock()
+	mpm.price = &price
+	mpm.priceAt = at
+}
+
+func (mpm *MockPaymentIssuer) ChangedPriceAt() market.PriceBoundary {
+	mpm.Lock()
+	defer mpm.Unlock()
+	return mpm.priceAt
+}
+
+func (mpm *MockPaymentIssuer) ChangedPrice() *market.Price {
+	mpm.Lock()
+	defer mpm.Unlock()
+	return mpm.price
+}
+
 type mockP2PDialer struct {
 	ch *mockP2PChannel
 }
@@ -658,8 +722,31 @@ func (m mockP2PDialer) Dial(ctx context.Context, consumerID identity.Identity, p
 }
 
 type mockP2PChannel struct {
-	status proto.Message
-	lock   sync.Mutex
+	status        proto.Message
+	priceAccepted bool
+	sessionID     session.ID
+	created       int
+	destroyed     int
+	handlers      map[string]p2p.HandlerFunc
+	lock          sync.Mutex
+}
+
+func (m *mockP2PChannel) handler(topic string) p2p.HandlerFunc {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.handlers[topic]
+}
+
+func (m *mockP2PChannel) sessionsCreated() int {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.created
+}
+
+func (m *mockP2PChannel) sessionsDestroyed() int {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.destroyed
 }
 
 func (m *mockP2PChannel) Conn() p2p.ServiceConn {
@@ -675,8 +762,15 @@ func (m *mockP2PChannel) getSentMsg() proto.Message {
 func (m *mockP2PChannel) Send(_ context.Context, topic string, msg *p2p.Message) (*p2p.Message, error) {
 	switch topic {
 	case p2p.TopicSessionCreate:
+		m.lock.Lock()
+		m.created++
+		sessionID := establishedSessionID
+		if m.sessionID != "" {
+			sessionID = m.sessionID
+		}
+		m.lock.Unlock()
 		res := &pb.SessionResponse{
-			ID: string(establishedSessionID),
+			ID: string(sessionID),
 		}
 		return p2p.ProtoMessage(res), nil
 	case p2p.TopicSessionStatus:
@@ -688,12 +782,37 @@ func (m *mockP2PChannel) Send(_ context.Context, topic string, msg *p2p.Message)
 		return nil, nil
 	case p2p.TopicSessionAcknowledge:
 		return nil, nil
+	case p2p.TopicSessionDestroy:
+		m.lock.Lock()
+		m.destroyed++
+		m.lock.Unlock()
+		return nil, nil
+	case p2p.TopicPriceChange:
+		m.lock.Lock()
+		defer m.lock.Unlock()
+		if !m.priceAccepted {
+			return p2p.ProtoMessage(&pb.PriceChangeResponse{Reason: "price is not valid"}), nil
+		}
+		return p2p.ProtoMessage(&pb.PriceChangeResponse{Accepted: true}), nil
 	}
 
 	return nil, errors.New("unexpected error")
 }
 
 func (m *mockP2PChannel) Handle(topic string, handler p2p.HandlerFunc) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if m.handlers == nil {
+		m.handlers = make(map[string]p2p.HandlerFunc)
+	}
+	m.handlers[topic] = handler
+}
+
+func (m *mockP2PChannel) OpenStream(_ context.Context, _ string) (p2p.Stream, error) {
+	return nil, p2p.ErrStreamNotSupported
+}
+
+func (m *mockP2PChannel) HandleStream(_ string, _ p2p.StreamHandlerFunc) {
 }
 
 func (m *mockP2PChannel) Tracer() *trace.Tracer {
@@ -0,0 +1,157 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package connection
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/mysteriumnetwork/node/core/connection/connectionstate"
+	"github.com/mysteriumnetwork/node/market"
+	"github.com/mysteriumnetwork/node/p2p"
+	"github.com/mysteriumnetwork/node/pb"
+	"github.com/mysteriumnetwork/node/session"
+)
+
+var (
+	errPriceChangeRejected     = errors.New("price change rejected")
+	errPriceChangeWrongSession = errors.New("price change for unknown session")
+	errPriceChangeTooExpensive = errors.New("price is higher than the current network price")
+	errPriceChangeBoundary     = errors.New("price change boundary does not match the session")
+	errPriceChangeIncrease     = errors.New("price increase is not accepted by the consumer")
+)
+
+// handlePriceChange registers handler for session price changes proposed by the provider.
+func (m *connectionManager) handlePriceChange(channel p2p.ChannelHandler, sessionID session.ID) {
+	channel.Handle(p2p.TopicPriceChange, func(c p2p.Context) error {
+		var req pb.PriceChangeRequest
+		if err := c.Request().UnmarshalProto(&req); err != nil {
+			return err
+		}
+
+		log.Debug().Msgf("Received P2P message for %q: %s", p2p.TopicPriceChange, req.String())
+
+		reply := pb.PriceChangeResponse{Accepted: true}
+		if err := m.acceptPrice(sessionID, &req); err != nil {
+			log.Warn().Err(err).Msgf("Price change for session %s rejected", sessionID)
+			reply = pb.PriceChangeResponse{Reason: err.Error()}
+		}
+
+		return c.OkWithReply(p2p.ProtoMessage(&reply))
+	})
+}
+
+// acceptPrice switches to the price proposed by the provider unless it is higher than the current network price.
+// Price increases are accepted only if the consumer allowed them, otherwise the session continues with the agreed price.
+// The price is switched at the boundary chosen by the provider if it is close to the one seen by the consumer.
+func (m *connectionManager) acceptPrice(sessionID session.ID, req *pb.PriceChangeRequest) error {
+	if session.ID(req.GetSessionID()) != sessionID {
+		return errPriceChangeWrongSession
+	}
+
+	price := market.Price{
+		PricePerHour: new(big.Int).SetBytes(req.GetPerHour()),
+		PricePerGiB:  new(big.Int).SetBytes(req.GetPerGib()),
+	}
+
+	proposal := m.Status().Proposal
+	if !m.config.PriceChange.AcceptIncrease && isIncrease(proposal.Price, price) {
+		return errPriceChangeIncrease
+	}
+
+	maxPrice, err := m.pricer.GetCurrentPrice(proposal.Location.IPType, proposal.Location.Country, proposal.ServiceType)
+	if err != nil {
+		return fmt.Errorf("could not get current price: %w", err)
+	}
+	if price.PricePerGiB.Cmp(maxPrice.PricePerGiB) > 0 || price.PricePerHour.Cmp(maxPrice.PricePerHour) > 0 {
+		return errPriceChangeTooExpensive
+	}
+
+	payments := m.currentPayments()
+	if payments == nil {
+		return errPriceChangeWrongSession
+	}
+	at := market.PriceBoundary{
+		Elapsed: time.Duration(req.GetBoundaryElapsedMs()) * time.Millisecond,
+		Bytes:   req.GetBoundaryBytes(),
+	}
+	if !at.Near(payments.PriceBoundary(), market.PriceBoundaryTolerance) {
+		return errPriceChangeBoundary
+	}
+
+	m.switchPrice(price, at)
+	return nil
+}
+
+// proposePrice asks the provider to continue the session with the given price.
+// The price is switched only after the provider accepts it, both peers switch it at the boundary sent in the request.
+func (m *connectionManager) proposePrice(price market.Price) error {
+	payments := m.currentPayments()
+	if payments == nil {
+		return errPriceChangeWrongSession
+	}
+	at := payments.PriceBoundary()
+
+	ctx, cancel := context.WithTimeout(context.Background(), m.config.KeepAlive.SendTimeout)
+	defer cancel()
+
+	msg := &pb.PriceChangeRequest{
+		SessionID:         string(m.Status().SessionID),
+		PerGib:            price.PricePerGiB.Bytes(),
+		PerHour:           price.PricePerHour.Bytes(),
+		BoundaryElapsedMs: at.Elapsed.Milliseconds(),
+		BoundaryBytes:     at.Bytes,
+	}
+	log.Debug().Msgf("Sending P2P message to %q: %s", p2p.TopicPriceChange, msg.String())
+	res, err := m.currentChannel().Send(ctx, p2p.TopicPriceChange, p2p.ProtoMessage(msg))
+	if err != nil {
+		return fmt.Errorf("could not send price change: %w", err)
+	}
+
+	var reply pb.PriceChangeResponse
+	if err := res.UnmarshalProto(&reply); err != nil {
+		return fmt.Errorf("could not unmarshal price change reply: %w", err)
+	}
+	if !reply.GetAccepted() {
+		return fmt.Errorf("%w: %s", errPriceChangeRejected, reply.GetReason())
+	}
+
+	m.switchPrice(price, at)
+	return nil
+}
+
+// switchPrice makes the invoice payer accept invoices with the new price from the given boundary.
+func (m *connectionManager) switchPrice(price market.Price, at market.PriceBoundary) {
+	if payments := m.currentPayments(); payments != nil {
+		payments.ChangePrice(price, at)
+	}
+	m.setStatus(func(status *connectionstate.Status) {
+		status.Proposal.Price = price
+	})
+	log.Info().Msgf("Session price changed to %s", price.String())
+}
+
+// isIncrease checks whether any component of the new price is higher than the current one.
+func isIncrease(current, next market.Price) bool {
+	return next.PricePerHour.Cmp(current.PricePerHour) > 0 || next.PricePerGiB.Cmp(current.PricePerGiB) > 0
+}
@@ -0,0 +1,325 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package connection
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mysteriumnetwork/node/core/connection/connectionstate"
+	"github.com/mysteriumnetwork/node/core/policy/localcopy"
+	"github.com/mysteriumnetwork/node/core/service"
+	"github.com/mysteriumnetwork/node/core/service/servicestate"
+	"github.com/mysteriumnetwork/node/datasize"
+	"github.com/mysteriumnetwork/node/identity"
+	"github.com/mysteriumnetwork/node/market"
+	"github.com/mysteriumnetwork/node/mocks"
+	"github.com/mysteriumnetwork/node/p2p"
+	"github.com/mysteriumnetwork/node/pb"
+	"github.com/mysteriumnetwork/node/session"
+	"github.com/mysteriumnetwork/node/trace"
+	"github.com/mysteriumnetwork/node/utils/reftracker"
+	"github.com/mysteriumnetwork/payments/crypto"
+)
+
+func (tc *testContext) TestProviderProposesPrice() {
+	gib := datasize.GiB.Bytes()
+
+	ch := &providerChannel{consumer: tc.mockP2P.ch}
+	reftracker.Singleton().Put("channel:"+ch.ID(), 10*time.Second, func() { ch.Close() })
+
+	engine := &providerPaymentEngine{boundary: market.PriceBoundary{Elapsed: time.Hour, Bytes: gib}}
+	sessions := service.NewSessionPool(mocks.NewEventBus())
+	provider := service.NewSessionManager(
+		service.NewInstance(activeProviderID, activeServiceType, struct{}{}, activeProposal.ServiceProposal, servicestate.Running, &providerService{}, localcopy.NewRepository(), &providerDiscovery{}),
+		sessions,
+		func(_, _ identity.Identity, _ int64, _ common.Address, _ string, _ chan crypto.ExchangeMessage, _ market.Price) (service.PaymentEngine, error) {
+			return engine, nil
+		},
+		mocks.NewEventBus(),
+		ch,
+		service.DefaultConfig(),
+		&providerPriceValidator{},
+	)
+	res, err := provider.Start(&pb.SessionRequest{
+		Consumer: &pb.ConsumerInfo{
+			Id:       consumerID.Address,
+			HermesID: hermesID.Hex(),
+			Pricing: &pb.Pricing{
+				PerGib:  activeProposal.Price.PricePerGiB.Bytes(),
+				PerHour: activeProposal.Price.PricePerHour.Bytes(),
+			},
+		},
+	})
+	assert.NoError(tc.T(), err)
+	sessionID := session.ID(res.ID)
+	defer func() {
+		if s, found := sessions.Find(sessionID); found {
+			s.Close()
+		}
+	}()
+
+	tc.mockP2P.ch.sessionID = sessionID
+	err = tc.connManager.Connect(consumerID, hermesID, activeProposalLookup, ConnectParams{})
+	assert.NoError(tc.T(), err)
+	assert.Equal(tc.T(), connectionstate.Connected, tc.connManager.Status().State)
+
+	// Consumer counted the session a bit differently, but switches the price at the provider's boundary.
+	tc.MockPaymentIssuer.Lock()
+	tc.MockPaymentIssuer.boundary = market.PriceBoundary{Elapsed: time.Hour + 2*time.Second, Bytes: gib + datasize.MiB.Bytes()}
+	tc.MockPaymentIssuer.Unlock()
+
+	newPrice := *market.NewPrice(1, 1)
+	assert.NoError(tc.T(), sessions.ProposePrice(sessionID, newPrice))
+	assert.Equal(tc.T(), &newPrice, engine.changedPrice())
+	assert.Equal(tc.T(), engine.boundary, engine.changedAt())
+	assert.Equal(tc.T(), &newPrice, tc.MockPaymentIssuer.ChangedPrice())
+	assert.Equal(tc.T(), engine.boundary, tc.MockPaymentIssuer.ChangedPriceAt())
+	assert.Equal(tc.T(), newPrice, tc.connManager.Status().Proposal.Price)
+
+	// Consumer rejects prices above the network price, provider keeps the previous one.
+	err = sessions.ProposePrice(sessionID, *market.NewPrice(5, 5))
+	assert.ErrorIs(tc.T(), err, service.ErrorPriceRejected)
+	assert.Equal(tc.T(), &newPrice, engine.changedPrice())
+
+	// Consumer rejects boundaries moved back, so used traffic is not billed with the new price.
+	engine.setBoundary(market.PriceBoundary{Elapsed: time.Hour - time.Minute, Bytes: gib - 100*datasize.MiB.Bytes()})
+	err = sessions.ProposePrice(sessionID, *market.NewPrice(0, 1))
+	assert.ErrorIs(tc.T(), err, service.ErrorPriceRejected)
+	assert.Equal(tc.T(), &newPrice, engine.changedPrice())
+	assert.Equal(tc.T(), &newPrice, tc.MockPaymentIssuer.ChangedPrice())
+
+	// Consumer accepts price increases only if it allowed them.
+	engine.setBoundary(market.PriceBoundary{Elapsed: time.Hour, Bytes: gib})
+	lowPrice := *market.NewPrice(0, 1)
+	assert.NoError(tc.T(), sessions.ProposePrice(sessionID, lowPrice))
+	assert.Equal(tc.T(), &lowPrice, tc.MockPaymentIssuer.ChangedPrice())
+
+	err = sessions.ProposePrice(sessionID, newPrice)
+	assert.ErrorIs(tc.T(), err, service.ErrorPriceRejected)
+	assert.Equal(tc.T(), &lowPrice, engine.changedPrice())
+	assert.Equal(tc.T(), &lowPrice, tc.MockPaymentIssuer.ChangedPrice())
+
+	tc.connManager.config.PriceChange.AcceptIncrease = true
+	assert.NoError(tc.T(), sessions.ProposePrice(sessionID, newPrice))
+	assert.Equal(tc.T(), &newPrice, engine.changedPrice())
+	assert.Equal(tc.T(), &newPrice, tc.MockPaymentIssuer.ChangedPrice())
+
+	assert.ErrorIs(tc.T(), sessions.ProposePrice("unknown", newPrice), service.ErrorSessionNotExists)
+	assert.NoError(tc.T(), tc.connManager.Disconnect())
+}
+
+func (tc *testContext) TestProviderPriceChangeIsUsedForPriceMonitoring() {
+	tc.fakeConnectionFactory.mockConnection.onStartReportStates = []fakeState{
+		connectedState,
+	}
+	pricer := &switchablePricer{price: activeProposal.Price}
+	tc.connManager.pricer = pricer
+	tc.connManager.priceCheckInterval = time.Millisecond
+
+	err := tc.connManager.Connect(consumerID, hermesID, activeProposalLookup, ConnectParams{})
+	assert.NoError(tc.T(), err)
+	waitABit()
+	assert.Equal(tc.T(), connectionstate.Connected, tc.connManager.Status().State)
+
+	// Provider lowers the price, consumer should not treat the network price drop to it as a reason to disconnect.
+	newPrice := *market.NewPrice(1, 1)
+	ch := &providerChannel{consumer: tc.mockP2P.ch}
+	res, err := ch.Send(context.Background(), p2p.TopicPriceChange, p2p.ProtoMessage(&pb.PriceChangeRequest{
+		SessionID: string(establishedSessionID),
+		PerGib:    newPrice.PricePerGiB.Bytes(),
+		PerHour:   newPrice.PricePerHour.Bytes(),
+	}))
+	assert.NoError(tc.T(), err)
+	var reply pb.PriceChangeResponse
+	assert.NoError(tc.T(), res.UnmarshalProto(&reply))
+	assert.True(tc.T(), reply.GetAccepted())
+
+	pricer.set(newPrice)
+	waitABit()
+
+	assert.Equal(tc.T(), connectionstate.Connected, tc.connManager.Status().State)
+	assert.Equal(tc.T(), newPrice, tc.connManager.Status().Proposal.Price)
+	assert.NoError(tc.T(), tc.connManager.Disconnect())
+}
+
+type switchablePricer struct {
+	lock  sync.Mutex
+	price market.Price
+}
+
+func (p *switchablePricer) set(price market.Price) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.price = price
+}
+
+func (p *switchablePricer) GetCurrentPrice(string, string, string) (market.Price, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.price, nil
+}
+
+// providerChannel delivers provider messages to the handlers registered by the consumer on the mock channel.
+type providerChannel struct {
+	consumer *mockP2PChannel
+}
+
+func (m *providerChannel) Send(_ context.Context, topic string, msg *p2p.Message) (*p2p.Message, error) {
+	handler := m.consumer.handler(topic)
+	if handler == nil {
+		return nil, nil
+	}
+
+	c := &providerContext{req: msg}
+	if err := handler(c); err != nil {
+		return nil, err
+	}
+	return c.res, nil
+}
+
+func (m *providerChannel) Handle(_ string, _ p2p.HandlerFunc) {
+}
+
+func (m *providerChannel) OpenStream(_ context.Context, _ string) (p2p.Stream, error) {
+	return nil, p2p.ErrStreamNotSupported
+}
+
+func (m *providerChannel) HandleStream(_ string, _ p2p.StreamHandlerFunc) {
+}
+
+func (m *providerChannel) Tracer() *trace.Tracer {
+	return trace.NewTracer("Provider connect")
+}
+
+func (m *providerChannel) ServiceConn() p2p.ServiceConn { return nil }
+
+func (m *providerChannel) Conn() p2p.ServiceConn { return nil }
+
+func (m *providerChannel) Close() error { return nil }
+
+func (m *providerChannel) ID() string { return fmt.Sprintf("%p", m) }
+
+type providerContext struct {
+	req *p2p.Message
+	res *p2p.Message
+}
+
+func (c *providerContext) Request() *p2p.Message {
+	return c.req
+}
+
+func (c *providerContext) Error(err error) error {
+	return err
+}
+
+func (c *providerContext) OkWithReply(msg *p2p.Message) error {
+	c.res = msg
+	return nil
+}
+
+func (c *providerContext) OK() error {
+	return nil
+}
+
+func (c *providerContext) PeerID() identity.Identity {
+	return activeProviderID
+}
+
+type providerPaymentEngine struct {
+	lock     sync.Mutex
+	boundary market.PriceBoundary
+	price    *market.Price
+	at       market.PriceBoundary
+}
+
+func (e *providerPaymentEngine) Start() error {
+	return nil
+}
+
+func (e *providerPaymentEngine) WaitFirstInvoice(time.Duration) error {
+	return nil
+}
+
+func (e *providerPaymentEngine) PriceBoundary() market.PriceBoundary {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	return e.boundary
+}
+
+func (e *providerPaymentEngine) setBoundary(at market.PriceBoundary) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	e.boundary = at
+}
+
+func (e *providerPaymentEngine) ChangePrice(price market.Price, at market.PriceBoundary) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	e.price = &price
+	e.at = at
+}
+
+func (e *providerPaymentEngine) changedPrice() *market.Price {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	return e.price
+}
+
+func (e *providerPaymentEngine) changedAt() market.PriceBoundary {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	return e.at
+}
+
+func (e *providerPaymentEngine) Stop() {
+}
+
+type providerService struct{}
+
+func (s *providerService) Serve(*service.Instance) error {
+	return nil
+}
+
+func (s *providerService) Stop() error {
+	return nil
+}
+
+func (s *providerService) ProvideConfig(_ string, _ json.RawMessage, _ p2p.ServiceConn) (*service.ConfigParams, error) {
+	return &service.ConfigParams{}, nil
+}
+
+type providerDiscovery struct{}
+
+func (d *providerDiscovery) Start(identity.Identity, func() market.ServiceProposal) {}
+
+func (d *providerDiscovery) Stop() {}
+
+func (d *providerDiscovery) Wait() {}
+
+type providerPriceValidator struct{}
+
+func (v *providerPriceValidator) IsPriceValid(market.Price, string, string, string) bool {
+	return true
+}
@@ -0,0 +1,73 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package connection
+
+import (
+	"github.com/rs/zerolog/log"
+
+	"github.com/mysteriumnetwork/node/core/connection/connectionstate"
+	"github.com/mysteriumnetwork/node/eventbus"
+)
+
+type providerFailures interface {
+	RecordFailure(consumerID, providerID string, limit int) (blocked bool, err error)
+	ResetFailures(consumerID, providerID string) error
+}
+
+// ProviderAutoBlocker blocks providers for a consumer after a number of consecutive failed connects.
+type ProviderAutoBlocker struct {
+	lists providerFailures
+	limit int
+}
+
+// NewProviderAutoBlocker returns a new ProviderAutoBlocker blocking providers after limit failed connects.
+func NewProviderAutoBlocker(lists providerFailures, limit int) *ProviderAutoBlocker {
+	return &ProviderAutoBlocker{
+		lists: lists,
+		limit: limit,
+	}
+}
+
+// Subscribe subscribes to connection state changes.
+func (b *ProviderAutoBlocker) Subscribe(bus eventbus.Subscriber) error {
+	return bus.SubscribeAsync(connectionstate.AppTopicConnectionState, b.handleState)
+}
+
+func (b *ProviderAutoBlocker) handleState(e connectionstate.AppEventConnectionState) {
+	consumerID := e.SessionInfo.ConsumerID.Address
+	providerID := e.SessionInfo.Proposal.ProviderID
+	if consumerID == "" || providerID == "" {
+		return
+	}
+
+	switch e.State {
+	case connectionstate.StateConnectionFailed:
+		blocked, err := b.lists.RecordFailure(consumerID, providerID, b.limit)
+		if err != nil {
+			log.Warn().Err(err).Msgf("Could not record failed connect to provider %s", providerID)
+			return
+		}
+		if blocked {
+			log.Info().Msgf("Provider %s blocked for consumer %s after %d failed connects", providerID, consumerID, b.limit)
+		}
+	case connectionstate.Connected:
+		if err := b.lists.ResetFailures(consumerID, providerID); err != nil {
+			log.Warn().Err(err).Msgf("Could not reset failed connects to provider %s", providerID)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package connection
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mysteriumnetwork/node/core/connection/connectionstate"
+	"github.com/mysteriumnetwork/node/core/discovery/proposal"
+	"github.com/mysteriumnetwork/node/identity"
+	"github.com/mysteriumnetwork/node/market"
+)
+
+type mockProviderFailures struct {
+	failures map[string]int
+	blocked  []string
+}
+
+func (m *mockProviderFailures) RecordFailure(consumerID, providerID string, limit int) (bool, error) {
+	m.failures[providerID]++
+	if m.failures[providerID] >= limit {
+		m.blocked = append(m.blocked, providerID)
+		return true, nil
+	}
+	return false, nil
+}
+
+func (m *mockProviderFailures) ResetFailures(consumerID, providerID string) error {
+	delete(m.failures, providerID)
+	return nil
+}
+
+func TestProviderAutoBlocker(t *testing.T) {
+	lists := &mockProviderFailures{failures: make(map[string]int)}
+	blocker := NewProviderAutoBlocker(lists, 2)
+	event := func(state connectionstate.State, providerID string) connectionstate.AppEventConnectionState {
+		return connectionstate.AppEventConnectionState{
+			State: state,
+			SessionInfo: connectionstate.Status{
+				ConsumerID: identity.FromAddress("0xc"),
+				Proposal:   proposal.PricedServiceProposal{ServiceProposal: market.ServiceProposal{ProviderID: providerID}},
+			},
+		}
+	}
+
+	blocker.handleState(event(connectionstate.StateConnectionFailed, "0x1"))
+	blocker.handleState(event(connectionstate.Connected, "0x1"))
+	blocker.handleState(event(connectionstate.StateConnectionFailed, "0x1"))
+	assert.Empty(t, lists.blocked)
+
+	blocker.handleState(event(connectionstate.Connecting, "0x1"))
+	blocker.handleState(event(connectionstate.StateConnectionFailed, "0x1"))
+	blocker.handleState(event(connectionstate.StateConnectionFailed, ""))
+	assert.Equal(t, []string{"0x1"}, lists.blocked)
+}
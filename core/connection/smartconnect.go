@@ -19,6 +19,7 @@ package connection
 
 import (
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/mysteriumnetwork/node/core/discovery/proposal"
@@ -29,10 +30,16 @@ type proposalRepository interface {
 }
 
 // FilteredProposals create an function to keep getting proposals from the discovery based on the provided filters.
+// Favourite providers of the consumer given in the filter are tried first.
 func FilteredProposals(f *proposal.Filter, sortBy string, repo proposalRepository) func() (*proposal.PricedServiceProposal, error) {
 	usedProposals := make(map[string]time.Time)
+	// Lookup is shared between reconnects and failover standby preparation.
+	var mu sync.Mutex
 
 	return func() (*proposal.PricedServiceProposal, error) {
+		mu.Lock()
+		defer mu.Unlock()
+
 		proposals, err := repo.Proposals(f)
 		if err != nil {
 			return nil, err
@@ -42,6 +49,7 @@ func FilteredProposals(f *proposal.Filter, sortBy string, repo proposalRepositor
 		if err != nil {
 			return nil, fmt.Errorf("failed to sort proposals: %w", err)
 		}
+		proposals = proposal.SortByFavourite(proposals)
 
 		for _, p := range proposals { // Trying to find providers that we didn't try to connect during 5 minutes.
 			if t, ok := usedProposals[p.ProviderID]; !ok || time.Since(t) > 5*time.Minute {
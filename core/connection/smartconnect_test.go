@@ -0,0 +1,53 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package connection
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mysteriumnetwork/node/core/discovery/proposal"
+	"github.com/mysteriumnetwork/node/market"
+)
+
+type mockProposalRepository struct {
+	proposals []proposal.PricedServiceProposal
+}
+
+func (m *mockProposalRepository) Proposals(_ *proposal.Filter) ([]proposal.PricedServiceProposal, error) {
+	return m.proposals, nil
+}
+
+func TestFilteredProposals_PrefersFavourites(t *testing.T) {
+	repo := &mockProposalRepository{proposals: []proposal.PricedServiceProposal{
+		{ServiceProposal: market.ServiceProposal{ProviderID: "0x1"}},
+		{ServiceProposal: market.ServiceProposal{ProviderID: "0x2"}, Favourite: true},
+		{ServiceProposal: market.ServiceProposal{ProviderID: "0x3"}},
+	}}
+	lookup := FilteredProposals(&proposal.Filter{}, "", repo)
+
+	var providers []string
+	for range repo.proposals {
+		p, err := lookup()
+		require.NoError(t, err)
+		providers = append(providers, p.ProviderID)
+	}
+	assert.Equal(t, []string{"0x2", "0x1", "0x3"}, providers)
+}
@@ -0,0 +1,130 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package control
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mysteriumnetwork/node/identity"
+)
+
+var (
+	errUnauthorized = errors.New("signer is not authorized")
+	errReplayed     = errors.New("request was already executed")
+	errBeforeStart  = errors.New("request was issued before the node started")
+)
+
+// authorizer verifies that requests are signed by an authorized key,
+// addressed to this node and neither stale nor replayed.
+//
+// Seen request IDs are kept in memory only, so requests issued before the node
+// started are rejected, as a previous run of the node might have executed them.
+type authorizer struct {
+	verifier  identity.Verifier
+	operators []string
+	maxSkew   time.Duration
+	now       func() time.Time
+	// started is the first whole second after the start, as request timestamps have second resolution.
+	started time.Time
+
+	mu   sync.Mutex
+	seen map[string]time.Time
+}
+
+func newAuthorizer(verifier identity.Verifier, operators []string, maxSkew time.Duration) *authorizer {
+	return &authorizer{
+		verifier:  verifier,
+		operators: operators,
+		maxSkew:   maxSkew,
+		now:       time.Now,
+		started:   time.Now().Truncate(time.Second).Add(time.Second),
+		seen:      make(map[string]time.Time),
+	}
+}
+
+// authorize returns the request of the message if it may be executed by the given node.
+func (a *authorizer) authorize(node string, msg SignedMessage) (Request, error) {
+	ok, signer := a.verifier.Verify(msg.Payload, identity.SignatureBytes(msg.Signature))
+	if !ok {
+		return Request{}, errors.New("invalid signature")
+	}
+	if !a.isAuthorized(node, signer.Address) {
+		return Request{}, errUnauthorized
+	}
+
+	var req Request
+	if err := json.Unmarshal(msg.Payload, &req); err != nil {
+		return Request{}, fmt.Errorf("could not parse request: %w", err)
+	}
+	if req.ID == "" {
+		return Request{}, errors.New("request ID is required")
+	}
+	if !strings.EqualFold(req.Identity, node) {
+		return Request{}, fmt.Errorf("request is addressed to %s", req.Identity)
+	}
+
+	now := a.now()
+	issued := time.Unix(req.Timestamp, 0)
+	if issued.Before(now.Add(-a.maxSkew)) || issued.After(now.Add(a.maxSkew)) {
+		return Request{}, errors.New("request timestamp is out of the allowed window")
+	}
+	if issued.Before(a.started) {
+		return Request{}, errBeforeStart
+	}
+
+	return req, a.remember(req.ID, now)
+}
+
+func (a *authorizer) isAuthorized(node, signer string) bool {
+	if strings.EqualFold(signer, node) {
+		return true
+	}
+
+	for _, operator := range a.operators {
+		if strings.EqualFold(signer, operator) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// remember records the request nonce. Nonces are kept for twice the allowed skew,
+// older requests are rejected by their timestamp anyway.
+func (a *authorizer) remember(id string, now time.Time) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for nonce, at := range a.seen {
+		if now.Sub(at) > 2*a.maxSkew {
+			delete(a.seen, nonce)
+		}
+	}
+
+	if _, ok := a.seen[id]; ok {
+		return errReplayed
+	}
+	a.seen[id] = now
+
+	return nil
+}
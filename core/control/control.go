@@ -18,43 +18,119 @@
 package control
 
 import (
+	"encoding/json"
 	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	nats_lib "github.com/nats-io/nats.go"
+	"github.com/rs/zerolog/log"
 
-	"github.com/mysteriumnetwork/node/communication"
 	"github.com/mysteriumnetwork/node/communication/nats"
+	"github.com/mysteriumnetwork/node/identity"
 	"github.com/mysteriumnetwork/node/tequilapi/client"
+	"github.com/mysteriumnetwork/node/tequilapi/contract"
 )
 
-type controlMessage []struct {
-	Service string `json:"service"`
-	Command string `json:"command"`
+// Config of the control plane.
+type Config struct {
+	// Operators are the addresses, besides the node identity, allowed to send commands.
+	Operators []string
+	// MaxSkew is the allowed difference between request timestamp and local time.
+	MaxSkew time.Duration
+}
+
+type tequilapiClient interface {
+	Services() (contract.ServiceListResponse, error)
+	ServiceStart(request contract.ServiceStartRequest) (contract.ServiceInfoDTO, error)
+	ServiceStop(id string) error
+	SetConfig(data map[string]interface{}) error
+	Settle(providerID identity.Identity, hermesIDs []common.Address, waitForBlockchain bool) error
 }
 
+var _ tequilapiClient = (*client.Client)(nil)
+
 // ControlPlane is a struct that represents the control plane of the node
 type ControlPlane struct {
-	nats     communication.Receiver
-	api      *client.Client
-	identity string
+	connection   nats.Connection
+	api          tequilapiClient
+	signer       identity.SignerFactory
+	auth         *authorizer
+	identity     string
+	subscription *nats_lib.Subscription
 }
 
 // NewControlPlane creates a new control plane
-func NewControlPlane(connection nats.Connection, api *client.Client) *ControlPlane {
+func NewControlPlane(connection nats.Connection, api tequilapiClient, signer identity.SignerFactory, verifier identity.Verifier, cfg Config) *ControlPlane {
 	return &ControlPlane{
-		nats: nats.NewReceiver(connection, communication.NewCodecJSON(), ""),
-		api:  api,
+		connection: connection,
+		api:        api,
+		signer:     signer,
+		auth:       newAuthorizer(verifier, cfg.Operators, cfg.MaxSkew),
 	}
 }
 
 // Start starts the control plane
-func (c *ControlPlane) Start(identity string) error {
+func (c *ControlPlane) Start(identity string) (err error) {
 	c.identity = identity
-	return c.nats.Receive(communication.MessageConsumer(&consumer{
-		callback: c.handler,
-		topic:    communication.MessageEndpoint(fmt.Sprintf("%s.control-plane.v1", identity)),
-	}))
+	c.subscription, err = c.connection.Subscribe(fmt.Sprintf("%s.control-plane.v1", identity), func(msg *nats_lib.Msg) {
+		reply, err := c.handle(msg.Data)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to handle control message")
+			return
+		}
+
+		if msg.Reply == "" {
+			return
+		}
+		if err := c.connection.Publish(msg.Reply, reply); err != nil {
+			log.Error().Err(err).Msg("Failed to publish control message result")
+		}
+	})
+	return err
 }
 
 // Stop stops the control plane
 func (c *ControlPlane) Stop() {
-	c.nats.ReceiveUnsubscribe(communication.MessageEndpoint(fmt.Sprintf("%s.control-plane.v1", c.identity)))
+	if c.subscription == nil {
+		return
+	}
+	if err := c.subscription.Unsubscribe(); err != nil {
+		log.Error().Err(err).Msg("Failed to unsubscribe from control plane")
+	}
+}
+
+// handle executes an authorized request and returns the signed result.
+func (c *ControlPlane) handle(data []byte) ([]byte, error) {
+	var msg SignedMessage
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return nil, fmt.Errorf("could not parse control message: %w", err)
+	}
+
+	result := Result{Identity: c.identity}
+	req, err := c.auth.authorize(c.identity, msg)
+	if err != nil {
+		log.Warn().Err(err).Msg("Rejected control request")
+		result.Error = err.Error()
+	} else {
+		result.RequestID = req.ID
+		result.Commands = c.execute(req.Commands)
+	}
+	result.Timestamp = time.Now().Unix()
+
+	return c.sign(result)
+}
+
+func (c *ControlPlane) sign(result Result) ([]byte, error) {
+	payload, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := c.signer(identity.FromAddress(c.identity)).Sign(payload)
+	if err != nil {
+		return nil, fmt.Errorf("could not sign control result: %w", err)
+	}
+
+	return json.Marshal(SignedMessage{Payload: payload, Signature: signature.Bytes()})
 }
@@ -0,0 +1,226 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package control
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mysteriumnetwork/node/identity"
+	"github.com/mysteriumnetwork/node/tequilapi/contract"
+)
+
+type fakeSigner struct {
+	key *ecdsa.PrivateKey
+}
+
+func (s *fakeSigner) Sign(message []byte) (identity.Signature, error) {
+	signature, err := crypto.Sign(crypto.Keccak256(message), s.key)
+	return identity.SignatureBytes(signature), err
+}
+
+type fakeAPI struct {
+	services []contract.ServiceInfoDTO
+	started  []contract.ServiceStartRequest
+	stopped  []string
+	config   map[string]interface{}
+	settled  []common.Address
+}
+
+func (api *fakeAPI) Services() (contract.ServiceListResponse, error) {
+	return api.services, nil
+}
+
+func (api *fakeAPI) ServiceStart(request contract.ServiceStartRequest) (contract.ServiceInfoDTO, error) {
+	api.started = append(api.started, request)
+	return contract.ServiceInfoDTO{}, nil
+}
+
+func (api *fakeAPI) ServiceStop(id string) error {
+	api.stopped = append(api.stopped, id)
+	return nil
+}
+
+func (api *fakeAPI) SetConfig(data map[string]interface{}) error {
+	api.config = data
+	return nil
+}
+
+func (api *fakeAPI) Settle(_ identity.Identity, hermesIDs []common.Address, _ bool) error {
+	api.settled = hermesIDs
+	return nil
+}
+
+type controlPlaneTest struct {
+	cp       *ControlPlane
+	api      *fakeAPI
+	node     *fakeSigner
+	nodeAddr string
+}
+
+func newControlPlaneTest(t *testing.T, operators ...string) *controlPlaneTest {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	node := &fakeSigner{key: key}
+	api := &fakeAPI{}
+	cp := NewControlPlane(nil, api, func(identity.Identity) identity.Signer { return node }, identity.NewVerifierSigned(), Config{
+		Operators: operators,
+		MaxSkew:   time.Minute,
+	})
+	cp.identity = crypto.PubkeyToAddress(key.PublicKey).Hex()
+	cp.auth.started = time.Now().Add(-time.Minute)
+
+	return &controlPlaneTest{cp: cp, api: api, node: node, nodeAddr: cp.identity}
+}
+
+func (test *controlPlaneTest) request(t *testing.T, signer *fakeSigner, req Request) Result {
+	payload, err := json.Marshal(req)
+	require.NoError(t, err)
+	signature, err := signer.Sign(payload)
+	require.NoError(t, err)
+	data, err := json.Marshal(SignedMessage{Payload: payload, Signature: signature.Bytes()})
+	require.NoError(t, err)
+
+	reply, err := test.cp.handle(data)
+	require.NoError(t, err)
+
+	var msg SignedMessage
+	require.NoError(t, json.Unmarshal(reply, &msg))
+	ok, replySigner := identity.NewVerifierSigned().Verify(msg.Payload, identity.SignatureBytes(msg.Signature))
+	require.True(t, ok)
+	assert.Equal(t, identity.FromAddress(test.nodeAddr), replySigner)
+
+	var result Result
+	require.NoError(t, json.Unmarshal(msg.Payload, &result))
+	return result
+}
+
+func TestControlPlane_ExecutesRequestSignedByNode(t *testing.T) {
+	test := newControlPlaneTest(t)
+	test.api.services = contract.ServiceListResponse{{ID: "1", Type: "wireguard"}}
+
+	result := test.request(t, test.node, Request{
+		ID:        "req-1",
+		Identity:  test.nodeAddr,
+		Timestamp: time.Now().Unix(),
+		Commands: []Command{
+			{Command: CommandStop, Service: "wireguard"},
+			{Command: CommandConfigSet, Config: map[string]interface{}{"payments.zero-stake-unsettled-amount": 5}},
+			{Command: CommandSettle, HermesIDs: []string{"0x0000000000000000000000000000000000000001"}},
+			{Command: "reboot"},
+		},
+	})
+
+	assert.Equal(t, "req-1", result.RequestID)
+	assert.Empty(t, result.Error)
+	require.Len(t, result.Commands, 4)
+	assert.Empty(t, result.Commands[0].Error)
+	assert.Empty(t, result.Commands[1].Error)
+	assert.Empty(t, result.Commands[2].Error)
+	assert.Equal(t, `unknown command: "reboot"`, result.Commands[3].Error)
+
+	assert.Equal(t, []string{"1"}, test.api.stopped)
+	assert.Equal(t, map[string]interface{}{"payments.zero-stake-unsettled-amount": float64(5)}, test.api.config)
+	assert.Equal(t, []common.Address{common.HexToAddress("0x1")}, test.api.settled)
+}
+
+func TestControlPlane_ServiceOptionsRestartsWithOptions(t *testing.T) {
+	test := newControlPlaneTest(t)
+	test.api.services = contract.ServiceListResponse{{ID: "1", Type: "noop"}}
+
+	result := test.request(t, test.node, Request{
+		ID:        "req-1",
+		Identity:  test.nodeAddr,
+		Timestamp: time.Now().Unix(),
+		Commands:  []Command{{Command: CommandServiceOptions, Service: "noop", Options: json.RawMessage(`{"port":1}`)}},
+	})
+
+	require.Len(t, result.Commands, 1)
+	assert.Empty(t, result.Commands[0].Error)
+	assert.Equal(t, []string{"1"}, test.api.stopped)
+	require.Len(t, test.api.started, 1)
+	assert.Equal(t, json.RawMessage(`{"port":1}`), test.api.started[0].Options)
+}
+
+func TestControlPlane_AuthorizesOperators(t *testing.T) {
+	operatorKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	operator := &fakeSigner{key: operatorKey}
+
+	test := newControlPlaneTest(t, crypto.PubkeyToAddress(operatorKey.PublicKey).Hex())
+	result := test.request(t, operator, Request{
+		ID:        "req-1",
+		Identity:  test.nodeAddr,
+		Timestamp: time.Now().Unix(),
+		Commands:  []Command{{Command: CommandStart, Service: "noop"}},
+	})
+
+	assert.Empty(t, result.Error)
+	assert.Len(t, test.api.started, 1)
+}
+
+func TestControlPlane_RejectsUnauthorizedRequests(t *testing.T) {
+	strangerKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	stranger := &fakeSigner{key: strangerKey}
+
+	test := newControlPlaneTest(t)
+	valid := func() Request {
+		return Request{
+			ID:        "req-1",
+			Identity:  test.nodeAddr,
+			Timestamp: time.Now().Unix(),
+			Commands:  []Command{{Command: CommandStart, Service: "noop"}},
+		}
+	}
+
+	result := test.request(t, stranger, valid())
+	assert.Equal(t, errUnauthorized.Error(), result.Error)
+
+	stale := valid()
+	stale.Timestamp = time.Now().Add(-time.Hour).Unix()
+	result = test.request(t, test.node, stale)
+	assert.Equal(t, "request timestamp is out of the allowed window", result.Error)
+
+	otherNode := valid()
+	otherNode.Identity = "0x0000000000000000000000000000000000000001"
+	result = test.request(t, test.node, otherNode)
+	assert.Contains(t, result.Error, "request is addressed to")
+
+	result = test.request(t, test.node, valid())
+	assert.Empty(t, result.Error)
+	result = test.request(t, test.node, valid())
+	assert.Equal(t, errReplayed.Error(), result.Error)
+
+	// Requests seen by a previous run of the node are not known after a restart.
+	beforeStart := valid()
+	beforeStart.ID = "req-2"
+	test.cp.auth.started = time.Now().Add(time.Second)
+	result = test.request(t, test.node, beforeStart)
+	assert.Equal(t, errBeforeStart.Error(), result.Error)
+
+	assert.Len(t, test.api.started, 1)
+}
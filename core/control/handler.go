@@ -18,64 +18,119 @@
 package control
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rs/zerolog/log"
+
+	"github.com/mysteriumnetwork/node/identity"
 	"github.com/mysteriumnetwork/node/services"
 	"github.com/mysteriumnetwork/node/tequilapi/contract"
-	"github.com/rs/zerolog/log"
 )
 
-// handler is a function that handles control messages
-func (c *ControlPlane) handler(request controlMessage) error {
+// execute runs the commands in order and reports the outcome of each of them.
+func (c *ControlPlane) execute(commands []Command) []CommandResult {
+	results := make([]CommandResult, 0, len(commands))
+	for _, cmd := range commands {
+		log.Info().Str("command", cmd.Command).Str("service", cmd.Service).Msg("Executing control request")
+
+		result := CommandResult{Command: cmd.Command, Service: cmd.Service}
+		if err := c.executeCommand(cmd); err != nil {
+			log.Warn().Err(err).Str("command", cmd.Command).Msg("Control request failed")
+			result.Error = err.Error()
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+func (c *ControlPlane) executeCommand(cmd Command) error {
+	switch cmd.Command {
+	case CommandStart:
+		return c.startService(cmd.Service, nil)
+	case CommandStop:
+		return c.forRunningServices(cmd.Service, func(service contract.ServiceInfoDTO) error {
+			return c.stopService(service.ID)
+		})
+	case CommandRestart:
+		return c.forRunningServices(cmd.Service, func(service contract.ServiceInfoDTO) error {
+			if err := c.stopService(service.ID); err != nil {
+				return err
+			}
+			return c.startService(service.Type, nil)
+		})
+	case CommandServiceOptions:
+		if len(cmd.Options) == 0 {
+			return errors.New("service options are required")
+		}
+		// Service is started with the new options even if it was not running.
+		if err := c.forRunningServices(cmd.Service, func(service contract.ServiceInfoDTO) error {
+			return c.stopService(service.ID)
+		}); err != nil && !errors.Is(err, errNotRunning) {
+			return err
+		}
+		return c.startService(cmd.Service, cmd.Options)
+	case CommandConfigSet:
+		if len(cmd.Config) == 0 {
+			return errors.New("config values are required")
+		}
+		return c.api.SetConfig(cmd.Config)
+	case CommandSettle:
+		hermesIDs := make([]common.Address, 0, len(cmd.HermesIDs))
+		for _, h := range cmd.HermesIDs {
+			if !common.IsHexAddress(h) {
+				return fmt.Errorf("invalid hermes ID: %q", h)
+			}
+			hermesIDs = append(hermesIDs, common.HexToAddress(h))
+		}
+		return c.api.Settle(identity.FromAddress(c.identity), hermesIDs, false)
+	default:
+		return fmt.Errorf("unknown command: %q", cmd.Command)
+	}
+}
+
+var errNotRunning = errors.New("service is not running")
+
+func (c *ControlPlane) forRunningServices(serviceType string, fn func(service contract.ServiceInfoDTO) error) error {
 	currentServices, err := c.api.Services()
 	if err != nil {
 		return err
 	}
 
-	for _, r := range request {
-		if r.Command == "start" {
-			log.Info().Str("service", r.Service).Msg("executing control start request")
-			if err := c.startService(r.Service, c.identity); err != nil {
-				log.Warn().AnErr("err", err).Msg("failed to start service")
-			}
+	found := false
+	for _, service := range currentServices {
+		if service.Type != serviceType {
 			continue
 		}
+		found = true
 
-		for _, service := range currentServices {
-			if service.Type != r.Service {
-				continue
-			}
-
-			if r.Command == "stop" {
-				log.Info().Str("service", service.Type).Msg("executing control stop request")
-				if err := c.stopService(service.ID); err != nil {
-					log.Warn().AnErr("err", err).Msg("failed to start service")
-				}
-			}
-
-			if r.Command == "restart" {
-				log.Info().Str("service", service.Type).Msg("executing control restart request")
-				if err := c.stopService(service.ID); err != nil {
-					log.Warn().AnErr("err", err).Msg("failed to stop service")
-				}
-				if err := c.startService(service.Type, c.identity); err != nil {
-					log.Warn().AnErr("err", err).Msg("failed to start service")
-				}
-			}
+		if err := fn(service); err != nil {
+			return err
 		}
 	}
+
+	if !found {
+		return errNotRunning
+	}
 	return nil
 }
 
-func (c *ControlPlane) startService(serviceType, providerID string) error {
+func (c *ControlPlane) startService(serviceType string, options json.RawMessage) error {
 	serviceOpts, err := services.GetStartOptions(serviceType)
 	if err != nil {
 		return err
 	}
 	startRequest := contract.ServiceStartRequest{
-		ProviderID:     providerID,
+		ProviderID:     c.identity,
 		Type:           serviceType,
 		AccessPolicies: &contract.ServiceAccessPolicies{IDs: serviceOpts.AccessPolicyList},
 		Options:        serviceOpts,
 	}
+	if options != nil {
+		startRequest.Options = options
+	}
 	_, err = c.api.ServiceStart(startRequest)
 	return err
 }
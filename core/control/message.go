@@ -0,0 +1,76 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package control
+
+import "encoding/json"
+
+// Supported control commands.
+const (
+	CommandStart          = "start"
+	CommandStop           = "stop"
+	CommandRestart        = "restart"
+	CommandConfigSet      = "config_set"
+	CommandSettle         = "settle"
+	CommandServiceOptions = "service_options"
+)
+
+// SignedMessage carries a JSON encoded Request or Result and the signature of its author.
+type SignedMessage struct {
+	Payload   []byte `json:"payload"`
+	Signature []byte `json:"signature"`
+}
+
+// Request is a batch of commands addressed to a single node.
+type Request struct {
+	// ID is a unique nonce of the request, it is rejected if seen again.
+	ID string `json:"id"`
+	// Identity of the node the request is addressed to.
+	Identity string `json:"identity"`
+	// Timestamp is the unix time of the request creation.
+	Timestamp int64     `json:"timestamp"`
+	Commands  []Command `json:"commands"`
+}
+
+// Command is a single control command.
+type Command struct {
+	Command string `json:"command"`
+	// Service type for start, stop, restart and service_options commands.
+	Service string `json:"service,omitempty"`
+	// Options of the service for service_options command.
+	Options json.RawMessage `json:"options,omitempty"`
+	// Config values for config_set command.
+	Config map[string]interface{} `json:"config,omitempty"`
+	// HermesIDs to settle with for settle command, active hermes is used if empty.
+	HermesIDs []string `json:"hermes_ids,omitempty"`
+}
+
+// Result is the outcome of a request, signed by the node.
+type Result struct {
+	RequestID string          `json:"request_id"`
+	Identity  string          `json:"identity"`
+	Timestamp int64           `json:"timestamp"`
+	Error     string          `json:"error,omitempty"`
+	Commands  []CommandResult `json:"commands,omitempty"`
+}
+
+// CommandResult is the outcome of a single command.
+type CommandResult struct {
+	Command string `json:"command"`
+	Service string `json:"service,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
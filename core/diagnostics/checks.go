@@ -0,0 +1,331 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package diagnostics
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/mysteriumnetwork/node/communication/nats"
+	"github.com/mysteriumnetwork/node/core/port"
+	"github.com/mysteriumnetwork/node/identity"
+	"github.com/mysteriumnetwork/node/identity/registry"
+	"github.com/mysteriumnetwork/node/nat"
+	"github.com/mysteriumnetwork/node/session/pingpong"
+)
+
+// Names of the built-in checks.
+const (
+	CheckNAT           = "nat_type"
+	CheckPort          = "udp_port_reachability"
+	CheckBroker        = "broker"
+	CheckDiscovery     = "discovery"
+	CheckDNS           = "dns"
+	CheckClockSkew     = "clock_skew"
+	CheckRegistration  = "identity_registration"
+	CheckHermesChannel = "hermes_channel"
+	CheckFirewall      = "firewall"
+)
+
+const (
+	portRequestTimeout = 2 * time.Second
+	maxClockSkew       = 30 * time.Second
+	warnClockSkew      = 5 * time.Second
+)
+
+type natProber interface {
+	Probe(context.Context) (nat.NATType, error)
+}
+
+type portAcquirer interface {
+	Acquire() (port.Port, error)
+}
+
+type brokerConnection interface {
+	Servers() []string
+}
+
+type httpClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+type identityProvider interface {
+	GetUnlockedIdentity() (identity.Identity, bool)
+}
+
+type registrationStatusProvider interface {
+	GetRegistrationStatus(chainID int64, id identity.Identity) (registry.RegistrationStatus, error)
+}
+
+type hermesProvider interface {
+	GetActiveHermes(chainID int64) (common.Address, error)
+}
+
+type hermesChannelFetcher interface {
+	Fetch(chainID int64, id identity.Identity, hermesID common.Address) (pingpong.HermesChannel, error)
+}
+
+// NATCheck detects NAT type of the node.
+func NATCheck(prober natProber) Check {
+	return Check{
+		Name: CheckNAT,
+		Run: func(ctx context.Context) Result {
+			natType, err := prober.Probe(ctx)
+			if err != nil {
+				return Warning(fmt.Sprintf("NAT type detection failed: %v", err), "Make sure outgoing UDP traffic is allowed, NAT type is detected using STUN servers")
+			}
+			name := nat.HumanReadableTypes[natType]
+			if natType == nat.NATTypeSymmetric {
+				return Warning(fmt.Sprintf("NAT type is %s", name), "Consumers behind NAT may fail to connect. Forward UDP ports to this host or enable UPnP on the router")
+			}
+			return OK(fmt.Sprintf("NAT type is %s", name))
+		},
+	}
+}
+
+// PortCheck checks whether an UDP port of the node's port range is reachable from the Internet.
+func PortCheck(ports portAcquirer, echoServers []string) Check {
+	return Check{
+		Name: CheckPort,
+		Run: func(ctx context.Context) Result {
+			if len(echoServers) == 0 {
+				return Skipped("no port check servers configured")
+			}
+			p, err := ports.Acquire()
+			if err != nil {
+				return Failed(fmt.Sprintf("could not acquire UDP port: %v", err), "Free up ports of the range given by --udp.ports or extend it")
+			}
+			ok, err := port.GloballyReachable(ctx, p, echoServers, portRequestTimeout)
+			if err != nil || !ok {
+				return Warning(fmt.Sprintf("UDP port %d is not reachable from the Internet", p), "Forward the UDP port range given by --udp.ports to this host, or enable UPnP on the router. Hole punching is used otherwise")
+			}
+			return OK(fmt.Sprintf("UDP port %d is reachable from the Internet", p))
+		},
+	}
+}
+
+// BrokerCheck checks whether message broker servers are reachable.
+func BrokerCheck(conn brokerConnection) Check {
+	return Check{
+		Name: CheckBroker,
+		Run: func(ctx context.Context) Result {
+			if c, ok := conn.(interface{ IsConnected() bool }); ok && !c.IsConnected() {
+				return Failed("node is not connected to the broker", "Check that outgoing TCP connections to the broker are allowed")
+			}
+
+			var failed []string
+			servers := conn.Servers()
+			for _, server := range servers {
+				serverURL, err := nats.ParseServerURL(server)
+				if err != nil {
+					failed = append(failed, server)
+					continue
+				}
+				if err := dial(ctx, serverURL.Host); err != nil {
+					failed = append(failed, serverURL.Host)
+				}
+			}
+			if len(failed) == len(servers) {
+				return Failed(fmt.Sprintf("broker is not reachable: %s", strings.Join(failed, ", ")), "Check that outgoing TCP connections to the broker are allowed and --broker-address is correct")
+			}
+			if len(failed) > 0 {
+				return Warning(fmt.Sprintf("some brokers are not reachable: %s", strings.Join(failed, ", ")), "Check that outgoing TCP connections to the broker are allowed")
+			}
+			return OK(fmt.Sprintf("%d broker(s) reachable", len(servers)))
+		},
+	}
+}
+
+// DiscoveryCheck checks whether discovery API responds.
+func DiscoveryCheck(client httpClient, address string) Check {
+	return Check{
+		Name: CheckDiscovery,
+		Run: func(ctx context.Context) Result {
+			res, err := head(ctx, client, address)
+			if err != nil {
+				return Failed(fmt.Sprintf("discovery is not reachable: %v", err), "Check the network connection and that --discovery.address is correct")
+			}
+			if res.StatusCode >= http.StatusInternalServerError {
+				return Failed(fmt.Sprintf("discovery responded with %s", res.Status), "Discovery is unavailable, try again later")
+			}
+			return OK(fmt.Sprintf("discovery at %s is reachable", address))
+		},
+	}
+}
+
+// DNSCheck checks whether given hosts are resolved.
+func DNSCheck(hosts []string) Check {
+	return Check{
+		Name: CheckDNS,
+		Run: func(ctx context.Context) Result {
+			var failed []string
+			for _, host := range hosts {
+				if net.ParseIP(host) != nil {
+					continue
+				}
+				if _, err := net.DefaultResolver.LookupHost(ctx, host); err != nil {
+					failed = append(failed, host)
+				}
+			}
+			if len(failed) > 0 {
+				return Failed(fmt.Sprintf("could not resolve %s", strings.Join(failed, ", ")), "Check the DNS servers of the host, e.g. /etc/resolv.conf")
+			}
+			return OK(fmt.Sprintf("%d host(s) resolved", len(hosts)))
+		},
+	}
+}
+
+// ClockSkewCheck compares local clock with the Date header returned by the given server.
+func ClockSkewCheck(client httpClient, address string) Check {
+	return Check{
+		Name: CheckClockSkew,
+		Run: func(ctx context.Context) Result {
+			sent := time.Now()
+			res, err := head(ctx, client, address)
+			if err != nil {
+				return Skipped(fmt.Sprintf("could not get time from %s: %v", address, err))
+			}
+			remote, err := http.ParseTime(res.Header.Get("Date"))
+			if err != nil {
+				return Skipped(fmt.Sprintf("%s did not return a valid Date header", address))
+			}
+
+			// Date header has second precision, so take the middle of the request as local time.
+			local := sent.Add(time.Since(sent) / 2)
+			skew := local.Sub(remote).Round(time.Second)
+			if skew < 0 {
+				skew = -skew
+			}
+
+			msg := fmt.Sprintf("clock skew is %s", skew)
+			remediation := "Synchronize the system clock, e.g. enable NTP"
+			switch {
+			case skew > maxClockSkew:
+				return Failed(msg, remediation)
+			case skew > warnClockSkew:
+				return Warning(msg, remediation)
+			}
+			return OK(msg)
+		},
+	}
+}
+
+// RegistrationCheck checks whether the unlocked identity is registered.
+func RegistrationCheck(identities identityProvider, registry registrationStatusProvider, chainID int64) Check {
+	return Check{
+		Name: CheckRegistration,
+		Run: func(ctx context.Context) Result {
+			id, ok := identities.GetUnlockedIdentity()
+			if !ok {
+				return Failed("no unlocked identity", "Unlock an identity with `myst cli` or tequilapi")
+			}
+			status, err := registry.GetRegistrationStatus(chainID, id)
+			if err != nil {
+				return Warning(fmt.Sprintf("could not get registration status of %s: %v", id.Address, err), "Check that the blockchain RPC is reachable")
+			}
+			if !status.Registered() {
+				return Failed(fmt.Sprintf("identity %s is %s", id.Address, status), "Register the identity with `myst account register`")
+			}
+			return OK(fmt.Sprintf("identity %s is registered", id.Address))
+		},
+	}
+}
+
+// HermesChannelCheck checks whether the unlocked identity has a channel with the active hermes.
+func HermesChannelCheck(identities identityProvider, hermeses hermesProvider, channels hermesChannelFetcher, chainID int64) Check {
+	return Check{
+		Name: CheckHermesChannel,
+		Run: func(ctx context.Context) Result {
+			id, ok := identities.GetUnlockedIdentity()
+			if !ok {
+				return Skipped("no unlocked identity")
+			}
+			hermesID, err := hermeses.GetActiveHermes(chainID)
+			if err != nil {
+				return Failed(fmt.Sprintf("could not get active hermes: %v", err), "Check that the blockchain RPC is reachable")
+			}
+			channel, err := channels.Fetch(chainID, id, hermesID)
+			if err != nil {
+				return Warning(fmt.Sprintf("could not fetch channel with hermes %s: %v", hermesID.Hex(), err), "Channel is opened once the identity is registered, make sure hermes is reachable")
+			}
+			return OK(fmt.Sprintf("channel %s with hermes %s is open", channel.ChannelID, hermesID.Hex()))
+		},
+	}
+}
+
+// FirewallCheck checks whether firewall rules can be managed by the node.
+// Probe is nil on platforms where the node does not manage the firewall.
+func FirewallCheck(probe func() error) Check {
+	return Check{
+		Name: CheckFirewall,
+		Run: func(ctx context.Context) Result {
+			if probe == nil {
+				return Skipped("firewall is not managed on this platform")
+			}
+			if err := probe(); err != nil {
+				return Failed(fmt.Sprintf("could not list firewall rules: %v", err), "Make sure iptables is installed and the node is allowed to run it with sudo")
+			}
+			return OK("firewall rules can be managed")
+		},
+	}
+}
+
+// Hosts returns host names of given URLs, skipping unparsable ones.
+func Hosts(addresses ...string) []string {
+	var hosts []string
+	for _, address := range addresses {
+		if !strings.Contains(address, "://") {
+			address = "//" + address
+		}
+		u, err := url.Parse(address)
+		if err != nil || u.Hostname() == "" {
+			continue
+		}
+		hosts = append(hosts, u.Hostname())
+	}
+	return hosts
+}
+
+func dial(ctx context.Context, address string) error {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+func head(ctx context.Context, client httpClient, address string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, address, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	res.Body.Close()
+	return res, nil
+}
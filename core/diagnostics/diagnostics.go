@@ -0,0 +1,171 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package diagnostics
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Status of a single check or of the whole report.
+type Status string
+
+const (
+	// StatusOK means the check passed.
+	StatusOK Status = "ok"
+	// StatusWarning means the node works, but may perform worse than it could.
+	StatusWarning Status = "warning"
+	// StatusFailed means the check found a problem which has to be fixed.
+	StatusFailed Status = "failed"
+	// StatusSkipped means the check could not run, e.g. it is not applicable to this platform.
+	StatusSkipped Status = "skipped"
+)
+
+var severity = map[Status]int{
+	StatusSkipped: 0,
+	StatusOK:      1,
+	StatusWarning: 2,
+	StatusFailed:  3,
+}
+
+// DefaultCheckTimeout is the time a single check is allowed to run.
+const DefaultCheckTimeout = 15 * time.Second
+
+// Result is an outcome of a single check.
+type Result struct {
+	Status      Status `json:"status"`
+	Message     string `json:"message"`
+	Remediation string `json:"remediation,omitempty"`
+}
+
+// CheckResult is an outcome of a named check.
+type CheckResult struct {
+	Name     string        `json:"name"`
+	Duration time.Duration `json:"duration"`
+	Result
+}
+
+// Report is an outcome of all checks.
+type Report struct {
+	Status    Status        `json:"status"`
+	StartedAt time.Time     `json:"started_at"`
+	Duration  time.Duration `json:"duration"`
+	Checks    []CheckResult `json:"checks"`
+}
+
+// Check is a single diagnostics step.
+type Check struct {
+	Name string
+	Run  func(ctx context.Context) Result
+}
+
+// Diagnostics runs a list of checks.
+type Diagnostics struct {
+	checks  []Check
+	timeout time.Duration
+}
+
+// NewDiagnostics returns diagnostics running given checks.
+func NewDiagnostics(timeout time.Duration, checks ...Check) *Diagnostics {
+	return &Diagnostics{
+		checks:  checks,
+		timeout: timeout,
+	}
+}
+
+// Run runs all checks concurrently and collects results in order of checks.
+func (d *Diagnostics) Run(ctx context.Context) Report {
+	report := Report{
+		Status:    StatusOK,
+		StartedAt: time.Now().UTC(),
+		Checks:    make([]CheckResult, len(d.checks)),
+	}
+
+	var wg sync.WaitGroup
+	for i, check := range d.checks {
+		wg.Add(1)
+		go func(i int, check Check) {
+			defer wg.Done()
+			report.Checks[i] = d.run(ctx, check)
+		}(i, check)
+	}
+	wg.Wait()
+
+	for _, check := range report.Checks {
+		if severity[check.Status] > severity[report.Status] {
+			report.Status = check.Status
+		}
+	}
+	report.Duration = time.Since(report.StartedAt)
+	return report
+}
+
+func (d *Diagnostics) run(ctx context.Context, check Check) CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan Result, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				log.Error().Msgf("Diagnostics check %s panicked: %v\n%s", check.Name, r, debug.Stack())
+				done <- Failed(fmt.Sprintf("check panicked: %v", r), "Report this issue to the node developers")
+			}
+		}()
+		done <- check.Run(ctx)
+	}()
+
+	var res Result
+	select {
+	case res = <-done:
+	case <-ctx.Done():
+		res = Failed("check timed out", "Check the network connection of the node")
+	}
+
+	return CheckResult{
+		Name:     check.Name,
+		Duration: time.Since(start),
+		Result:   res,
+	}
+}
+
+// OK returns a passed check result.
+func OK(message string) Result {
+	return Result{Status: StatusOK, Message: message}
+}
+
+// Warning returns a warning check result.
+func Warning(message, remediation string) Result {
+	return Result{Status: StatusWarning, Message: message, Remediation: remediation}
+}
+
+// Failed returns a failed check result.
+func Failed(message, remediation string) Result {
+	return Result{Status: StatusFailed, Message: message, Remediation: remediation}
+}
+
+// Skipped returns a skipped check result.
+func Skipped(message string) Result {
+	return Result{Status: StatusSkipped, Message: message}
+}
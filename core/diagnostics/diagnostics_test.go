@@ -0,0 +1,154 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package diagnostics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mysteriumnetwork/node/identity"
+	"github.com/mysteriumnetwork/node/identity/registry"
+	"github.com/mysteriumnetwork/node/nat"
+)
+
+func TestDiagnostics_Run(t *testing.T) {
+	d := NewDiagnostics(
+		50*time.Millisecond,
+		Check{Name: "ok", Run: func(ctx context.Context) Result { return OK("fine") }},
+		Check{Name: "warning", Run: func(ctx context.Context) Result { return Warning("meh", "fix it") }},
+		Check{Name: "slow", Run: func(ctx context.Context) Result {
+			<-ctx.Done()
+			time.Sleep(10 * time.Millisecond)
+			return OK("late")
+		}},
+		Check{Name: "panic", Run: func(ctx context.Context) Result { panic("boom") }},
+	)
+
+	report := d.Run(context.Background())
+
+	assert.Equal(t, StatusFailed, report.Status)
+	assert.Len(t, report.Checks, 4)
+	assert.Equal(t, "ok", report.Checks[0].Name)
+	assert.Equal(t, StatusOK, report.Checks[0].Status)
+	assert.Equal(t, StatusWarning, report.Checks[1].Status)
+	assert.Equal(t, "fix it", report.Checks[1].Remediation)
+	assert.Equal(t, StatusFailed, report.Checks[2].Status)
+	assert.Equal(t, "check timed out", report.Checks[2].Message)
+	assert.Equal(t, StatusFailed, report.Checks[3].Status)
+}
+
+func TestDiagnostics_Run_SkippedDoesNotLowerStatus(t *testing.T) {
+	d := NewDiagnostics(
+		time.Second,
+		Check{Name: "skipped", Run: func(ctx context.Context) Result { return Skipped("n/a") }},
+	)
+
+	assert.Equal(t, StatusOK, d.Run(context.Background()).Status)
+}
+
+type mockProber struct {
+	natType nat.NATType
+	err     error
+}
+
+func (m *mockProber) Probe(context.Context) (nat.NATType, error) {
+	return m.natType, m.err
+}
+
+func TestNATCheck(t *testing.T) {
+	assert.Equal(t, StatusOK, NATCheck(&mockProber{natType: nat.NATTypeFullCone}).Run(context.Background()).Status)
+	assert.Equal(t, StatusWarning, NATCheck(&mockProber{natType: nat.NATTypeSymmetric}).Run(context.Background()).Status)
+	assert.Equal(t, StatusWarning, NATCheck(&mockProber{err: errors.New("no")}).Run(context.Background()).Status)
+}
+
+func TestClockSkewCheck(t *testing.T) {
+	offset := time.Duration(0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Date", time.Now().Add(offset).UTC().Format(http.TimeFormat))
+	}))
+	defer server.Close()
+
+	check := ClockSkewCheck(http.DefaultClient, server.URL)
+	assert.Equal(t, StatusOK, check.Run(context.Background()).Status)
+
+	offset = -10 * time.Second
+	assert.Equal(t, StatusWarning, check.Run(context.Background()).Status)
+
+	offset = time.Hour
+	assert.Equal(t, StatusFailed, check.Run(context.Background()).Status)
+}
+
+func TestDiscoveryCheck(t *testing.T) {
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	check := DiscoveryCheck(http.DefaultClient, server.URL)
+	assert.Equal(t, StatusOK, check.Run(context.Background()).Status)
+
+	status = http.StatusBadGateway
+	assert.Equal(t, StatusFailed, check.Run(context.Background()).Status)
+}
+
+type mockIdentities struct {
+	id *identity.Identity
+}
+
+func (m *mockIdentities) GetUnlockedIdentity() (identity.Identity, bool) {
+	if m.id == nil {
+		return identity.Identity{}, false
+	}
+	return *m.id, true
+}
+
+type mockRegistry struct {
+	status registry.RegistrationStatus
+}
+
+func (m *mockRegistry) GetRegistrationStatus(int64, identity.Identity) (registry.RegistrationStatus, error) {
+	return m.status, nil
+}
+
+func TestRegistrationCheck(t *testing.T) {
+	id := identity.FromAddress("0x1")
+
+	res := RegistrationCheck(&mockIdentities{}, &mockRegistry{}, 1).Run(context.Background())
+	assert.Equal(t, StatusFailed, res.Status)
+
+	res = RegistrationCheck(&mockIdentities{id: &id}, &mockRegistry{status: registry.Unregistered}, 1).Run(context.Background())
+	assert.Equal(t, StatusFailed, res.Status)
+	assert.NotEmpty(t, res.Remediation)
+
+	res = RegistrationCheck(&mockIdentities{id: &id}, &mockRegistry{status: registry.Registered}, 1).Run(context.Background())
+	assert.Equal(t, StatusOK, res.Status)
+}
+
+func TestHosts(t *testing.T) {
+	assert.Equal(t,
+		[]string{"discovery.mysterium.network", "broker.mysterium.network", "1.2.3.4"},
+		Hosts("https://discovery.mysterium.network/api/v4", "broker.mysterium.network:4222", "nats://1.2.3.4", "://"),
+	)
+}
@@ -183,7 +183,7 @@ func (d *Discovery) registerIdentity() {
 }
 
 func (d *Discovery) registerProposal() {
-	proposal := d.proposal()
+	proposal := d.signedProposal()
 	err := d.proposalRegistry.RegisterProposal(proposal, d.signer)
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to register proposal, retrying after 1 min")
@@ -204,7 +204,7 @@ func (d *Discovery) pingProposal() {
 	case <-d.stop:
 		return
 	case <-time.After(d.proposalPingTTL):
-		proposal := d.proposal()
+		proposal := d.signedProposal()
 		err := d.proposalRegistry.PingProposal(proposal, d.signer)
 		if err != nil {
 			log.Error().Err(err).Msg("Failed to ping proposal")
@@ -215,7 +215,7 @@ func (d *Discovery) pingProposal() {
 }
 
 func (d *Discovery) unregisterProposal() {
-	proposal := d.proposal()
+	proposal := d.signedProposal()
 	err := d.proposalRegistry.UnregisterProposal(proposal, d.signer)
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to unregister proposal: ")
@@ -225,6 +225,18 @@ func (d *Discovery) unregisterProposal() {
 	d.changeStatus(ProposalUnregistered)
 }
 
+// signedProposal returns the current proposal signed with the provider identity.
+// If signing fails the proposal is announced unsigned, consumers not requiring signatures can still use it.
+func (d *Discovery) signedProposal() market.ServiceProposal {
+	proposal := d.proposal()
+	signed, err := SignProposal(proposal, d.signer)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to sign proposal")
+		return proposal
+	}
+	return signed
+}
+
 func (d *Discovery) checkRegistration() {
 	// check if node's identity is registered
 	chainID := config.GetInt64(config.FlagChainID)
@@ -27,4 +27,13 @@ const (
 	AppTopicProposalRemoved = "ProposalRemoved"
 	// AppTopicProposalAnnounce represent proposal events topic.
 	AppTopicProposalAnnounce = "proposalEvent"
+	// AppTopicProposalRejected represents proposal dropped because of a bad provider signature
+	AppTopicProposalRejected = "ProposalRejected"
 )
+
+// AppEventProposalRejected is published when a proposal fails the provider signature check.
+type AppEventProposalRejected struct {
+	ProviderID  string
+	ServiceType string
+	Reason      string
+}
@@ -25,10 +25,13 @@ import (
 )
 
 // PricedServiceProposalRepository enriches proposals with price data as pricing data is not available on raw proposals.
+// If a snapshot is given, it is refreshed from this repository and used as a fallback once the base repository fails.
 type PricedServiceProposalRepository struct {
 	baseRepo      proposal.Repository
 	pip           PriceInfoProvider
 	filterPresets proposal.FilterPresetRepository
+	providerLists proposal.ProviderListRepository
+	snapshot      *ProposalSnapshot
 }
 
 // PriceInfoProvider allows to fetch the current pricing for services.
@@ -37,19 +40,25 @@ type PriceInfoProvider interface {
 }
 
 // NewPricedServiceProposalRepository returns a new instance of PricedServiceProposalRepository.
-func NewPricedServiceProposalRepository(baseRepo proposal.Repository, pip PriceInfoProvider, filterPresets proposal.FilterPresetRepository) *PricedServiceProposalRepository {
-	return &PricedServiceProposalRepository{
+func NewPricedServiceProposalRepository(baseRepo proposal.Repository, pip PriceInfoProvider, filterPresets proposal.FilterPresetRepository, providerLists proposal.ProviderListRepository, snapshot *ProposalSnapshot) *PricedServiceProposalRepository {
+	pspr := &PricedServiceProposalRepository{
 		baseRepo:      baseRepo,
 		pip:           pip,
 		filterPresets: filterPresets,
+		providerLists: providerLists,
+		snapshot:      snapshot,
 	}
+	if snapshot != nil {
+		snapshot.fetch = pspr.allProposals
+	}
+	return pspr
 }
 
 // Proposal fetches the proposal from base repository and enriches it with pricing data.
 func (pspr *PricedServiceProposalRepository) Proposal(id market.ProposalID) (*proposal.PricedServiceProposal, error) {
 	prop, err := pspr.baseRepo.Proposal(id)
 	if err != nil {
-		return nil, err
+		return pspr.snapshotProposal(id, err)
 	}
 
 	// base repo can sometimes return nil proposals.
@@ -62,12 +71,15 @@ func (pspr *PricedServiceProposalRepository) Proposal(id market.ProposalID) (*pr
 }
 
 // Proposals fetches proposals from base repository and enriches them with pricing data.
+// Provider lists of the consumer are applied if the filter has one.
 func (pspr *PricedServiceProposalRepository) Proposals(filter *proposal.Filter) ([]proposal.PricedServiceProposal, error) {
+	var priced []proposal.PricedServiceProposal
 	proposals, err := pspr.baseRepo.Proposals(filter)
-	if err != nil {
+	if err == nil {
+		priced = pspr.toPricedProposals(proposals)
+	} else if priced, err = pspr.snapshotProposals(filter, err); err != nil {
 		return nil, err
 	}
-	priced := pspr.toPricedProposals(proposals)
 
 	if filter != nil && filter.PresetID != 0 {
 		preset, err := pspr.filterPresets.Get(filter.PresetID)
@@ -77,6 +89,14 @@ func (pspr *PricedServiceProposalRepository) Proposals(filter *proposal.Filter)
 		priced = preset.Filter(priced)
 	}
 
+	if filter != nil && filter.ConsumerID != "" && pspr.providerLists != nil {
+		lists, err := pspr.providerLists.Get(filter.ConsumerID)
+		if err != nil {
+			return nil, err
+		}
+		priced = lists.Apply(priced, append([]string{filter.ProviderID}, filter.ProviderIDs...)...)
+	}
+
 	return priced, nil
 }
 
@@ -115,3 +135,53 @@ func (pspr *PricedServiceProposalRepository) toPricedProposal(in market.ServiceP
 		Price:           price,
 	}, nil
 }
+
+// allProposals returns all proposals known to discovery for the snapshot.
+func (pspr *PricedServiceProposalRepository) allProposals() ([]proposal.PricedServiceProposal, error) {
+	proposals, err := pspr.baseRepo.Proposals(&proposal.Filter{
+		AccessPolicy:            "all",
+		IncludeMonitoringFailed: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return pspr.toPricedProposals(proposals), nil
+}
+
+func (pspr *PricedServiceProposalRepository) snapshotProposals(filter *proposal.Filter, discoveryErr error) ([]proposal.PricedServiceProposal, error) {
+	if pspr.snapshot == nil {
+		return nil, discoveryErr
+	}
+
+	snapshot, err := pspr.snapshot.Proposals()
+	if err != nil {
+		log.Debug().Err(err).Msg("Proposal snapshot is not available")
+		return nil, discoveryErr
+	}
+	log.Warn().Err(discoveryErr).Msg("Discovery failed, using proposal snapshot")
+
+	if filter == nil {
+		return snapshot, nil
+	}
+	filtered := make([]proposal.PricedServiceProposal, 0)
+	for _, p := range snapshot {
+		if filter.Matches(p.ServiceProposal) {
+			filtered = append(filtered, p)
+		}
+	}
+	return filtered, nil
+}
+
+func (pspr *PricedServiceProposalRepository) snapshotProposal(id market.ProposalID, discoveryErr error) (*proposal.PricedServiceProposal, error) {
+	snapshot, err := pspr.snapshotProposals(nil, discoveryErr)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, p := range snapshot {
+		if p.UniqueID() == id {
+			return &p, nil
+		}
+	}
+	return nil, discoveryErr
+}
@@ -68,7 +68,7 @@ func TestGetProposal(t *testing.T) {
 			errToReturn:      nil,
 		}
 
-		repo := NewPricedServiceProposalRepository(mr, mp, presetRepository)
+		repo := NewPricedServiceProposalRepository(mr, mp, presetRepository, nil, nil)
 
 		result, err := repo.Proposal(market.ProposalID{})
 		assert.NoError(t, err)
@@ -82,7 +82,7 @@ func TestGetProposal(t *testing.T) {
 			errToReturn: mockError,
 		}
 
-		repo := NewPricedServiceProposalRepository(mr, &mockPriceInfoProvider{}, presetRepository)
+		repo := NewPricedServiceProposalRepository(mr, &mockPriceInfoProvider{}, presetRepository, nil, nil)
 		_, err := repo.Proposal(market.ProposalID{})
 		assert.Error(t, err)
 		assert.Equal(t, mockError, err)
@@ -94,7 +94,7 @@ func TestGetProposal(t *testing.T) {
 		}
 		repo := NewPricedServiceProposalRepository(&mockRepository{
 			proposalToReturn: &mockProposal,
-		}, mp, nil)
+		}, mp, nil, nil, nil)
 
 		_, err := repo.Proposal(market.ProposalID{})
 		assert.Error(t, err)
@@ -117,7 +117,7 @@ func TestGetProposals(t *testing.T) {
 			errToReturn:       nil,
 		}
 
-		repo := NewPricedServiceProposalRepository(mr, mp, presetRepository)
+		repo := NewPricedServiceProposalRepository(mr, mp, presetRepository, nil, nil)
 
 		result, err := repo.Proposals(nil)
 		assert.NoError(t, err)
@@ -131,7 +131,7 @@ func TestGetProposals(t *testing.T) {
 			errToReturn: mockError,
 		}
 
-		repo := NewPricedServiceProposalRepository(mr, &mockPriceInfoProvider{}, presetRepository)
+		repo := NewPricedServiceProposalRepository(mr, &mockPriceInfoProvider{}, presetRepository, nil, nil)
 		_, err := repo.Proposals(nil)
 		assert.Error(t, err)
 		assert.Equal(t, mockError, err)
@@ -143,12 +143,49 @@ func TestGetProposals(t *testing.T) {
 		}
 		repo := NewPricedServiceProposalRepository(&mockRepository{
 			proposalsToReturn: []market.ServiceProposal{mockProposal},
-		}, mp, presetRepository)
+		}, mp, presetRepository, nil, nil)
 
 		res, err := repo.Proposals(nil)
 		assert.NoError(t, err)
 		assert.Len(t, res, 0)
 	})
+	t.Run("applies consumer provider lists", func(t *testing.T) {
+		favourite, blocked := mockProposal, mockProposal
+		favourite.ProviderID = "0x1"
+		blocked.ProviderID = "0x2"
+		lists := &mockProviderListRepository{lists: proposal.ProviderLists{
+			Favourites: []string{"0x1"},
+			Blocked:    []string{"0x2"},
+		}}
+		repo := NewPricedServiceProposalRepository(&mockRepository{
+			proposalsToReturn: []market.ServiceProposal{mockProposal, favourite, blocked},
+		}, &mockPriceInfoProvider{}, presetRepository, lists, nil)
+
+		res, err := repo.Proposals(&proposal.Filter{})
+		assert.NoError(t, err)
+		assert.Len(t, res, 3)
+
+		res, err = repo.Proposals(&proposal.Filter{ConsumerID: "0xc"})
+		assert.NoError(t, err)
+		assert.Equal(t, "0xc", lists.consumerID)
+		assert.Len(t, res, 2)
+		assert.False(t, res[0].Favourite)
+		assert.True(t, res[1].Favourite)
+
+		res, err = repo.Proposals(&proposal.Filter{ConsumerID: "0xc", ProviderID: "0x2"})
+		assert.NoError(t, err)
+		assert.Len(t, res, 3)
+	})
+}
+
+type mockProviderListRepository struct {
+	consumerID string
+	lists      proposal.ProviderLists
+}
+
+func (m *mockProviderListRepository) Get(consumerID string) (proposal.ProviderLists, error) {
+	m.consumerID = consumerID
+	return m.lists, nil
 }
 
 type mockRepository struct {
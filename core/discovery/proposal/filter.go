@@ -20,6 +20,7 @@ package proposal
 import (
 	"sync"
 
+	"github.com/mysteriumnetwork/node/core/discovery/query"
 	"github.com/mysteriumnetwork/node/core/discovery/reducer"
 	"github.com/mysteriumnetwork/node/market"
 	"github.com/mysteriumnetwork/node/market/mysterium"
@@ -41,6 +42,8 @@ type Filter struct {
 	ExcludeUnsupported                 bool
 	IncludeMonitoringFailed            bool
 	NATCompatibility                   nat.NATType
+	Query                              *query.Query
+	ConsumerID                         string
 	condition                          reducer.AndCondition
 	buildOnce                          sync.Once
 }
@@ -76,6 +79,9 @@ func (filter *Filter) Build() {
 				conditions = append(conditions, reducer.AccessPolicy(filter.AccessPolicy, filter.AccessPolicySource))
 			}
 		}
+		if filter.Query != nil {
+			conditions = append(conditions, filter.Query.Matches)
+		}
 		filter.condition = reducer.And(conditions...)
 	})
 }
@@ -20,6 +20,7 @@ package proposal
 import (
 	"testing"
 
+	"github.com/mysteriumnetwork/node/core/discovery/query"
 	"github.com/mysteriumnetwork/node/market"
 	"github.com/stretchr/testify/assert"
 )
@@ -161,3 +162,22 @@ func Test_ProposalFilter_Filters_Unsupported(t *testing.T) {
 	assert.False(t, filter.Matches(proposalEmpty))
 	assert.True(t, filter.Matches(proposalSupported))
 }
+
+func Test_ProposalFilter_FiltersByQuery(t *testing.T) {
+	q, err := query.Parse(`city ~ "vil" or asn >= 1000`)
+	assert.NoError(t, err)
+
+	filter := &Filter{
+		ServiceType: serviceTypeStreaming,
+		Query:       q,
+	}
+	assert.False(t, filter.Matches(proposalEmpty))
+	assert.True(t, filter.Matches(proposalProvider1Streaming))
+	assert.False(t, filter.Matches(proposalProvider1Noop))
+	assert.True(t, filter.Matches(proposalProvider2Streaming))
+
+	filter = &Filter{
+		Query: q,
+	}
+	assert.False(t, filter.Matches(proposalProvider1Noop))
+}
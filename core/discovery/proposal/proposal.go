@@ -18,6 +18,8 @@
 package proposal
 
 import (
+	"time"
+
 	"github.com/mysteriumnetwork/node/market"
 )
 
@@ -34,4 +36,8 @@ type Repository interface {
 type PricedServiceProposal struct {
 	market.ServiceProposal
 	Price market.Price `json:"price,omitempty"`
+	// SnapshotAt is set if the proposal comes from the local snapshot taken at this time.
+	SnapshotAt *time.Time `json:"snapshot_at,omitempty"`
+	// Favourite is set if the consumer marked the provider as a favourite.
+	Favourite bool `json:"favourite,omitempty"`
 }
@@ -0,0 +1,182 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package proposal
+
+import (
+	"slices"
+	"sync"
+)
+
+const providerListsBucket = "consumer-provider-lists"
+
+// ProviderListRepository provides providers marked by consumers as favourite or blocked.
+type ProviderListRepository interface {
+	Get(consumerID string) (ProviderLists, error)
+}
+
+// ProviderLists holds providers a consumer marked as favourite or blocked.
+type ProviderLists struct {
+	ConsumerID string
+	Favourites []string
+	Blocked    []string
+	// Failures counts consecutive failed connects per provider.
+	Failures map[string]int
+}
+
+// IsFavourite checks whether provider is a favourite one.
+func (l ProviderLists) IsFavourite(providerID string) bool {
+	return slices.Contains(l.Favourites, providerID)
+}
+
+// IsBlocked checks whether provider is blocked.
+func (l ProviderLists) IsBlocked(providerID string) bool {
+	return slices.Contains(l.Blocked, providerID)
+}
+
+// Apply removes proposals of blocked providers and marks favourite ones.
+// Blocked providers given in pinned are kept, as they were asked for explicitly.
+func (l ProviderLists) Apply(proposals []PricedServiceProposal, pinned ...string) []PricedServiceProposal {
+	res := make([]PricedServiceProposal, 0, len(proposals))
+	for _, p := range proposals {
+		if l.IsBlocked(p.ProviderID) && !slices.Contains(pinned, p.ProviderID) {
+			continue
+		}
+		p.Favourite = l.IsFavourite(p.ProviderID)
+		res = append(res, p)
+	}
+	return res
+}
+
+type providerListStorage interface {
+	GetValue(bucket string, key interface{}, to interface{}) error
+	SetValue(bucket string, key interface{}, to interface{}) error
+}
+
+// ProviderListStorage persists provider lists of consumers.
+type ProviderListStorage struct {
+	lock    sync.Mutex
+	storage providerListStorage
+}
+
+// NewProviderListStorage constructor for ProviderListStorage
+func NewProviderListStorage(storage providerListStorage) *ProviderListStorage {
+	return &ProviderListStorage{
+		storage: storage,
+	}
+}
+
+// Get returns provider lists of the consumer, empty if none were stored.
+func (pls *ProviderListStorage) Get(consumerID string) (ProviderLists, error) {
+	pls.lock.Lock()
+	defer pls.lock.Unlock()
+
+	return pls.get(consumerID)
+}
+
+// SetFavourite adds or removes provider from consumer favourites.
+func (pls *ProviderListStorage) SetFavourite(consumerID, providerID string, favourite bool) error {
+	return pls.update(consumerID, func(l *ProviderLists) {
+		l.Favourites = withMember(l.Favourites, providerID, favourite)
+	})
+}
+
+// SetBlocked adds or removes provider from consumer blocked providers.
+// Unblocking also resets failed connects counted for automatic blocking.
+func (pls *ProviderListStorage) SetBlocked(consumerID, providerID string, blocked bool) error {
+	return pls.update(consumerID, func(l *ProviderLists) {
+		l.Blocked = withMember(l.Blocked, providerID, blocked)
+		if !blocked {
+			delete(l.Failures, providerID)
+		}
+	})
+}
+
+// RecordFailure counts a failed connect to the provider and blocks it once the limit is reached.
+// It returns true if the provider got blocked.
+func (pls *ProviderListStorage) RecordFailure(consumerID, providerID string, limit int) (blocked bool, err error) {
+	err = pls.update(consumerID, func(l *ProviderLists) {
+		if l.IsBlocked(providerID) {
+			return
+		}
+		l.Failures[providerID]++
+		if l.Failures[providerID] >= limit {
+			l.Blocked = withMember(l.Blocked, providerID, true)
+			delete(l.Failures, providerID)
+			blocked = true
+		}
+	})
+	return blocked, err
+}
+
+// ResetFailures forgets failed connects to the provider.
+func (pls *ProviderListStorage) ResetFailures(consumerID, providerID string) error {
+	pls.lock.Lock()
+	defer pls.lock.Unlock()
+
+	lists, err := pls.get(consumerID)
+	if err != nil || lists.Failures[providerID] == 0 {
+		return err
+	}
+	delete(lists.Failures, providerID)
+	return pls.storage.SetValue(providerListsBucket, consumerID, lists)
+}
+
+func (pls *ProviderListStorage) update(consumerID string, change func(l *ProviderLists)) error {
+	pls.lock.Lock()
+	defer pls.lock.Unlock()
+
+	lists, err := pls.get(consumerID)
+	if err != nil {
+		return err
+	}
+	change(&lists)
+	return pls.storage.SetValue(providerListsBucket, consumerID, lists)
+}
+
+func (pls *ProviderListStorage) get(consumerID string) (ProviderLists, error) {
+	lists := ProviderLists{}
+	err := pls.storage.GetValue(providerListsBucket, consumerID, &lists)
+	if err != nil && err.Error() != errMsgBoltNotFound {
+		return ProviderLists{}, err
+	}
+
+	lists.ConsumerID = consumerID
+	if lists.Favourites == nil {
+		lists.Favourites = []string{}
+	}
+	if lists.Blocked == nil {
+		lists.Blocked = []string{}
+	}
+	if lists.Failures == nil {
+		lists.Failures = make(map[string]int)
+	}
+	return lists, nil
+}
+
+func withMember(list []string, item string, present bool) []string {
+	res := make([]string, 0, len(list)+1)
+	for _, i := range list {
+		if i != item {
+			res = append(res, i)
+		}
+	}
+	if present {
+		res = append(res, item)
+	}
+	return res
+}
@@ -0,0 +1,101 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package proposal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mysteriumnetwork/node/core/storage/boltdb"
+	"github.com/mysteriumnetwork/node/market"
+)
+
+func Test_ProviderListStorage(t *testing.T) {
+	bolt, err := boltdb.NewStorage(t.TempDir())
+	require.NoError(t, err)
+	defer bolt.Close()
+	storage := NewProviderListStorage(bolt)
+
+	lists, err := storage.Get("0xc")
+	assert.NoError(t, err)
+	assert.Equal(t, ProviderLists{ConsumerID: "0xc", Favourites: []string{}, Blocked: []string{}, Failures: map[string]int{}}, lists)
+
+	assert.NoError(t, storage.SetFavourite("0xc", "0x1", true))
+	assert.NoError(t, storage.SetFavourite("0xc", "0x1", true))
+	assert.NoError(t, storage.SetBlocked("0xc", "0x2", true))
+	lists, err = storage.Get("0xc")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"0x1"}, lists.Favourites)
+	assert.Equal(t, []string{"0x2"}, lists.Blocked)
+
+	other, err := storage.Get("0xd")
+	assert.NoError(t, err)
+	assert.Empty(t, other.Favourites)
+
+	assert.NoError(t, storage.SetFavourite("0xc", "0x1", false))
+	assert.NoError(t, storage.SetBlocked("0xc", "0x2", false))
+	lists, err = storage.Get("0xc")
+	assert.NoError(t, err)
+	assert.Empty(t, lists.Favourites)
+	assert.Empty(t, lists.Blocked)
+}
+
+func Test_ProviderListStorage_RecordFailure(t *testing.T) {
+	bolt, err := boltdb.NewStorage(t.TempDir())
+	require.NoError(t, err)
+	defer bolt.Close()
+	storage := NewProviderListStorage(bolt)
+
+	blocked, err := storage.RecordFailure("0xc", "0x1", 2)
+	assert.NoError(t, err)
+	assert.False(t, blocked)
+	assert.NoError(t, storage.ResetFailures("0xc", "0x1"))
+
+	blocked, err = storage.RecordFailure("0xc", "0x1", 2)
+	assert.NoError(t, err)
+	assert.False(t, blocked)
+	blocked, err = storage.RecordFailure("0xc", "0x1", 2)
+	assert.NoError(t, err)
+	assert.True(t, blocked)
+
+	lists, err := storage.Get("0xc")
+	assert.NoError(t, err)
+	assert.True(t, lists.IsBlocked("0x1"))
+	assert.Empty(t, lists.Failures)
+}
+
+func Test_ProviderLists_Apply(t *testing.T) {
+	proposals := []PricedServiceProposal{
+		{ServiceProposal: market.ServiceProposal{ProviderID: "0x1"}},
+		{ServiceProposal: market.ServiceProposal{ProviderID: "0x2"}},
+		{ServiceProposal: market.ServiceProposal{ProviderID: "0x3"}},
+	}
+	lists := ProviderLists{Favourites: []string{"0x3"}, Blocked: []string{"0x1", "0x2"}}
+
+	res := lists.Apply(proposals)
+	require.Len(t, res, 1)
+	assert.Equal(t, "0x3", res[0].ProviderID)
+	assert.True(t, res[0].Favourite)
+	assert.False(t, proposals[2].Favourite)
+
+	res = lists.Apply(proposals, "0x2")
+	require.Len(t, res, 2)
+	assert.Equal(t, "0x2", res[0].ProviderID)
+}
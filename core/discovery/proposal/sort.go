@@ -55,13 +55,25 @@ func Sort(proposals []PricedServiceProposal, sortType string) ([]PricedServicePr
 	}
 }
 
+// SortByFavourite moves proposals of favourite providers first, keeping the order otherwise.
+func SortByFavourite(proposals []PricedServiceProposal) []PricedServiceProposal {
+	tmp := make([]PricedServiceProposal, len(proposals))
+	copy(tmp, proposals)
+
+	sort.SliceStable(tmp, func(i, j int) bool {
+		return tmp[i].Favourite && !tmp[j].Favourite
+	})
+
+	return tmp
+}
+
 // SortByQuality sorts proposals list based on provider quality.
 func SortByQuality(proposals []PricedServiceProposal) []PricedServiceProposal {
 	tmp := make([]PricedServiceProposal, len(proposals))
 	copy(tmp, proposals)
 
 	sort.Slice(tmp, func(i, j int) bool {
-		return proposals[i].Quality.Quality > proposals[j].Quality.Quality
+		return tmp[i].Quality.Quality > tmp[j].Quality.Quality
 	})
 
 	return tmp
@@ -73,7 +85,7 @@ func SortByLatency(proposals []PricedServiceProposal) []PricedServiceProposal {
 	copy(tmp, proposals)
 
 	sort.Slice(tmp, func(i, j int) bool {
-		return proposals[i].Quality.Latency < proposals[j].Quality.Latency
+		return tmp[i].Quality.Latency < tmp[j].Quality.Latency
 	})
 
 	return tmp
@@ -85,7 +97,7 @@ func SortByUptime(proposals []PricedServiceProposal) []PricedServiceProposal {
 	copy(tmp, proposals)
 
 	sort.Slice(tmp, func(i, j int) bool {
-		return proposals[i].Quality.Uptime > proposals[j].Quality.Uptime
+		return tmp[i].Quality.Uptime > tmp[j].Quality.Uptime
 	})
 
 	return tmp
@@ -97,7 +109,7 @@ func SortByBandwidth(proposals []PricedServiceProposal) []PricedServiceProposal
 	copy(tmp, proposals)
 
 	sort.Slice(tmp, func(i, j int) bool {
-		return proposals[i].Quality.Bandwidth > proposals[j].Quality.Bandwidth
+		return tmp[i].Quality.Bandwidth > tmp[j].Quality.Bandwidth
 	})
 
 	return tmp
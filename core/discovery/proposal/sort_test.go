@@ -0,0 +1,66 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package proposal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mysteriumnetwork/node/market"
+)
+
+func sortTestProposals(qualities ...market.Quality) []PricedServiceProposal {
+	proposals := make([]PricedServiceProposal, len(qualities))
+	for i, q := range qualities {
+		proposals[i].ProviderID = string(rune('a' + i))
+		proposals[i].Quality = q
+	}
+	return proposals
+}
+
+func providerIDs(proposals []PricedServiceProposal) (ids []string) {
+	for _, p := range proposals {
+		ids = append(ids, p.ProviderID)
+	}
+	return ids
+}
+
+func Test_SortByQualityMetrics(t *testing.T) {
+	proposals := sortTestProposals(
+		market.Quality{Quality: 1, Latency: 300, Uptime: 20, Bandwidth: 5},
+		market.Quality{Quality: 3, Latency: 100, Uptime: 10, Bandwidth: 50},
+		market.Quality{Quality: 2, Latency: 200, Uptime: 30, Bandwidth: 1},
+		market.Quality{Quality: 0, Latency: 50, Uptime: 5, Bandwidth: 10},
+	)
+
+	for sortType, expected := range map[string][]string{
+		SortTypeQuality:   {"b", "c", "a", "d"},
+		SortTypeLatency:   {"d", "b", "c", "a"},
+		SortTypeUptime:    {"c", "a", "b", "d"},
+		SortTypeBandwidth: {"b", "d", "a", "c"},
+	} {
+		t.Run(sortType, func(t *testing.T) {
+			sorted, err := Sort(proposals, sortType)
+			assert.NoError(t, err)
+			assert.Equal(t, expected, providerIDs(sorted))
+			// Input is left untouched.
+			assert.Equal(t, []string{"a", "b", "c", "d"}, providerIDs(proposals))
+		})
+	}
+}
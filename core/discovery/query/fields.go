@@ -0,0 +1,64 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package query
+
+import (
+	"sort"
+
+	"github.com/mysteriumnetwork/node/core/discovery/reducer"
+)
+
+type fieldKind int
+
+const (
+	fieldString fieldKind = iota
+	fieldNumber
+)
+
+type field struct {
+	kind     fieldKind
+	selector reducer.FieldSelector
+}
+
+var fields = map[string]field{
+	"provider_id":   {kind: fieldString, selector: reducer.ProviderID},
+	"service_type":  {kind: fieldString, selector: reducer.ServiceType},
+	"continent":     {kind: fieldString, selector: reducer.LocationContinent},
+	"country":       {kind: fieldString, selector: reducer.LocationCountry},
+	"region":        {kind: fieldString, selector: reducer.LocationRegion},
+	"city":          {kind: fieldString, selector: reducer.LocationCity},
+	"isp":           {kind: fieldString, selector: reducer.LocationISP},
+	"ip_type":       {kind: fieldString, selector: reducer.LocationType},
+	"asn":           {kind: fieldNumber, selector: reducer.LocationASN},
+	"compatibility": {kind: fieldNumber, selector: reducer.Compatibility},
+	"quality":       {kind: fieldNumber, selector: reducer.Quality},
+	"bandwidth":     {kind: fieldNumber, selector: reducer.Bandwidth},
+	"latency":       {kind: fieldNumber, selector: reducer.Latency},
+	"uptime":        {kind: fieldNumber, selector: reducer.Uptime},
+	"packet_loss":   {kind: fieldNumber, selector: reducer.PacketLoss},
+}
+
+// Fields returns names of all fields which can be used in queries.
+func Fields() []string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
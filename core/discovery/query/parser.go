@@ -0,0 +1,232 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/mysteriumnetwork/node/core/discovery/reducer"
+	"github.com/mysteriumnetwork/node/market"
+)
+
+type condition = func(market.ServiceProposal) bool
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) expect(kind tokenKind, what string) (token, error) {
+	t := p.next()
+	if t.kind != kind {
+		return t, fmt.Errorf("expected %s, got %s at position %d", what, t, t.pos)
+	}
+	return t, nil
+}
+
+func (p *parser) parseOr() (condition, error) {
+	first, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	conditions := []reducer.OrCondition{first}
+	for p.peek().is("or") {
+		p.next()
+		c, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, c)
+	}
+	if len(conditions) == 1 {
+		return first, nil
+	}
+	return reducer.Or(conditions...), nil
+}
+
+func (p *parser) parseAnd() (condition, error) {
+	first, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	conditions := []reducer.AndCondition{first}
+	for p.peek().is("and") {
+		p.next()
+		c, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, c)
+	}
+	if len(conditions) == 1 {
+		return first, nil
+	}
+	return reducer.And(conditions...), nil
+}
+
+func (p *parser) parseUnary() (condition, error) {
+	switch t := p.peek(); {
+	case t.is("not"):
+		p.next()
+		c, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return reducer.Not(c), nil
+	case t.kind == tokenLParen:
+		p.next()
+		c, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(tokenRParen, "')'"); err != nil {
+			return nil, err
+		}
+		return c, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (condition, error) {
+	name, err := p.expect(tokenWord, "field name")
+	if err != nil {
+		return nil, err
+	}
+	f, ok := fields[strings.ToLower(name.value)]
+	if !ok {
+		return nil, fmt.Errorf("unknown field '%s' at position %d", name.value, name.pos)
+	}
+
+	negate := false
+	if p.peek().is("not") {
+		p.next()
+		negate = true
+		if !p.peek().is("in") {
+			t := p.peek()
+			return nil, fmt.Errorf("expected 'in', got %s at position %d", t, t.pos)
+		}
+	}
+	if p.peek().is("in") {
+		p.next()
+		c, err := p.parseIn(name.value, f)
+		if err != nil {
+			return nil, err
+		}
+		if negate {
+			return reducer.Not(c), nil
+		}
+		return c, nil
+	}
+
+	op, err := p.expect(tokenOperator, "operator")
+	if err != nil {
+		return nil, err
+	}
+	value, err := p.parseValue()
+	if err != nil {
+		return nil, err
+	}
+	return compare(name.value, f, op, value)
+}
+
+func (p *parser) parseIn(name string, f field) (condition, error) {
+	if _, err := p.expect(tokenLParen, "'('"); err != nil {
+		return nil, err
+	}
+
+	var conditions []reducer.OrCondition
+	for {
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		c, err := compare(name, f, token{kind: tokenOperator, value: "=", pos: value.pos}, value)
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, c)
+
+		t := p.next()
+		if t.kind == tokenRParen {
+			return reducer.Or(conditions...), nil
+		}
+		if t.kind != tokenComma {
+			return nil, fmt.Errorf("expected ',' or ')', got %s at position %d", t, t.pos)
+		}
+	}
+}
+
+func (p *parser) parseValue() (token, error) {
+	t := p.next()
+	if t.kind != tokenWord && t.kind != tokenString {
+		return t, fmt.Errorf("expected value, got %s at position %d", t, t.pos)
+	}
+	return t, nil
+}
+
+func compare(name string, f field, op, value token) (condition, error) {
+	if f.kind == fieldString {
+		switch op.value {
+		case "=":
+			return reducer.EqualFold(f.selector, value.value), nil
+		case "!=":
+			return reducer.Not(reducer.EqualFold(f.selector, value.value)), nil
+		case "~":
+			return reducer.ContainsFold(f.selector, value.value), nil
+		case "!~":
+			return reducer.Not(reducer.ContainsFold(f.selector, value.value)), nil
+		}
+		return nil, fmt.Errorf("operator '%s' is not supported for field '%s' at position %d", op.value, name, op.pos)
+	}
+
+	number, err := strconv.ParseFloat(value.value, 64)
+	if err != nil {
+		return nil, fmt.Errorf("field '%s' expects a number, got %s at position %d", name, value, value.pos)
+	}
+	switch op.value {
+	case "=":
+		return reducer.EqualNumber(f.selector, number), nil
+	case "!=":
+		return reducer.Not(reducer.EqualNumber(f.selector, number)), nil
+	case ">":
+		return reducer.GreaterThan(f.selector, number), nil
+	case ">=":
+		return reducer.GreaterOrEqual(f.selector, number), nil
+	case "<":
+		return reducer.LessThan(f.selector, number), nil
+	case "<=":
+		return reducer.LessOrEqual(f.selector, number), nil
+	}
+	return nil, fmt.Errorf("operator '%s' is not supported for field '%s' at position %d", op.value, name, op.pos)
+}
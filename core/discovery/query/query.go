@@ -0,0 +1,86 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+// Package query implements a small textual language for filtering proposals.
+//
+// A query is a boolean expression of field comparisons, e.g.:
+//
+//	country in (DE, NL) and city = "Berlin" and not isp ~ "hosting" and quality >= 2
+//
+// Comparisons are combined with "and", "or", "not" and parentheses, "and" binds tighter than "or".
+// String fields support "=", "!=", "~" (contains) and "!~" and are compared ignoring case.
+// Numeric fields support "=", "!=", ">", ">=", "<" and "<=".
+// Both support "in (...)" and "not in (...)".
+package query
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mysteriumnetwork/node/market"
+)
+
+// Query is a parsed proposal query.
+type Query struct {
+	source    string
+	condition condition
+}
+
+// Parse compiles the given query into proposal conditions.
+// An empty query results in a nil Query, which matches every proposal.
+func Parse(input string) (*Query, error) {
+	if strings.TrimSpace(input) == "" {
+		return nil, nil
+	}
+
+	tokens, err := tokenize(input)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	c, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokenEOF {
+		return nil, fmt.Errorf("unexpected %s at position %d", t, t.pos)
+	}
+
+	return &Query{source: input, condition: c}, nil
+}
+
+// Matches checks if the proposal matches the query.
+func (q *Query) Matches(proposal market.ServiceProposal) bool {
+	if q == nil {
+		return true
+	}
+	return q.condition(proposal)
+}
+
+// String returns the query source.
+func (q *Query) String() string {
+	if q == nil {
+		return ""
+	}
+	return q.source
+}
+
+// MarshalText serialises the query to its source.
+func (q *Query) MarshalText() ([]byte, error) {
+	return []byte(q.String()), nil
+}
@@ -0,0 +1,134 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package query
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mysteriumnetwork/node/market"
+)
+
+var (
+	proposalBerlin = market.ServiceProposal{
+		ProviderID:  "0x1",
+		ServiceType: "wireguard",
+		Location:    market.Location{Country: "DE", City: "Berlin", ISP: "Deutsche Telekom", ASN: 3320, IPType: "residential"},
+		Quality:     market.Quality{Quality: 2.5, Bandwidth: 40},
+	}
+	proposalAmsterdam = market.ServiceProposal{
+		ProviderID:  "0x2",
+		ServiceType: "wireguard",
+		Location:    market.Location{Country: "NL", City: "Amsterdam", ISP: "Cheap Hosting B.V.", ASN: 60781, IPType: "hosting"},
+		Quality:     market.Quality{Quality: 2.9, Bandwidth: 100},
+	}
+	proposalVilnius = market.ServiceProposal{
+		ProviderID:  "0x3",
+		ServiceType: "openvpn",
+		Location:    market.Location{Country: "LT", City: "Vilnius", ISP: "Telia", ASN: 8764, IPType: "residential"},
+		Quality:     market.Quality{Quality: 1.2, Bandwidth: 10},
+	}
+	proposals = []market.ServiceProposal{proposalBerlin, proposalAmsterdam, proposalVilnius}
+)
+
+func matching(q *Query) []string {
+	var ids []string
+	for _, p := range proposals {
+		if q.Matches(p) {
+			ids = append(ids, p.ProviderID)
+		}
+	}
+	return ids
+}
+
+func TestParse_Matches(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected []string
+	}{
+		{`country = DE`, []string{"0x1"}},
+		{`country = "de"`, []string{"0x1"}},
+		{`country != DE`, []string{"0x2", "0x3"}},
+		{`country in (DE, NL)`, []string{"0x1", "0x2"}},
+		{`country not in (DE, NL)`, []string{"0x3"}},
+		{`isp ~ "hosting"`, []string{"0x2"}},
+		{`isp !~ hosting`, []string{"0x1", "0x3"}},
+		{`quality >= 2`, []string{"0x1", "0x2"}},
+		{`quality < 2 or bandwidth > 50`, []string{"0x2", "0x3"}},
+		{`asn = 3320`, []string{"0x1"}},
+		{`asn in (3320, 8764)`, []string{"0x1", "0x3"}},
+		{`country in (DE,NL) and city = "Berlin" and not isp ~ "hosting" and quality >= 2`, []string{"0x1"}},
+		{`service_type = wireguard and (city = Berlin or city = Vilnius)`, []string{"0x1"}},
+		{`not (country = DE or country = NL)`, []string{"0x3"}},
+		{`COUNTRY = DE AND Quality >= 2`, []string{"0x1"}},
+		{`city = 'Amsterdam' or ip_type = residential and quality > 2`, []string{"0x1", "0x2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			q, err := Parse(tt.query)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, matching(q))
+		})
+	}
+}
+
+func TestParse_Empty(t *testing.T) {
+	q, err := Parse("  ")
+	require.NoError(t, err)
+	assert.Nil(t, q)
+	assert.Len(t, matching(q), len(proposals))
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		query string
+		err   string
+	}{
+		{`town = Berlin`, "unknown field 'town' at position 0"},
+		{`country >= DE`, "operator '>=' is not supported for field 'country' at position 8"},
+		{`quality ~ 2`, "operator '~' is not supported for field 'quality' at position 8"},
+		{`quality >= high`, "field 'quality' expects a number, got 'high' at position 11"},
+		{`country = "DE`, "unterminated string at position 10"},
+		{`country in (DE NL)`, "expected ',' or ')', got 'NL' at position 15"},
+		{`country = DE and`, "expected field name, got end of query at position 16"},
+		{`(country = DE`, "expected ')', got end of query at position 13"},
+		{`country = DE city = Berlin`, "unexpected 'city' at position 13"},
+		{`country not = DE`, "expected 'in', got '=' at position 12"},
+		{`country => DE`, "expected value, got '>' at position 9"},
+		{`country == DE`, "unknown operator '==' at position 8"},
+		{`country = DE; quality > 1`, "unexpected character ';' at position 12"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			_, err := Parse(tt.query)
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+}
+
+func TestQuery_MarshalText(t *testing.T) {
+	q, err := Parse(`country = DE`)
+	require.NoError(t, err)
+
+	b, err := json.Marshal(struct{ Query *Query }{q})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"Query":"country = DE"}`, string(b))
+}
@@ -0,0 +1,74 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package reducer
+
+import (
+	"github.com/mysteriumnetwork/node/market"
+)
+
+// EqualNumber returns a matcher for checking if proposal's numeric field is equal to given value
+func EqualNumber(field FieldSelector, valueExpected float64) func(market.ServiceProposal) bool {
+	return compareNumber(field, func(value float64) bool {
+		return value == valueExpected
+	})
+}
+
+// GreaterThan returns a matcher for checking if proposal's numeric field is greater than given value
+func GreaterThan(field FieldSelector, valueExpected float64) func(market.ServiceProposal) bool {
+	return compareNumber(field, func(value float64) bool {
+		return value > valueExpected
+	})
+}
+
+// GreaterOrEqual returns a matcher for checking if proposal's numeric field is greater than or equal to given value
+func GreaterOrEqual(field FieldSelector, valueExpected float64) func(market.ServiceProposal) bool {
+	return compareNumber(field, func(value float64) bool {
+		return value >= valueExpected
+	})
+}
+
+// LessThan returns a matcher for checking if proposal's numeric field is less than given value
+func LessThan(field FieldSelector, valueExpected float64) func(market.ServiceProposal) bool {
+	return compareNumber(field, func(value float64) bool {
+		return value < valueExpected
+	})
+}
+
+// LessOrEqual returns a matcher for checking if proposal's numeric field is less than or equal to given value
+func LessOrEqual(field FieldSelector, valueExpected float64) func(market.ServiceProposal) bool {
+	return compareNumber(field, func(value float64) bool {
+		return value <= valueExpected
+	})
+}
+
+// compareNumber never matches fields which are not numeric.
+func compareNumber(field FieldSelector, compare func(value float64) bool) func(market.ServiceProposal) bool {
+	return Field(field, func(value interface{}) bool {
+		switch valueTyped := value.(type) {
+		case int:
+			return compare(float64(valueTyped))
+		case int64:
+			return compare(float64(valueTyped))
+		case float32:
+			return compare(float64(valueTyped))
+		case float64:
+			return compare(valueTyped)
+		}
+		return false
+	})
+}
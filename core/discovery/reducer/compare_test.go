@@ -0,0 +1,70 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package reducer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_EqualNumber(t *testing.T) {
+	match := EqualNumber(LocationASN, 1000)
+
+	assert.False(t, match(proposalEmpty))
+	assert.True(t, match(proposalProvider1Streaming))
+	assert.False(t, match(proposalProvider2Streaming))
+}
+
+func Test_GreaterThan(t *testing.T) {
+	match := GreaterThan(LocationASN, 124)
+
+	assert.False(t, match(proposalEmpty))
+	assert.True(t, match(proposalProvider1Streaming))
+	assert.False(t, match(proposalProvider2Streaming))
+}
+
+func Test_GreaterOrEqual(t *testing.T) {
+	match := GreaterOrEqual(LocationASN, 124)
+
+	assert.False(t, match(proposalEmpty))
+	assert.True(t, match(proposalProvider1Streaming))
+	assert.True(t, match(proposalProvider2Streaming))
+}
+
+func Test_LessThan(t *testing.T) {
+	match := LessThan(LocationASN, 1000)
+
+	assert.True(t, match(proposalEmpty))
+	assert.False(t, match(proposalProvider1Streaming))
+	assert.True(t, match(proposalProvider2Streaming))
+}
+
+func Test_LessOrEqual(t *testing.T) {
+	match := LessOrEqual(LocationASN, 1000)
+
+	assert.True(t, match(proposalEmpty))
+	assert.True(t, match(proposalProvider1Streaming))
+	assert.True(t, match(proposalProvider2Streaming))
+}
+
+func Test_CompareNumber_SkipsNonNumericFields(t *testing.T) {
+	match := GreaterOrEqual(fieldProviderID, 0)
+
+	assert.False(t, match(proposalProvider1Streaming))
+}
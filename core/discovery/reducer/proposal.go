@@ -46,6 +46,61 @@ func LocationType(proposal market.ServiceProposal) interface{} {
 	return proposal.Location.IPType
 }
 
+// LocationContinent selects location continent from proposal
+func LocationContinent(proposal market.ServiceProposal) interface{} {
+	return proposal.Location.Continent
+}
+
+// LocationRegion selects location region from proposal
+func LocationRegion(proposal market.ServiceProposal) interface{} {
+	return proposal.Location.Region
+}
+
+// LocationCity selects location city from proposal
+func LocationCity(proposal market.ServiceProposal) interface{} {
+	return proposal.Location.City
+}
+
+// LocationASN selects location ASN from proposal
+func LocationASN(proposal market.ServiceProposal) interface{} {
+	return proposal.Location.ASN
+}
+
+// LocationISP selects location ISP from proposal
+func LocationISP(proposal market.ServiceProposal) interface{} {
+	return proposal.Location.ISP
+}
+
+// Compatibility selects compatibility level from proposal
+func Compatibility(proposal market.ServiceProposal) interface{} {
+	return proposal.Compatibility
+}
+
+// Quality selects service quality from proposal
+func Quality(proposal market.ServiceProposal) interface{} {
+	return proposal.Quality.Quality
+}
+
+// Bandwidth selects service bandwidth from proposal
+func Bandwidth(proposal market.ServiceProposal) interface{} {
+	return proposal.Quality.Bandwidth
+}
+
+// Latency selects service latency from proposal
+func Latency(proposal market.ServiceProposal) interface{} {
+	return proposal.Quality.Latency
+}
+
+// Uptime selects service uptime from proposal
+func Uptime(proposal market.ServiceProposal) interface{} {
+	return proposal.Quality.Uptime
+}
+
+// PacketLoss selects service packet loss from proposal
+func PacketLoss(proposal market.ServiceProposal) interface{} {
+	return proposal.Quality.PacketLoss
+}
+
 // AccessPolicy returns a matcher for checking if proposal allows given access policy
 func AccessPolicy(id, source string) func(market.ServiceProposal) bool {
 	return func(proposal market.ServiceProposal) bool {
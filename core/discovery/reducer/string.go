@@ -0,0 +1,41 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package reducer
+
+import (
+	"strings"
+
+	"github.com/mysteriumnetwork/node/market"
+)
+
+// EqualFold returns a matcher for checking if proposal's string field is equal to given value ignoring case
+func EqualFold(field FieldSelector, valueExpected string) func(market.ServiceProposal) bool {
+	return Field(field, func(value interface{}) bool {
+		valueTyped, ok := value.(string)
+		return ok && strings.EqualFold(valueTyped, valueExpected)
+	})
+}
+
+// ContainsFold returns a matcher for checking if proposal's string field contains given substring ignoring case
+func ContainsFold(field FieldSelector, substring string) func(market.ServiceProposal) bool {
+	substring = strings.ToLower(substring)
+	return Field(field, func(value interface{}) bool {
+		valueTyped, ok := value.(string)
+		return ok && strings.Contains(strings.ToLower(valueTyped), substring)
+	})
+}
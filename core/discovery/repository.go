@@ -18,23 +18,32 @@
 package discovery
 
 import (
+	"errors"
+	"fmt"
 	"sync"
 
 	"github.com/rs/zerolog/log"
 
 	"github.com/mysteriumnetwork/node/core/discovery/proposal"
+	"github.com/mysteriumnetwork/node/eventbus"
 	"github.com/mysteriumnetwork/node/market"
 	"github.com/mysteriumnetwork/node/utils"
 )
 
 // repository provides proposals from multiple other repositories.
+// Proposals with an invalid provider signature are never returned, unsigned ones only if signatures are not required.
 type repository struct {
-	delegates []proposal.Repository
+	delegates        []proposal.Repository
+	publisher        eventbus.Publisher
+	requireSignature bool
 }
 
 // NewRepository constructs a new composite repository.
-func NewRepository() *repository {
-	return &repository{}
+func NewRepository(publisher eventbus.Publisher, requireSignature bool) *repository {
+	return &repository{
+		publisher:        publisher,
+		requireSignature: requireSignature,
+	}
 }
 
 // Add adds a delegate repositories from which proposals can be acquired.
@@ -49,6 +58,10 @@ func (c *repository) Proposal(id market.ProposalID) (*market.ServiceProposal, er
 	for _, delegate := range c.delegates {
 		serviceProposal, err := delegate.Proposal(id)
 		if err == nil {
+			err = c.verify(*serviceProposal)
+		}
+		if err == nil {
+			serviceProposal.DiscardReceivedJSON()
 			return serviceProposal, nil
 		}
 		allErrors.Add(err)
@@ -79,6 +92,10 @@ func (c *repository) Proposals(filter *proposal.Filter) ([]market.ServiceProposa
 	for i, repoProposals := range proposals {
 		log.Trace().Msgf("Retrieved %d proposals from repository %d", len(repoProposals), i)
 		for _, p := range repoProposals {
+			if c.verify(p) != nil {
+				continue
+			}
+			p.DiscardReceivedJSON()
 			uniqueProposals[p.UniqueID()] = p
 		}
 	}
@@ -111,3 +128,18 @@ func (c *repository) Countries(filter *proposal.Filter) (map[string]int, error)
 
 	return countries, nil
 }
+
+func (c *repository) verify(p market.ServiceProposal) error {
+	err := VerifyProposal(p)
+	if err == nil || errors.Is(err, ErrProposalNotSigned) && !c.requireSignature {
+		return nil
+	}
+
+	log.Debug().Err(err).Msgf("Rejecting proposal %s of provider %s", p.ServiceType, p.ProviderID)
+	c.publisher.Publish(AppTopicProposalRejected, AppEventProposalRejected{
+		ProviderID:  p.ProviderID,
+		ServiceType: p.ServiceType,
+		Reason:      err.Error(),
+	})
+	return fmt.Errorf("proposal %s of provider %s rejected: %w", p.ServiceType, p.ProviderID, err)
+}
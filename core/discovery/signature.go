@@ -0,0 +1,67 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package discovery
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/mysteriumnetwork/node/identity"
+	"github.com/mysteriumnetwork/node/market"
+)
+
+var (
+	// ErrProposalNotSigned is returned for proposals which carry no provider signature.
+	ErrProposalNotSigned = errors.New("proposal is not signed")
+	// ErrProposalSignatureInvalid is returned for proposals which are not signed by their provider or were modified after signing.
+	ErrProposalSignatureInvalid = errors.New("proposal signature is invalid")
+)
+
+// SignProposal signs the canonical proposal body with the provider identity.
+func SignProposal(proposal market.ServiceProposal, signer identity.Signer) (market.ServiceProposal, error) {
+	body, err := proposal.CanonicalBody()
+	if err != nil {
+		return proposal, fmt.Errorf("could not encode proposal: %w", err)
+	}
+
+	signature, err := signer.Sign(body)
+	if err != nil {
+		return proposal, fmt.Errorf("could not sign proposal: %w", err)
+	}
+
+	proposal.Signature = signature.Base64()
+	return proposal, nil
+}
+
+// VerifyProposal checks that the proposal is signed by the identity in its ProviderID.
+func VerifyProposal(proposal market.ServiceProposal) error {
+	if proposal.Signature == "" {
+		return ErrProposalNotSigned
+	}
+
+	body, err := proposal.CanonicalBody()
+	if err != nil {
+		return fmt.Errorf("could not encode proposal: %w", err)
+	}
+
+	verifier := identity.NewVerifierIdentity(identity.FromAddress(proposal.ProviderID))
+	if ok, _ := verifier.Verify(body, identity.SignatureBase64(proposal.Signature)); !ok {
+		return ErrProposalSignatureInvalid
+	}
+	return nil
+}
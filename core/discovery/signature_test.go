@@ -0,0 +1,179 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package discovery
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mysteriumnetwork/node/core/discovery/proposal"
+	"github.com/mysteriumnetwork/node/eventbus"
+	"github.com/mysteriumnetwork/node/identity"
+	"github.com/mysteriumnetwork/node/market"
+	"github.com/mysteriumnetwork/node/mocks"
+)
+
+const signerAddress = "0x53a835143c0ef3bbcbfa796d7eb738ca7dd28f68"
+
+func newProposalSigner(t *testing.T) identity.Signer {
+	ks := identity.NewMockKeystoreWith(identity.MockKeys)
+	require.NoError(t, ks.Unlock(accounts.Account{Address: common.HexToAddress(signerAddress)}, ""))
+	return identity.NewSigner(ks, identity.FromAddress(signerAddress))
+}
+
+func signedProposal(t *testing.T) market.ServiceProposal {
+	p := market.NewProposal(signerAddress, "wireguard", market.NewProposalOpts{
+		Location: &market.Location{Country: "DE", City: "Berlin"},
+	})
+	signed, err := SignProposal(p, newProposalSigner(t))
+	require.NoError(t, err)
+	return signed
+}
+
+func TestVerifyProposal(t *testing.T) {
+	signed := signedProposal(t)
+	assert.NotEmpty(t, signed.Signature)
+	assert.NoError(t, VerifyProposal(signed))
+
+	// Fields filled in by discovery are not covered.
+	withQuality := signed
+	withQuality.ID = 42
+	withQuality.Quality = market.Quality{Quality: 2}
+	assert.NoError(t, VerifyProposal(withQuality))
+
+	tampered := signed
+	tampered.Location.Country = "US"
+	assert.ErrorIs(t, VerifyProposal(tampered), ErrProposalSignatureInvalid)
+
+	impersonated := signed
+	impersonated.ProviderID = "0x0000000000000000000000000000000000000001"
+	assert.ErrorIs(t, VerifyProposal(impersonated), ErrProposalSignatureInvalid)
+
+	unsigned := signed
+	unsigned.Signature = ""
+	assert.ErrorIs(t, VerifyProposal(unsigned), ErrProposalNotSigned)
+}
+
+func TestVerifyProposal_KeepsFieldsUnknownToNode(t *testing.T) {
+	signed := signedProposal(t)
+	data, err := json.Marshal(signed)
+	require.NoError(t, err)
+
+	// Simulate a newer node that signs a field this node does not know about.
+	var newer map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &newer))
+	newer["bandwidth_class"] = "premium"
+	for _, field := range []string{"id", "quality", "signature"} {
+		delete(newer, field)
+	}
+	body, err := json.Marshal(newer)
+	require.NoError(t, err)
+	signature, err := newProposalSigner(t).Sign(body)
+	require.NoError(t, err)
+	newer["signature"] = signature.Base64()
+	data, err = json.Marshal(newer)
+	require.NoError(t, err)
+
+	var received market.ServiceProposal
+	require.NoError(t, json.Unmarshal(data, &received))
+	assert.NoError(t, VerifyProposal(received))
+
+	// Re-encoding before the received JSON is discarded keeps the unknown field.
+	data, err = json.Marshal(received)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"bandwidth_class":"premium"`)
+	var restored market.ServiceProposal
+	require.NoError(t, json.Unmarshal(data, &restored))
+	assert.NoError(t, VerifyProposal(restored))
+
+	newer["bandwidth_class"] = "basic"
+	data, err = json.Marshal(newer)
+	require.NoError(t, err)
+	var tampered market.ServiceProposal
+	require.NoError(t, json.Unmarshal(data, &tampered))
+	assert.ErrorIs(t, VerifyProposal(tampered), ErrProposalSignatureInvalid)
+}
+
+func TestRepository_RejectsTamperedProposals(t *testing.T) {
+	signed := signedProposal(t)
+	tampered := signed
+	tampered.ServiceType = "openvpn"
+	unsigned := market.NewProposal("0x0000000000000000000000000000000000000002", "wireguard", market.NewProposalOpts{})
+
+	publisher := mocks.NewEventBus()
+	repo := NewRepository(publisher, false)
+	repo.Add(&mockRepository{proposalsToReturn: []market.ServiceProposal{signed, tampered, unsigned}})
+
+	proposals, err := repo.Proposals(&proposal.Filter{})
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []market.ServiceProposal{signed, unsigned}, proposals)
+
+	event := publisher.Pop()
+	assert.Equal(t, AppEventProposalRejected{
+		ProviderID:  signerAddress,
+		ServiceType: "openvpn",
+		Reason:      ErrProposalSignatureInvalid.Error(),
+	}, event)
+
+	repo = NewRepository(eventbus.New(), true)
+	repo.Add(&mockRepository{
+		proposalsToReturn: []market.ServiceProposal{signed, tampered, unsigned},
+		proposalToReturn:  &unsigned,
+	})
+
+	proposals, err = repo.Proposals(&proposal.Filter{})
+	assert.NoError(t, err)
+	assert.Equal(t, []market.ServiceProposal{signed}, proposals)
+
+	_, err = repo.Proposal(unsigned.UniqueID())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), ErrProposalNotSigned.Error())
+}
+
+func TestRepository_DiscardsReceivedJSONOfVerifiedProposals(t *testing.T) {
+	data, err := json.Marshal(signedProposal(t))
+	require.NoError(t, err)
+	var received market.ServiceProposal
+	require.NoError(t, json.Unmarshal(data, &received))
+
+	repo := NewRepository(eventbus.New(), true)
+	repo.Add(&mockRepository{
+		proposalsToReturn: []market.ServiceProposal{received},
+		proposalToReturn:  &received,
+	})
+
+	proposals, err := repo.Proposals(&proposal.Filter{})
+	require.NoError(t, err)
+	require.Len(t, proposals, 1)
+	single, err := repo.Proposal(received.UniqueID())
+	require.NoError(t, err)
+
+	// Changes made after verification are not hidden behind the received copy.
+	for _, p := range []market.ServiceProposal{proposals[0], *single} {
+		p.Location.Country = "US"
+		data, err := json.Marshal(p)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), `"country":"US"`)
+		assert.ErrorIs(t, VerifyProposal(p), ErrProposalSignatureInvalid)
+	}
+}
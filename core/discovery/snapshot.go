@@ -0,0 +1,184 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package discovery
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/mysteriumnetwork/node/core/discovery/proposal"
+	"github.com/mysteriumnetwork/node/market"
+)
+
+const (
+	snapshotBucket = "proposal-snapshot"
+	snapshotKey    = "last"
+	// snapshotRetryInterval is used instead of the refresh interval while discovery is unreachable.
+	snapshotRetryInterval = time.Minute
+)
+
+type snapshotStorage interface {
+	GetValue(bucket string, key interface{}, to interface{}) error
+	SetValue(bucket string, key interface{}, to interface{}) error
+}
+
+// snapshotEntry is stored separately from proposal.PricedServiceProposal,
+// as the embedded proposal JSON unmarshaler would drop the price.
+type snapshotEntry struct {
+	Proposal market.ServiceProposal `json:"proposal"`
+	Price    market.Price           `json:"price"`
+}
+
+type snapshotRecord struct {
+	Entries   []snapshotEntry `json:"entries"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+// ProposalSnapshot keeps the last good set of priced proposals in persistent storage,
+// so consumers can find providers while discovery is unreachable.
+type ProposalSnapshot struct {
+	storage snapshotStorage
+	fetch   func() ([]proposal.PricedServiceProposal, error)
+	every   time.Duration
+
+	lock   sync.RWMutex
+	loaded bool
+	record snapshotRecord
+
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewProposalSnapshot creates a new proposal snapshot refreshed with the given interval.
+func NewProposalSnapshot(storage snapshotStorage, every time.Duration) *ProposalSnapshot {
+	return &ProposalSnapshot{
+		storage: storage,
+		every:   every,
+		stop:    make(chan struct{}),
+	}
+}
+
+// Start starts refreshing the snapshot in the background.
+func (ps *ProposalSnapshot) Start() error {
+	if ps.fetch == nil {
+		return fmt.Errorf("proposal snapshot has no source")
+	}
+
+	go ps.refreshLoop()
+	return nil
+}
+
+// Stop stops refreshing the snapshot.
+func (ps *ProposalSnapshot) Stop() {
+	ps.stopOnce.Do(func() {
+		close(ps.stop)
+	})
+}
+
+func (ps *ProposalSnapshot) refreshLoop() {
+	for {
+		next := ps.every
+		if err := ps.Refresh(); err != nil {
+			log.Warn().Err(err).Msgf("Could not refresh proposal snapshot, retrying in %s", snapshotRetryInterval)
+			next = snapshotRetryInterval
+		}
+
+		select {
+		case <-ps.stop:
+			return
+		case <-time.After(next):
+		}
+	}
+}
+
+// Refresh fetches the current proposals and stores them as the last good set.
+func (ps *ProposalSnapshot) Refresh() error {
+	proposals, err := ps.fetch()
+	if err != nil {
+		return err
+	}
+	if len(proposals) == 0 {
+		return fmt.Errorf("no proposals to snapshot")
+	}
+
+	return ps.Save(proposals, time.Now())
+}
+
+// Save stores the given proposals as the last good set.
+func (ps *ProposalSnapshot) Save(proposals []proposal.PricedServiceProposal, updatedAt time.Time) error {
+	record := snapshotRecord{
+		Entries:   make([]snapshotEntry, len(proposals)),
+		UpdatedAt: updatedAt.UTC(),
+	}
+	for i, p := range proposals {
+		record.Entries[i] = snapshotEntry{Proposal: p.ServiceProposal, Price: p.Price}
+	}
+
+	ps.lock.Lock()
+	defer ps.lock.Unlock()
+
+	if err := ps.storage.SetValue(snapshotBucket, snapshotKey, record); err != nil {
+		return fmt.Errorf("could not store proposal snapshot: %w", err)
+	}
+	ps.record = record
+	ps.loaded = true
+	log.Debug().Msgf("Stored proposal snapshot with %d proposals", len(proposals))
+	return nil
+}
+
+// Proposals returns the last good set of proposals, each marked with the snapshot time.
+func (ps *ProposalSnapshot) Proposals() ([]proposal.PricedServiceProposal, error) {
+	record, err := ps.load()
+	if err != nil {
+		return nil, err
+	}
+
+	updatedAt := record.UpdatedAt
+	proposals := make([]proposal.PricedServiceProposal, len(record.Entries))
+	for i, entry := range record.Entries {
+		proposals[i] = proposal.PricedServiceProposal{
+			ServiceProposal: entry.Proposal,
+			Price:           entry.Price,
+			SnapshotAt:      &updatedAt,
+		}
+	}
+	return proposals, nil
+}
+
+func (ps *ProposalSnapshot) load() (snapshotRecord, error) {
+	ps.lock.RLock()
+	if ps.loaded {
+		defer ps.lock.RUnlock()
+		return ps.record, nil
+	}
+	ps.lock.RUnlock()
+
+	ps.lock.Lock()
+	defer ps.lock.Unlock()
+
+	var record snapshotRecord
+	if err := ps.storage.GetValue(snapshotBucket, snapshotKey, &record); err != nil {
+		return snapshotRecord{}, fmt.Errorf("could not load proposal snapshot: %w", err)
+	}
+	ps.record = record
+	ps.loaded = true
+	return record, nil
+}
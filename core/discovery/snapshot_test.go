@@ -0,0 +1,98 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package discovery
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mysteriumnetwork/node/core/discovery/proposal"
+	"github.com/mysteriumnetwork/node/core/storage/boltdb"
+	"github.com/mysteriumnetwork/node/market"
+)
+
+func TestProposalSnapshot_FallbackWhenDiscoveryFails(t *testing.T) {
+	storage, err := boltdb.NewStorage(t.TempDir())
+	require.NoError(t, err)
+	defer storage.Close()
+
+	mockPrice := market.Price{
+		PricePerHour: big.NewInt(1),
+		PricePerGiB:  big.NewInt(2),
+	}
+	mr := &mockRepository{
+		proposalsToReturn: []market.ServiceProposal{mockProposal},
+	}
+	snapshot := NewProposalSnapshot(storage, time.Hour)
+	repo := NewPricedServiceProposalRepository(mr, &mockPriceInfoProvider{priceToReturn: mockPrice}, presetRepository, nil, snapshot)
+
+	proposals, err := repo.Proposals(&proposal.Filter{LocationCountry: "yes"})
+	require.NoError(t, err)
+	require.Len(t, proposals, 1)
+	assert.Nil(t, proposals[0].SnapshotAt)
+
+	require.NoError(t, snapshot.Refresh())
+
+	// Discovery goes down and the node restarts.
+	mr.errToReturn = errors.New("discovery is unreachable")
+	snapshot = NewProposalSnapshot(storage, time.Hour)
+	repo = NewPricedServiceProposalRepository(mr, &mockPriceInfoProvider{priceToReturn: mockPrice}, presetRepository, nil, snapshot)
+
+	proposals, err = repo.Proposals(&proposal.Filter{LocationCountry: "yes"})
+	require.NoError(t, err)
+	require.Len(t, proposals, 1)
+	assert.Equal(t, mockProposal.ProviderID, proposals[0].ProviderID)
+	assert.Equal(t, mockProposal.Location, proposals[0].Location)
+	assert.Equal(t, 0, mockPrice.PricePerGiB.Cmp(proposals[0].Price.PricePerGiB))
+	require.NotNil(t, proposals[0].SnapshotAt)
+	assert.WithinDuration(t, time.Now(), *proposals[0].SnapshotAt, time.Minute)
+
+	proposals, err = repo.Proposals(&proposal.Filter{LocationCountry: "no"})
+	require.NoError(t, err)
+	assert.Len(t, proposals, 0)
+
+	p, err := repo.Proposal(mockProposal.UniqueID())
+	require.NoError(t, err)
+	assert.Equal(t, mockProposal.ProviderID, p.ProviderID)
+	assert.NotNil(t, p.SnapshotAt)
+
+	_, err = repo.Proposal(market.ProposalID{ProviderID: "0x1", ServiceType: "wireguard"})
+	assert.EqualError(t, err, "discovery is unreachable")
+}
+
+func TestProposalSnapshot_BubblesErrorsWithoutSnapshot(t *testing.T) {
+	storage, err := boltdb.NewStorage(t.TempDir())
+	require.NoError(t, err)
+	defer storage.Close()
+
+	mr := &mockRepository{errToReturn: errors.New("discovery is unreachable")}
+	snapshot := NewProposalSnapshot(storage, time.Hour)
+	repo := NewPricedServiceProposalRepository(mr, &mockPriceInfoProvider{}, presetRepository, nil, snapshot)
+
+	_, err = repo.Proposals(nil)
+	assert.EqualError(t, err, "discovery is unreachable")
+	assert.EqualError(t, snapshot.Refresh(), "discovery is unreachable")
+
+	mr.errToReturn = nil
+	assert.EqualError(t, snapshot.Refresh(), "no proposals to snapshot")
+}
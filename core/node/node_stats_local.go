@@ -0,0 +1,340 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package node
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mysteriumnetwork/payments/crypto"
+
+	"github.com/mysteriumnetwork/node/consumer/session"
+	"github.com/mysteriumnetwork/node/identity"
+	"github.com/mysteriumnetwork/node/session/connectivity"
+)
+
+const (
+	// activityPeriod is the period provider activity and quality are evaluated for.
+	activityPeriod = 30 * 24 * time.Hour
+	// qualityMax is the top of the Quality Oracle quality scale.
+	qualityMax = 3.0
+)
+
+type sessionHistory interface {
+	List(filter *session.Filter) ([]session.History, error)
+}
+
+type connectivityStatuses interface {
+	GetAllStatusEntries() []connectivity.StatusEntry
+}
+
+type uptimeHistory interface {
+	OnlineHours(from time.Time) (int, error)
+}
+
+// EarningsHistory should return amounts promised to the provider since the given time, by the hour they were promised.
+type EarningsHistory func(id identity.Identity, from time.Time) (map[time.Time]*big.Int, error)
+
+// LocalStats computes provider statistics from sessions, payments and uptime recorded by this node.
+type LocalStats struct {
+	sessions sessionHistory
+	earnings EarningsHistory
+	statuses connectivityStatuses
+	uptime   uptimeHistory
+	bucket   time.Duration
+	now      func() time.Time
+}
+
+// NewLocalStats returns provider statistics computed from the given node records.
+// Series are split into buckets of the given size, zero picks it by the requested range.
+func NewLocalStats(
+	sessions sessionHistory,
+	earnings EarningsHistory,
+	statuses connectivityStatuses,
+	uptime uptimeHistory,
+	bucket time.Duration,
+) *LocalStats {
+	return &LocalStats{
+		sessions: sessions,
+		earnings: earnings,
+		statuses: statuses,
+		uptime:   uptime,
+		bucket:   bucket,
+		now:      time.Now,
+	}
+}
+
+// ProviderSessionsList returns sessions provided during the given range.
+func (ls *LocalStats) ProviderSessionsList(id identity.Identity, rangeTime string) ([]SessionItem, error) {
+	_, sessions, err := ls.provided(id, rangeTime)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]SessionItem, 0, len(sessions))
+	for _, s := range sessions {
+		items = append(items, SessionItem{
+			ID:              string(s.SessionID),
+			ConsumerCountry: s.ConsumerCountry,
+			ServiceType:     s.ServiceType,
+			Duration:        int64(s.GetDuration().Seconds()),
+			StartedAt:       s.Started.Unix(),
+			Earning:         crypto.BigMystToDecimal(tokens(s)).String(),
+			Transferred:     int64(s.DataSent + s.DataReceived),
+		})
+	}
+	return items, nil
+}
+
+// ProviderTransferredData returns total traffic served during the given range.
+func (ls *LocalStats) ProviderTransferredData(id identity.Identity, rangeTime string) (TransferredData, error) {
+	_, sessions, err := ls.provided(id, rangeTime)
+	if err != nil {
+		return TransferredData{}, err
+	}
+
+	var data TransferredData
+	for _, s := range sessions {
+		data.Bytes += int(s.DataSent + s.DataReceived)
+	}
+	return data, nil
+}
+
+// ProviderSessionsCount returns number of sessions provided during the given range.
+func (ls *LocalStats) ProviderSessionsCount(id identity.Identity, rangeTime string) (SessionsCount, error) {
+	_, sessions, err := ls.provided(id, rangeTime)
+	if err != nil {
+		return SessionsCount{}, err
+	}
+
+	return SessionsCount{Count: len(sessions)}, nil
+}
+
+// ProviderConsumersCount returns number of unique consumers served during the given range.
+func (ls *LocalStats) ProviderConsumersCount(id identity.Identity, rangeTime string) (ConsumersCount, error) {
+	_, sessions, err := ls.provided(id, rangeTime)
+	if err != nil {
+		return ConsumersCount{}, err
+	}
+
+	consumers := make(map[identity.Identity]struct{})
+	for _, s := range sessions {
+		consumers[s.ConsumerID] = struct{}{}
+	}
+	return ConsumersCount{Count: len(consumers)}, nil
+}
+
+// ProviderEarningsSeries returns amounts promised to the provider in each bucket of the given range.
+// Payments are recorded hourly, so buckets shorter than an hour get whole hours.
+func (ls *LocalStats) ProviderEarningsSeries(id identity.Identity, rangeTime string) (EarningsSeries, error) {
+	data, err := ls.series(rangeTime, func(from time.Time) ([]seriesPoint, error) {
+		earnings, err := ls.earnings(id, from)
+		if err != nil {
+			return nil, fmt.Errorf("could not list earnings: %w", err)
+		}
+
+		points := make([]seriesPoint, 0, len(earnings))
+		for hour, amount := range earnings {
+			points = append(points, seriesPoint{at: hour, value: amount})
+		}
+		return points, nil
+	}, func(sum *big.Int) string {
+		return crypto.BigMystToDecimal(sum).String()
+	})
+	return EarningsSeries{Data: data}, err
+}
+
+// ProviderSessionsSeries returns number of sessions started in each bucket of the given range.
+func (ls *LocalStats) ProviderSessionsSeries(id identity.Identity, rangeTime string) (SessionsSeries, error) {
+	data, err := ls.series(rangeTime, ls.sessionPoints(id, func(session.History) *big.Int {
+		return big.NewInt(1)
+	}), (*big.Int).String)
+	return SessionsSeries{Data: data}, err
+}
+
+// ProviderTransferredDataSeries returns bytes transferred by sessions started in each bucket of the given range.
+func (ls *LocalStats) ProviderTransferredDataSeries(id identity.Identity, rangeTime string) (TransferredDataSeries, error) {
+	data, err := ls.series(rangeTime, ls.sessionPoints(id, func(s session.History) *big.Int {
+		return new(big.Int).SetUint64(s.DataSent + s.DataReceived)
+	}), (*big.Int).String)
+	return TransferredDataSeries{Data: data}, err
+}
+
+// ProviderActivityStats returns shares of the last 30 days the node was running and serving sessions.
+func (ls *LocalStats) ProviderActivityStats(id identity.Identity) (ActivityStats, error) {
+	from := ls.now().Add(-activityPeriod)
+	online, err := ls.uptime.OnlineHours(from)
+	if err != nil {
+		return ActivityStats{}, fmt.Errorf("could not get node uptime: %w", err)
+	}
+
+	sessions, err := ls.sessions.List(session.NewFilter().
+		SetDirection(session.DirectionProvided).
+		SetProviderID(id).
+		SetStartedFrom(from),
+	)
+	if err != nil {
+		return ActivityStats{}, fmt.Errorf("could not list provided sessions: %w", err)
+	}
+
+	now := ls.now()
+	active := make(map[int64]struct{})
+	for _, s := range sessions {
+		ended := s.Updated
+		if ended.IsZero() || ended.After(now) {
+			ended = now
+		}
+		for hour := s.Started.Truncate(time.Hour); !hour.After(ended); hour = hour.Add(time.Hour) {
+			active[hour.Unix()] = struct{}{}
+		}
+	}
+
+	hours := float64(activityPeriod / time.Hour)
+	return ActivityStats{
+		Online: min(float64(online)/hours, 1) * 100,
+		Active: min(float64(len(active))/hours, 1) * 100,
+	}, nil
+}
+
+// ProviderQuality returns quality on the Quality Oracle scale from the share of successful
+// connectivity reports consumers sent to this node. It is zero until any report is received.
+func (ls *LocalStats) ProviderQuality(id identity.Identity) (QualityInfo, error) {
+	var ok, total int
+	for _, entry := range ls.statuses.GetAllStatusEntries() {
+		total++
+		if entry.StatusCode == connectivity.StatusConnectionOk {
+			ok++
+		}
+	}
+	if total == 0 {
+		return QualityInfo{}, nil
+	}
+	return QualityInfo{Quality: qualityMax * float64(ok) / float64(total)}, nil
+}
+
+func (ls *LocalStats) provided(id identity.Identity, rangeTime string) (time.Time, []session.History, error) {
+	period, err := parseRange(rangeTime)
+	if err != nil {
+		return time.Time{}, nil, err
+	}
+
+	from := ls.now().Add(-period)
+	sessions, err := ls.sessions.List(session.NewFilter().
+		SetDirection(session.DirectionProvided).
+		SetProviderID(id).
+		SetStartedFrom(from),
+	)
+	if err != nil {
+		return time.Time{}, nil, fmt.Errorf("could not list provided sessions: %w", err)
+	}
+	return from, sessions, nil
+}
+
+type seriesPoint struct {
+	at    time.Time
+	value *big.Int
+}
+
+func (ls *LocalStats) sessionPoints(id identity.Identity, value func(session.History) *big.Int) func(time.Time) ([]seriesPoint, error) {
+	return func(from time.Time) ([]seriesPoint, error) {
+		sessions, err := ls.sessions.List(session.NewFilter().
+			SetDirection(session.DirectionProvided).
+			SetProviderID(id).
+			SetStartedFrom(from),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("could not list provided sessions: %w", err)
+		}
+
+		points := make([]seriesPoint, 0, len(sessions))
+		for _, s := range sessions {
+			points = append(points, seriesPoint{at: s.Started, value: value(s)})
+		}
+		return points, nil
+	}
+}
+
+func (ls *LocalStats) series(rangeTime string, points func(from time.Time) ([]seriesPoint, error), format func(*big.Int) string) ([]SeriesItem, error) {
+	period, err := parseRange(rangeTime)
+	if err != nil {
+		return nil, err
+	}
+
+	bucket := ls.bucketFor(rangeTime)
+	from := ls.now().Add(-period).Truncate(bucket)
+	list, err := points(from)
+	if err != nil {
+		return nil, err
+	}
+
+	buckets := make([]*big.Int, int(ls.now().Sub(from)/bucket)+1)
+	for i := range buckets {
+		buckets[i] = new(big.Int)
+	}
+	for _, p := range list {
+		i := int(p.at.Sub(from) / bucket)
+		if i < 0 || i >= len(buckets) {
+			continue
+		}
+		buckets[i].Add(buckets[i], p.value)
+	}
+
+	data := make([]SeriesItem, 0, len(buckets))
+	for i, sum := range buckets {
+		data = append(data, SeriesItem{
+			Value:     format(sum),
+			Timestamp: from.Add(time.Duration(i) * bucket).Unix(),
+		})
+	}
+	return data, nil
+}
+
+func (ls *LocalStats) bucketFor(rangeTime string) time.Duration {
+	if ls.bucket > 0 {
+		return ls.bucket
+	}
+	if period, _ := parseRange(rangeTime); period <= 24*time.Hour {
+		return time.Hour
+	}
+	return 24 * time.Hour
+}
+
+// parseRange parses ranges accepted by Quality Oracle, e.g. "1d" or "7d".
+func parseRange(rangeTime string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(rangeTime, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err == nil && n > 0 {
+			return time.Duration(n) * 24 * time.Hour, nil
+		}
+	}
+	period, err := time.ParseDuration(rangeTime)
+	if err != nil || period <= 0 {
+		return 0, fmt.Errorf("invalid time range %q", rangeTime)
+	}
+	return period, nil
+}
+
+func tokens(s session.History) *big.Int {
+	if s.Tokens == nil {
+		return new(big.Int)
+	}
+	return s.Tokens
+}
@@ -0,0 +1,218 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package node
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mysteriumnetwork/node/consumer/session"
+	"github.com/mysteriumnetwork/node/identity"
+	"github.com/mysteriumnetwork/node/session/connectivity"
+)
+
+var (
+	statsProvider = identity.FromAddress("0x1")
+	statsNow      = time.Date(2026, 10, 19, 12, 30, 0, 0, time.UTC)
+)
+
+type mockSessionHistory struct {
+	sessions []session.History
+	filter   *session.Filter
+}
+
+func (m *mockSessionHistory) List(filter *session.Filter) ([]session.History, error) {
+	m.filter = filter
+	var res []session.History
+	for _, s := range m.sessions {
+		if !s.Started.Before(*filter.StartedFrom) {
+			res = append(res, s)
+		}
+	}
+	return res, nil
+}
+
+type mockStatuses []connectivity.StatusEntry
+
+func (m mockStatuses) GetAllStatusEntries() []connectivity.StatusEntry {
+	return m
+}
+
+type mockUptime int
+
+func (m mockUptime) OnlineHours(time.Time) (int, error) {
+	return int(m), nil
+}
+
+func testEarnings(id identity.Identity, from time.Time) (map[time.Time]*big.Int, error) {
+	earnings := map[time.Time]*big.Int{
+		statsNow.Add(-time.Hour).Truncate(time.Hour): big.NewInt(750_000_000_000_000_000),
+		statsNow.Add(-72 * time.Hour):                big.NewInt(1),
+	}
+	for hour := range earnings {
+		if hour.Before(from) {
+			delete(earnings, hour)
+		}
+	}
+	return earnings, nil
+}
+
+func newTestLocalStats(bucket time.Duration) (*LocalStats, *mockSessionHistory) {
+	history := &mockSessionHistory{
+		sessions: []session.History{
+			{
+				SessionID:       "s1",
+				ConsumerID:      identity.FromAddress("0xc1"),
+				ConsumerCountry: "LT",
+				ServiceType:     "wireguard",
+				DataSent:        100,
+				DataReceived:    20,
+				Tokens:          big.NewInt(500_000_000_000_000_000),
+				Started:         statsNow.Add(-90 * time.Minute),
+				Updated:         statsNow.Add(-30 * time.Minute),
+			},
+			{
+				SessionID:   "s2",
+				ConsumerID:  identity.FromAddress("0xc1"),
+				ServiceType: "wireguard",
+				DataSent:    10,
+				Tokens:      big.NewInt(250_000_000_000_000_000),
+				Started:     statsNow.Add(-80 * time.Minute),
+				Updated:     statsNow.Add(-70 * time.Minute),
+			},
+			{
+				SessionID:  "s3",
+				ConsumerID: identity.FromAddress("0xc2"),
+				DataSent:   1,
+				Started:    statsNow.Add(-10 * time.Minute),
+				Updated:    statsNow,
+			},
+			{
+				SessionID:  "old",
+				ConsumerID: identity.FromAddress("0xc3"),
+				DataSent:   1000,
+				Started:    statsNow.Add(-48 * time.Hour),
+				Updated:    statsNow.Add(-47 * time.Hour),
+			},
+		},
+	}
+	statuses := mockStatuses{
+		{StatusCode: connectivity.StatusConnectionOk},
+		{StatusCode: connectivity.StatusConnectionOk},
+		{StatusCode: connectivity.StatusConnectionOk},
+		{StatusCode: connectivity.StatusSessionPaymentsFailed},
+	}
+	stats := NewLocalStats(history, testEarnings, statuses, mockUptime(360), bucket)
+	stats.now = func() time.Time { return statsNow }
+	return stats, history
+}
+
+func TestLocalStats_Totals(t *testing.T) {
+	stats, history := newTestLocalStats(0)
+
+	sessions, err := stats.ProviderSessionsList(statsProvider, "1d")
+	require.NoError(t, err)
+	require.Len(t, sessions, 3)
+	assert.Equal(t, SessionItem{
+		ID:              "s1",
+		ConsumerCountry: "LT",
+		ServiceType:     "wireguard",
+		Duration:        3600,
+		StartedAt:       statsNow.Add(-90 * time.Minute).Unix(),
+		Earning:         "0.5",
+		Transferred:     120,
+	}, sessions[0])
+	assert.Equal(t, session.DirectionProvided, *history.filter.Direction)
+	assert.Equal(t, statsProvider, *history.filter.ProviderID)
+	assert.Equal(t, statsNow.Add(-24*time.Hour), *history.filter.StartedFrom)
+
+	data, err := stats.ProviderTransferredData(statsProvider, "1d")
+	require.NoError(t, err)
+	assert.Equal(t, 131, data.Bytes)
+
+	sessionsCount, err := stats.ProviderSessionsCount(statsProvider, "1d")
+	require.NoError(t, err)
+	assert.Equal(t, 3, sessionsCount.Count)
+
+	consumersCount, err := stats.ProviderConsumersCount(statsProvider, "1d")
+	require.NoError(t, err)
+	assert.Equal(t, 2, consumersCount.Count)
+
+	sessionsCount, err = stats.ProviderSessionsCount(statsProvider, "7d")
+	require.NoError(t, err)
+	assert.Equal(t, 4, sessionsCount.Count)
+
+	_, err = stats.ProviderSessionsCount(statsProvider, "forever")
+	assert.Error(t, err)
+}
+
+func TestLocalStats_Series(t *testing.T) {
+	stats, _ := newTestLocalStats(0)
+
+	earnings, err := stats.ProviderEarningsSeries(statsProvider, "1d")
+	require.NoError(t, err)
+	require.Len(t, earnings.Data, 25)
+	assert.Equal(t, statsNow.Add(-24*time.Hour).Truncate(time.Hour).Unix(), earnings.Data[0].Timestamp)
+	assert.Equal(t, "0", earnings.Data[22].Value)
+	assert.Equal(t, SeriesItem{Value: "0.75", Timestamp: statsNow.Add(-time.Hour).Truncate(time.Hour).Unix()}, earnings.Data[23])
+	assert.Equal(t, "0", earnings.Data[24].Value)
+
+	sessions, err := stats.ProviderSessionsSeries(statsProvider, "1d")
+	require.NoError(t, err)
+	assert.Equal(t, "2", sessions.Data[23].Value)
+	assert.Equal(t, "1", sessions.Data[24].Value)
+
+	data, err := stats.ProviderTransferredDataSeries(statsProvider, "7d")
+	require.NoError(t, err)
+	require.Len(t, data.Data, 8)
+	assert.Equal(t, "1000", data.Data[5].Value)
+	assert.Equal(t, "131", data.Data[7].Value)
+}
+
+func TestLocalStats_ConfiguredBucket(t *testing.T) {
+	stats, _ := newTestLocalStats(6 * time.Hour)
+
+	sessions, err := stats.ProviderSessionsSeries(statsProvider, "1d")
+	require.NoError(t, err)
+	require.Len(t, sessions.Data, 5)
+	assert.Equal(t, "2", sessions.Data[3].Value)
+	assert.Equal(t, "1", sessions.Data[4].Value)
+}
+
+func TestLocalStats_ActivityAndQuality(t *testing.T) {
+	stats, history := newTestLocalStats(0)
+
+	activity, err := stats.ProviderActivityStats(statsProvider)
+	require.NoError(t, err)
+	assert.Equal(t, 50.0, activity.Online)
+	assert.InDelta(t, 4.0/720*100, activity.Active, 1e-9)
+	assert.Equal(t, statsNow.Add(-30*24*time.Hour), *history.filter.StartedFrom)
+
+	quality, err := stats.ProviderQuality(statsProvider)
+	require.NoError(t, err)
+	assert.Equal(t, 2.25, quality.Quality)
+
+	stats.statuses = mockStatuses{}
+	quality, err = stats.ProviderQuality(statsProvider)
+	require.NoError(t, err)
+	assert.Zero(t, quality.Quality)
+}
@@ -0,0 +1,246 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package node
+
+import (
+	"math/big"
+	"sort"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/mysteriumnetwork/node/identity"
+)
+
+// ProviderStats provides provider statistics over a time range.
+type ProviderStats interface {
+	ProviderSessionsList(id identity.Identity, rangeTime string) ([]SessionItem, error)
+	ProviderTransferredData(id identity.Identity, rangeTime string) (TransferredData, error)
+	ProviderSessionsCount(id identity.Identity, rangeTime string) (SessionsCount, error)
+	ProviderConsumersCount(id identity.Identity, rangeTime string) (ConsumersCount, error)
+	ProviderEarningsSeries(id identity.Identity, rangeTime string) (EarningsSeries, error)
+	ProviderSessionsSeries(id identity.Identity, rangeTime string) (SessionsSeries, error)
+	ProviderTransferredDataSeries(id identity.Identity, rangeTime string) (TransferredDataSeries, error)
+	ProviderActivityStats(id identity.Identity) (ActivityStats, error)
+	ProviderQuality(id identity.Identity) (QualityInfo, error)
+}
+
+// SelectProviderStats returns statistics of the given source.
+func SelectProviderStats(source StatsSource, remote, local ProviderStats) ProviderStats {
+	switch source {
+	case StatsSourceLocal:
+		return local
+	case StatsSourceMerged:
+		return NewMergedStats(remote, local)
+	default:
+		return remote
+	}
+}
+
+// MergedStats combines remote provider statistics with locally recorded ones.
+// Totals and series points keep the larger of both values, as either side may miss some sessions.
+// Activity and quality are scores rather than sums, so remote ones are preferred and local ones
+// are used when Quality Oracle is unavailable. Either source is used alone when the other one fails.
+type MergedStats struct {
+	remote ProviderStats
+	local  ProviderStats
+}
+
+// NewMergedStats returns statistics merged from the given sources.
+func NewMergedStats(remote, local ProviderStats) *MergedStats {
+	return &MergedStats{
+		remote: remote,
+		local:  local,
+	}
+}
+
+// ProviderSessionsList returns remote sessions complemented by the local ones remote does not know about.
+func (ms *MergedStats) ProviderSessionsList(id identity.Identity, rangeTime string) ([]SessionItem, error) {
+	remote, err := ms.remote.ProviderSessionsList(id, rangeTime)
+	local, localErr := ms.local.ProviderSessionsList(id, rangeTime)
+	if localErr != nil {
+		logLocalFailure(localErr, "sessions list")
+		return remote, err
+	}
+	if err != nil {
+		logRemoteFailure(err, "sessions list")
+		return local, nil
+	}
+
+	merged := make([]SessionItem, 0, len(remote)+len(local))
+	known := make(map[string]struct{}, len(remote))
+	for _, s := range remote {
+		known[s.ID] = struct{}{}
+		merged = append(merged, s)
+	}
+	for _, s := range local {
+		if _, ok := known[s.ID]; !ok {
+			merged = append(merged, s)
+		}
+	}
+	return merged, nil
+}
+
+// ProviderTransferredData returns the larger of remote and local transferred data.
+func (ms *MergedStats) ProviderTransferredData(id identity.Identity, rangeTime string) (TransferredData, error) {
+	remote, err := ms.remote.ProviderTransferredData(id, rangeTime)
+	local, localErr := ms.local.ProviderTransferredData(id, rangeTime)
+	if localErr != nil {
+		logLocalFailure(localErr, "transferred data")
+		return remote, err
+	}
+	if err != nil {
+		logRemoteFailure(err, "transferred data")
+		return local, nil
+	}
+	if local.Bytes > remote.Bytes {
+		return local, nil
+	}
+	return remote, nil
+}
+
+// ProviderSessionsCount returns the larger of remote and local sessions count.
+func (ms *MergedStats) ProviderSessionsCount(id identity.Identity, rangeTime string) (SessionsCount, error) {
+	remote, err := ms.remote.ProviderSessionsCount(id, rangeTime)
+	local, localErr := ms.local.ProviderSessionsCount(id, rangeTime)
+	if localErr != nil {
+		logLocalFailure(localErr, "sessions count")
+		return remote, err
+	}
+	if err != nil {
+		logRemoteFailure(err, "sessions count")
+		return local, nil
+	}
+	if local.Count > remote.Count {
+		return local, nil
+	}
+	return remote, nil
+}
+
+// ProviderConsumersCount returns the larger of remote and local consumers count.
+func (ms *MergedStats) ProviderConsumersCount(id identity.Identity, rangeTime string) (ConsumersCount, error) {
+	remote, err := ms.remote.ProviderConsumersCount(id, rangeTime)
+	local, localErr := ms.local.ProviderConsumersCount(id, rangeTime)
+	if localErr != nil {
+		logLocalFailure(localErr, "consumers count")
+		return remote, err
+	}
+	if err != nil {
+		logRemoteFailure(err, "consumers count")
+		return local, nil
+	}
+	if local.Count > remote.Count {
+		return local, nil
+	}
+	return remote, nil
+}
+
+// ProviderEarningsSeries returns remote and local earnings series merged by timestamp.
+func (ms *MergedStats) ProviderEarningsSeries(id identity.Identity, rangeTime string) (EarningsSeries, error) {
+	remote, err := ms.remote.ProviderEarningsSeries(id, rangeTime)
+	local, localErr := ms.local.ProviderEarningsSeries(id, rangeTime)
+	data, err := mergeSeries(remote.Data, err, local.Data, localErr, "earnings series")
+	return EarningsSeries{Data: data}, err
+}
+
+// ProviderSessionsSeries returns remote and local sessions series merged by timestamp.
+func (ms *MergedStats) ProviderSessionsSeries(id identity.Identity, rangeTime string) (SessionsSeries, error) {
+	remote, err := ms.remote.ProviderSessionsSeries(id, rangeTime)
+	local, localErr := ms.local.ProviderSessionsSeries(id, rangeTime)
+	data, err := mergeSeries(remote.Data, err, local.Data, localErr, "sessions series")
+	return SessionsSeries{Data: data}, err
+}
+
+// ProviderTransferredDataSeries returns remote and local transferred data series merged by timestamp.
+func (ms *MergedStats) ProviderTransferredDataSeries(id identity.Identity, rangeTime string) (TransferredDataSeries, error) {
+	remote, err := ms.remote.ProviderTransferredDataSeries(id, rangeTime)
+	local, localErr := ms.local.ProviderTransferredDataSeries(id, rangeTime)
+	data, err := mergeSeries(remote.Data, err, local.Data, localErr, "transferred data series")
+	return TransferredDataSeries{Data: data}, err
+}
+
+// ProviderActivityStats returns remote activity stats, or local ones if remote are unavailable.
+func (ms *MergedStats) ProviderActivityStats(id identity.Identity) (ActivityStats, error) {
+	remote, err := ms.remote.ProviderActivityStats(id)
+	if err == nil {
+		return remote, nil
+	}
+	logRemoteFailure(err, "activity stats")
+	return ms.local.ProviderActivityStats(id)
+}
+
+// ProviderQuality returns remote quality, or local one if remote is unavailable.
+func (ms *MergedStats) ProviderQuality(id identity.Identity) (QualityInfo, error) {
+	remote, err := ms.remote.ProviderQuality(id)
+	if err == nil {
+		return remote, nil
+	}
+	logRemoteFailure(err, "quality")
+	return ms.local.ProviderQuality(id)
+}
+
+// mergeSeries combines series points by timestamp, keeping the larger value where both have one.
+func mergeSeries(remote []SeriesItem, err error, local []SeriesItem, localErr error, stats string) ([]SeriesItem, error) {
+	if localErr != nil {
+		logLocalFailure(localErr, stats)
+		return remote, err
+	}
+	if err != nil {
+		logRemoteFailure(err, stats)
+		return local, nil
+	}
+
+	points := make(map[int64]SeriesItem, len(remote)+len(local))
+	for _, item := range remote {
+		points[item.Timestamp] = item
+	}
+	for _, item := range local {
+		known, ok := points[item.Timestamp]
+		if !ok || seriesValueLess(known.Value, item.Value) {
+			points[item.Timestamp] = item
+		}
+	}
+
+	merged := make([]SeriesItem, 0, len(points))
+	for _, item := range points {
+		merged = append(merged, item)
+	}
+	sort.Slice(merged, func(i, j int) bool {
+		return merged[i].Timestamp < merged[j].Timestamp
+	})
+	return merged, nil
+}
+
+func seriesValueLess(a, b string) bool {
+	x, ok := new(big.Float).SetString(a)
+	if !ok {
+		return false
+	}
+	y, ok := new(big.Float).SetString(b)
+	if !ok {
+		return false
+	}
+	return x.Cmp(y) < 0
+}
+
+func logLocalFailure(err error, stats string) {
+	log.Warn().Err(err).Msgf("Failed to get provider %s from local sessions, using quality oracle statistics", stats)
+}
+
+func logRemoteFailure(err error, stats string) {
+	log.Warn().Err(err).Msgf("Failed to get provider %s from quality oracle, using local statistics", stats)
+}
@@ -0,0 +1,159 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package node
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mysteriumnetwork/node/identity"
+)
+
+type mockProviderStats struct {
+	sessions []SessionItem
+	count    int
+	err      error
+}
+
+func (m *mockProviderStats) ProviderSessionsList(identity.Identity, string) ([]SessionItem, error) {
+	return m.sessions, m.err
+}
+
+func (m *mockProviderStats) ProviderTransferredData(identity.Identity, string) (TransferredData, error) {
+	return TransferredData{Bytes: m.count}, m.err
+}
+
+func (m *mockProviderStats) ProviderSessionsCount(identity.Identity, string) (SessionsCount, error) {
+	return SessionsCount{Count: m.count}, m.err
+}
+
+func (m *mockProviderStats) ProviderConsumersCount(identity.Identity, string) (ConsumersCount, error) {
+	return ConsumersCount{Count: m.count}, m.err
+}
+
+func (m *mockProviderStats) ProviderEarningsSeries(identity.Identity, string) (EarningsSeries, error) {
+	return EarningsSeries{Data: m.series()}, m.err
+}
+
+func (m *mockProviderStats) ProviderSessionsSeries(identity.Identity, string) (SessionsSeries, error) {
+	return SessionsSeries{Data: m.series()}, m.err
+}
+
+func (m *mockProviderStats) ProviderTransferredDataSeries(identity.Identity, string) (TransferredDataSeries, error) {
+	return TransferredDataSeries{Data: m.series()}, m.err
+}
+
+func (m *mockProviderStats) ProviderActivityStats(identity.Identity) (ActivityStats, error) {
+	return ActivityStats{Online: float64(m.count)}, m.err
+}
+
+func (m *mockProviderStats) ProviderQuality(identity.Identity) (QualityInfo, error) {
+	return QualityInfo{Quality: float64(m.count)}, m.err
+}
+
+func (m *mockProviderStats) series() []SeriesItem {
+	return []SeriesItem{{Value: "1", Timestamp: int64(m.count)}}
+}
+
+func TestMergedStats(t *testing.T) {
+	local := &mockProviderStats{sessions: []SessionItem{{ID: "s1"}, {ID: "s3"}}, count: 5}
+	remote := &mockProviderStats{sessions: []SessionItem{{ID: "s1"}, {ID: "s2"}}, count: 3}
+	stats := NewMergedStats(remote, local)
+
+	sessions, err := stats.ProviderSessionsList(statsProvider, "1d")
+	require.NoError(t, err)
+	assert.Equal(t, []SessionItem{{ID: "s1"}, {ID: "s2"}, {ID: "s3"}}, sessions)
+
+	count, err := stats.ProviderSessionsCount(statsProvider, "1d")
+	require.NoError(t, err)
+	assert.Equal(t, 5, count.Count)
+
+	series, err := stats.ProviderSessionsSeries(statsProvider, "1d")
+	require.NoError(t, err)
+	assert.Equal(t, []SeriesItem{{Value: "1", Timestamp: 3}, {Value: "1", Timestamp: 5}}, series.Data)
+
+	quality, err := stats.ProviderQuality(statsProvider)
+	require.NoError(t, err)
+	assert.Equal(t, 3.0, quality.Quality)
+
+	remote.err = errors.New("oracle is down")
+
+	sessions, err = stats.ProviderSessionsList(statsProvider, "1d")
+	require.NoError(t, err)
+	assert.Equal(t, local.sessions, sessions)
+
+	data, err := stats.ProviderTransferredData(statsProvider, "1d")
+	require.NoError(t, err)
+	assert.Equal(t, 5, data.Bytes)
+
+	earnings, err := stats.ProviderEarningsSeries(statsProvider, "1d")
+	require.NoError(t, err)
+	assert.Equal(t, []SeriesItem{{Value: "1", Timestamp: 5}}, earnings.Data)
+
+	activity, err := stats.ProviderActivityStats(statsProvider)
+	require.NoError(t, err)
+	assert.Equal(t, 5.0, activity.Online)
+
+	remote.err = nil
+	local.err = errors.New("storage is closed")
+
+	sessions, err = stats.ProviderSessionsList(statsProvider, "1d")
+	require.NoError(t, err)
+	assert.Equal(t, remote.sessions, sessions)
+
+	count, err = stats.ProviderSessionsCount(statsProvider, "1d")
+	require.NoError(t, err)
+	assert.Equal(t, 3, count.Count)
+
+	consumers, err := stats.ProviderConsumersCount(statsProvider, "1d")
+	require.NoError(t, err)
+	assert.Equal(t, 3, consumers.Count)
+
+	data, err = stats.ProviderTransferredData(statsProvider, "1d")
+	require.NoError(t, err)
+	assert.Equal(t, 3, data.Bytes)
+
+	remote.err = errors.New("oracle is down")
+	_, err = stats.ProviderSessionsCount(statsProvider, "1d")
+	assert.Error(t, err)
+}
+
+func TestMergeSeries(t *testing.T) {
+	remote := []SeriesItem{{Value: "0.5", Timestamp: 10}, {Value: "2", Timestamp: 20}}
+	local := []SeriesItem{{Value: "1", Timestamp: 20}, {Value: "0.75", Timestamp: 0}, {Value: "0.25", Timestamp: 10}}
+
+	merged, err := mergeSeries(remote, nil, local, nil, "series")
+	require.NoError(t, err)
+	assert.Equal(t, []SeriesItem{
+		{Value: "0.75", Timestamp: 0},
+		{Value: "0.5", Timestamp: 10},
+		{Value: "2", Timestamp: 20},
+	}, merged)
+}
+
+func TestSelectProviderStats(t *testing.T) {
+	local, remote := &mockProviderStats{}, &mockProviderStats{}
+
+	assert.Same(t, local, SelectProviderStats(StatsSourceLocal, remote, local))
+	assert.Same(t, remote, SelectProviderStats(StatsSourceRemote, remote, local))
+	assert.IsType(t, &MergedStats{}, SelectProviderStats(StatsSourceMerged, remote, local))
+	assert.Same(t, remote, SelectProviderStats("", remote, local))
+}
@@ -0,0 +1,132 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package node
+
+import (
+	"sync"
+	"time"
+
+	"github.com/asdine/storm/v3"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	uptimeBucket    = "node-uptime"
+	uptimeRetention = 30 * 24 * time.Hour
+	uptimeInterval  = 10 * time.Minute
+)
+
+type uptimeStorage interface {
+	Store(bucket string, data interface{}) error
+	GetAllFrom(bucket string, data interface{}) error
+	Delete(bucket string, data interface{}) error
+}
+
+type uptimeHour struct {
+	Hour int64 `storm:"id"`
+}
+
+// UptimeTracker records hours during which the node was running.
+type UptimeTracker struct {
+	storage uptimeStorage
+	now     func() time.Time
+	stop    chan struct{}
+	once    sync.Once
+}
+
+// NewUptimeTracker returns a tracker keeping node uptime in the given storage.
+func NewUptimeTracker(storage uptimeStorage) *UptimeTracker {
+	return &UptimeTracker{
+		storage: storage,
+		now:     time.Now,
+		stop:    make(chan struct{}),
+	}
+}
+
+// Start marks the current hour as online and keeps marking hours until stopped.
+func (ut *UptimeTracker) Start() {
+	go func() {
+		for {
+			if err := ut.mark(); err != nil {
+				log.Warn().Err(err).Msg("Failed to record node uptime")
+			}
+
+			select {
+			case <-ut.stop:
+				return
+			case <-time.After(uptimeInterval):
+			}
+		}
+	}()
+}
+
+// Stop stops recording uptime.
+func (ut *UptimeTracker) Stop() {
+	ut.once.Do(func() {
+		close(ut.stop)
+	})
+}
+
+// OnlineHours returns the number of hours the node was running since the given time.
+func (ut *UptimeTracker) OnlineHours(from time.Time) (int, error) {
+	hours, err := ut.hours()
+	if err != nil {
+		return 0, err
+	}
+
+	from = from.Truncate(time.Hour)
+	count := 0
+	for _, h := range hours {
+		if h.Hour >= from.Unix() {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (ut *UptimeTracker) mark() error {
+	now := ut.now().UTC()
+	if err := ut.storage.Store(uptimeBucket, &uptimeHour{Hour: now.Truncate(time.Hour).Unix()}); err != nil {
+		return errors.Wrap(err, "could not store uptime hour")
+	}
+
+	hours, err := ut.hours()
+	if err != nil {
+		return err
+	}
+	expired := now.Add(-uptimeRetention).Unix()
+	for i := range hours {
+		if hours[i].Hour >= expired {
+			continue
+		}
+		if err := ut.storage.Delete(uptimeBucket, &hours[i]); err != nil && !errors.Is(err, storm.ErrNotFound) {
+			return errors.Wrap(err, "could not remove expired uptime hour")
+		}
+	}
+	return nil
+}
+
+func (ut *UptimeTracker) hours() ([]uptimeHour, error) {
+	var hours []uptimeHour
+	err := ut.storage.GetAllFrom(uptimeBucket, &hours)
+	if err != nil && !errors.Is(err, storm.ErrNotFound) {
+		return nil, errors.Wrap(err, "could not load uptime hours")
+	}
+	return hours, nil
+}
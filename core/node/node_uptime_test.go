@@ -0,0 +1,57 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package node
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mysteriumnetwork/node/core/storage/boltdb"
+)
+
+func TestUptimeTracker_OnlineHours(t *testing.T) {
+	storage, err := boltdb.NewStorage(t.TempDir())
+	require.NoError(t, err)
+	defer storage.Close()
+
+	tracker := NewUptimeTracker(storage)
+	now := statsNow
+	tracker.now = func() time.Time { return now }
+
+	for _, at := range []time.Time{
+		statsNow.Add(-31 * 24 * time.Hour),
+		statsNow.Add(-2 * time.Hour),
+		statsNow.Add(-90 * time.Minute),
+		statsNow,
+		statsNow.Add(10 * time.Minute),
+	} {
+		now = at
+		require.NoError(t, tracker.mark())
+	}
+
+	hours, err := tracker.OnlineHours(statsNow.Add(-24 * time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 3, hours)
+
+	hours, err = tracker.OnlineHours(time.Time{})
+	require.NoError(t, err)
+	assert.Equal(t, 3, hours, "hours past retention are removed")
+}
@@ -88,6 +88,7 @@ type Options struct {
 	PilvytisAddress         string
 	ObserverAddress         string
 	SSE                     OptionsSSE
+	Webhook                 OptionsWebhook
 }
 
 // GetOptions retrieves node options from the app configuration.
@@ -127,7 +128,9 @@ func GetOptions() *Options {
 		SwarmDialerDNSHeadstart: config.GetDuration(config.FlagDNSResolutionHeadstart),
 		FeedbackURL:             config.GetString(config.FlagFeedbackURL),
 		Keystore: OptionsKeystore{
-			UseLightweight: config.GetBool(config.FlagKeystoreLightweight),
+			UseLightweight:   config.GetBool(config.FlagKeystoreLightweight),
+			RemoteSigner:     config.GetString(config.FlagKeystoreRemoteSigner),
+			RemoteSignerMode: config.GetString(config.FlagKeystoreRemoteSignerMode),
 		},
 		LogOptions:     *GetLogOptions(),
 		OptionsNetwork: network,
@@ -135,6 +138,9 @@ func GetOptions() *Options {
 		Quality: OptionsQuality{
 			Type:    QualityType(config.GetString(config.FlagQualityType)),
 			Address: config.GetString(config.FlagQualityAddress),
+
+			StatsSource: StatsSource(config.GetString(config.FlagProviderStatsSource)),
+			StatsBucket: config.GetDuration(config.FlagProviderStatsBucket),
 		},
 		Location: OptionsLocation{
 			IPDetectorURL: config.GetString(config.FlagIPDetectorURL),
@@ -170,6 +176,11 @@ func GetOptions() *Options {
 			HermesStatusRecheckInterval:    config.GetDuration(config.FlagPaymentsHermesStatusRecheckInterval),
 			MinAutoSettleAmount:            config.GetFloat64(config.FlagPaymentsZeroStakeUnsettledAmount),
 
+			SettlementScheduler:         config.GetBool(config.FlagPaymentsSettleScheduler),
+			SettlementSchedulerInterval: config.GetDuration(config.FlagPaymentsSettleSchedulerInterval),
+			SettlementTargetFeeRatio:    config.GetFloat64(config.FlagPaymentsSettleTargetFeeRatio),
+			SettlementMaxUnsettledAge:   config.GetDuration(config.FlagPaymentsSettleMaxUnsettledAge),
+
 			ProviderInvoiceFrequency:      config.GetDuration(config.FlagPaymentsProviderInvoiceFrequency),
 			ProviderLimitInvoiceFrequency: config.GetDuration(config.FlagPaymentsLimitProviderInvoiceFrequency),
 			MaxUnpaidInvoiceValue:         config.GetBigInt(config.FlagPaymentsUnpaidInvoiceValue),
@@ -205,6 +216,13 @@ func GetOptions() *Options {
 		SSE: OptionsSSE{
 			Enabled: config.GetBool(config.FlagSSEEnable),
 		},
+		Webhook: OptionsWebhook{
+			URLs:        config.GetStringSlice(config.FlagWebhookURL),
+			Secret:      config.GetString(config.FlagWebhookSecret),
+			Events:      config.GetStringSlice(config.FlagWebhookEvents),
+			MaxAttempts: config.GetInt(config.FlagWebhookMaxAttempts),
+			MaxQueued:   config.GetInt(config.FlagWebhookMaxQueued),
+		},
 	}
 }
 
@@ -235,11 +253,15 @@ func GetDiscoveryOptions() *OptionsDiscovery {
 	}
 
 	return &OptionsDiscovery{
-		Types:         types,
-		PingInterval:  config.GetDuration(config.FlagDiscoveryPingInterval),
-		FetchEnabled:  true,
-		FetchInterval: config.GetDuration(config.FlagDiscoveryFetchInterval),
-		DHT:           *GetDHTOptions(),
+		Types:             types,
+		PingInterval:      config.GetDuration(config.FlagDiscoveryPingInterval),
+		FetchEnabled:      true,
+		FetchInterval:     config.GetDuration(config.FlagDiscoveryFetchInterval),
+		DHT:               *GetDHTOptions(),
+		RequireSignature:  config.GetBool(config.FlagDiscoveryRequireSignature),
+		Snapshot:          config.GetBool(config.FlagDiscoverySnapshot),
+		SnapshotInterval:  config.GetDuration(config.FlagDiscoverySnapshotInterval),
+		AutoBlockFailures: config.GetInt(config.FlagDiscoveryAutoBlockFailures),
 	}
 }
 
@@ -256,4 +278,7 @@ func GetDHTOptions() *OptionsDHT {
 // OptionsKeystore stores the keystore configuration
 type OptionsKeystore struct {
 	UseLightweight bool
+	RemoteSigner   string
+	// RemoteSignerMode is one of identity.RemoteSignerMode values.
+	RemoteSignerMode string
 }
@@ -39,6 +39,13 @@ type OptionsDiscovery struct {
 	FetchEnabled  bool
 	FetchInterval time.Duration
 	DHT           OptionsDHT
+	// RequireSignature rejects proposals which are not signed by their provider.
+	RequireSignature bool
+	// Snapshot keeps the last good set of proposals on disk as a fallback for unreachable discovery.
+	Snapshot         bool
+	SnapshotInterval time.Duration
+	// AutoBlockFailures blocks a provider for the consumer after this many consecutive failed connects, 0 disables.
+	AutoBlockFailures int
 }
 
 // OptionsDHT describes possible parameters of DHT configuration.
@@ -40,6 +40,11 @@ type OptionsPayments struct {
 	MinAutoSettleAmount            float64
 	MaxUnSettledAmount             float64
 
+	SettlementScheduler         bool
+	SettlementSchedulerInterval time.Duration
+	SettlementTargetFeeRatio    float64
+	SettlementMaxUnsettledAge   time.Duration
+
 	ProviderInvoiceFrequency      time.Duration
 	ProviderLimitInvoiceFrequency time.Duration
 
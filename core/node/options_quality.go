@@ -17,6 +17,8 @@
 
 package node
 
+import "time"
+
 // QualityType identifies Quality Oracle provider
 type QualityType string
 
@@ -29,8 +31,23 @@ const (
 	QualityTypeNone = QualityType("none")
 )
 
+// StatsSource identifies where provider statistics are taken from
+type StatsSource string
+
+const (
+	// StatsSourceLocal computes provider statistics from locally recorded sessions
+	StatsSourceLocal = StatsSource("local")
+	// StatsSourceRemote fetches provider statistics from Quality Oracle
+	StatsSourceRemote = StatsSource("remote")
+	// StatsSourceMerged combines Quality Oracle statistics with locally recorded ones
+	StatsSourceMerged = StatsSource("merged")
+)
+
 // OptionsQuality describes possible parameters of Quality Oracle configuration
 type OptionsQuality struct {
 	Type    QualityType
 	Address string
+
+	StatsSource StatsSource
+	StatsBucket time.Duration
 }
@@ -201,6 +201,7 @@ func (manager *Manager) Start(providerID identity.Identity, serviceType string,
 		subscribeSessionAcknowledge(mng, ch)
 		subscribeSessionDestroy(mng, ch)
 		subscribeSessionPayments(mng, ch)
+		subscribeSessionPriceChange(mng, ch)
 	}
 	stopP2PListener, err := manager.p2pListener.Listen(providerID, serviceType, channelHandlers)
 	if err != nil {
@@ -48,6 +48,10 @@ type Session struct {
 	cleanup          []func() error
 	tracer           *trace.Tracer
 	once             sync.Once
+
+	paymentEngineLock sync.Mutex
+	paymentEngine     PaymentEngine
+	priceProposer     func(market.Price) error
 }
 
 // Close ends session.
@@ -74,6 +78,38 @@ func (s *Session) Done() <-chan struct{} {
 	return s.done
 }
 
+func (s *Session) setPaymentEngine(engine PaymentEngine) {
+	s.paymentEngineLock.Lock()
+	defer s.paymentEngineLock.Unlock()
+
+	s.paymentEngine = engine
+}
+
+func (s *Session) getPaymentEngine() PaymentEngine {
+	s.paymentEngineLock.Lock()
+	defer s.paymentEngineLock.Unlock()
+
+	return s.paymentEngine
+}
+
+func (s *Session) setPriceProposer(propose func(market.Price) error) {
+	s.paymentEngineLock.Lock()
+	defer s.paymentEngineLock.Unlock()
+
+	s.priceProposer = propose
+}
+
+func (s *Session) proposePrice(price market.Price) error {
+	s.paymentEngineLock.Lock()
+	propose := s.priceProposer
+	s.paymentEngineLock.Unlock()
+
+	if propose == nil {
+		return ErrorSessionNotExists
+	}
+	return propose(price)
+}
+
 func (s *Session) addCleanup(fn func() error) {
 	s.cleanupLock.Lock()
 	defer s.cleanupLock.Unlock()
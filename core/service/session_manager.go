@@ -49,6 +49,8 @@ var (
 	ErrorSessionNotExists = errors.New("session does not exists")
 	// ErrorWrongSessionOwner returned when consumer tries to destroy session that does not belongs to him
 	ErrorWrongSessionOwner = errors.New("wrong session owner")
+	// ErrorPriceRejected returned when the peer does not agree to the proposed session price
+	ErrorPriceRejected = errors.New("price change rejected")
 )
 
 // IDGenerator defines method for session id generation
@@ -119,6 +121,8 @@ type PriceValidator interface {
 type PaymentEngine interface {
 	Start() error
 	WaitFirstInvoice(time.Duration) error
+	PriceBoundary() market.PriceBoundary
+	ChangePrice(market.Price, market.PriceBoundary)
 	Stop()
 }
 
@@ -315,6 +319,65 @@ func (manager *SessionManager) Destroy(consumerID identity.Identity, sessionID s
 	return nil
 }
 
+// ChangePrice handles the price change proposed by the consumer.
+// The price is accepted if it is valid for the service, invoices are issued with it from the given boundary.
+func (manager *SessionManager) ChangePrice(consumerID identity.Identity, sessionID string, price market.Price, at market.PriceBoundary) error {
+	session, found := manager.sessionStorage.Find(session.ID(sessionID))
+	if !found {
+		return ErrorSessionNotExists
+	}
+	if session.ConsumerID != consumerID {
+		return ErrorWrongSessionOwner
+	}
+	engine := session.getPaymentEngine()
+	if engine == nil {
+		return ErrorSessionNotExists
+	}
+
+	if err := manager.validatePrice(price, session.Proposal.Location.IPType, session.Proposal.Location.Country, session.Proposal.ServiceType); err != nil {
+		return fmt.Errorf("%w: %v", ErrorPriceRejected, err)
+	}
+	if !at.Near(engine.PriceBoundary(), market.PriceBoundaryTolerance) {
+		return fmt.Errorf("%w: boundary does not match the session", ErrorPriceRejected)
+	}
+
+	engine.ChangePrice(price, at)
+	return nil
+}
+
+// proposePrice asks the consumer to continue the session with the given price.
+// The price is switched only after the consumer accepts it, both peers switch it at the boundary sent in the request.
+func (manager *SessionManager) proposePrice(session *Session, engine PaymentEngine, price market.Price) error {
+	at := engine.PriceBoundary()
+
+	ctx, cancel := context.WithTimeout(context.Background(), manager.config.KeepAlive.SendTimeout)
+	defer cancel()
+
+	msg := &pb.PriceChangeRequest{
+		SessionID:         string(session.ID),
+		PerGib:            price.PricePerGiB.Bytes(),
+		PerHour:           price.PricePerHour.Bytes(),
+		BoundaryElapsedMs: at.Elapsed.Milliseconds(),
+		BoundaryBytes:     at.Bytes,
+	}
+	log.Debug().Msgf("Sending P2P message to %q: %s", p2p.TopicPriceChange, msg.String())
+	res, err := manager.channel.Send(ctx, p2p.TopicPriceChange, p2p.ProtoMessage(msg))
+	if err != nil {
+		return fmt.Errorf("could not send price change: %w", err)
+	}
+
+	var reply pb.PriceChangeResponse
+	if err := res.UnmarshalProto(&reply); err != nil {
+		return fmt.Errorf("could not unmarshal price change reply: %w", err)
+	}
+	if !reply.GetAccepted() {
+		return fmt.Errorf("%w: %s", ErrorPriceRejected, reply.GetReason())
+	}
+
+	engine.ChangePrice(price, at)
+	return nil
+}
+
 func (manager *SessionManager) paymentLoop(session *Session, price market.Price) error {
 	trace := session.tracer.StartStage("Provider session create (payment)")
 	defer session.tracer.EndStage(trace)
@@ -327,6 +390,11 @@ func (manager *SessionManager) paymentLoop(session *Session, price market.Price)
 		return err
 	}
 
+	session.setPaymentEngine(engine)
+	session.setPriceProposer(func(price market.Price) error {
+		return manager.proposePrice(session, engine, price)
+	})
+
 	// stop the balance tracker once the session is finished
 	session.addCleanup(func() error {
 		engine.Stop()
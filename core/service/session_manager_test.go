@@ -67,19 +67,31 @@ var (
 type mockBalanceTracker struct {
 	paymentError      error
 	firstPaymentError error
+	boundary          market.PriceBoundary
+	changedPrice      *market.Price
+	changedAt         market.PriceBoundary
 }
 
-func (m mockBalanceTracker) Start() error {
+func (m *mockBalanceTracker) Start() error {
 	return m.paymentError
 }
 
-func (m mockBalanceTracker) Stop() {
+func (m *mockBalanceTracker) Stop() {
 }
 
-func (m mockBalanceTracker) WaitFirstInvoice(time.Duration) error {
+func (m *mockBalanceTracker) WaitFirstInvoice(time.Duration) error {
 	return m.firstPaymentError
 }
 
+func (m *mockBalanceTracker) PriceBoundary() market.PriceBoundary {
+	return m.boundary
+}
+
+func (m *mockBalanceTracker) ChangePrice(price market.Price, at market.PriceBoundary) {
+	m.changedPrice = &price
+	m.changedAt = at
+}
+
 type mockP2PChannel struct {
 	tracer *trace.Tracer
 }
@@ -91,6 +103,13 @@ func (m *mockP2PChannel) Send(_ context.Context, _ string, _ *p2p.Message) (*p2p
 func (m *mockP2PChannel) Handle(topic string, handler p2p.HandlerFunc) {
 }
 
+func (m *mockP2PChannel) OpenStream(_ context.Context, _ string) (p2p.Stream, error) {
+	return nil, p2p.ErrStreamNotSupported
+}
+
+func (m *mockP2PChannel) HandleStream(_ string, _ p2p.StreamHandlerFunc) {
+}
+
 func (m *mockP2PChannel) Tracer() *trace.Tracer {
 	return m.tracer
 }
@@ -358,6 +377,55 @@ func TestManager_Start_RejectsInvalidPricing(t *testing.T) {
 	assert.Equal(t, "consumer asking for invalid price", err.Error())
 }
 
+func TestManager_ChangePrice(t *testing.T) {
+	publisher := mocks.NewEventBus()
+	sessionStore := NewSessionPool(publisher)
+	engine := &mockBalanceTracker{boundary: market.PriceBoundary{Elapsed: time.Hour - time.Second, Bytes: 50}}
+	manager := newManager(currentService, sessionStore, publisher, engine, true)
+
+	session, err := manager.Start(&pb.SessionRequest{
+		Consumer: &pb.ConsumerInfo{
+			Id:       consumerID.Address,
+			HermesID: hermesID.String(),
+			Pricing: &pb.Pricing{
+				PerGib:  big.NewInt(2).Bytes(),
+				PerHour: big.NewInt(2).Bytes(),
+			},
+		},
+		ProposalID: int64(currentProposalID),
+	})
+	assert.NoError(t, err)
+
+	price := *market.NewPrice(1, 1)
+	at := market.PriceBoundary{Elapsed: time.Hour, Bytes: 100}
+	err = manager.ChangePrice(consumerID, "unknown", price, at)
+	assert.Exactly(t, ErrorSessionNotExists, err)
+
+	err = manager.ChangePrice(identity.FromAddress("some other id"), session.ID, price, at)
+	assert.Exactly(t, ErrorWrongSessionOwner, err)
+	assert.Nil(t, engine.changedPrice)
+
+	manager.priceValidator = &mockPriceValidator{toReturn: false}
+	err = manager.ChangePrice(consumerID, session.ID, price, at)
+	assert.ErrorIs(t, err, ErrorPriceRejected)
+	assert.Nil(t, engine.changedPrice)
+
+	manager.priceValidator = &mockPriceValidator{toReturn: true}
+	err = manager.ChangePrice(consumerID, session.ID, price, market.PriceBoundary{Elapsed: 2 * time.Hour})
+	assert.ErrorIs(t, err, ErrorPriceRejected)
+	assert.Nil(t, engine.changedPrice)
+
+	// Consumer moves the boundary back to bill used traffic with the new price.
+	err = manager.ChangePrice(consumerID, session.ID, price, market.PriceBoundary{Elapsed: time.Hour - time.Minute, Bytes: 50})
+	assert.ErrorIs(t, err, ErrorPriceRejected)
+	assert.Nil(t, engine.changedPrice)
+
+	err = manager.ChangePrice(consumerID, session.ID, price, at)
+	assert.NoError(t, err)
+	assert.Equal(t, &price, engine.changedPrice)
+	assert.Equal(t, at, engine.changedAt)
+}
+
 type mockPriceValidator struct {
 	toReturn bool
 }
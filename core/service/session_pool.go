@@ -21,6 +21,7 @@ import (
 	"sync"
 
 	"github.com/mysteriumnetwork/node/identity"
+	"github.com/mysteriumnetwork/node/market"
 	"github.com/mysteriumnetwork/node/session"
 	"github.com/mysteriumnetwork/node/session/event"
 )
@@ -78,6 +79,16 @@ func (sp *SessionPool) Find(id session.ID) (*Session, bool) {
 	return instance, found
 }
 
+// ProposePrice asks the consumer of the given session to continue it with the given price.
+func (sp *SessionPool) ProposePrice(id session.ID, price market.Price) error {
+	instance, found := sp.Find(id)
+	if !found {
+		return ErrorSessionNotExists
+	}
+
+	return instance.proposePrice(price)
+}
+
 // FindOpts provides fields to search sessions.
 type FindOpts struct {
 	Peer        *identity.Identity
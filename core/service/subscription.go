@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/mysteriumnetwork/node/identity"
+	"github.com/mysteriumnetwork/node/market"
 	"github.com/mysteriumnetwork/node/p2p"
 	"github.com/mysteriumnetwork/node/pb"
 	"github.com/mysteriumnetwork/node/session/connectivity"
@@ -137,6 +138,30 @@ func subscribeSessionAcknowledge(mng *SessionManager, ch p2p.ChannelHandler) {
 	})
 }
 
+func subscribeSessionPriceChange(mng *SessionManager, ch p2p.ChannelHandler) {
+	ch.Handle(p2p.TopicPriceChange, func(c p2p.Context) error {
+		var req pb.PriceChangeRequest
+		if err := c.Request().UnmarshalProto(&req); err != nil {
+			return err
+		}
+
+		log.Debug().Msgf("Received P2P message for %q: %s", p2p.TopicPriceChange, req.String())
+
+		price := mng.remapPricing(&pb.Pricing{PerGib: req.GetPerGib(), PerHour: req.GetPerHour()})
+		reply := pb.PriceChangeResponse{Accepted: true}
+		at := market.PriceBoundary{
+			Elapsed: time.Duration(req.GetBoundaryElapsedMs()) * time.Millisecond,
+			Bytes:   req.GetBoundaryBytes(),
+		}
+		if err := mng.ChangePrice(c.PeerID(), req.GetSessionID(), price, at); err != nil {
+			log.Warn().Err(err).Msgf("Price change for session %s rejected", req.GetSessionID())
+			reply = pb.PriceChangeResponse{Reason: err.Error()}
+		}
+
+		return c.OkWithReply(p2p.ProtoMessage(&reply))
+	})
+}
+
 const bigIntBase int = 10
 
 func subscribeSessionPayments(mng *SessionManager, ch p2p.ChannelHandler) {
@@ -26,9 +26,9 @@ import (
 )
 
 type linuxShaper struct {
-	ws          *wondershaper.Shaper
-	listener    eventListener
-	listenTopic string
+	ws           *wondershaper.Shaper
+	listener     eventListener
+	listenTopics []string
 }
 
 type linuxShaperNoop struct{}
@@ -51,9 +51,12 @@ func create(listener eventListener) Shaper {
 	ws.Stdout = log.Logger
 	ws.Stderr = log.Logger
 	return &linuxShaper{
-		ws:          ws,
-		listener:    listener,
-		listenTopic: config.AppTopicConfig(config.FlagShaperEnabled.Name),
+		ws:       ws,
+		listener: listener,
+		listenTopics: []string{
+			config.AppTopicConfig(config.FlagShaperEnabled.Name),
+			config.AppTopicConfig(config.FlagShaperBandwidth.Name),
+		},
 	}
 }
 
@@ -77,9 +80,13 @@ func (s *linuxShaper) Start(interfaceName string) error {
 		return nil
 	}
 
-	err := s.listener.SubscribeAsync(s.listenTopic, applyLimits)
-	if err != nil {
-		return errors.Wrap(err, "could not subscribe to topic: "+s.listenTopic)
+	for _, topic := range s.listenTopics {
+		err := s.listener.SubscribeAsync(topic, func(config.AppEventConfigChanged) {
+			applyLimits()
+		})
+		if err != nil {
+			return errors.Wrap(err, "could not subscribe to topic: "+topic)
+		}
 	}
 
 	return applyLimits()
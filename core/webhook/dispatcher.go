@@ -0,0 +1,515 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+
+	"github.com/mysteriumnetwork/node/core/service/servicestate"
+	"github.com/mysteriumnetwork/node/eventbus"
+	"github.com/mysteriumnetwork/node/identity/registry"
+	"github.com/mysteriumnetwork/node/nat"
+	"github.com/mysteriumnetwork/node/nat/behavior"
+	sessionEvent "github.com/mysteriumnetwork/node/session/event"
+	pingpongEvent "github.com/mysteriumnetwork/node/session/pingpong/event"
+)
+
+// Headers set on every webhook request.
+const (
+	HeaderEvent     = "X-Myst-Event"
+	HeaderDelivery  = "X-Myst-Delivery"
+	HeaderTimestamp = "X-Myst-Timestamp"
+	HeaderSignature = "X-Myst-Signature"
+)
+
+const (
+	defaultMaxAttempts     = 10
+	defaultRetryBackoff    = 5 * time.Second
+	defaultMaxRetryBackoff = 10 * time.Minute
+	defaultMaxQueued       = 1000
+)
+
+// errPermanent marks responses which will not succeed on retry.
+var errPermanent = errors.New("webhook endpoint rejected the event")
+
+type httpClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Config describes webhook endpoints and delivery policy.
+type Config struct {
+	URLs   []string
+	Secret string
+	// Events limits delivered events, all events are delivered if empty.
+	Events          []string
+	MaxAttempts     int
+	RetryBackoff    time.Duration
+	MaxRetryBackoff time.Duration
+	// MaxQueued limits pending events per endpoint, the oldest ones are dropped past it.
+	MaxQueued int
+}
+
+// Dispatcher turns node events into signed HTTP webhooks. Every event is
+// written to a persistent outbox first and removed only after the endpoint
+// accepts it, failed deliveries are retried with exponential backoff.
+// Each endpoint is delivered to independently, so a slow or failing
+// endpoint does not hold back the others.
+type Dispatcher struct {
+	cfg       Config
+	client    httpClient
+	outbox    *outbox
+	events    map[string]bool
+	endpoints []*endpoint
+
+	stop     chan struct{}
+	wg       sync.WaitGroup
+	stopOnce sync.Once
+}
+
+// endpoint keeps the pending deliveries of a single webhook URL in memory,
+// the outbox is only read on start.
+type endpoint struct {
+	url  string
+	wake chan struct{}
+
+	mu    sync.Mutex
+	queue []*delivery
+}
+
+// NewDispatcher returns a new webhook dispatcher.
+func NewDispatcher(storage outboxStorage, client httpClient, cfg Config) *Dispatcher {
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = defaultMaxAttempts
+	}
+	if cfg.RetryBackoff <= 0 {
+		cfg.RetryBackoff = defaultRetryBackoff
+	}
+	if cfg.MaxRetryBackoff < cfg.RetryBackoff {
+		cfg.MaxRetryBackoff = defaultMaxRetryBackoff
+	}
+	if cfg.MaxQueued <= 0 {
+		cfg.MaxQueued = defaultMaxQueued
+	}
+
+	events := make(map[string]bool)
+	for _, e := range cfg.Events {
+		events[e] = true
+	}
+	var endpoints []*endpoint
+	for _, u := range cfg.URLs {
+		endpoints = append(endpoints, &endpoint{url: u, wake: make(chan struct{}, 1)})
+	}
+
+	return &Dispatcher{
+		cfg:       cfg,
+		client:    client,
+		outbox:    newOutbox(storage),
+		events:    events,
+		endpoints: endpoints,
+		stop:      make(chan struct{}),
+	}
+}
+
+// Subscribe subscribes the dispatcher to the node events it delivers.
+func (d *Dispatcher) Subscribe(bus eventbus.Subscriber) error {
+	subscription := map[string]interface{}{
+		sessionEvent.AppTopicSession:             d.handleSessionEvent,
+		pingpongEvent.AppTopicInvoicePaid:        d.handleInvoicePaid,
+		pingpongEvent.AppTopicSettlementComplete: d.handleSettlementComplete,
+		servicestate.AppTopicServiceStatus:       d.handleServiceStatus,
+		registry.AppTopicIdentityRegistration:    d.handleIdentityRegistration,
+		behavior.AppTopicNATTypeDetected:         d.handleNATType,
+	}
+
+	for topic, fn := range subscription {
+		if err := bus.SubscribeAsync(topic, fn); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Start starts delivering events from the outbox, including the ones
+// left over from the previous run.
+func (d *Dispatcher) Start() {
+	d.restore()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-d.stop
+		cancel()
+	}()
+
+	for _, e := range d.endpoints {
+		d.wg.Add(1)
+		go func(e *endpoint) {
+			defer d.wg.Done()
+			d.run(ctx, e)
+		}(e)
+	}
+}
+
+// Stop stops event delivery, pending events stay in the outbox.
+func (d *Dispatcher) Stop() {
+	d.stopOnce.Do(func() {
+		close(d.stop)
+		d.wg.Wait()
+	})
+}
+
+// restore loads deliveries left over from the previous run into the endpoint queues.
+func (d *Dispatcher) restore() {
+	pending, err := d.outbox.pending()
+	if err != nil {
+		log.Error().Err(err).Msg("Could not load webhook outbox")
+		return
+	}
+
+	for i := range pending {
+		dl := &pending[i]
+		e := d.endpoint(dl.URL)
+		if e == nil {
+			log.Warn().Msgf("Dropping webhook event %s for unconfigured endpoint %s", dl.ID, dl.URL)
+			d.discard(dl)
+			continue
+		}
+		d.push(e, dl)
+	}
+}
+
+func (d *Dispatcher) endpoint(url string) *endpoint {
+	for _, e := range d.endpoints {
+		if e.url == url {
+			return e
+		}
+	}
+	return nil
+}
+
+func (d *Dispatcher) handleSessionEvent(e sessionEvent.AppEventSession) {
+	switch e.Status {
+	case sessionEvent.CreatedStatus:
+		d.enqueue(EventSessionCreated, sessionData(e))
+	case sessionEvent.RemovedStatus:
+		d.enqueue(EventSessionEnded, sessionData(e))
+	}
+}
+
+func (d *Dispatcher) handleInvoicePaid(e pingpongEvent.AppEventInvoicePaid) {
+	d.enqueue(EventInvoicePaid, invoiceData(e))
+}
+
+func (d *Dispatcher) handleSettlementComplete(e pingpongEvent.AppEventSettlementComplete) {
+	d.enqueue(EventSettlementCompleted, settlementData(e))
+}
+
+func (d *Dispatcher) handleServiceStatus(e servicestate.AppEventServiceStatus) {
+	d.enqueue(EventServiceStatusChanged, serviceStatusData(e))
+}
+
+func (d *Dispatcher) handleIdentityRegistration(e registry.AppEventIdentityRegistration) {
+	d.enqueue(EventIdentityRegistration, registrationData(e))
+}
+
+func (d *Dispatcher) handleNATType(t nat.NATType) {
+	d.enqueue(EventNATTypeDetected, natTypeData(t))
+}
+
+func (d *Dispatcher) enqueue(event string, data interface{}) {
+	if len(d.events) > 0 && !d.events[event] {
+		return
+	}
+
+	id, err := uuid.NewV4()
+	if err != nil {
+		log.Error().Err(err).Msg("Could not generate webhook event ID")
+		return
+	}
+
+	now := time.Now().UTC()
+	body, err := json.Marshal(Payload{
+		ID:        id.String(),
+		Event:     event,
+		CreatedAt: now,
+		Data:      data,
+	})
+	if err != nil {
+		log.Error().Err(err).Msgf("Could not marshal webhook event %s", event)
+		return
+	}
+
+	for i, e := range d.endpoints {
+		dl := &delivery{
+			ID:          id.String() + "/" + strconv.Itoa(i),
+			URL:         e.url,
+			Event:       event,
+			Body:        body,
+			CreatedAt:   now,
+			NextAttempt: now,
+		}
+		if err := d.outbox.put(dl); err != nil {
+			log.Error().Err(err).Msgf("Could not queue webhook event %s", event)
+			continue
+		}
+		d.push(e, dl)
+
+		select {
+		case e.wake <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// push adds the delivery to the endpoint queue, dropping the oldest deliveries past the limit.
+func (d *Dispatcher) push(e *endpoint, dl *delivery) {
+	e.mu.Lock()
+	for _, queued := range e.queue {
+		// Events queued before the start are restored from the outbox too.
+		if queued.ID == dl.ID {
+			e.mu.Unlock()
+			return
+		}
+	}
+	i := sort.Search(len(e.queue), func(i int) bool {
+		return e.queue[i].CreatedAt.After(dl.CreatedAt)
+	})
+	e.queue = append(e.queue, nil)
+	copy(e.queue[i+1:], e.queue[i:])
+	e.queue[i] = dl
+
+	var dropped []*delivery
+	if over := len(e.queue) - d.cfg.MaxQueued; over > 0 {
+		dropped = append(dropped, e.queue[:over]...)
+		e.queue = append([]*delivery(nil), e.queue[over:]...)
+	}
+	e.mu.Unlock()
+
+	for _, dl := range dropped {
+		log.Warn().Msgf("Dropping webhook event %s, endpoint %s has more than %d pending events", dl.ID, e.url, d.cfg.MaxQueued)
+		d.discard(dl)
+	}
+}
+
+// due returns the deliveries which are due and the time of the earliest
+// scheduled retry, zero if there is nothing scheduled.
+func (e *endpoint) due(now time.Time) (due []*delivery, next time.Time) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for _, dl := range e.queue {
+		if now.Before(dl.NextAttempt) {
+			next = earliest(next, dl.NextAttempt)
+			continue
+		}
+		due = append(due, dl)
+	}
+	return due, next
+}
+
+// take removes the delivery from the queue, returns false if it was already dropped.
+func (e *endpoint) take(dl *delivery) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for i, queued := range e.queue {
+		if queued == dl {
+			e.queue = append(e.queue[:i], e.queue[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// reschedule sets the next attempt of the delivery, returns false if it was already dropped.
+func (e *endpoint) reschedule(dl *delivery, attempts int, next time.Time) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for _, queued := range e.queue {
+		if queued == dl {
+			dl.Attempts = attempts
+			dl.NextAttempt = next
+			return true
+		}
+	}
+	return false
+}
+
+func (d *Dispatcher) run(ctx context.Context, e *endpoint) {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-e.wake:
+		case <-timer.C:
+		}
+
+		next := d.deliverDue(ctx, e)
+
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+		if !next.IsZero() {
+			timer.Reset(time.Until(next))
+		}
+	}
+}
+
+// deliverDue sends the endpoint deliveries which are due and returns the time
+// of the earliest scheduled retry, zero if there is nothing left.
+func (d *Dispatcher) deliverDue(ctx context.Context, e *endpoint) time.Time {
+	due, next := e.due(time.Now())
+	for _, dl := range due {
+		if ctx.Err() != nil {
+			return time.Time{}
+		}
+
+		err := d.send(ctx, dl)
+		if err == nil {
+			if e.take(dl) {
+				d.discard(dl)
+			}
+			continue
+		}
+		if ctx.Err() != nil {
+			return time.Time{}
+		}
+
+		attempts := dl.Attempts + 1
+		if errors.Is(err, errPermanent) || attempts >= d.cfg.MaxAttempts {
+			log.Error().Err(err).Msgf("Dropping webhook event %s after %d attempts", dl.ID, attempts)
+			if e.take(dl) {
+				d.discard(dl)
+			}
+			continue
+		}
+
+		retryAt := time.Now().Add(d.backoff(attempts))
+		if !e.reschedule(dl, attempts, retryAt) {
+			continue
+		}
+		log.Warn().Err(err).Msgf("Webhook event %s delivery failed, retrying at %s", dl.ID, retryAt)
+		if err := d.outbox.put(d.snapshot(e, dl)); err != nil {
+			log.Error().Err(err).Msg("Could not reschedule webhook event")
+		}
+		next = earliest(next, retryAt)
+	}
+
+	return next
+}
+
+// snapshot copies the delivery under the endpoint lock, so it can be stored while the queue changes.
+func (d *Dispatcher) snapshot(e *endpoint, dl *delivery) *delivery {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	c := *dl
+	return &c
+}
+
+func (d *Dispatcher) discard(dl *delivery) {
+	if err := d.outbox.remove(dl); err != nil {
+		log.Error().Err(err).Msg("Could not remove webhook event from outbox")
+	}
+}
+
+func (d *Dispatcher) send(ctx context.Context, dl *delivery) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, dl.URL, bytes.NewReader(dl.Body))
+	if err != nil {
+		return fmt.Errorf("%w: %v", errPermanent, err)
+	}
+
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEvent, dl.Event)
+	req.Header.Set(HeaderDelivery, dl.ID)
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	if d.cfg.Secret != "" {
+		req.Header.Set(HeaderSignature, Sign(d.cfg.Secret, timestamp, dl.Body))
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return nil
+	case resp.StatusCode == http.StatusRequestTimeout, resp.StatusCode == http.StatusTooManyRequests:
+		return fmt.Errorf("endpoint responded with %d", resp.StatusCode)
+	case resp.StatusCode >= 400 && resp.StatusCode < 500:
+		return fmt.Errorf("%w: endpoint responded with %d", errPermanent, resp.StatusCode)
+	default:
+		return fmt.Errorf("endpoint responded with %d", resp.StatusCode)
+	}
+}
+
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	backoff := d.cfg.RetryBackoff
+	for i := 1; i < attempts; i++ {
+		backoff *= 2
+		if backoff >= d.cfg.MaxRetryBackoff {
+			return d.cfg.MaxRetryBackoff
+		}
+	}
+	return backoff
+}
+
+// Sign returns the signature header value for the given payload. Receivers
+// verify it by computing HMAC-SHA256 over "<timestamp>.<body>" with the
+// shared secret.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func earliest(a, b time.Time) time.Time {
+	if a.IsZero() || b.Before(a) {
+		return b
+	}
+	return a
+}
@@ -0,0 +1,312 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package webhook
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mysteriumnetwork/node/core/storage/boltdb"
+	"github.com/mysteriumnetwork/node/eventbus"
+	"github.com/mysteriumnetwork/node/identity"
+	"github.com/mysteriumnetwork/node/nat"
+	"github.com/mysteriumnetwork/node/nat/behavior"
+	sessionEvent "github.com/mysteriumnetwork/node/session/event"
+	pingpongEvent "github.com/mysteriumnetwork/node/session/pingpong/event"
+)
+
+type receivedRequest struct {
+	header http.Header
+	body   []byte
+}
+
+type testEndpoint struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	requests []receivedRequest
+	statuses []int
+}
+
+func newTestEndpoint(statuses ...int) *testEndpoint {
+	e := &testEndpoint{statuses: statuses}
+	e.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		e.mu.Lock()
+		defer e.mu.Unlock()
+		e.requests = append(e.requests, receivedRequest{header: r.Header, body: body})
+		status := http.StatusOK
+		if len(e.statuses) > 0 {
+			status, e.statuses = e.statuses[0], e.statuses[1:]
+		}
+		w.WriteHeader(status)
+	}))
+	return e
+}
+
+func (e *testEndpoint) received() []receivedRequest {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]receivedRequest(nil), e.requests...)
+}
+
+func newTestStorage(t *testing.T) *boltdb.Bolt {
+	dir, err := os.MkdirTemp("", "webhookTest")
+	require.NoError(t, err)
+	bolt, err := boltdb.NewStorage(dir)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		bolt.Close()
+		os.RemoveAll(dir)
+	})
+	return bolt
+}
+
+func pendingCount(t *testing.T, storage *boltdb.Bolt) int {
+	list, err := newOutbox(storage).pending()
+	require.NoError(t, err)
+	return len(list)
+}
+
+func TestDispatcher_DeliversSignedEvent(t *testing.T) {
+	endpoint := newTestEndpoint()
+	defer endpoint.Close()
+	storage := newTestStorage(t)
+
+	bus := eventbus.New()
+	dispatcher := NewDispatcher(storage, http.DefaultClient, Config{
+		URLs:   []string{endpoint.URL},
+		Secret: "secret",
+	})
+	require.NoError(t, dispatcher.Subscribe(bus))
+	dispatcher.Start()
+	defer dispatcher.Stop()
+
+	bus.Publish(pingpongEvent.AppTopicSettlementComplete, pingpongEvent.AppEventSettlementComplete{
+		ProviderID: identity.FromAddress("0x1"),
+		ChainID:    137,
+	})
+
+	assert.Eventually(t, func() bool { return len(endpoint.received()) == 1 }, 2*time.Second, 10*time.Millisecond)
+	req := endpoint.received()[0]
+
+	timestamp, err := strconv.ParseInt(req.header.Get(HeaderTimestamp), 10, 64)
+	require.NoError(t, err)
+	assert.Equal(t, Sign("secret", timestamp, req.body), req.header.Get(HeaderSignature))
+	assert.Equal(t, EventSettlementCompleted, req.header.Get(HeaderEvent))
+
+	var payload struct {
+		ID    string         `json:"id"`
+		Event string         `json:"event"`
+		Data  SettlementData `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(req.body, &payload))
+	assert.NotEmpty(t, payload.ID)
+	assert.Equal(t, EventSettlementCompleted, payload.Event)
+	assert.Equal(t, SettlementData{ProviderID: "0x1", HermesID: "0x0000000000000000000000000000000000000000", ChainID: 137}, payload.Data)
+
+	assert.Eventually(t, func() bool { return pendingCount(t, storage) == 0 }, 2*time.Second, 10*time.Millisecond)
+}
+
+func TestDispatcher_RetriesWithBackoff(t *testing.T) {
+	endpoint := newTestEndpoint(http.StatusInternalServerError, http.StatusBadGateway)
+	defer endpoint.Close()
+	storage := newTestStorage(t)
+
+	dispatcher := NewDispatcher(storage, http.DefaultClient, Config{
+		URLs:         []string{endpoint.URL},
+		RetryBackoff: 20 * time.Millisecond,
+	})
+	dispatcher.Start()
+	defer dispatcher.Stop()
+
+	dispatcher.handleNATType(nat.NATType("symmetric"))
+
+	assert.Eventually(t, func() bool { return len(endpoint.received()) == 3 }, 2*time.Second, 10*time.Millisecond)
+	received := endpoint.received()
+	assert.Equal(t, received[0].body, received[2].body)
+	assert.Equal(t, received[0].header.Get(HeaderDelivery), received[2].header.Get(HeaderDelivery))
+	assert.Eventually(t, func() bool { return pendingCount(t, storage) == 0 }, 2*time.Second, 10*time.Millisecond)
+}
+
+func TestDispatcher_DropsEvent(t *testing.T) {
+	t.Run("after max attempts", func(t *testing.T) {
+		endpoint := newTestEndpoint(http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusServiceUnavailable)
+		defer endpoint.Close()
+		storage := newTestStorage(t)
+
+		dispatcher := NewDispatcher(storage, http.DefaultClient, Config{
+			URLs:         []string{endpoint.URL},
+			MaxAttempts:  2,
+			RetryBackoff: 10 * time.Millisecond,
+		})
+		dispatcher.Start()
+		defer dispatcher.Stop()
+
+		dispatcher.handleNATType(nat.NATType("symmetric"))
+
+		assert.Eventually(t, func() bool { return pendingCount(t, storage) == 0 }, 2*time.Second, 10*time.Millisecond)
+		assert.Len(t, endpoint.received(), 2)
+	})
+
+	t.Run("rejected by endpoint", func(t *testing.T) {
+		endpoint := newTestEndpoint(http.StatusBadRequest)
+		defer endpoint.Close()
+		storage := newTestStorage(t)
+
+		dispatcher := NewDispatcher(storage, http.DefaultClient, Config{
+			URLs:         []string{endpoint.URL},
+			RetryBackoff: 10 * time.Millisecond,
+		})
+		dispatcher.Start()
+		defer dispatcher.Stop()
+
+		dispatcher.handleNATType(nat.NATType("symmetric"))
+
+		assert.Eventually(t, func() bool { return pendingCount(t, storage) == 0 }, 2*time.Second, 10*time.Millisecond)
+		assert.Len(t, endpoint.received(), 1)
+	})
+}
+
+func TestDispatcher_DeliversOutboxAfterRestart(t *testing.T) {
+	endpoint := newTestEndpoint()
+	defer endpoint.Close()
+	storage := newTestStorage(t)
+	cfg := Config{URLs: []string{endpoint.URL}}
+
+	stopped := NewDispatcher(storage, http.DefaultClient, cfg)
+	stopped.handleSessionEvent(sessionEvent.AppEventSession{
+		Status:  sessionEvent.CreatedStatus,
+		Session: sessionEvent.SessionContext{ID: "session-1"},
+	})
+	stopped.handleSessionEvent(sessionEvent.AppEventSession{
+		Status:  sessionEvent.AcknowledgedStatus,
+		Session: sessionEvent.SessionContext{ID: "session-1"},
+	})
+	assert.Equal(t, 1, pendingCount(t, storage))
+	assert.Empty(t, endpoint.received())
+
+	dispatcher := NewDispatcher(storage, http.DefaultClient, cfg)
+	dispatcher.Start()
+	defer dispatcher.Stop()
+
+	assert.Eventually(t, func() bool { return len(endpoint.received()) == 1 }, 2*time.Second, 10*time.Millisecond)
+	assert.Equal(t, EventSessionCreated, endpoint.received()[0].header.Get(HeaderEvent))
+	assert.Empty(t, endpoint.received()[0].header.Get(HeaderSignature))
+}
+
+func TestDispatcher_FiltersEvents(t *testing.T) {
+	endpoint := newTestEndpoint()
+	defer endpoint.Close()
+	storage := newTestStorage(t)
+
+	bus := eventbus.New()
+	dispatcher := NewDispatcher(storage, http.DefaultClient, Config{
+		URLs:   []string{endpoint.URL},
+		Events: []string{EventNATTypeDetected},
+	})
+	require.NoError(t, dispatcher.Subscribe(bus))
+
+	bus.Publish(pingpongEvent.AppTopicSettlementComplete, pingpongEvent.AppEventSettlementComplete{})
+	bus.Publish(behavior.AppTopicNATTypeDetected, nat.NATType("none"))
+
+	assert.Eventually(t, func() bool { return pendingCount(t, storage) == 1 }, 2*time.Second, 10*time.Millisecond)
+	pending, err := newOutbox(storage).pending()
+	require.NoError(t, err)
+	assert.Equal(t, EventNATTypeDetected, pending[0].Event)
+}
+
+func TestDispatcher_Backoff(t *testing.T) {
+	dispatcher := NewDispatcher(nil, nil, Config{
+		RetryBackoff:    time.Second,
+		MaxRetryBackoff: 10 * time.Second,
+	})
+
+	assert.Equal(t, time.Second, dispatcher.backoff(1))
+	assert.Equal(t, 2*time.Second, dispatcher.backoff(2))
+	assert.Equal(t, 8*time.Second, dispatcher.backoff(4))
+	assert.Equal(t, 10*time.Second, dispatcher.backoff(5))
+	assert.Equal(t, 10*time.Second, dispatcher.backoff(50))
+}
+
+func TestDispatcher_DropsOldestEventsPastLimit(t *testing.T) {
+	endpoint := newTestEndpoint()
+	defer endpoint.Close()
+	storage := newTestStorage(t)
+
+	dispatcher := NewDispatcher(storage, http.DefaultClient, Config{
+		URLs:      []string{endpoint.URL},
+		MaxQueued: 2,
+	})
+	for _, id := range []string{"session-1", "session-2", "session-3"} {
+		dispatcher.handleSessionEvent(sessionEvent.AppEventSession{
+			Status:  sessionEvent.CreatedStatus,
+			Session: sessionEvent.SessionContext{ID: id},
+		})
+	}
+	assert.Equal(t, 2, pendingCount(t, storage))
+
+	dispatcher.Start()
+	defer dispatcher.Stop()
+
+	assert.Eventually(t, func() bool { return len(endpoint.received()) == 2 }, 2*time.Second, 10*time.Millisecond)
+	var sessions []string
+	for _, req := range endpoint.received() {
+		var payload struct {
+			Data SessionData `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(req.body, &payload))
+		sessions = append(sessions, payload.Data.SessionID)
+	}
+	assert.Equal(t, []string{"session-2", "session-3"}, sessions)
+	assert.Eventually(t, func() bool { return pendingCount(t, storage) == 0 }, 2*time.Second, 10*time.Millisecond)
+}
+
+func TestDispatcher_DeliversToEndpointsIndependently(t *testing.T) {
+	blocked := make(chan struct{})
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-blocked
+	}))
+	defer slow.Close()
+	defer close(blocked)
+	endpoint := newTestEndpoint()
+	defer endpoint.Close()
+	storage := newTestStorage(t)
+
+	dispatcher := NewDispatcher(storage, http.DefaultClient, Config{
+		URLs: []string{slow.URL, endpoint.URL},
+	})
+	dispatcher.Start()
+	defer dispatcher.Stop()
+
+	dispatcher.handleNATType(nat.NATType("symmetric"))
+	dispatcher.handleNATType(nat.NATType("none"))
+
+	assert.Eventually(t, func() bool { return len(endpoint.received()) == 2 }, 2*time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,163 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package webhook
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/mysteriumnetwork/node/core/service/servicestate"
+	"github.com/mysteriumnetwork/node/identity/registry"
+	"github.com/mysteriumnetwork/node/nat"
+	sessionEvent "github.com/mysteriumnetwork/node/session/event"
+	pingpongEvent "github.com/mysteriumnetwork/node/session/pingpong/event"
+)
+
+// Event names delivered to webhook endpoints.
+const (
+	EventSessionCreated       = "session.created"
+	EventSessionEnded         = "session.ended"
+	EventInvoicePaid          = "invoice.paid"
+	EventSettlementCompleted  = "settlement.completed"
+	EventServiceStatusChanged = "service.status_changed"
+	EventIdentityRegistration = "identity.registration"
+	EventNATTypeDetected      = "nat.type_detected"
+)
+
+// Events lists all event names supported by webhooks.
+var Events = []string{
+	EventSessionCreated,
+	EventSessionEnded,
+	EventInvoicePaid,
+	EventSettlementCompleted,
+	EventServiceStatusChanged,
+	EventIdentityRegistration,
+	EventNATTypeDetected,
+}
+
+// Payload is the JSON body posted to webhook endpoints.
+type Payload struct {
+	ID        string      `json:"id"`
+	Event     string      `json:"event"`
+	CreatedAt time.Time   `json:"created_at"`
+	Data      interface{} `json:"data"`
+}
+
+// SessionData describes a session event.
+type SessionData struct {
+	SessionID   string    `json:"session_id"`
+	ServiceID   string    `json:"service_id"`
+	ServiceType string    `json:"service_type"`
+	ConsumerID  string    `json:"consumer_id"`
+	ProviderID  string    `json:"provider_id"`
+	HermesID    string    `json:"hermes_id"`
+	StartedAt   time.Time `json:"started_at"`
+}
+
+// InvoiceData describes a paid invoice.
+type InvoiceData struct {
+	SessionID      string   `json:"session_id"`
+	ConsumerID     string   `json:"consumer_id"`
+	ProviderID     string   `json:"provider_id"`
+	AgreementID    *big.Int `json:"agreement_id"`
+	AgreementTotal *big.Int `json:"agreement_total"`
+	TransactorFee  *big.Int `json:"transactor_fee"`
+	ChainID        int64    `json:"chain_id"`
+}
+
+// SettlementData describes a completed settlement.
+type SettlementData struct {
+	ProviderID string `json:"provider_id"`
+	HermesID   string `json:"hermes_id"`
+	ChainID    int64  `json:"chain_id"`
+}
+
+// ServiceStatusData describes a service status change.
+type ServiceStatusData struct {
+	ServiceID   string `json:"service_id"`
+	ProviderID  string `json:"provider_id"`
+	ServiceType string `json:"service_type"`
+	Status      string `json:"status"`
+}
+
+// RegistrationData describes an identity registration status change.
+type RegistrationData struct {
+	Identity string `json:"identity"`
+	Status   string `json:"status"`
+	ChainID  int64  `json:"chain_id"`
+}
+
+// NATTypeData describes a detected NAT type.
+type NATTypeData struct {
+	NATType string `json:"nat_type"`
+}
+
+func sessionData(e sessionEvent.AppEventSession) SessionData {
+	return SessionData{
+		SessionID:   e.Session.ID,
+		ServiceID:   e.Service.ID,
+		ServiceType: e.Session.Proposal.ServiceType,
+		ConsumerID:  e.Session.ConsumerID.Address,
+		ProviderID:  e.Session.Proposal.ProviderID,
+		HermesID:    e.Session.HermesID.Hex(),
+		StartedAt:   e.Session.StartedAt,
+	}
+}
+
+func invoiceData(e pingpongEvent.AppEventInvoicePaid) InvoiceData {
+	return InvoiceData{
+		SessionID:      e.SessionID,
+		ConsumerID:     e.ConsumerID.Address,
+		ProviderID:     e.Invoice.Provider,
+		AgreementID:    e.Invoice.AgreementID,
+		AgreementTotal: e.Invoice.AgreementTotal,
+		TransactorFee:  e.Invoice.TransactorFee,
+		ChainID:        e.Invoice.ChainID,
+	}
+}
+
+func settlementData(e pingpongEvent.AppEventSettlementComplete) SettlementData {
+	return SettlementData{
+		ProviderID: e.ProviderID.Address,
+		HermesID:   e.HermesID.Hex(),
+		ChainID:    e.ChainID,
+	}
+}
+
+func serviceStatusData(e servicestate.AppEventServiceStatus) ServiceStatusData {
+	return ServiceStatusData{
+		ServiceID:   e.ID,
+		ProviderID:  e.ProviderID,
+		ServiceType: e.Type,
+		Status:      e.Status,
+	}
+}
+
+func registrationData(e registry.AppEventIdentityRegistration) RegistrationData {
+	return RegistrationData{
+		Identity: e.ID.Address,
+		Status:   e.Status.String(),
+		ChainID:  e.ChainID,
+	}
+}
+
+func natTypeData(t nat.NATType) NATTypeData {
+	return NATTypeData{
+		NATType: string(t),
+	}
+}
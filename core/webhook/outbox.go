@@ -0,0 +1,80 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package webhook
+
+import (
+	"sort"
+	"time"
+
+	"github.com/asdine/storm/v3"
+	"github.com/pkg/errors"
+)
+
+const outboxBucket = "webhook-outbox"
+
+type outboxStorage interface {
+	Store(bucket string, data interface{}) error
+	GetAllFrom(bucket string, data interface{}) error
+	Delete(bucket string, data interface{}) error
+}
+
+// delivery is a single pending webhook request kept in the outbox
+// until the endpoint acknowledges it or attempts run out.
+type delivery struct {
+	ID          string `storm:"id"`
+	URL         string
+	Event       string
+	Body        []byte
+	Attempts    int
+	CreatedAt   time.Time
+	NextAttempt time.Time
+}
+
+// outbox persists pending deliveries so they survive node restarts.
+type outbox struct {
+	storage outboxStorage
+}
+
+func newOutbox(storage outboxStorage) *outbox {
+	return &outbox{storage: storage}
+}
+
+func (o *outbox) put(d *delivery) error {
+	return errors.Wrap(o.storage.Store(outboxBucket, d), "could not store webhook delivery")
+}
+
+func (o *outbox) remove(d *delivery) error {
+	err := o.storage.Delete(outboxBucket, d)
+	if err != nil && !errors.Is(err, storm.ErrNotFound) {
+		return errors.Wrap(err, "could not remove webhook delivery")
+	}
+	return nil
+}
+
+// pending returns all deliveries ordered by creation time.
+func (o *outbox) pending() ([]delivery, error) {
+	var list []delivery
+	err := o.storage.GetAllFrom(outboxBucket, &list)
+	if err != nil && !errors.Is(err, storm.ErrNotFound) {
+		return nil, errors.Wrap(err, "could not load webhook outbox")
+	}
+	sort.SliceStable(list, func(i, j int) bool {
+		return list[i].CreatedAt.Before(list[j].CreatedAt)
+	})
+	return list, nil
+}
@@ -0,0 +1,187 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package dns
+
+import (
+	"bufio"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+// BlocklistHandler is a DNS handler which refuses to resolve domains from blocklist files.
+type BlocklistHandler struct {
+	resolver dns.Handler
+	files    []string
+
+	mu       sync.RWMutex
+	domains  map[string]struct{}
+	modTimes map[string]time.Time
+
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// BlockDomains creates a DNS handler answering NXDOMAIN for the listed domains and their subdomains.
+// Files contain one domain per line, hosts file format is accepted as well.
+// Files are checked for changes and reloaded every reloadInterval, zero disables reloading.
+func BlockDomains(resolver dns.Handler, files []string, reloadInterval time.Duration) (*BlocklistHandler, error) {
+	bh := &BlocklistHandler{
+		resolver: resolver,
+		files:    files,
+		stop:     make(chan struct{}),
+	}
+	if err := bh.reload(); err != nil {
+		return nil, err
+	}
+
+	if reloadInterval > 0 {
+		go bh.reloadLoop(reloadInterval)
+	}
+	return bh, nil
+}
+
+// ServeDNS answers blocked queries or passes them to the resolver.
+func (bh *BlocklistHandler) ServeDNS(writer dns.ResponseWriter, req *dns.Msg) {
+	for _, q := range req.Question {
+		if bh.isBlocked(q.Name) {
+			log.Debug().Msgf("Blocked DNS query: %s", q.Name)
+
+			resp := &dns.Msg{}
+			resp.SetRcode(req, dns.RcodeNameError)
+			writer.WriteMsg(resp)
+			return
+		}
+	}
+
+	bh.resolver.ServeDNS(writer, req)
+}
+
+// Stop stops reloading blocklist files.
+func (bh *BlocklistHandler) Stop() {
+	bh.stopOnce.Do(func() {
+		close(bh.stop)
+	})
+}
+
+func (bh *BlocklistHandler) isBlocked(name string) bool {
+	name = strings.ToLower(strings.TrimSuffix(name, "."))
+
+	bh.mu.RLock()
+	defer bh.mu.RUnlock()
+
+	for {
+		if _, ok := bh.domains[name]; ok {
+			return true
+		}
+		i := strings.IndexByte(name, '.')
+		if i < 0 {
+			return false
+		}
+		name = name[i+1:]
+	}
+}
+
+func (bh *BlocklistHandler) reloadLoop(interval time.Duration) {
+	for {
+		select {
+		case <-bh.stop:
+			return
+		case <-time.After(interval):
+		}
+
+		if err := bh.reload(); err != nil {
+			log.Warn().Err(err).Msg("Failed to reload DNS blocklist, keeping the previous one")
+		}
+	}
+}
+
+// reload loads all blocklist files again if any of them has changed since the last load.
+func (bh *BlocklistHandler) reload() error {
+	modTimes := make(map[string]time.Time, len(bh.files))
+	changed := bh.domains == nil
+	for _, file := range bh.files {
+		info, err := os.Stat(file)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read DNS blocklist %s", file)
+		}
+		modTimes[file] = info.ModTime()
+		if !info.ModTime().Equal(bh.modTimes[file]) {
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+
+	domains := make(map[string]struct{})
+	for _, file := range bh.files {
+		if err := loadBlocklist(file, domains); err != nil {
+			return err
+		}
+	}
+
+	bh.mu.Lock()
+	bh.domains = domains
+	bh.modTimes = modTimes
+	bh.mu.Unlock()
+
+	log.Info().Msgf("Loaded %d domains to DNS blocklist", len(domains))
+	return nil
+}
+
+func loadBlocklist(file string, domains map[string]struct{}) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read DNS blocklist %s", file)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		// Hosts file entries start with an address, e.g. "0.0.0.0 ads.example.com".
+		if len(fields) > 1 && net.ParseIP(fields[0]) != nil {
+			fields = fields[1:]
+		}
+
+		for _, domain := range fields {
+			domain = strings.ToLower(strings.TrimSuffix(domain, "."))
+			if domain == "localhost" || net.ParseIP(domain) != nil {
+				continue
+			}
+			domains[domain] = struct{}{}
+		}
+	}
+
+	return errors.Wrapf(scanner.Err(), "failed to parse DNS blocklist %s", file)
+}
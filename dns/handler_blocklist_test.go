@@ -0,0 +1,103 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package dns
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_BlockDomains(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "blocklist")
+	content := "# ads\n" +
+		"ads.example.com\n" +
+		"0.0.0.0 tracker.net malware.org # hosts file entry\n" +
+		"127.0.0.1 localhost\n"
+	require.NoError(t, os.WriteFile(file, []byte(content), 0600))
+
+	resolved := 0
+	handler, err := BlockDomains(
+		dns.HandlerFunc(func(writer dns.ResponseWriter, req *dns.Msg) {
+			resolved++
+			resp := &dns.Msg{}
+			resp.SetReply(req)
+			writer.WriteMsg(resp)
+		}),
+		[]string{file},
+		0,
+	)
+	require.NoError(t, err)
+	defer handler.Stop()
+
+	tests := []struct {
+		name    string
+		blocked bool
+	}{
+		{"ads.example.com.", true},
+		{"cdn.ads.example.com.", true},
+		{"TRACKER.net.", true},
+		{"malware.org.", true},
+		{"example.com.", false},
+		{"localhost.", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &dns.Msg{}
+			req.SetQuestion(tt.name, dns.TypeA)
+			writer := &recordingWriter{}
+			handler.ServeDNS(writer, req)
+
+			if tt.blocked {
+				assert.Equal(t, dns.RcodeNameError, writer.responseMsg.Rcode)
+			} else {
+				assert.Equal(t, dns.RcodeSuccess, writer.responseMsg.Rcode)
+			}
+		})
+	}
+	assert.Equal(t, 2, resolved)
+}
+
+func Test_BlockDomainsReload(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "blocklist")
+	require.NoError(t, os.WriteFile(file, []byte("ads.example.com\n"), 0600))
+
+	handler, err := BlockDomains(dns.HandlerFunc(func(dns.ResponseWriter, *dns.Msg) {}), []string{file}, 0)
+	require.NoError(t, err)
+	assert.True(t, handler.isBlocked("ads.example.com."))
+
+	require.NoError(t, os.WriteFile(file, []byte("tracker.net\n"), 0600))
+	require.NoError(t, os.Chtimes(file, time.Now(), time.Now().Add(time.Minute)))
+	assert.NoError(t, handler.reload())
+	assert.False(t, handler.isBlocked("ads.example.com."))
+	assert.True(t, handler.isBlocked("tracker.net."))
+
+	require.NoError(t, os.Remove(file))
+	assert.Error(t, handler.reload())
+	assert.True(t, handler.isBlocked("tracker.net."), "should keep the previous blocklist")
+}
+
+func Test_BlockDomainsFailsWithoutFile(t *testing.T) {
+	_, err := BlockDomains(dns.HandlerFunc(func(dns.ResponseWriter, *dns.Msg) {}), []string{"/non/existing"}, 0)
+	assert.Error(t, err)
+}
@@ -0,0 +1,190 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package dns
+
+import (
+	"container/list"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+// maxCacheTTL limits how long an answer is kept even if upstream allows more.
+const maxCacheTTL = time.Hour
+
+// CacheAnswers creates a DNS handler which caches resolved answers for the duration of their TTL.
+// At most size answers are kept, the least recently used are evicted first.
+func CacheAnswers(resolver dns.Handler, size int) dns.Handler {
+	return &cacheHandler{
+		resolver: resolver,
+		size:     size,
+		entries:  make(map[cacheKey]*list.Element),
+		lru:      list.New(),
+		now:      time.Now,
+	}
+}
+
+type cacheKey struct {
+	name   string
+	qtype  uint16
+	qclass uint16
+}
+
+type cacheEntry struct {
+	key      cacheKey
+	msg      *dns.Msg
+	storedAt time.Time
+	expireAt time.Time
+}
+
+type cacheHandler struct {
+	resolver dns.Handler
+	size     int
+
+	mu      sync.Mutex
+	entries map[cacheKey]*list.Element
+	lru     *list.List
+	now     func() time.Time
+}
+
+func (ch *cacheHandler) ServeDNS(writer dns.ResponseWriter, req *dns.Msg) {
+	if len(req.Question) != 1 {
+		ch.resolver.ServeDNS(writer, req)
+		return
+	}
+
+	q := req.Question[0]
+	key := cacheKey{name: strings.ToLower(q.Name), qtype: q.Qtype, qclass: q.Qclass}
+	if resp, ok := ch.get(key, req); ok {
+		writer.WriteMsg(resp)
+		return
+	}
+
+	resolverWriter := &recordingWriter{writer: writer}
+	ch.resolver.ServeDNS(resolverWriter, req)
+	resp := resolverWriter.responseMsg
+	if resp == nil {
+		return
+	}
+
+	if ttl := cacheTTL(resp); ttl > 0 {
+		ch.put(key, resp.Copy(), ttl)
+	}
+	writer.WriteMsg(resp)
+}
+
+// get returns a copy of the cached answer with TTLs decreased by the time spent in cache.
+func (ch *cacheHandler) get(key cacheKey, req *dns.Msg) (*dns.Msg, bool) {
+	ch.mu.Lock()
+	defer ch.mu.Unlock()
+
+	el, ok := ch.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	entry := el.Value.(*cacheEntry)
+	now := ch.now()
+	if !now.Before(entry.expireAt) {
+		ch.lru.Remove(el)
+		delete(ch.entries, key)
+		return nil, false
+	}
+	ch.lru.MoveToFront(el)
+
+	resp := entry.msg.Copy()
+	resp.Id = req.Id
+	elapsed := uint32(now.Sub(entry.storedAt) / time.Second)
+	for _, section := range [][]dns.RR{resp.Answer, resp.Ns, resp.Extra} {
+		for _, rr := range section {
+			hdr := rr.Header()
+			if hdr.Rrtype == dns.TypeOPT {
+				continue
+			}
+			if hdr.Ttl > elapsed {
+				hdr.Ttl -= elapsed
+			} else {
+				hdr.Ttl = 0
+			}
+		}
+	}
+	return resp, true
+}
+
+func (ch *cacheHandler) put(key cacheKey, msg *dns.Msg, ttl time.Duration) {
+	ch.mu.Lock()
+	defer ch.mu.Unlock()
+
+	now := ch.now()
+	entry := &cacheEntry{key: key, msg: msg, storedAt: now, expireAt: now.Add(ttl)}
+	if el, ok := ch.entries[key]; ok {
+		el.Value = entry
+		ch.lru.MoveToFront(el)
+		return
+	}
+
+	ch.entries[key] = ch.lru.PushFront(entry)
+	for ch.lru.Len() > ch.size {
+		oldest := ch.lru.Back()
+		ch.lru.Remove(oldest)
+		delete(ch.entries, oldest.Value.(*cacheEntry).key)
+	}
+}
+
+// cacheTTL returns how long the response can be cached, zero means it must not be cached.
+// Negative answers are cached according to the SOA record as described in RFC 2308.
+func cacheTTL(resp *dns.Msg) time.Duration {
+	if resp.Truncated {
+		return 0
+	}
+
+	var ttl uint32
+	switch {
+	case resp.Rcode == dns.RcodeSuccess && len(resp.Answer) > 0:
+		ttl = minTTL(resp.Answer)
+	case resp.Rcode == dns.RcodeSuccess || resp.Rcode == dns.RcodeNameError:
+		for _, rr := range resp.Ns {
+			if soa, ok := rr.(*dns.SOA); ok {
+				ttl = soa.Hdr.Ttl
+				if soa.Minttl < ttl {
+					ttl = soa.Minttl
+				}
+			}
+		}
+	default:
+		return 0
+	}
+
+	d := time.Duration(ttl) * time.Second
+	if d > maxCacheTTL {
+		return maxCacheTTL
+	}
+	return d
+}
+
+func minTTL(records []dns.RR) uint32 {
+	ttl := records[0].Header().Ttl
+	for _, rr := range records[1:] {
+		if rr.Header().Ttl < ttl {
+			ttl = rr.Header().Ttl
+		}
+	}
+	return ttl
+}
@@ -0,0 +1,128 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package dns
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CacheAnswers(t *testing.T) {
+	now := time.Now()
+	calls := 0
+	handler := CacheAnswers(
+		dns.HandlerFunc(func(writer dns.ResponseWriter, req *dns.Msg) {
+			calls++
+			resp := &dns.Msg{}
+			resp.SetReply(req)
+			resp.Answer = []dns.RR{
+				&dns.A{
+					Hdr: dns.RR_Header{Name: req.Question[0].Name, Rrtype: dns.TypeA, Class: dns.ClassINET, Ttl: 60},
+					A:   net.ParseIP("0.0.0.1"),
+				},
+			}
+			writer.WriteMsg(resp)
+		}),
+		1,
+	).(*cacheHandler)
+	handler.now = func() time.Time { return now }
+
+	query := func(name string, id uint16) *dns.Msg {
+		req := &dns.Msg{}
+		req.SetQuestion(name, dns.TypeA)
+		req.Id = id
+		writer := &recordingWriter{}
+		handler.ServeDNS(writer, req)
+		return writer.responseMsg
+	}
+
+	resp := query("example.com.", 1)
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, uint32(60), resp.Answer[0].Header().Ttl)
+
+	now = now.Add(20 * time.Second)
+	resp = query("EXAMPLE.com.", 2)
+	assert.Equal(t, 1, calls, "should be answered from cache")
+	assert.Equal(t, uint16(2), resp.Id)
+	assert.Equal(t, uint32(40), resp.Answer[0].Header().Ttl)
+
+	now = now.Add(40 * time.Second)
+	query("example.com.", 3)
+	assert.Equal(t, 2, calls, "should expire after TTL")
+
+	query("other.com.", 4)
+	query("example.com.", 5)
+	assert.Equal(t, 4, calls, "should evict the least recently used answer")
+}
+
+func Test_CacheTTL(t *testing.T) {
+	soa := &dns.SOA{Hdr: dns.RR_Header{Rrtype: dns.TypeSOA, Ttl: 300}, Minttl: 30}
+
+	tests := []struct {
+		name string
+		resp *dns.Msg
+		ttl  time.Duration
+	}{
+		{
+			"should use minimal answer TTL",
+			&dns.Msg{Answer: []dns.RR{
+				&dns.A{Hdr: dns.RR_Header{Rrtype: dns.TypeA, Ttl: 120}},
+				&dns.A{Hdr: dns.RR_Header{Rrtype: dns.TypeA, Ttl: 60}},
+			}},
+			time.Minute,
+		},
+		{
+			"should limit TTL",
+			&dns.Msg{Answer: []dns.RR{&dns.A{Hdr: dns.RR_Header{Rrtype: dns.TypeA, Ttl: 86400}}}},
+			maxCacheTTL,
+		},
+		{
+			"should cache negative answer using SOA",
+			&dns.Msg{MsgHdr: dns.MsgHdr{Rcode: dns.RcodeNameError}, Ns: []dns.RR{soa}},
+			30 * time.Second,
+		},
+		{
+			"should not cache negative answer without SOA",
+			&dns.Msg{MsgHdr: dns.MsgHdr{Rcode: dns.RcodeNameError}},
+			0,
+		},
+		{
+			"should not cache server failure",
+			&dns.Msg{MsgHdr: dns.MsgHdr{Rcode: dns.RcodeServerFailure}, Ns: []dns.RR{soa}},
+			0,
+		},
+		{
+			"should not cache truncated answer",
+			&dns.Msg{
+				MsgHdr: dns.MsgHdr{Truncated: true},
+				Answer: []dns.RR{&dns.A{Hdr: dns.RR_Header{Rrtype: dns.TypeA, Ttl: 60}}},
+			},
+			0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.ttl, cacheTTL(tt.resp))
+		})
+	}
+}
@@ -0,0 +1,62 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package dns
+
+import (
+	"github.com/miekg/dns"
+
+	"github.com/mysteriumnetwork/node/config"
+)
+
+// ProviderHandler is the DNS handler chain served by providers to consumers:
+// the system resolver behind an answer cache and a domain blocklist, as configured by the DNS flags.
+type ProviderHandler struct {
+	dns.Handler
+	blocklist *BlocklistHandler
+}
+
+// NewProviderHandler creates the provider DNS handler chain from the current configuration.
+func NewProviderHandler() (*ProviderHandler, error) {
+	handler, err := ResolveViaSystem()
+	if err != nil {
+		return nil, err
+	}
+
+	if size := config.GetInt(config.FlagDNSCacheSize); size > 0 {
+		handler = CacheAnswers(handler, size)
+	}
+
+	ph := &ProviderHandler{}
+	if files := config.GetStringSlice(config.FlagDNSBlocklist); len(files) > 0 {
+		ph.blocklist, err = BlockDomains(handler, files, config.GetDuration(config.FlagDNSBlocklistReload))
+		if err != nil {
+			return nil, err
+		}
+		handler = ph.blocklist
+	}
+	ph.Handler = handler
+
+	return ph, nil
+}
+
+// Stop stops reloading of the blocklist files.
+func (ph *ProviderHandler) Stop() {
+	if ph.blocklist != nil {
+		ph.blocklist.Stop()
+	}
+}
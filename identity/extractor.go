@@ -18,6 +18,7 @@
 package identity
 
 import (
+	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/pkg/errors"
 )
@@ -36,12 +37,21 @@ type extractor struct{}
 
 // Extractor extracts identity which was used to sign given message
 func (extractor *extractor) Extract(message []byte, signature Signature) (Identity, error) {
+	return extractFromHash(messageHash(message), signature)
+}
+
+// extractText extracts identity which was used to sign given message with EIP-191 prefix.
+func extractText(message []byte, signature Signature) (Identity, error) {
+	return extractFromHash(accounts.TextHash(message), signature)
+}
+
+func extractFromHash(hash []byte, signature Signature) (Identity, error) {
 	signatureBytes := signature.Bytes()
 	if len(signatureBytes) == 0 {
 		return Identity{}, errors.New("empty signature")
 	}
 
-	recoveredKey, err := crypto.Ecrecover(messageHash(message), signatureBytes)
+	recoveredKey, err := crypto.Ecrecover(hash, signatureBytes)
 	if err != nil {
 		return Identity{}, err
 	}
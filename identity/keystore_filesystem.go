@@ -18,6 +18,7 @@
 package identity
 
 import (
+	"bytes"
 	"crypto/aes"
 	"crypto/cipher"
 	"crypto/ecdsa"
@@ -34,6 +35,7 @@ import (
 	ethKs "github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rs/zerolog/log"
 	"golang.org/x/crypto/hkdf"
 )
 
@@ -49,12 +51,32 @@ type ethKeystore interface {
 // NewKeystoreFilesystem create new keystore, which keeps keys in filesystem.
 func NewKeystoreFilesystem(directory string, ks ethKeystore) *Keystore {
 	return &Keystore{
-		ethKeystore: ks,
-		loadKey:     loadStoredKey,
-		unlocked:    make(map[common.Address]*unlocked),
+		ethKeystore:    ks,
+		loadKey:        loadStoredKey,
+		unlocked:       make(map[common.Address]*unlocked),
+		remoteUnlocked: make(map[common.Address][]byte),
 	}
 }
 
+// NewKeystoreWithRemoteSigner creates keystore which keeps new keys in the remote signer
+// and signs with them there, while keys already in filesystem keep working as before.
+func NewKeystoreWithRemoteSigner(directory string, ks ethKeystore, remote remoteSigner) *Keystore {
+	keystore := NewKeystoreFilesystem(directory, ks)
+	keystore.remote = remote
+	if err := keystore.refreshRemoteAccounts(); err != nil {
+		log.Warn().Err(err).Msg("Failed to list remote signer accounts")
+	}
+	return keystore
+}
+
+type remoteSigner interface {
+	Accounts() ([]common.Address, error)
+	NewAccount() (common.Address, error)
+	SignHash(address common.Address, hash []byte) ([]byte, error)
+	SignText(address common.Address, data []byte) ([]byte, error)
+	SignsHashes() bool
+}
+
 // Keystore handles everything that's related to eth accounts.
 type Keystore struct {
 	ethKeystore
@@ -62,6 +84,70 @@ type Keystore struct {
 
 	unlocked map[common.Address]*unlocked // Currently unlocked account (decrypted private keys)
 	mu       sync.RWMutex
+
+	remote         remoteSigner
+	remoteAccounts []common.Address
+	// remoteUnlocked holds encryption keys of unlocked remote accounts.
+	remoteUnlocked map[common.Address][]byte
+}
+
+// remoteEncryptionMessage is signed by remote accounts to derive their encryption keys.
+var remoteEncryptionMessage = []byte("Mysterium node keystore encryption key")
+
+// ErrRemoteSignerNotDeterministic is returned on unlock if remote signer produces different signatures
+// of the same hash, encryption key derived from the signature would change between unlocks.
+var ErrRemoteSignerNotDeterministic = errors.New("remote signer signatures are not deterministic")
+
+// Accounts returns accounts kept in filesystem and remote signer.
+func (ks *Keystore) Accounts() []accounts.Account {
+	list := ks.ethKeystore.Accounts()
+	if ks.remote == nil {
+		return list
+	}
+
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+	for _, addr := range ks.remoteAccounts {
+		list = append(list, remoteAccount(addr))
+	}
+	return list
+}
+
+// Find resolves the given account in filesystem or remote signer.
+func (ks *Keystore) Find(a accounts.Account) (accounts.Account, error) {
+	found, err := ks.ethKeystore.Find(a)
+	if err == nil || ks.remote == nil {
+		return found, err
+	}
+
+	if ks.isRemote(a.Address) {
+		return remoteAccount(a.Address), nil
+	}
+	// The account might be added to remote signer after we listed it.
+	if rerr := ks.refreshRemoteAccounts(); rerr != nil {
+		return found, err
+	}
+	if ks.isRemote(a.Address) {
+		return remoteAccount(a.Address), nil
+	}
+	return found, err
+}
+
+// NewAccount creates a new account. When remote signer is used, the account is created there
+// and passphrase is not used, as the signer protects its keys itself.
+func (ks *Keystore) NewAccount(passphrase string) (accounts.Account, error) {
+	if ks.remote == nil {
+		return ks.ethKeystore.NewAccount(passphrase)
+	}
+
+	addr, err := ks.remote.NewAccount()
+	if err != nil {
+		return accounts.Account{}, err
+	}
+	ks.mu.Lock()
+	ks.remoteAccounts = append(ks.remoteAccounts, addr)
+	ks.mu.Unlock()
+	return remoteAccount(addr), nil
 }
 
 // Unlock unlocks the given account indefinitely.
@@ -72,6 +158,7 @@ func (ks *Keystore) Unlock(a accounts.Account, passphrase string) error {
 // Lock removes the private key with the given address from memory.
 func (ks *Keystore) Lock(addr common.Address) error {
 	ks.mu.Lock()
+	delete(ks.remoteUnlocked, addr)
 	if unl, found := ks.unlocked[addr]; found {
 		ks.mu.Unlock()
 		ks.expire(addr, unl, time.Duration(0)*time.Nanosecond)
@@ -88,7 +175,14 @@ func (ks *Keystore) Lock(addr common.Address) error {
 // If the account address is already unlocked for a duration, TimedUnlock extends or
 // shortens the active unlock timeout. If the address was previously unlocked
 // indefinitely the timeout is not altered.
+//
+// Remote accounts are unlocked by checking that the remote signer signs with them,
+// the passphrase is not used and the timeout is not applied.
 func (ks *Keystore) TimedUnlock(a accounts.Account, passphrase string, timeout time.Duration) error {
+	if ks.isRemote(a.Address) {
+		return ks.unlockRemote(a.Address)
+	}
+
 	a, key, err := ks.getDecryptedKey(a, passphrase)
 	if err != nil {
 		return err
@@ -148,15 +242,7 @@ func (ks *Keystore) expire(addr common.Address, u *unlocked, timeout time.Durati
 
 // Encrypt takes a derived key for the given address and encrypts the plaintext.
 func (ks *Keystore) Encrypt(addr common.Address, plaintext []byte) ([]byte, error) {
-	ks.mu.RLock()
-	defer ks.mu.RUnlock()
-
-	key, found := ks.unlocked[addr]
-	if !found {
-		return nil, ethKs.ErrLocked
-	}
-
-	keyDerived, err := key.deriveKey()
+	keyDerived, err := ks.encryptionKey(addr)
 	if err != nil {
 		return nil, err
 	}
@@ -181,15 +267,7 @@ func (ks *Keystore) Encrypt(addr common.Address, plaintext []byte) ([]byte, erro
 
 // Decrypt takes a derived key for the given address and decrypts the encrypted message.
 func (ks *Keystore) Decrypt(addr common.Address, encrypted []byte) ([]byte, error) {
-	ks.mu.RLock()
-	defer ks.mu.RUnlock()
-
-	key, found := ks.unlocked[addr]
-	if !found {
-		return nil, ethKs.ErrLocked
-	}
-
-	keyDerived, err := key.deriveKey()
+	keyDerived, err := ks.encryptionKey(addr)
 	if err != nil {
 		return nil, err
 	}
@@ -216,6 +294,13 @@ func (ks *Keystore) Decrypt(addr common.Address, encrypted []byte) ([]byte, erro
 // SignHash calculates a ECDSA signature for the given hash. The produced
 // signature is in the [R || S || V] format where V is 0 or 1.
 func (ks *Keystore) SignHash(a accounts.Account, hash []byte) ([]byte, error) {
+	ks.mu.RLock()
+	_, remote := ks.remoteUnlocked[a.Address]
+	ks.mu.RUnlock()
+	if remote {
+		return ks.remote.SignHash(a.Address, hash)
+	}
+
 	// Look up the key to sign with and abort if it cannot be found
 	ks.mu.RLock()
 	defer ks.mu.RUnlock()
@@ -228,6 +313,98 @@ func (ks *Keystore) SignHash(a accounts.Account, hash []byte) ([]byte, error) {
 	return crypto.Sign(hash, unlockedKey.PrivateKey)
 }
 
+// SignMessage signs the given message of the identity. Remote signers which can not sign
+// plain hashes sign the message with EIP-191 prefix instead.
+func (ks *Keystore) SignMessage(a accounts.Account, message []byte) ([]byte, error) {
+	ks.mu.RLock()
+	_, remote := ks.remoteUnlocked[a.Address]
+	ks.mu.RUnlock()
+	if remote && !ks.remote.SignsHashes() {
+		return ks.remote.SignText(a.Address, message)
+	}
+
+	return ks.SignHash(a, messageHash(message))
+}
+
+func (ks *Keystore) encryptionKey(addr common.Address) ([]byte, error) {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+
+	if key, found := ks.remoteUnlocked[addr]; found {
+		return key, nil
+	}
+
+	key, found := ks.unlocked[addr]
+	if !found {
+		return nil, ethKs.ErrLocked
+	}
+	return key.deriveKey()
+}
+
+func (ks *Keystore) unlockRemote(addr common.Address) error {
+	// Same key is derived on every unlock only if the signer signs deterministically (RFC 6979).
+	sign := func() ([]byte, error) {
+		if ks.remote.SignsHashes() {
+			return ks.remote.SignHash(addr, messageHash(remoteEncryptionMessage))
+		}
+		return ks.remote.SignText(addr, remoteEncryptionMessage)
+	}
+	signature, err := sign()
+	if err != nil {
+		return err
+	}
+	repeated, err := sign()
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(signature, repeated) {
+		return ErrRemoteSignerNotDeterministic
+	}
+	key, err := deriveKey(signature[:64])
+	if err != nil {
+		return err
+	}
+
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+	ks.remoteUnlocked[addr] = key
+	return nil
+}
+
+func (ks *Keystore) isRemote(addr common.Address) bool {
+	if ks.remote == nil {
+		return false
+	}
+
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+	for _, remote := range ks.remoteAccounts {
+		if remote == addr {
+			return true
+		}
+	}
+	return false
+}
+
+func (ks *Keystore) refreshRemoteAccounts() error {
+	addresses, err := ks.remote.Accounts()
+	if err != nil {
+		return err
+	}
+
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+	ks.remoteAccounts = addresses
+	return nil
+}
+
+func remoteAccount(addr common.Address) accounts.Account {
+	return accounts.Account{
+		Address: addr,
+		URL:     accounts.URL{Scheme: "remote"},
+	}
+}
+
 // zeroKey zeroes a private key in memory.
 func zeroKey(k *ecdsa.PrivateKey) {
 	b := k.D.Bits()
@@ -242,8 +419,12 @@ type unlocked struct {
 }
 
 func (u *unlocked) deriveKey() ([]byte, error) {
+	return deriveKey(u.Key.PrivateKey.D.Bytes())
+}
+
+func deriveKey(secret []byte) ([]byte, error) {
 	hashFunc := sha512.New
-	hkdfDerived := hkdf.New(hashFunc, u.Key.PrivateKey.D.Bytes(), nil, nil)
+	hkdfDerived := hkdf.New(hashFunc, secret, nil, nil)
 	key := make([]byte, 32)
 	_, err := io.ReadFull(hkdfDerived, key)
 	return key, err
@@ -30,6 +30,11 @@ type Signer interface {
 	Sign(message []byte) (Signature, error)
 }
 
+// messageSigner is implemented by keystores which can not sign every message as a plain hash.
+type messageSigner interface {
+	SignMessage(a accounts.Account, message []byte) ([]byte, error)
+}
+
 type keystoreSigner struct {
 	keystore keystore
 	account  accounts.Account
@@ -47,7 +52,13 @@ func NewSigner(keystore keystore, identity Identity) Signer {
 
 // Sign signs given message and returns signature
 func (ksSigner *keystoreSigner) Sign(message []byte) (Signature, error) {
-	signature, err := ksSigner.keystore.SignHash(ksSigner.account, messageHash(message))
+	var signature []byte
+	var err error
+	if ms, ok := ksSigner.keystore.(messageSigner); ok {
+		signature, err = ms.SignMessage(ksSigner.account, message)
+	} else {
+		signature, err = ksSigner.keystore.SignHash(ksSigner.account, messageHash(message))
+	}
 	if err != nil {
 		return Signature{}, err
 	}
@@ -0,0 +1,176 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package identity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// RemoteSignerHashContentType is a content type of account_signData requests
+// asking remote signer to sign the given 32 byte hash as is, without EIP-191 prefix.
+const RemoteSignerHashContentType = "application/x-raw-hash"
+
+// RemoteSignerTextContentType is a content type of account_signData requests
+// asking remote signer to sign the given data with EIP-191 personal message prefix.
+const RemoteSignerTextContentType = "text/plain"
+
+const remoteSignerTimeout = 30 * time.Second
+
+// RemoteSignerMode tells which signatures the remote signer is able to make.
+type RemoteSignerMode string
+
+const (
+	// RemoteSignerModeHash is used with signers which sign plain hashes, all node signatures are made by them.
+	RemoteSignerModeHash RemoteSignerMode = "hash"
+	// RemoteSignerModeClef is used with stock Clef, which signs EIP-191 prefixed data only.
+	RemoteSignerModeClef RemoteSignerMode = "clef"
+)
+
+// ErrRemoteSignerHashUnsupported is returned when a plain hash signature is requested from a signer
+// which signs EIP-191 prefixed data only. Payment promises and transactor requests are verified
+// over plain hashes by the contracts, so they can not be signed by such signer.
+var ErrRemoteSignerHashUnsupported = errors.New("remote signer signs EIP-191 prefixed data only, plain hash signatures are not supported")
+
+// RemoteSigner signs with keys held by a separate signer process, reachable over HTTP or Unix socket.
+//
+// The signer has to serve these JSON-RPC methods of Clef external API:
+//   - account_list() returns addresses of the keys it holds,
+//   - account_new() creates a key and returns its address,
+//   - account_signData(contentType, address, data) returns a 65 byte [R || S || V]
+//     signature, V being 0, 1, 27 or 28. Signatures have to be deterministic (RFC 6979),
+//     data encryption key of the identity is derived from one.
+//
+// In RemoteSignerModeHash the signer signs plain hashes with RemoteSignerHashContentType.
+// In RemoteSignerModeClef only RemoteSignerTextContentType (EIP-191) is used, which stock Clef
+// supports. The node then signs its own messages with EIP-191, peers verify such signatures against
+// the expected identity, while plain hash signatures of payments fail with ErrRemoteSignerHashUnsupported.
+type RemoteSigner struct {
+	client  *rpc.Client
+	mode    RemoteSignerMode
+	timeout time.Duration
+}
+
+// NewRemoteSigner connects to remote signer at the given HTTP URL or Unix socket path.
+func NewRemoteSigner(address string, mode RemoteSignerMode) (*RemoteSigner, error) {
+	switch mode {
+	case RemoteSignerModeHash, RemoteSignerModeClef:
+	default:
+		return nil, fmt.Errorf("unknown remote signer mode %q", mode)
+	}
+
+	client, err := rpc.Dial(address)
+	if err != nil {
+		return nil, fmt.Errorf("could not connect to remote signer: %w", err)
+	}
+
+	return &RemoteSigner{
+		client:  client,
+		mode:    mode,
+		timeout: remoteSignerTimeout,
+	}, nil
+}
+
+// SignsHashes checks if the remote signer signs plain hashes.
+func (rs *RemoteSigner) SignsHashes() bool {
+	return rs.mode == RemoteSignerModeHash
+}
+
+// Accounts lists accounts held by remote signer.
+func (rs *RemoteSigner) Accounts() ([]common.Address, error) {
+	var addresses []common.Address
+	if err := rs.call(&addresses, "account_list"); err != nil {
+		return nil, err
+	}
+	return addresses, nil
+}
+
+// NewAccount asks remote signer to create a new account.
+func (rs *RemoteSigner) NewAccount() (common.Address, error) {
+	var address common.Address
+	err := rs.call(&address, "account_new")
+	return address, err
+}
+
+// SignHash asks remote signer to sign the given hash. The produced
+// signature is in the [R || S || V] format where V is 0 or 1.
+func (rs *RemoteSigner) SignHash(address common.Address, hash []byte) ([]byte, error) {
+	if !rs.SignsHashes() {
+		return nil, ErrRemoteSignerHashUnsupported
+	}
+
+	return rs.signData(address, RemoteSignerHashContentType, hash, hash)
+}
+
+// SignText asks remote signer to sign the given data with EIP-191 personal message prefix,
+// the signature is made over accounts.TextHash of the data. The produced signature is in
+// the [R || S || V] format where V is 0 or 1.
+func (rs *RemoteSigner) SignText(address common.Address, data []byte) ([]byte, error) {
+	hash := accounts.TextHash(data)
+	if rs.SignsHashes() {
+		return rs.signData(address, RemoteSignerHashContentType, hash, hash)
+	}
+
+	return rs.signData(address, RemoteSignerTextContentType, data, hash)
+}
+
+// signData requests the signature of data and checks that it is made by the address over the given hash.
+func (rs *RemoteSigner) signData(address common.Address, contentType string, data, hash []byte) ([]byte, error) {
+	var signature hexutil.Bytes
+	if err := rs.call(&signature, "account_signData", contentType, common.NewMixedcaseAddress(address), hexutil.Bytes(data)); err != nil {
+		return nil, err
+	}
+	if len(signature) != 65 {
+		return nil, fmt.Errorf("remote signer returned signature of invalid length %d", len(signature))
+	}
+	if signature[64] >= 27 {
+		signature[64] -= 27
+	}
+
+	pub, err := crypto.SigToPub(hash, signature)
+	if err != nil {
+		return nil, fmt.Errorf("remote signer returned invalid signature: %w", err)
+	}
+	if signer := crypto.PubkeyToAddress(*pub); signer != address {
+		return nil, fmt.Errorf("remote signer signed with %s instead of %s", signer.Hex(), address.Hex())
+	}
+	return signature, nil
+}
+
+// Close closes connection to remote signer.
+func (rs *RemoteSigner) Close() {
+	rs.client.Close()
+}
+
+func (rs *RemoteSigner) call(result interface{}, method string, args ...interface{}) error {
+	ctx, cancel := context.WithTimeout(context.Background(), rs.timeout)
+	defer cancel()
+
+	if err := rs.client.CallContext(ctx, result, method, args...); err != nil {
+		return fmt.Errorf("remote signer %s failed: %w", method, err)
+	}
+	return nil
+}
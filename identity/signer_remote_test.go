@@ -0,0 +1,297 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package identity
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"net"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	ethKs "github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRemoteSigner implements the account namespace served by remote signers.
+type fakeRemoteSigner struct {
+	mu   sync.Mutex
+	keys map[common.Address]*ecdsa.PrivateKey
+	// malleate alternates between the two valid signatures of a hash, making signing non-deterministic.
+	malleate bool
+	signed   int
+	// signWith signs with the given key instead of the requested account one.
+	signWith *ecdsa.PrivateKey
+	// clef signs EIP-191 prefixed data only, as stock Clef does.
+	clef bool
+}
+
+func (c *fakeRemoteSigner) List() []common.Address {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var list []common.Address
+	for addr := range c.keys {
+		list = append(list, addr)
+	}
+	return list
+}
+
+func (c *fakeRemoteSigner) New() (common.Address, error) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+	c.keys[addr] = key
+	return addr, nil
+}
+
+func (c *fakeRemoteSigner) SignData(contentType string, addr common.MixedcaseAddress, data hexutil.Bytes) (hexutil.Bytes, error) {
+	hash := []byte(data)
+	switch {
+	case c.clef && contentType == RemoteSignerTextContentType:
+		hash = accounts.TextHash(data)
+	case c.clef || contentType != RemoteSignerHashContentType:
+		return nil, errors.New("unsupported content type")
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	key, ok := c.keys[addr.Address()]
+	if !ok {
+		return nil, errors.New("unknown account")
+	}
+	if c.signWith != nil {
+		key = c.signWith
+	}
+
+	signature, err := crypto.Sign(hash, key)
+	if err != nil {
+		return nil, err
+	}
+	c.signed++
+	if c.malleate && c.signed%2 == 0 {
+		// (R, N - S) with flipped V is a valid signature of the same hash too.
+		n := crypto.S256().Params().N
+		s := new(big.Int).Sub(n, new(big.Int).SetBytes(signature[32:64]))
+		copy(signature[32:64], common.LeftPadBytes(s.Bytes(), 32))
+		signature[64] ^= 1
+	}
+	signature[64] += 27
+	return signature, nil
+}
+
+func newFakeRemoteSigner(t *testing.T) (*fakeRemoteSigner, *rpc.Server) {
+	signer := &fakeRemoteSigner{keys: make(map[common.Address]*ecdsa.PrivateKey)}
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("account", signer))
+	t.Cleanup(server.Stop)
+	return signer, server
+}
+
+func TestRemoteSigner(t *testing.T) {
+	_, server := newFakeRemoteSigner(t)
+
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	socket := filepath.Join(t.TempDir(), "signer.ipc")
+	listener, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+	go server.ServeListener(listener)
+	defer listener.Close()
+
+	for name, address := range map[string]string{"http": httpServer.URL, "unix socket": socket} {
+		t.Run(name, func(t *testing.T) {
+			remote, err := NewRemoteSigner(address, RemoteSignerModeHash)
+			require.NoError(t, err)
+			defer remote.Close()
+
+			addr, err := remote.NewAccount()
+			require.NoError(t, err)
+
+			list, err := remote.Accounts()
+			require.NoError(t, err)
+			assert.Contains(t, list, addr)
+
+			hash := messageHash([]byte(secretMessage))
+			signature, err := remote.SignHash(addr, hash)
+			require.NoError(t, err)
+			assert.Less(t, signature[64], byte(2))
+
+			pub, err := crypto.SigToPub(hash, signature)
+			require.NoError(t, err)
+			assert.Equal(t, addr, crypto.PubkeyToAddress(*pub))
+		})
+	}
+}
+
+func TestKeystore_RemoteSigner(t *testing.T) {
+	fake, server := newFakeRemoteSigner(t)
+	existing, err := fake.New()
+	require.NoError(t, err)
+
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+	remote, err := NewRemoteSigner(httpServer.URL, RemoteSignerModeHash)
+	require.NoError(t, err)
+	defer remote.Close()
+
+	ks := NewKeystoreWithRemoteSigner("", &ethKeystoreMock{account: encryptionAccount}, remote)
+	ks.loadKey = func(addr common.Address, filename, auth string) (*ethKs.Key, error) {
+		return &ethKs.Key{Address: addr, PrivateKey: encryptionKey}, nil
+	}
+
+	var addresses []common.Address
+	for _, a := range ks.Accounts() {
+		addresses = append(addresses, a.Address)
+	}
+	assert.ElementsMatch(t, []common.Address{encryptionAddress, existing}, addresses)
+
+	created, err := ks.NewAccount("ignored")
+	require.NoError(t, err)
+	found, err := ks.Find(created)
+	require.NoError(t, err)
+	assert.Equal(t, created.Address, found.Address)
+
+	// account added to the signer after listing is found as well
+	added, err := fake.New()
+	require.NoError(t, err)
+	_, err = ks.Find(remoteAccount(added))
+	assert.NoError(t, err)
+
+	signer := NewSigner(ks, FromAddress(created.Address.Hex()))
+	_, err = signer.Sign([]byte(secretMessage))
+	assert.ErrorIs(t, err, ethKs.ErrLocked)
+	_, err = ks.Encrypt(created.Address, []byte(secretMessage))
+	assert.ErrorIs(t, err, ethKs.ErrLocked)
+
+	require.NoError(t, ks.Unlock(created, ""))
+
+	signature, err := signer.Sign([]byte(secretMessage))
+	require.NoError(t, err)
+	ok, id := NewVerifierSigned().Verify([]byte(secretMessage), signature)
+	assert.True(t, ok)
+	assert.Equal(t, FromAddress(created.Address.Hex()), id)
+
+	encrypted, err := ks.Encrypt(created.Address, []byte(secretMessage))
+	require.NoError(t, err)
+
+	// encryption key is the same after unlocking again
+	require.NoError(t, ks.Lock(created.Address))
+	require.NoError(t, ks.Unlock(created, ""))
+	decrypted, err := ks.Decrypt(created.Address, encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, secretMessage, string(decrypted))
+
+	// filesystem accounts keep working
+	require.NoError(t, ks.Unlock(encryptionAccount, ""))
+	_, err = NewSigner(ks, FromAddress(encryptionAddress.Hex())).Sign([]byte(secretMessage))
+	assert.NoError(t, err)
+}
+
+func TestRemoteSigner_RejectsSignatureOfOtherKey(t *testing.T) {
+	fake, server := newFakeRemoteSigner(t)
+	addr, err := fake.New()
+	require.NoError(t, err)
+	fake.signWith, err = crypto.GenerateKey()
+	require.NoError(t, err)
+
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+	remote, err := NewRemoteSigner(httpServer.URL, RemoteSignerModeHash)
+	require.NoError(t, err)
+	defer remote.Close()
+
+	_, err = remote.SignHash(addr, messageHash([]byte(secretMessage)))
+	assert.Error(t, err)
+}
+
+func TestKeystore_RemoteSignerNotDeterministic(t *testing.T) {
+	fake, server := newFakeRemoteSigner(t)
+	addr, err := fake.New()
+	require.NoError(t, err)
+	fake.malleate = true
+
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+	remote, err := NewRemoteSigner(httpServer.URL, RemoteSignerModeHash)
+	require.NoError(t, err)
+	defer remote.Close()
+
+	ks := NewKeystoreWithRemoteSigner("", &ethKeystoreMock{account: encryptionAccount}, remote)
+	err = ks.Unlock(remoteAccount(addr), "")
+	assert.ErrorIs(t, err, ErrRemoteSignerNotDeterministic)
+
+	_, err = ks.Encrypt(addr, []byte(secretMessage))
+	assert.ErrorIs(t, err, ethKs.ErrLocked)
+}
+
+func TestKeystore_RemoteSignerClef(t *testing.T) {
+	fake, server := newFakeRemoteSigner(t)
+	fake.clef = true
+
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+	remote, err := NewRemoteSigner(httpServer.URL, RemoteSignerModeClef)
+	require.NoError(t, err)
+	defer remote.Close()
+
+	ks := NewKeystoreWithRemoteSigner("", &ethKeystoreMock{account: encryptionAccount}, remote)
+	created, err := ks.NewAccount("ignored")
+	require.NoError(t, err)
+	require.NoError(t, ks.Unlock(created, ""))
+
+	// identity messages are signed with EIP-191 prefix and verified against the expected identity
+	id := FromAddress(created.Address.Hex())
+	signature, err := NewSigner(ks, id).Sign([]byte(secretMessage))
+	require.NoError(t, err)
+	ok, _ := NewVerifierIdentity(id).Verify([]byte(secretMessage), signature)
+	assert.True(t, ok)
+	ok, _ = NewVerifierIdentity(FromAddress(encryptionAddress.Hex())).Verify([]byte(secretMessage), signature)
+	assert.False(t, ok)
+
+	// plain hashes of payments can not be signed
+	_, err = ks.SignHash(created, messageHash([]byte(secretMessage)))
+	assert.ErrorIs(t, err, ErrRemoteSignerHashUnsupported)
+
+	encrypted, err := ks.Encrypt(created.Address, []byte(secretMessage))
+	require.NoError(t, err)
+	require.NoError(t, ks.Lock(created.Address))
+	require.NoError(t, ks.Unlock(created, ""))
+	decrypted, err := ks.Decrypt(created.Address, encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, secretMessage, string(decrypted))
+}
+
+func TestNewRemoteSigner_UnknownMode(t *testing.T) {
+	_, err := NewRemoteSigner("http://localhost:8550", RemoteSignerMode("eip712"))
+	assert.Error(t, err)
+}
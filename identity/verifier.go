@@ -36,6 +36,9 @@ func NewVerifierSigned() *verifierSigned {
 //   - checks signature's sanity
 //   - checks if message was unchanged by middleman
 //   - checks if message is from exact identity
+//
+// Messages signed with EIP-191 prefix by remote signers are accepted too,
+// the signer is known, so there is no ambiguity which hash was signed.
 func NewVerifierIdentity(peerID Identity) *verifierIdentity {
 	return &verifierIdentity{NewExtractor(), peerID}
 }
@@ -59,6 +62,12 @@ func (verifier *verifierIdentity) Verify(message []byte, signature Signature) (b
 	if err != nil {
 		return false, identity
 	}
+	if identity == verifier.peerID {
+		return true, identity
+	}
 
-	return identity == verifier.peerID, identity
+	if textIdentity, err := extractText(message, signature); err == nil && textIdentity == verifier.peerID {
+		return true, textIdentity
+	}
+	return false, identity
 }
@@ -74,3 +74,33 @@ func NewPrice(perHour, perGiB int64) *Price {
 func (p Price) String() string {
 	return p.PricePerHour.String() + "/h, " + p.PricePerGiB.String() + "/GiB "
 }
+
+// PriceBoundary marks the point of a session from which a new price is applied.
+// Both session peers switch the price at the same boundary, so their totals match.
+type PriceBoundary struct {
+	// Elapsed is the session duration billed with the previous price.
+	Elapsed time.Duration
+	// Bytes is the total data (up and down) billed with the previous price.
+	Bytes uint64
+}
+
+// PriceBoundaryTolerance is the largest difference between the peers' views of a price boundary
+// which is still accepted when the price is switched. It is kept small, as the proposing peer
+// could otherwise move the boundary back and bill traffic already used with the new price.
+var PriceBoundaryTolerance = PriceBoundary{Elapsed: 5 * time.Second, Bytes: 5 << 20}
+
+// Near reports whether the boundary differs from the other one by no more than the given tolerance.
+// Peers count session time and data independently, so their views of the same point differ slightly.
+func (b PriceBoundary) Near(other PriceBoundary, tolerance PriceBoundary) bool {
+	elapsed := b.Elapsed - other.Elapsed
+	if elapsed < 0 {
+		elapsed = -elapsed
+	}
+
+	bytes := b.Bytes - other.Bytes
+	if other.Bytes > b.Bytes {
+		bytes = other.Bytes - b.Bytes
+	}
+
+	return elapsed <= tolerance.Elapsed && bytes <= tolerance.Bytes
+}
@@ -18,6 +18,7 @@
 package market
 
 import (
+	"bytes"
 	"encoding/json"
 
 	validation "github.com/go-ozzo/ozzo-validation"
@@ -56,8 +57,18 @@ type ServiceProposal struct {
 
 	// Quality represents the service quality.
 	Quality Quality `json:"quality"`
+
+	// Signature of the canonical proposal body made with the provider identity key
+	Signature string `json:"signature,omitempty"`
+
+	// raw is the JSON a signed proposal was decoded from, it keeps fields unknown to this node
+	// until the signature is verified.
+	raw json.RawMessage
 }
 
+// unsignedProposalFields are filled in by discovery and are not covered by the provider signature.
+var unsignedProposalFields = []string{"id", "quality", "signature"}
+
 // NewProposalOpts optional params for the new proposal creation.
 type NewProposalOpts struct {
 	Location       *Location
@@ -111,6 +122,59 @@ func (proposal *ServiceProposal) UniqueID() ProposalID {
 	}
 }
 
+// CanonicalBody returns the proposal body covered by the provider signature.
+// Decoded proposals use the JSON they were received as, so fields added by newer nodes stay covered.
+// Fields filled in by discovery (ID and quality) and the signature itself are left out, keys are sorted.
+func (proposal ServiceProposal) CanonicalBody() ([]byte, error) {
+	data := proposal.raw
+	if data == nil {
+		var err error
+		if data, err = proposal.MarshalJSON(); err != nil {
+			return nil, err
+		}
+	}
+
+	var body map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&body); err != nil {
+		return nil, err
+	}
+	for _, field := range unsignedProposalFields {
+		delete(body, field)
+	}
+	return json.Marshal(body)
+}
+
+// DiscardReceivedJSON drops the JSON the proposal was decoded from. It is called once the signature
+// is verified, so that later changes of the proposal are not hidden behind the received copy.
+func (proposal *ServiceProposal) DiscardReceivedJSON() {
+	proposal.raw = nil
+}
+
+// MarshalJSON encodes the proposal, keeping the fields unknown to this node if it was decoded from JSON.
+func (proposal ServiceProposal) MarshalJSON() ([]byte, error) {
+	type plainProposal ServiceProposal
+	data, err := json.Marshal(plainProposal(proposal))
+	if err != nil || proposal.raw == nil {
+		return data, err
+	}
+
+	var known, received map[string]json.RawMessage
+	if err := json.Unmarshal(data, &known); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(proposal.raw, &received); err != nil {
+		return nil, err
+	}
+	for field, value := range received {
+		if _, ok := known[field]; !ok {
+			known[field] = value
+		}
+	}
+	return json.Marshal(known)
+}
+
 // UnmarshalJSON is custom json unmarshaler to dynamically fill in ServiceProposal values
 func (proposal *ServiceProposal) UnmarshalJSON(data []byte) error {
 	var jsonData struct {
@@ -123,6 +187,7 @@ func (proposal *ServiceProposal) UnmarshalJSON(data []byte) error {
 		Contacts       *json.RawMessage `json:"contacts"`
 		AccessPolicies *[]AccessPolicy  `json:"access_policies,omitempty"`
 		Quality        Quality          `json:"quality"`
+		Signature      string           `json:"signature,omitempty"`
 	}
 	if err := json.Unmarshal(data, &jsonData); err != nil {
 		return err
@@ -139,6 +204,11 @@ func (proposal *ServiceProposal) UnmarshalJSON(data []byte) error {
 	proposal.Contacts = unserializeContacts(jsonData.Contacts)
 	proposal.AccessPolicies = jsonData.AccessPolicies
 	proposal.Quality = jsonData.Quality
+	proposal.Signature = jsonData.Signature
+	// Received JSON is needed only to verify the signature.
+	if proposal.Signature != "" {
+		proposal.raw = append(json.RawMessage(nil), data...)
+	}
 
 	return nil
 }
@@ -52,7 +52,7 @@ func Test_ServiceProposal_Serialize(t *testing.T) {
 	assert.Nil(t, err)
 
 	expectedJSON := `{
-      "compatibility": 2,
+      "compatibility": 3,
 	  "format": "service-proposal/v3",
 	  "service_type": "mock_service",
 	  "provider_id": "node",
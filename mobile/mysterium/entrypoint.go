@@ -94,6 +94,7 @@ type MobileNode struct {
 	entertainmentEstimator    *entertainment.Estimator
 	residentCountry           *identity.ResidentCountry
 	filterPresetStorage       *proposal.FilterPresetStorage
+	providerListStorage       *proposal.ProviderListStorage
 	hermesMigrator            *migration.HermesMigrator
 	servicesManager           *service.Manager
 	earningsProvider          earningsProvider
@@ -295,9 +296,12 @@ func NewNode(appPath string, options *MobileNodeOptions) (*MobileNode, error) {
 			Address: options.QualityOracleURL,
 		},
 		Discovery: node.OptionsDiscovery{
-			Types:        []node.DiscoveryType{node.DiscoveryTypeAPI},
-			Address:      network.DiscoveryAddress,
-			FetchEnabled: false,
+			Types:            []node.DiscoveryType{node.DiscoveryTypeAPI},
+			Address:          network.DiscoveryAddress,
+			FetchEnabled:     false,
+			RequireSignature: true,
+			Snapshot:         true,
+			SnapshotInterval: 30 * time.Minute,
 			DHT: node.OptionsDHT{
 				Address:        "0.0.0.0",
 				Port:           0,
@@ -353,6 +357,7 @@ func NewNode(appPath string, options *MobileNodeOptions) (*MobileNode, error) {
 	}
 	if options.IsProvider {
 		nodeOptions.Discovery.FetchEnabled = true
+		nodeOptions.Discovery.Snapshot = false
 		nodeOptions.Discovery.PingInterval = config.GetDuration(config.FlagDiscoveryPingInterval)
 		nodeOptions.Discovery.FetchInterval = config.GetDuration(config.FlagDiscoveryFetchInterval)
 		nodeOptions.Payments = node.OptionsPayments{
@@ -417,6 +422,7 @@ func NewNode(appPath string, options *MobileNodeOptions) (*MobileNode, error) {
 		proposalsManager: newProposalsManager(
 			di.ProposalRepository,
 			di.FilterPresetStorage,
+			di.ProviderListStorage,
 			di.NATProber,
 			time.Duration(options.CacheTTLSeconds)*time.Second,
 		),
@@ -432,6 +438,7 @@ func NewNode(appPath string, options *MobileNodeOptions) (*MobileNode, error) {
 		),
 		residentCountry:     di.ResidentCountry,
 		filterPresetStorage: di.FilterPresetStorage,
+		providerListStorage: di.ProviderListStorage,
 		hermesMigrator:      di.HermesMigrator,
 		earningsProvider:    di.HermesChannelRepository,
 	}
@@ -635,6 +642,7 @@ func (mb *MobileNode) Connect(req *ConnectRequest) *ConnectResponse {
 		IPType:                  req.IPType,
 		IncludeMonitoringFailed: req.IncludeMonitoringFailed,
 		ExcludeUnsupported:      true,
+		ConsumerID:              identity.FromAddress(req.IdentityAddress).Address,
 	}
 
 	proposalLookup := connection.FilteredProposals(f, req.SortBy, mb.proposalsManager.repository)
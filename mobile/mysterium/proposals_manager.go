@@ -25,6 +25,7 @@ import (
 
 	"github.com/mysteriumnetwork/node/core/discovery/proposal"
 	"github.com/mysteriumnetwork/node/core/quality"
+	"github.com/mysteriumnetwork/node/identity"
 	"github.com/mysteriumnetwork/node/market"
 	"github.com/mysteriumnetwork/node/money"
 	"github.com/mysteriumnetwork/node/nat"
@@ -67,8 +68,11 @@ type GetProposalsRequest struct {
 	QualityMin       float32
 	PresetID         int
 	NATCompatibility string
+	// ConsumerID excludes blocked providers of the consumer and marks favourite ones.
+	ConsumerID string
 }
 
+// toFilter does not include consumer, so cached proposals can be shared between consumers.
 func (r GetProposalsRequest) toFilter() *proposal.Filter {
 	return &proposal.Filter{
 		PresetID:           r.PresetID,
@@ -94,6 +98,7 @@ type proposalDTO struct {
 	IPType       string               `json:"ip_type"`
 	QualityLevel proposalQualityLevel `json:"quality_level"`
 	Price        proposalPrice        `json:"price"`
+	Favourite    bool                 `json:"favourite,omitempty"`
 }
 
 type proposalPrice struct {
@@ -129,12 +134,14 @@ type natProber interface {
 func newProposalsManager(
 	repository proposalRepository,
 	filterPresetStorage *proposal.FilterPresetStorage,
+	providerLists proposal.ProviderListRepository,
 	natProber natProber,
 	cacheTTL time.Duration,
 ) *proposalsManager {
 	return &proposalsManager{
 		repository:          repository,
 		filterPresetStorage: filterPresetStorage,
+		providerLists:       providerLists,
 		cacheTTL:            cacheTTL,
 		natProber:           natProber,
 	}
@@ -146,6 +153,7 @@ type proposalsManager struct {
 	cachedAt            time.Time
 	cacheTTL            time.Duration
 	filterPresetStorage *proposal.FilterPresetStorage
+	providerLists       proposal.ProviderListRepository
 	natProber           natProber
 }
 
@@ -167,20 +175,28 @@ func (m *proposalsManager) getProposals(req *GetProposalsRequest) (*getProposals
 		m.addToCache(apiProposals)
 	}
 
-	filteredProposals, err := m.applyFilter(req.PresetID, m.getFromCache())
+	filteredProposals, err := m.applyFilter(req, m.getFromCache())
 	if err != nil {
 		return nil, err
 	}
 	return m.map2Response(filteredProposals)
 }
 
-func (m *proposalsManager) applyFilter(presetID int, proposals []proposal.PricedServiceProposal) ([]proposal.PricedServiceProposal, error) {
-	if presetID != 0 {
-		preset, err := m.filterPresetStorage.Get(presetID)
+func (m *proposalsManager) applyFilter(req *GetProposalsRequest, proposals []proposal.PricedServiceProposal) ([]proposal.PricedServiceProposal, error) {
+	if req.PresetID != 0 {
+		preset, err := m.filterPresetStorage.Get(req.PresetID)
 		if err != nil {
 			return nil, err
 		}
-		return preset.Filter(proposals), nil
+		proposals = preset.Filter(proposals)
+	}
+
+	if req.ConsumerID != "" && m.providerLists != nil {
+		lists, err := m.providerLists.Get(identity.FromAddress(req.ConsumerID).Address)
+		if err != nil {
+			return nil, err
+		}
+		proposals = lists.Apply(proposals)
 	}
 
 	return proposals, nil
@@ -265,6 +281,7 @@ func (m *proposalsManager) mapProposal(p *proposal.PricedServiceProposal) *propo
 		ProviderID:   p.ProviderID,
 		ServiceType:  p.ServiceType,
 		QualityLevel: proposalQualityLevelUnknown,
+		Favourite:    p.Favourite,
 		Price: proposalPrice{
 			Currency: money.CurrencyMyst.String(),
 			PerGiB:   perGib,
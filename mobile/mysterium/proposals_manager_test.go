@@ -45,6 +45,7 @@ func (s *proposalManagerTestSuite) SetupTest() {
 	s.proposalsManager = newProposalsManager(
 		s.repository,
 		nil,
+		nil,
 		&mockNATProber{"none", nil},
 		60*time.Second,
 	)
@@ -136,6 +137,35 @@ func (s *proposalManagerTestSuite) TestGetProposalsFromAPIWhenNotFoundInCache()
 	}`, string(bytes))
 }
 
+func (s *proposalManagerTestSuite) TestGetProposalsAppliesProviderLists() {
+	s.proposalsManager.providerLists = &mockProviderLists{lists: proposal.ProviderLists{
+		Favourites: []string{"p1"},
+		Blocked:    []string{"p2"},
+	}}
+	s.proposalsManager.cachedAt = time.Now().Add(1 * time.Hour)
+	s.proposalsManager.cache = []proposal.PricedServiceProposal{
+		{
+			ServiceProposal: market.NewProposal("p1", "wireguard", market.NewProposalOpts{}),
+			Price:           market.Price{PricePerHour: big.NewInt(1), PricePerGiB: big.NewInt(2)},
+		},
+		{
+			ServiceProposal: market.NewProposal("p2", "wireguard", market.NewProposalOpts{}),
+			Price:           market.Price{PricePerHour: big.NewInt(1), PricePerGiB: big.NewInt(2)},
+		},
+	}
+
+	proposals, err := s.proposalsManager.getProposals(&GetProposalsRequest{})
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), proposals.Proposals, 2)
+
+	proposals, err = s.proposalsManager.getProposals(&GetProposalsRequest{ConsumerID: "0xC"})
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), proposals.Proposals, 1)
+	assert.Equal(s.T(), "p1", proposals.Proposals[0].ProviderID)
+	assert.True(s.T(), proposals.Proposals[0].Favourite)
+	assert.Equal(s.T(), "0xc", s.proposalsManager.providerLists.(*mockProviderLists).consumerID)
+}
+
 func TestProposalManagerSuite(t *testing.T) {
 	suite.Run(t, new(proposalManagerTestSuite))
 }
@@ -167,3 +197,13 @@ type mockNATProber struct {
 func (m *mockNATProber) Probe(_ context.Context) (nat.NATType, error) {
 	return m.returnRes, m.returnErr
 }
+
+type mockProviderLists struct {
+	consumerID string
+	lists      proposal.ProviderLists
+}
+
+func (m *mockProviderLists) Get(consumerID string) (proposal.ProviderLists, error) {
+	m.consumerID = consumerID
+	return m.lists, nil
+}
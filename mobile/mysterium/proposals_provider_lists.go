@@ -0,0 +1,50 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package mysterium
+
+import (
+	"encoding/json"
+
+	"github.com/mysteriumnetwork/node/identity"
+)
+
+// ProviderLists represents providers a consumer marked as favourite or blocked.
+type ProviderLists struct {
+	Favourites []string `json:"favourites"`
+	Blocked    []string `json:"blocked"`
+}
+
+// GetProviderLists returns favourite and blocked providers of the consumer.
+// see ProviderLists for contract
+func (mb *MobileNode) GetProviderLists(consumerID string) ([]byte, error) {
+	lists, err := mb.providerListStorage.Get(identity.FromAddress(consumerID).Address)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(ProviderLists{Favourites: lists.Favourites, Blocked: lists.Blocked})
+}
+
+// SetFavouriteProvider adds or removes provider from favourites of the consumer.
+func (mb *MobileNode) SetFavouriteProvider(consumerID, providerID string, favourite bool) error {
+	return mb.providerListStorage.SetFavourite(identity.FromAddress(consumerID).Address, identity.FromAddress(providerID).Address, favourite)
+}
+
+// SetBlockedProvider blocks or unblocks provider for the consumer.
+func (mb *MobileNode) SetBlockedProvider(consumerID, providerID string, blocked bool) error {
+	return mb.providerListStorage.SetBlocked(identity.FromAddress(consumerID).Address, identity.FromAddress(providerID).Address, blocked)
+}
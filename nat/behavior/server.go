@@ -0,0 +1,167 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package behavior
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+
+	"github.com/pion/stun"
+	"github.com/rs/zerolog/log"
+)
+
+// CHANGE-REQUEST flags from RFC 5780 Section 7.2
+const (
+	changeIPFlag   = 0x04
+	changePortFlag = 0x02
+)
+
+// Server is a STUN server supporting NAT behavior discovery from RFC 5780.
+// It listens on every combination of the primary and alternate IPs and ports
+// to be able to respond from a changed address or port.
+type Server struct {
+	// conns are indexed by [ip][port], where 0 is primary and 1 is alternate.
+	conns [2][2]*net.UDPConn
+	wg    sync.WaitGroup
+	once  sync.Once
+}
+
+// ListenServer starts listening for STUN requests on the primary and alternate
+// addresses, which must differ both in IP and port.
+func ListenServer(primary, alternate string) (*Server, error) {
+	p, err := net.ResolveUDPAddr("udp4", primary)
+	if err != nil {
+		return nil, fmt.Errorf("invalid primary address: %w", err)
+	}
+	a, err := net.ResolveUDPAddr("udp4", alternate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid alternate address: %w", err)
+	}
+	if p.IP == nil || a.IP == nil || p.IP.IsUnspecified() || a.IP.IsUnspecified() {
+		return nil, errors.New("primary and alternate IPs must be specified")
+	}
+	if p.IP.Equal(a.IP) || p.Port == a.Port {
+		return nil, errors.New("primary and alternate addresses must differ in both IP and port")
+	}
+
+	s := &Server{}
+	ips := [2]net.IP{p.IP, a.IP}
+	ports := [2]int{p.Port, a.Port}
+	for i, ip := range ips {
+		for j, port := range ports {
+			conn, err := net.ListenUDP("udp4", &net.UDPAddr{IP: ip, Port: port})
+			if err != nil {
+				s.Close()
+				return nil, fmt.Errorf("could not listen on %s:%d: %w", ip, port, err)
+			}
+			s.conns[i][j] = conn
+		}
+	}
+
+	for i := range s.conns {
+		for j := range s.conns[i] {
+			s.wg.Add(1)
+			go s.serve(i, j)
+		}
+	}
+
+	log.Info().Msgf("STUN server listening on %s and %s", p, a)
+	return s, nil
+}
+
+// Close stops the server.
+func (s *Server) Close() error {
+	s.once.Do(func() {
+		for i := range s.conns {
+			for _, conn := range s.conns[i] {
+				if conn != nil {
+					conn.Close()
+				}
+			}
+		}
+	})
+	s.wg.Wait()
+	return nil
+}
+
+func (s *Server) serve(ip, port int) {
+	defer s.wg.Done()
+
+	conn := s.conns[ip][port]
+	buf := make([]byte, 1500)
+	for {
+		n, addr, err := conn.ReadFromUDP(buf)
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return
+			}
+			continue
+		}
+
+		if err := s.handle(buf[:n], addr, ip, port); err != nil {
+			log.Trace().Err(err).Msgf("Failed to handle STUN request from %s", addr)
+		}
+	}
+}
+
+func (s *Server) handle(data []byte, from *net.UDPAddr, ip, port int) error {
+	if !stun.IsMessage(data) {
+		return errors.New("not a STUN message")
+	}
+
+	req := &stun.Message{Raw: append([]byte(nil), data...)}
+	if err := req.Decode(); err != nil {
+		return err
+	}
+	if req.Type != stun.BindingRequest {
+		return fmt.Errorf("unsupported message type: %s", req.Type)
+	}
+
+	respIP, respPort := ip, port
+	if change, err := req.Get(stun.AttrChangeRequest); err == nil && len(change) == 4 {
+		if change[3]&changeIPFlag != 0 {
+			respIP = 1 - ip
+		}
+		if change[3]&changePortFlag != 0 {
+			respPort = 1 - port
+		}
+	}
+
+	respConn := s.conns[respIP][respPort]
+	origin := respConn.LocalAddr().(*net.UDPAddr)
+	other := s.conns[1-ip][1-port].LocalAddr().(*net.UDPAddr)
+
+	resp, err := stun.Build(
+		stun.NewTransactionIDSetter(req.TransactionID),
+		stun.BindingSuccess,
+		&stun.XORMappedAddress{IP: from.IP, Port: from.Port},
+		&stun.MappedAddress{IP: from.IP, Port: from.Port},
+		&stun.ResponseOrigin{IP: origin.IP, Port: origin.Port},
+		&stun.OtherAddress{IP: other.IP, Port: other.Port},
+		stun.NewSoftware("myst"),
+		stun.Fingerprint,
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = respConn.WriteToUDP(resp.Raw, from)
+	return err
+}
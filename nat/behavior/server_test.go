@@ -0,0 +1,94 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package behavior
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pion/stun"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mysteriumnetwork/node/nat"
+)
+
+func TestServer_DiscoverNATBehavior(t *testing.T) {
+	server, err := ListenServer("127.0.0.1:43478", "127.0.0.2:43479")
+	require.NoError(t, err)
+	defer server.Close()
+
+	ctx := context.Background()
+
+	filtering, err := DiscoverNATFiltering(ctx, "127.0.0.1:43478", time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, FilteringIndependent, filtering)
+
+	natType, err := DiscoverNATBehavior(ctx, "127.0.0.1:43478", time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, nat.NATTypeNone, natType)
+}
+
+func TestServer_RespondsFromChangedAddress(t *testing.T) {
+	server, err := ListenServer("127.0.0.1:43480", "127.0.0.2:43481")
+	require.NoError(t, err)
+	defer server.Close()
+
+	conn, err := connect("127.0.0.1:43480")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	for _, tc := range []struct {
+		change []byte
+		origin string
+	}{
+		{nil, "127.0.0.1:43480"},
+		{changeRequestPort, "127.0.0.1:43481"},
+		{changeRequestAddressPort, "127.0.0.2:43481"},
+	} {
+		request := stun.MustBuild(stun.TransactionID, stun.BindingRequest)
+		if tc.change != nil {
+			request.Add(stun.AttrChangeRequest, tc.change)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		resp, err := conn.roundTrip(ctx, request, conn.RemoteAddr)
+		cancel()
+		require.NoError(t, err)
+
+		var origin stun.ResponseOrigin
+		require.NoError(t, origin.GetFrom(resp))
+		assert.Equal(t, tc.origin, origin.String())
+
+		other := parse(resp).otherAddr
+		require.NotNil(t, other)
+		assert.Equal(t, "127.0.0.2:43481", other.String())
+	}
+}
+
+func TestListenServer_RequiresDistinctAddresses(t *testing.T) {
+	_, err := ListenServer("127.0.0.1:43478", "127.0.0.1:43479")
+	assert.Error(t, err)
+
+	_, err = ListenServer("127.0.0.1:43478", "127.0.0.2:43478")
+	assert.Error(t, err)
+
+	_, err = ListenServer("0.0.0.0:43478", "127.0.0.2:43479")
+	assert.Error(t, err)
+}
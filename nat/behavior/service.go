@@ -58,10 +58,15 @@ type ConnectionStatusProvider interface {
 }
 
 // NewNATProber constructs some suitable NATProber without any implementation
-// guarantees.
-func NewNATProber(connStatusProvider ConnectionStatusProvider, eventbus eventbus.Publisher) NATProber {
+// guarantees. Given RFC 5780 compatible servers are used for probing,
+// the default ones are used if none are given.
+func NewNATProber(connStatusProvider ConnectionStatusProvider, eventbus eventbus.Publisher, servers []string) NATProber {
+	if len(servers) == 0 {
+		servers = compatibleSTUNServers
+	}
+
 	var prober NATProber
-	prober = newConcurrentNATProber(compatibleSTUNServers, concurrentRequestTimeout)
+	prober = newConcurrentNATProber(servers, concurrentRequestTimeout)
 	prober = newGatedNATProber(connStatusProvider, eventbus, prober)
 	return prober
 }
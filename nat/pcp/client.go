@@ -0,0 +1,318 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package pcp
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jackpal/gateway"
+)
+
+// ServerPort is the port PCP and NAT-PMP servers listen on.
+const ServerPort = 5351
+
+const (
+	versionNATPMP = 0
+	versionPCP    = 2
+
+	opExternalAddress = 0
+	opMap             = 1
+	opResponse        = 0x80
+
+	resultSuccess            = 0
+	resultUnsupportedVersion = 1
+
+	pcpHeaderSize           = 24
+	pcpMapPayloadSize       = 36
+	natpmpMapRespSize       = 16
+	natpmpAddrRespSize      = 12
+	defaultTimeout          = 250 * time.Millisecond
+	defaultAttempts         = 2
+	maxResponseSize         = 1100
+	protocolNumberUDP       = 17
+	protocolNumberTCP       = 6
+	natpmpOpMapUDP          = 1
+	natpmpOpMapTCP          = 2
+	unknownVersion     byte = 0xff
+)
+
+var (
+	// ErrNoResponse is returned when the gateway does not answer PCP nor NAT-PMP requests.
+	ErrNoResponse = errors.New("no response from gateway")
+
+	errUnsupportedVersion = errors.New("unsupported protocol version")
+)
+
+// Mapping describes a port mapping created on the gateway.
+type Mapping struct {
+	Protocol     string
+	InternalPort int
+	ExternalPort int
+	ExternalIP   net.IP
+	Lifetime     time.Duration
+}
+
+// Client requests port mappings from the gateway. PCP (RFC 6887) is tried
+// first and the client falls back to NAT-PMP (RFC 6886) if the gateway
+// reports it does not speak PCP.
+type Client struct {
+	gateway  *net.UDPAddr
+	timeout  time.Duration
+	attempts int
+
+	mu      sync.Mutex
+	version byte
+	nonces  map[string][12]byte
+}
+
+// NewClient returns a client talking to the given PCP or NAT-PMP server address.
+func NewClient(gateway *net.UDPAddr) *Client {
+	return &Client{
+		gateway:  gateway,
+		timeout:  defaultTimeout,
+		attempts: defaultAttempts,
+		version:  unknownVersion,
+		nonces:   make(map[string][12]byte),
+	}
+}
+
+// Discover returns a client for the default gateway.
+func Discover() (*Client, error) {
+	ip, err := gateway.DiscoverGateway()
+	if err != nil {
+		return nil, fmt.Errorf("could not discover gateway: %w", err)
+	}
+	return NewClient(&net.UDPAddr{IP: ip, Port: ServerPort}), nil
+}
+
+// AddMapping creates or renews a mapping of the internal port. The gateway
+// may assign a different external port and lifetime than suggested.
+func (c *Client) AddMapping(protocol string, internalPort, externalPort int, lifetime time.Duration) (Mapping, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.version != versionNATPMP {
+		m, err := c.mapPCP(protocol, internalPort, externalPort, lifetime)
+		if !errors.Is(err, errUnsupportedVersion) {
+			if err == nil {
+				c.version = versionPCP
+			}
+			return m, err
+		}
+		c.version = versionNATPMP
+	}
+
+	return c.mapNATPMP(protocol, internalPort, externalPort, lifetime)
+}
+
+// DeleteMapping removes the mapping of the internal port.
+func (c *Client) DeleteMapping(protocol string, internalPort int) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var err error
+	if c.version == versionNATPMP {
+		_, err = c.mapNATPMP(protocol, internalPort, 0, 0)
+	} else {
+		_, err = c.mapPCP(protocol, internalPort, 0, 0)
+	}
+	delete(c.nonces, nonceKey(protocol, internalPort))
+	return err
+}
+
+func (c *Client) mapPCP(protocol string, internalPort, externalPort int, lifetime time.Duration) (Mapping, error) {
+	proto, err := protocolNumber(protocol)
+	if err != nil {
+		return Mapping{}, err
+	}
+
+	// Renewals and deletion must reuse the nonce of the mapping.
+	key := nonceKey(protocol, internalPort)
+	nonce, ok := c.nonces[key]
+	if !ok {
+		if _, err := rand.Read(nonce[:]); err != nil {
+			return Mapping{}, err
+		}
+	}
+
+	conn, err := net.DialUDP("udp", nil, c.gateway)
+	if err != nil {
+		return Mapping{}, err
+	}
+	defer conn.Close()
+
+	req := make([]byte, pcpHeaderSize+pcpMapPayloadSize)
+	req[0] = versionPCP
+	req[1] = opMap
+	binary.BigEndian.PutUint32(req[4:8], uint32(lifetime/time.Second))
+	copy(req[8:24], conn.LocalAddr().(*net.UDPAddr).IP.To16())
+	copy(req[24:36], nonce[:])
+	req[36] = proto
+	binary.BigEndian.PutUint16(req[40:42], uint16(internalPort))
+	binary.BigEndian.PutUint16(req[42:44], uint16(externalPort))
+	copy(req[44:60], net.IPv6zero)
+
+	resp, err := c.exchange(conn, req, func(resp []byte) bool {
+		if len(resp) >= 4 && resp[0] == versionNATPMP {
+			// NAT-PMP servers answer unknown versions with their own header.
+			return true
+		}
+		return len(resp) >= pcpHeaderSize+pcpMapPayloadSize &&
+			resp[1] == opMap|opResponse &&
+			bytes.Equal(resp[24:36], nonce[:])
+	})
+	if err != nil {
+		return Mapping{}, err
+	}
+	if resp[0] != versionPCP {
+		return Mapping{}, errUnsupportedVersion
+	}
+	if result := resp[3]; result != resultSuccess {
+		if result == resultUnsupportedVersion {
+			return Mapping{}, errUnsupportedVersion
+		}
+		return Mapping{}, fmt.Errorf("PCP mapping failed with result code %d", result)
+	}
+
+	if lifetime > 0 {
+		c.nonces[key] = nonce
+	}
+	return Mapping{
+		Protocol:     protocol,
+		InternalPort: int(binary.BigEndian.Uint16(resp[40:42])),
+		ExternalPort: int(binary.BigEndian.Uint16(resp[42:44])),
+		ExternalIP:   net.IP(resp[44:60]).To16(),
+		Lifetime:     time.Duration(binary.BigEndian.Uint32(resp[4:8])) * time.Second,
+	}, nil
+}
+
+func (c *Client) mapNATPMP(protocol string, internalPort, externalPort int, lifetime time.Duration) (Mapping, error) {
+	op := byte(natpmpOpMapUDP)
+	if strings.EqualFold(protocol, "TCP") {
+		op = natpmpOpMapTCP
+	} else if !strings.EqualFold(protocol, "UDP") {
+		return Mapping{}, fmt.Errorf("unsupported protocol %s", protocol)
+	}
+
+	conn, err := net.DialUDP("udp", nil, c.gateway)
+	if err != nil {
+		return Mapping{}, err
+	}
+	defer conn.Close()
+
+	req := make([]byte, 12)
+	req[1] = op
+	binary.BigEndian.PutUint16(req[4:6], uint16(internalPort))
+	binary.BigEndian.PutUint16(req[6:8], uint16(externalPort))
+	binary.BigEndian.PutUint32(req[8:12], uint32(lifetime/time.Second))
+
+	resp, err := c.exchange(conn, req, func(resp []byte) bool {
+		return len(resp) >= natpmpMapRespSize &&
+			resp[0] == versionNATPMP &&
+			resp[1] == op|opResponse &&
+			binary.BigEndian.Uint16(resp[8:10]) == uint16(internalPort)
+	})
+	if err != nil {
+		return Mapping{}, err
+	}
+	if result := binary.BigEndian.Uint16(resp[2:4]); result != resultSuccess {
+		return Mapping{}, fmt.Errorf("NAT-PMP mapping failed with result code %d", result)
+	}
+
+	m := Mapping{
+		Protocol:     protocol,
+		InternalPort: internalPort,
+		ExternalPort: int(binary.BigEndian.Uint16(resp[10:12])),
+		Lifetime:     time.Duration(binary.BigEndian.Uint32(resp[12:16])) * time.Second,
+	}
+	if lifetime == 0 {
+		return m, nil
+	}
+
+	// Unlike PCP, NAT-PMP reports the external address separately.
+	resp, err = c.exchange(conn, []byte{versionNATPMP, opExternalAddress}, func(resp []byte) bool {
+		return len(resp) >= natpmpAddrRespSize &&
+			resp[0] == versionNATPMP &&
+			resp[1] == opExternalAddress|opResponse
+	})
+	if err != nil {
+		return Mapping{}, err
+	}
+	if result := binary.BigEndian.Uint16(resp[2:4]); result != resultSuccess {
+		return Mapping{}, fmt.Errorf("NAT-PMP external address request failed with result code %d", result)
+	}
+	m.ExternalIP = net.IPv4(resp[8], resp[9], resp[10], resp[11])
+
+	return m, nil
+}
+
+// exchange sends the request and waits for a matching response, the request
+// is retransmitted with doubling timeouts.
+func (c *Client) exchange(conn *net.UDPConn, req []byte, match func([]byte) bool) ([]byte, error) {
+	buf := make([]byte, maxResponseSize)
+	timeout := c.timeout
+
+	for attempt := 0; attempt < c.attempts; attempt++ {
+		if _, err := conn.Write(req); err != nil {
+			return nil, err
+		}
+
+		if err := conn.SetReadDeadline(time.Now().Add(timeout)); err != nil {
+			return nil, err
+		}
+		for {
+			n, err := conn.Read(buf)
+			if err != nil {
+				var netErr net.Error
+				if errors.As(err, &netErr) && netErr.Timeout() {
+					break
+				}
+				return nil, err
+			}
+			if match(buf[:n]) {
+				return buf[:n], nil
+			}
+		}
+
+		timeout *= 2
+	}
+
+	return nil, ErrNoResponse
+}
+
+func protocolNumber(protocol string) (byte, error) {
+	switch strings.ToUpper(protocol) {
+	case "UDP":
+		return protocolNumberUDP, nil
+	case "TCP":
+		return protocolNumberTCP, nil
+	}
+	return 0, fmt.Errorf("unsupported protocol %s", protocol)
+}
+
+func nonceKey(protocol string, internalPort int) string {
+	return fmt.Sprintf("%s/%d", strings.ToUpper(protocol), internalPort)
+}
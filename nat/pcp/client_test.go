@@ -0,0 +1,197 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package pcp
+
+import (
+	"encoding/binary"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeGateway is a local PCP and NAT-PMP server.
+type fakeGateway struct {
+	conn       *net.UDPConn
+	natpmpOnly bool
+	externalIP net.IP
+	// grantedLifetime overrides requested lifetimes if set.
+	grantedLifetime uint32
+
+	mu       sync.Mutex
+	mappings map[uint16]uint32
+	requests int
+}
+
+func newFakeGateway(t *testing.T, natpmpOnly bool, opts ...func(*fakeGateway)) *fakeGateway {
+	conn, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	require.NoError(t, err)
+
+	g := &fakeGateway{
+		conn:       conn,
+		natpmpOnly: natpmpOnly,
+		externalIP: net.IPv4(203, 0, 113, 7),
+		mappings:   make(map[uint16]uint32),
+	}
+	for _, opt := range opts {
+		opt(g)
+	}
+	go g.serve()
+	t.Cleanup(func() { conn.Close() })
+	return g
+}
+
+func (g *fakeGateway) client() *Client {
+	c := NewClient(g.conn.LocalAddr().(*net.UDPAddr))
+	c.timeout = 50 * time.Millisecond
+	return c
+}
+
+func (g *fakeGateway) mapping(port int) (uint32, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	lifetime, ok := g.mappings[uint16(port)]
+	return lifetime, ok
+}
+
+func (g *fakeGateway) requestCount() int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.requests
+}
+
+func (g *fakeGateway) serve() {
+	buf := make([]byte, 1100)
+	for {
+		n, addr, err := g.conn.ReadFromUDP(buf)
+		if err != nil {
+			return
+		}
+		if resp := g.handle(buf[:n]); resp != nil {
+			g.conn.WriteToUDP(resp, addr)
+		}
+	}
+}
+
+func (g *fakeGateway) handle(req []byte) []byte {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.requests++
+
+	switch {
+	case req[0] == versionPCP && !g.natpmpOnly:
+		resp := make([]byte, pcpHeaderSize+pcpMapPayloadSize)
+		resp[0] = versionPCP
+		resp[1] = req[1] | opResponse
+		lifetime := g.store(binary.BigEndian.Uint16(req[40:42]), binary.BigEndian.Uint32(req[4:8]))
+		binary.BigEndian.PutUint32(resp[4:8], lifetime)
+		copy(resp[24:44], req[24:44])
+		copy(resp[44:60], g.externalIP.To16())
+		return resp
+	case req[0] == versionNATPMP && req[1] == opExternalAddress:
+		resp := make([]byte, natpmpAddrRespSize)
+		resp[1] = opExternalAddress | opResponse
+		copy(resp[8:12], g.externalIP.To4())
+		return resp
+	case req[0] == versionNATPMP:
+		resp := make([]byte, natpmpMapRespSize)
+		resp[1] = req[1] | opResponse
+		port := binary.BigEndian.Uint16(req[4:6])
+		lifetime := g.store(port, binary.BigEndian.Uint32(req[8:12]))
+		binary.BigEndian.PutUint16(resp[8:10], port)
+		if lifetime > 0 {
+			binary.BigEndian.PutUint16(resp[10:12], port)
+		}
+		binary.BigEndian.PutUint32(resp[12:16], lifetime)
+		return resp
+	default:
+		// NAT-PMP servers answer unknown versions with UNSUPP_VERSION.
+		resp := make([]byte, 8)
+		resp[1] = req[1] | opResponse
+		binary.BigEndian.PutUint16(resp[2:4], resultUnsupportedVersion)
+		return resp
+	}
+}
+
+func (g *fakeGateway) store(port uint16, lifetime uint32) uint32 {
+	if lifetime == 0 {
+		delete(g.mappings, port)
+		return 0
+	}
+	if g.grantedLifetime > 0 {
+		lifetime = g.grantedLifetime
+	}
+	g.mappings[port] = lifetime
+	return lifetime
+}
+
+func TestClient_PCP(t *testing.T) {
+	gw := newFakeGateway(t, false)
+	client := gw.client()
+
+	m, err := client.AddMapping("UDP", 41000, 41000, time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, 41000, m.InternalPort)
+	assert.Equal(t, 41000, m.ExternalPort)
+	assert.Equal(t, time.Hour, m.Lifetime)
+	assert.True(t, gw.externalIP.Equal(m.ExternalIP))
+	assert.Equal(t, byte(versionPCP), client.version)
+
+	lifetime, ok := gw.mapping(41000)
+	assert.True(t, ok)
+	assert.Equal(t, uint32(3600), lifetime)
+
+	require.NoError(t, client.DeleteMapping("UDP", 41000))
+	_, ok = gw.mapping(41000)
+	assert.False(t, ok)
+}
+
+func TestClient_FallsBackToNATPMP(t *testing.T) {
+	gw := newFakeGateway(t, true)
+	client := gw.client()
+
+	m, err := client.AddMapping("UDP", 41000, 41000, time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, 41000, m.ExternalPort)
+	assert.True(t, gw.externalIP.Equal(m.ExternalIP))
+	assert.Equal(t, byte(versionNATPMP), client.version)
+
+	requests := gw.requestCount()
+	_, err = client.AddMapping("UDP", 41001, 41001, time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, requests+2, gw.requestCount(), "PCP is not retried once NAT-PMP is detected")
+
+	require.NoError(t, client.DeleteMapping("UDP", 41000))
+	_, ok := gw.mapping(41000)
+	assert.False(t, ok)
+}
+
+func TestClient_NoResponse(t *testing.T) {
+	conn, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	require.NoError(t, err)
+	defer conn.Close()
+
+	client := NewClient(conn.LocalAddr().(*net.UDPAddr))
+	client.timeout = 10 * time.Millisecond
+
+	_, err = client.AddMapping("UDP", 41000, 41000, time.Hour)
+	assert.ErrorIs(t, err, ErrNoResponse)
+}
@@ -0,0 +1,162 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package pcp
+
+import (
+	"errors"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/mysteriumnetwork/node/eventbus"
+	"github.com/mysteriumnetwork/node/nat/event"
+)
+
+// StageName is used to indicate PCP and NAT-PMP port mapping NAT traversal stage.
+const StageName = "pcp_port_mapping"
+
+// DefaultLifetime is the mapping lifetime requested from the gateway.
+const DefaultLifetime = 20 * time.Minute
+
+// minRenewInterval limits renewals when the gateway grants very short lifetimes.
+const minRenewInterval = 5 * time.Second
+
+type mappingClient interface {
+	AddMapping(protocol string, internalPort, externalPort int, lifetime time.Duration) (Mapping, error)
+	DeleteMapping(protocol string, internalPort int) error
+}
+
+// PortMapper maps ports on the gateway using PCP or NAT-PMP and renews
+// the mappings until they are released.
+type PortMapper struct {
+	client    mappingClient
+	publisher eventbus.Publisher
+	lifetime  time.Duration
+	minRenew  time.Duration
+}
+
+// NewPortMapper returns a new PCP port mapper.
+func NewPortMapper(client mappingClient, publisher eventbus.Publisher, lifetime time.Duration) *PortMapper {
+	return &PortMapper{
+		client:    client,
+		publisher: publisher,
+		lifetime:  lifetime,
+		minRenew:  minRenewInterval,
+	}
+}
+
+// Map maps the port for the given protocol. It returns release func which
+// must be called when port is no longer needed and ok which is true if
+// port mapping was successful.
+func (p *PortMapper) Map(id, protocol string, port int, name string) (release func(), ok bool) {
+	m, err := p.client.AddMapping(protocol, port, port, p.lifetime)
+	if err == nil && !publicIP(m.ExternalIP) {
+		p.deleteMapping(protocol, port)
+		err = errors.New("gateway external IP is not public")
+		log.Info().Err(err).Msgf("Port mapping is useless, skipping it. External IP: %s", m.ExternalIP)
+	}
+	p.notify(id, err)
+	if err != nil {
+		log.Warn().Err(err).Msgf("Couldn't add PCP port mapping for port %d", port)
+		return nil, false
+	}
+	if m.ExternalPort != port {
+		p.deleteMapping(protocol, port)
+		log.Info().Msgf("Gateway mapped port %d to different external port %d, skipping it", port, m.ExternalPort)
+		return nil, false
+	}
+	log.Info().Msgf("Mapped network port %d via PCP, lifetime %s", port, m.Lifetime)
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		lifetime := m.Lifetime
+		for {
+			select {
+			case <-stop:
+				return
+			case <-time.After(p.renewInterval(lifetime)):
+			}
+
+			m, err := p.client.AddMapping(protocol, port, port, p.lifetime)
+			p.notify(id, err)
+			if err != nil {
+				log.Warn().Err(err).Msgf("Couldn't renew PCP port mapping for port %d", port)
+				// Retry sooner, the previous mapping is still alive for a while.
+				lifetime /= 2
+				continue
+			}
+			lifetime = m.Lifetime
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			close(stop)
+			<-done
+			p.deleteMapping(protocol, port)
+		})
+	}, true
+}
+
+func (p *PortMapper) deleteMapping(protocol string, port int) {
+	log.Debug().Msgf("Deleting PCP port mapping for port: %d", port)
+	if err := p.client.DeleteMapping(protocol, port); err != nil {
+		log.Warn().Err(err).Msg("Couldn't delete PCP port mapping")
+	}
+}
+
+func (p *PortMapper) notify(id string, err error) {
+	if err != nil {
+		p.publisher.Publish(event.AppTopicTraversal, event.BuildFailureEvent(id, StageName, err))
+	} else {
+		p.publisher.Publish(event.AppTopicTraversal, event.BuildSuccessfulEvent(id, StageName))
+	}
+}
+
+// renewInterval renews mappings at half of their lifetime as RFC 6887 suggests.
+func (p *PortMapper) renewInterval(lifetime time.Duration) time.Duration {
+	if interval := lifetime / 2; interval > p.minRenew {
+		return interval
+	}
+	return p.minRenew
+}
+
+func publicIP(ip net.IP) bool {
+	if ip == nil || ip.IsUnspecified() {
+		return false
+	}
+	for _, s := range []string{
+		"10.0.0.0/8",
+		"172.16.0.0/12",
+		"192.168.0.0/16",
+		"100.64.0.0/10",
+		"127.0.0.0/8",
+		"169.254.0.0/16",
+	} {
+		_, subnet, _ := net.ParseCIDR(s)
+		if subnet.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,87 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package pcp
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mysteriumnetwork/node/mocks"
+	"github.com/mysteriumnetwork/node/nat/event"
+)
+
+func TestPortMapper_RenewsAndReleases(t *testing.T) {
+	gw := newFakeGateway(t, false, func(g *fakeGateway) { g.grantedLifetime = 1 })
+	bus := mocks.NewEventBus()
+	mapper := NewPortMapper(gw.client(), bus, time.Hour)
+	mapper.minRenew = 10 * time.Millisecond
+
+	release, ok := mapper.Map("id", "UDP", 41000, "Test")
+	assert.True(t, ok)
+
+	// Granted lifetime of 1s is renewed every 500ms.
+	assert.Eventually(t, func() bool { return gw.requestCount() >= 2 }, 2*time.Second, 10*time.Millisecond)
+	_, mapped := gw.mapping(41000)
+	assert.True(t, mapped)
+
+	release()
+	release()
+	_, mapped = gw.mapping(41000)
+	assert.False(t, mapped)
+
+	history := bus.GetEventHistory()
+	assert.True(t, len(history) >= 2)
+	for _, e := range history {
+		assert.Equal(t, event.AppTopicTraversal, e.Topic)
+		assert.Equal(t, event.BuildSuccessfulEvent("id", StageName), e.Event)
+	}
+}
+
+func TestPortMapper_Fails(t *testing.T) {
+	t.Run("private external IP", func(t *testing.T) {
+		gw := newFakeGateway(t, true, func(g *fakeGateway) { g.externalIP = net.IPv4(192, 168, 1, 10) })
+		bus := mocks.NewEventBus()
+		mapper := NewPortMapper(gw.client(), bus, time.Hour)
+
+		release, ok := mapper.Map("id", "UDP", 41000, "Test")
+		assert.False(t, ok)
+		assert.Nil(t, release)
+		_, mapped := gw.mapping(41000)
+		assert.False(t, mapped)
+
+		e := bus.Pop().(event.Event)
+		assert.False(t, e.Successful)
+		assert.Equal(t, StageName, e.Stage)
+	})
+
+	t.Run("no gateway", func(t *testing.T) {
+		conn, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+		assert.NoError(t, err)
+		defer conn.Close()
+		client := NewClient(conn.LocalAddr().(*net.UDPAddr))
+		client.timeout = 10 * time.Millisecond
+		bus := mocks.NewEventBus()
+
+		_, ok := NewPortMapper(client, bus, time.Hour).Map("id", "UDP", 41000, "Test")
+		assert.False(t, ok)
+		assert.Equal(t, event.BuildFailureEvent("id", StageName, ErrNoResponse), bus.Pop())
+	})
+}
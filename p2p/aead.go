@@ -0,0 +1,308 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package p2p
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/curve25519"
+	"golang.org/x/crypto/hkdf"
+)
+
+const (
+	aeadVersion    = 1
+	aeadHeaderSize = 1 + 4 + 8
+
+	// aeadOverhead is the number of bytes added to every sealed packet.
+	aeadOverhead = aeadHeaderSize + chacha20poly1305.Overhead
+
+	// replayWindowSize is the number of recent packet counters remembered for replay detection.
+	replayWindowSize = 64
+
+	// maxEpochSkip limits how far ahead of the current receive epoch a packet can be.
+	maxEpochSkip = 8
+
+	defaultRekeyAfterPackets = 1 << 20
+	defaultRekeyAfterTime    = 2 * time.Minute
+)
+
+var (
+	errPacketTooShort     = errors.New("p2p packet is too short")
+	errPacketVersion      = errors.New("p2p packet version is not supported")
+	errPacketEpoch        = errors.New("p2p packet epoch is not accepted")
+	errPacketReplayed     = errors.New("p2p packet is replayed")
+	errPacketAuthenticity = errors.New("p2p packet authentication failed")
+)
+
+// packetCrypt seals and opens p2p channel packets with ChaCha20-Poly1305.
+//
+// Each direction has its own key chain derived from the channel key exchange. Channel keys are generated
+// for every dial, so the chain is unique per channel. Sender moves to the next epoch key after sending
+// rekeyAfterPackets packets or after rekeyAfterTime, previous keys are discarded and can't be derived back.
+type packetCrypt struct {
+	rekeyAfterPackets uint64
+	rekeyAfterTime    time.Duration
+
+	sendMu sync.Mutex
+	send   sendState
+
+	recvMu sync.Mutex
+	recv   recvState
+}
+
+type sendState struct {
+	epoch     uint32
+	counter   uint64
+	startedAt time.Time
+	aead      cipher.AEAD
+	chainKey  [32]byte
+}
+
+type recvState struct {
+	current  *recvEpoch
+	previous *recvEpoch
+	chainKey [32]byte
+}
+
+type recvEpoch struct {
+	epoch  uint32
+	aead   cipher.AEAD
+	replay replayWindow
+}
+
+// newPacketCrypt creates packet crypt from local private key and remote peer public key.
+func newPacketCrypt(privateKey PrivateKey, peerPublicKey PublicKey) (*packetCrypt, error) {
+	publicKey, err := curve25519.X25519(privateKey[:], curve25519.Basepoint)
+	if err != nil {
+		return nil, fmt.Errorf("could not compute public key: %w", err)
+	}
+	sharedKey, err := curve25519.X25519(privateKey[:], peerPublicKey[:])
+	if err != nil {
+		return nil, fmt.Errorf("could not compute shared key: %w", err)
+	}
+
+	// Both peers must agree on the salt and on direction labels, so keys are ordered.
+	low, high := publicKey, peerPublicKey[:]
+	sendLabel, recvLabel := "p2p send low", "p2p send high"
+	if bytes.Compare(low, high) > 0 {
+		low, high = high, low
+		sendLabel, recvLabel = recvLabel, sendLabel
+	}
+	prk := hkdf.Extract(sha256.New, sharedKey, append(append([]byte{}, low...), high...))
+
+	sendChain, err := expandKey(prk, sendLabel)
+	if err != nil {
+		return nil, err
+	}
+	recvChain, err := expandKey(prk, recvLabel)
+	if err != nil {
+		return nil, err
+	}
+
+	sendAEAD, sendNext, err := ratchet(sendChain)
+	if err != nil {
+		return nil, err
+	}
+	recvAEAD, recvNext, err := ratchet(recvChain)
+	if err != nil {
+		return nil, err
+	}
+
+	return &packetCrypt{
+		rekeyAfterPackets: defaultRekeyAfterPackets,
+		rekeyAfterTime:    defaultRekeyAfterTime,
+		send: sendState{
+			startedAt: time.Now(),
+			aead:      sendAEAD,
+			chainKey:  sendNext,
+		},
+		recv: recvState{
+			current:  &recvEpoch{aead: recvAEAD},
+			chainKey: recvNext,
+		},
+	}, nil
+}
+
+// seal encrypts packet and appends it to dst.
+func (pc *packetCrypt) seal(dst, packet []byte) ([]byte, error) {
+	pc.sendMu.Lock()
+	defer pc.sendMu.Unlock()
+
+	s := &pc.send
+	if s.counter >= pc.rekeyAfterPackets || time.Since(s.startedAt) >= pc.rekeyAfterTime {
+		aead, next, err := ratchet(s.chainKey)
+		if err != nil {
+			return nil, fmt.Errorf("could not rekey: %w", err)
+		}
+		s.epoch++
+		s.counter = 0
+		s.startedAt = time.Now()
+		s.aead = aead
+		s.chainKey = next
+	}
+
+	header := make([]byte, aeadHeaderSize)
+	header[0] = aeadVersion
+	binary.BigEndian.PutUint32(header[1:5], s.epoch)
+	binary.BigEndian.PutUint64(header[5:], s.counter)
+	nonce := packetNonce(s.counter)
+	s.counter++
+
+	dst = append(dst, header...)
+	return s.aead.Seal(dst, nonce, packet, header), nil
+}
+
+// open authenticates and decrypts packet and appends it to dst.
+// Tampered, replayed and too old packets are rejected.
+func (pc *packetCrypt) open(dst, packet []byte) ([]byte, error) {
+	if len(packet) < aeadOverhead {
+		return nil, errPacketTooShort
+	}
+	header := packet[:aeadHeaderSize]
+	if header[0] != aeadVersion {
+		return nil, errPacketVersion
+	}
+	epoch := binary.BigEndian.Uint32(header[1:5])
+	counter := binary.BigEndian.Uint64(header[5:])
+
+	pc.recvMu.Lock()
+	defer pc.recvMu.Unlock()
+
+	r := &pc.recv
+	var ep *recvEpoch
+	var nextChain [32]byte
+	switch {
+	case epoch == r.current.epoch:
+		ep = r.current
+	case r.previous != nil && epoch == r.previous.epoch:
+		ep = r.previous
+	case epoch > r.current.epoch && epoch-r.current.epoch <= maxEpochSkip:
+		// Keys are advanced only after the packet is authenticated.
+		var aead cipher.AEAD
+		var err error
+		nextChain = r.chainKey
+		for i := r.current.epoch; i < epoch; i++ {
+			aead, nextChain, err = ratchet(nextChain)
+			if err != nil {
+				return nil, fmt.Errorf("could not rekey: %w", err)
+			}
+		}
+		ep = &recvEpoch{epoch: epoch, aead: aead}
+	default:
+		return nil, errPacketEpoch
+	}
+
+	if !ep.replay.check(counter) {
+		return nil, errPacketReplayed
+	}
+	dst, err := ep.aead.Open(dst, packetNonce(counter), packet[aeadHeaderSize:], header)
+	if err != nil {
+		return nil, errPacketAuthenticity
+	}
+	ep.replay.update(counter)
+
+	if epoch > r.current.epoch {
+		if epoch-r.current.epoch == 1 {
+			r.previous = r.current
+		} else {
+			r.previous = nil
+		}
+		r.current = ep
+		r.chainKey = nextChain
+	}
+	return dst, nil
+}
+
+// ratchet derives epoch cipher and the next chain key from the given chain key.
+func ratchet(chainKey [32]byte) (cipher.AEAD, [32]byte, error) {
+	key, err := expandKey(chainKey[:], "p2p packet key")
+	if err != nil {
+		return nil, [32]byte{}, err
+	}
+	next, err := expandKey(chainKey[:], "p2p chain key")
+	if err != nil {
+		return nil, [32]byte{}, err
+	}
+	aead, err := chacha20poly1305.New(key[:])
+	if err != nil {
+		return nil, [32]byte{}, fmt.Errorf("could not create ChaCha20-Poly1305 cipher: %w", err)
+	}
+	return aead, next, nil
+}
+
+func expandKey(secret []byte, info string) ([32]byte, error) {
+	var key [32]byte
+	if _, err := io.ReadFull(hkdf.Expand(sha256.New, secret, []byte(info)), key[:]); err != nil {
+		return key, fmt.Errorf("could not derive key: %w", err)
+	}
+	return key, nil
+}
+
+func packetNonce(counter uint64) []byte {
+	nonce := make([]byte, chacha20poly1305.NonceSize)
+	binary.BigEndian.PutUint64(nonce[chacha20poly1305.NonceSize-8:], counter)
+	return nonce
+}
+
+// replayWindow is a sliding window of recently received packet counters.
+type replayWindow struct {
+	started bool
+	highest uint64
+	bitmap  uint64
+}
+
+// check reports whether packet with given counter was not received yet and is not too old.
+func (w *replayWindow) check(counter uint64) bool {
+	if !w.started || counter > w.highest {
+		return true
+	}
+	diff := w.highest - counter
+	if diff >= replayWindowSize {
+		return false
+	}
+	return w.bitmap&(1<<diff) == 0
+}
+
+// update marks packet with given counter as received.
+func (w *replayWindow) update(counter uint64) {
+	switch {
+	case !w.started:
+		w.started = true
+		w.highest = counter
+		w.bitmap = 1
+	case counter > w.highest:
+		shift := counter - w.highest
+		if shift >= replayWindowSize {
+			w.bitmap = 1
+		} else {
+			w.bitmap = w.bitmap<<shift | 1
+		}
+		w.highest = counter
+	default:
+		w.bitmap |= 1 << (w.highest - counter)
+	}
+}
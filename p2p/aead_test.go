@@ -0,0 +1,151 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package p2p
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestPacketCrypts(t *testing.T) (*packetCrypt, *packetCrypt) {
+	pub1, priv1, err := GenerateKey()
+	require.NoError(t, err)
+	pub2, priv2, err := GenerateKey()
+	require.NoError(t, err)
+
+	pc1, err := newPacketCrypt(priv1, pub2)
+	require.NoError(t, err)
+	pc2, err := newPacketCrypt(priv2, pub1)
+	require.NoError(t, err)
+	return pc1, pc2
+}
+
+func TestPacketCrypt_SealOpen(t *testing.T) {
+	pc1, pc2 := newTestPacketCrypts(t)
+
+	sealed, err := pc1.seal(nil, []byte("ping"))
+	require.NoError(t, err)
+	assert.Len(t, sealed, len("ping")+aeadOverhead)
+	plain, err := pc2.open(nil, sealed)
+	require.NoError(t, err)
+	assert.Equal(t, "ping", string(plain))
+
+	sealed, err = pc2.seal(nil, []byte("pong"))
+	require.NoError(t, err)
+	plain, err = pc1.open(nil, sealed)
+	require.NoError(t, err)
+	assert.Equal(t, "pong", string(plain))
+
+	// Packets sent by ourselves must not be accepted as peer packets.
+	sealed, err = pc1.seal(nil, []byte("echo"))
+	require.NoError(t, err)
+	_, err = pc1.open(nil, sealed)
+	assert.Equal(t, errPacketAuthenticity, err)
+}
+
+func TestPacketCrypt_RejectsTamperedPackets(t *testing.T) {
+	pc1, pc2 := newTestPacketCrypts(t)
+
+	for _, idx := range []int{0, 2, 8, aeadHeaderSize, aeadHeaderSize + 3} {
+		sealed, err := pc1.seal(nil, []byte("hello"))
+		require.NoError(t, err)
+		sealed[idx] ^= 0x01
+
+		_, err = pc2.open(nil, sealed)
+		assert.Error(t, err, "tampered byte %d", idx)
+	}
+
+	_, err := pc2.open(nil, []byte{aeadVersion, 0, 0})
+	assert.Equal(t, errPacketTooShort, err)
+
+	// Untampered packets are still accepted.
+	sealed, err := pc1.seal(nil, []byte("hello"))
+	require.NoError(t, err)
+	_, err = pc2.open(nil, sealed)
+	assert.NoError(t, err)
+}
+
+func TestPacketCrypt_RejectsReplayedPackets(t *testing.T) {
+	pc1, pc2 := newTestPacketCrypts(t)
+
+	var packets [][]byte
+	for i := 0; i < replayWindowSize+4; i++ {
+		sealed, err := pc1.seal(nil, []byte("data"))
+		require.NoError(t, err)
+		packets = append(packets, sealed)
+	}
+
+	_, err := pc2.open(nil, packets[1])
+	require.NoError(t, err)
+	_, err = pc2.open(nil, packets[1])
+	assert.Equal(t, errPacketReplayed, err)
+
+	// Reordered packets inside the window are accepted once.
+	_, err = pc2.open(nil, packets[0])
+	assert.NoError(t, err)
+	_, err = pc2.open(nil, packets[0])
+	assert.Equal(t, errPacketReplayed, err)
+
+	// Packets older than the window are rejected.
+	_, err = pc2.open(nil, packets[len(packets)-1])
+	require.NoError(t, err)
+	_, err = pc2.open(nil, packets[2])
+	assert.Equal(t, errPacketReplayed, err)
+}
+
+func TestPacketCrypt_Rekey(t *testing.T) {
+	pc1, pc2 := newTestPacketCrypts(t)
+	pc1.rekeyAfterPackets = 2
+
+	var packets [][]byte
+	for i := 0; i < 6; i++ {
+		sealed, err := pc1.seal(nil, []byte("data"))
+		require.NoError(t, err)
+		packets = append(packets, sealed)
+	}
+	assert.Equal(t, uint32(2), pc1.send.epoch)
+
+	// Receiver follows sender epochs and accepts late packets from the previous epoch.
+	_, err := pc2.open(nil, packets[2])
+	require.NoError(t, err)
+	_, err = pc2.open(nil, packets[4])
+	require.NoError(t, err)
+	assert.Equal(t, uint32(2), pc2.recv.current.epoch)
+	_, err = pc2.open(nil, packets[3])
+	assert.NoError(t, err)
+
+	// Keys of older epochs are discarded.
+	_, err = pc2.open(nil, packets[0])
+	assert.Equal(t, errPacketEpoch, err)
+
+	// Forged packet from a future epoch does not advance receiver keys.
+	forged := append([]byte{}, packets[5]...)
+	forged[4] = 3
+	_, err = pc2.open(nil, forged)
+	assert.Equal(t, errPacketAuthenticity, err)
+	assert.Equal(t, uint32(2), pc2.recv.current.epoch)
+	_, err = pc2.open(nil, packets[5])
+	assert.NoError(t, err)
+
+	// Epochs too far ahead are rejected without key derivation.
+	forged[4] = maxEpochSkip + 3
+	_, err = pc2.open(nil, forged)
+	assert.Equal(t, errPacketEpoch, err)
+}
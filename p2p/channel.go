@@ -32,6 +32,7 @@ import (
 	"golang.org/x/crypto/nacl/box"
 
 	"github.com/mysteriumnetwork/node/identity"
+	"github.com/mysteriumnetwork/node/p2p/compat"
 	"github.com/mysteriumnetwork/node/router"
 	"github.com/mysteriumnetwork/node/trace"
 )
@@ -69,6 +70,7 @@ type ChannelHandler interface {
 type Channel interface {
 	ChannelSender
 	ChannelHandler
+	ChannelStreamer
 
 	// Tracer returns tracer which tracks channel establishment
 	Tracer() *trace.Tracer
@@ -135,6 +137,10 @@ type transport struct {
 	// this is needed to detect remote peer address changes as we can simply use conn.ReadFromUDP and
 	// get updated peer address.
 	proxyConn ServiceConn
+
+	// crypt authenticates and encrypts packets exchanged with remote peer. It is nil for peers
+	// which don't support AEAD, KCP session block crypt is used instead.
+	crypt *packetCrypt
 }
 
 // channel implements Channel interface.
@@ -163,6 +169,11 @@ type channel struct {
 	streams      map[uint64]*stream
 	nextStreamID uint64
 
+	// msgStreams holds bidirectional message streams opened by either peer.
+	msgStreamsMu    sync.Mutex
+	msgStreams      map[streamKey]*msgStream
+	nextMsgStreamID uint64
+
 	// privateKey is channel's private key. For now it's here just to be able to recreate the same channel for unit tests.
 	privateKey PrivateKey
 
@@ -203,8 +214,22 @@ func newChannel(remoteConn ServiceConn, privateKey PrivateKey, peerPubKey Public
 		return nil, fmt.Errorf("failed to protect udp proxy connection: %w", err)
 	}
 
+	var blockCrypt kcp.BlockCrypt
+	var crypt *packetCrypt
+	if compat.FeatureAEAD(peerCompatibility) {
+		crypt, err = newPacketCrypt(privateKey, peerPubKey)
+		if err != nil {
+			return nil, fmt.Errorf("could not create packet crypt: %w", err)
+		}
+	} else {
+		blockCrypt, err = newBlockCrypt(privateKey, peerPubKey)
+		if err != nil {
+			return nil, fmt.Errorf("could not create block crypt: %w", err)
+		}
+	}
+
 	// Setup KCP session. It will write to proxy conn only.
-	udpSession, localConn, err := listenUDPSession(proxyConn.LocalAddr(), privateKey, peerPubKey)
+	udpSession, localConn, err := listenUDPSession(proxyConn.LocalAddr(), blockCrypt)
 	if err != nil {
 		return nil, fmt.Errorf("could not create KCP UDP session: %w", err)
 	}
@@ -218,6 +243,7 @@ func newChannel(remoteConn ServiceConn, privateKey PrivateKey, peerPubKey Public
 		remoteConn: remoteConn,
 		localConn:  localConn,
 		proxyConn:  proxyConn,
+		crypt:      crypt,
 	}
 
 	peer := peer{
@@ -229,6 +255,7 @@ func newChannel(remoteConn ServiceConn, privateKey PrivateKey, peerPubKey Public
 		tr:               &tr,
 		topicHandlers:    make(map[string]HandlerFunc),
 		streams:          make(map[uint64]*stream),
+		msgStreams:       make(map[streamKey]*msgStream),
 		privateKey:       privateKey,
 		peer:             &peer,
 		localSessionAddr: localConn.LocalAddr().(*net.UDPAddr),
@@ -270,6 +297,7 @@ func (c *channel) launchReadSendLoops() error {
 // If remote peer addr changes it will be updated and next send will use new addr.
 func (c *channel) remoteReadLoop(rc, pc *net.UDPConn) {
 	buf := make([]byte, mtuLimit)
+	plain := make([]byte, mtuLimit)
 	latestPeerAddr := c.peer.addr()
 
 	for {
@@ -288,6 +316,15 @@ func (c *channel) remoteReadLoop(rc, pc *net.UDPConn) {
 			return
 		}
 
+		packet := buf[:n]
+		if c.tr.crypt != nil {
+			packet, err = c.tr.crypt.open(plain[:0], packet)
+			if err != nil {
+				log.Trace().Err(err).Msg("Dropping packet from remote conn")
+				continue
+			}
+		}
+
 		// Check if peer port changed.
 		if addr, ok := addr.(*net.UDPAddr); ok {
 			if addr.IP.Equal(latestPeerAddr.IP) && addr.Port != latestPeerAddr.Port {
@@ -297,7 +334,7 @@ func (c *channel) remoteReadLoop(rc, pc *net.UDPConn) {
 			}
 		}
 
-		_, err = pc.WriteToUDP(buf[:n], c.localSessionAddr)
+		_, err = pc.WriteToUDP(packet, c.localSessionAddr)
 		if err != nil {
 			if !errNetClose(err) {
 				log.Error().Err(err).Msg("Write to local udp session failed")
@@ -312,6 +349,7 @@ func (c *channel) remoteReadLoop(rc, pc *net.UDPConn) {
 // Packets to proxy conn are written by local KCP UDP session from localSendLoop.
 func (c *channel) remoteSendLoop(rc, pc *net.UDPConn) {
 	buf := make([]byte, mtuLimit)
+	sealed := make([]byte, mtuLimit+aeadOverhead)
 
 	for {
 		select {
@@ -329,7 +367,16 @@ func (c *channel) remoteSendLoop(rc, pc *net.UDPConn) {
 			return
 		}
 
-		_, err = rc.WriteToUDP(buf[:n], c.peer.addr())
+		packet := buf[:n]
+		if c.tr.crypt != nil {
+			packet, err = c.tr.crypt.seal(sealed[:0], packet)
+			if err != nil {
+				log.Error().Err(err).Msg("Could not seal packet for remote peer")
+				continue
+			}
+		}
+
+		_, err = rc.WriteToUDP(packet, c.peer.addr())
 		if err != nil {
 			if !errNetClose(err) {
 				log.Error().Err(err).Msgf("Write to remote peer conn failed")
@@ -361,6 +408,12 @@ func (c *channel) localReadLoop(tr *transport) {
 			fmt.Printf("recv from %s: %+v\n", tr.session.RemoteAddr(), msg)
 		}
 
+		// Stream frames are handled in order of arrival.
+		if isStreamFrame(&msg) {
+			c.handleStreamFrame(&msg)
+			continue
+		}
+
 		// If message contains topic it means that peer is making a request
 		// and waits for response.
 		if msg.topic != "" {
@@ -498,6 +551,7 @@ func (c *channel) Close() error {
 		}
 
 		c.tr = nil
+		c.closeMsgStreams()
 	})
 
 	if err := router.RemoveExcludedIP(c.peer.remoteAddr.IP); err != nil {
@@ -621,12 +675,7 @@ func reopenConn(conn ServiceConn) (ServiceConn, error) {
 	return conn, nil
 }
 
-func listenUDPSession(proxyAddr net.Addr, privateKey PrivateKey, peerPubKey PublicKey) (sess *kcp.UDPSession, localconn *net.UDPConn, err error) {
-	blockCrypt, err := newBlockCrypt(privateKey, peerPubKey)
-	if err != nil {
-		return nil, nil, fmt.Errorf("could not create block crypt: %w", err)
-	}
-
+func listenUDPSession(proxyAddr net.Addr, blockCrypt kcp.BlockCrypt) (sess *kcp.UDPSession, localconn *net.UDPConn, err error) {
 	localConn, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.ParseIP("127.0.0.1")})
 	if err != nil {
 		return nil, nil, fmt.Errorf("could not create UDP conn: %w", err)
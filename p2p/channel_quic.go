@@ -128,6 +128,15 @@ func (c *channelQuic) Send(ctx context.Context, topic string, msg *Message) (*Me
 	}, nil
 }
 
+// OpenStream is not supported for QUIC channels.
+func (c *channelQuic) OpenStream(ctx context.Context, topic string) (Stream, error) {
+	return nil, ErrStreamNotSupported
+}
+
+// HandleStream is not supported for QUIC channels, peer stream open requests are rejected.
+func (c *channelQuic) HandleStream(topic string, handler StreamHandlerFunc) {
+}
+
 func (c *channelQuic) Close() error {
 	if c.release != nil {
 		c.release()
@@ -29,6 +29,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/mysteriumnetwork/node/core/port"
+	"github.com/mysteriumnetwork/node/p2p/compat"
 	"github.com/mysteriumnetwork/node/pb"
 )
 
@@ -260,6 +261,30 @@ func TestChannel_Detect_And_Update_Peer_Addr(t *testing.T) {
 	_, err = consumer.Send(ctx, "ping", &Message{Data: []byte("pingasssas")})
 }
 
+func TestChannel_AEAD(t *testing.T) {
+	for name, compatibility := range map[string]int{"AEAD": compat.Compatibility, "Legacy block crypt": 2} {
+		t.Run(name, func(t *testing.T) {
+			provider, consumer, err := createTestChannelsWithCompatibility(compatibility, compatibility)
+			require.NoError(t, err)
+			defer provider.Close()
+			defer consumer.Close()
+
+			assert.Equal(t, compat.FeatureAEAD(compatibility), provider.(*channel).tr.crypt != nil)
+			assert.Equal(t, compat.FeatureAEAD(compatibility), consumer.(*channel).tr.crypt != nil)
+
+			provider.Handle("ping", func(c Context) error {
+				return c.OkWithReply(&Message{Data: append([]byte("re: "), c.Request().Data...)})
+			})
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			res, err := consumer.Send(ctx, "ping", &Message{Data: []byte("hello")})
+			require.NoError(t, err)
+			assert.Equal(t, "re: hello", string(res.Data))
+		})
+	}
+}
+
 func BenchmarkChannel_Send(b *testing.B) {
 	provider, consumer, err := createTestChannels()
 	require.NoError(b, err)
@@ -306,6 +331,10 @@ func reopenChannelWithNewLocalAddr(c *channel) (*channel, error) {
 }
 
 func createTestChannels() (Channel, Channel, error) {
+	return createTestChannelsWithCompatibility(1, 1)
+}
+
+func createTestChannelsWithCompatibility(providerCompatibility, consumerCompatibility int) (Channel, Channel, error) {
 	ports, err := acquirePorts(2)
 	if err != nil {
 		return nil, nil, err
@@ -332,13 +361,13 @@ func createTestChannels() (Channel, Channel, error) {
 		return nil, nil, err
 	}
 
-	provider, err := newChannel(providerConn, providerPrivateKey, consumerPublicKey, 1)
+	provider, err := newChannel(providerConn, providerPrivateKey, consumerPublicKey, consumerCompatibility)
 	if err != nil {
 		return nil, nil, err
 	}
 	provider.launchReadSendLoops()
 
-	consumer, err := newChannel(consumerConn, consumerPrivateKey, providerPublicKey, 1)
+	consumer, err := newChannel(consumerConn, consumerPrivateKey, providerPublicKey, providerCompatibility)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -18,10 +18,16 @@
 package compat
 
 // Compatibility level of P2P protocol
-const Compatibility = 2
+const Compatibility = 3
 
 // FeaturePBP2P reports whether peer supports new wire format
 // for transportMsg envelopes
 func FeaturePBP2P(peerCompatibility int) bool {
 	return peerCompatibility >= 1
 }
+
+// FeatureAEAD reports whether peer supports authenticated
+// packet encryption with periodic rekeying
+func FeatureAEAD(peerCompatibility int) bool {
+	return peerCompatibility >= 3
+}
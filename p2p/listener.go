@@ -52,15 +52,20 @@ type Listener interface {
 	GetContact() market.Contact
 }
 
+// punchFailureTTL is how long hole punching is skipped for a peer it has failed with.
+const punchFailureTTL = 10 * time.Minute
+
 // NewListener creates new p2p communication listener which is used on provider side.
-func NewListener(brokerConn nats.Connection, signer identity.SignerFactory, verifier identity.Verifier, ipResolver ip.Resolver, eventBus eventbus.EventBus) Listener {
+func NewListener(brokerConn nats.Connection, signer identity.SignerFactory, verifier identity.Verifier, ipResolver ip.Resolver, eventBus eventbus.EventBus, relayer nat.Relayer) Listener {
 	return &listener{
 		brokerConn:     brokerConn,
 		pendingConfigs: map[PublicKey]p2pConnectConfig{},
+		punchFailures:  map[identity.Identity]time.Time{},
 		ipResolver:     ipResolver,
 		signer:         signer,
 		verifier:       verifier,
 		eventBus:       eventBus,
+		relayer:        relayer,
 	}
 }
 
@@ -71,17 +76,24 @@ type listener struct {
 	signer     identity.SignerFactory
 	verifier   identity.Verifier
 	ipResolver ip.Resolver
+	relayer    nat.Relayer
 
 	// Keys holds pendingConfigs temporary configs for provider side since it
 	// need to handle key exchange in two steps.
 	pendingConfigs   map[PublicKey]p2pConnectConfig
 	pendingConfigsMu sync.Mutex
+
+	// punchFailures holds peers which hole punching has recently failed with,
+	// connections from them fall through to the next traversal method.
+	punchFailures   map[identity.Identity]time.Time
+	punchFailuresMu sync.Mutex
 }
 
 type p2pConnectConfig struct {
 	publicIP         string
 	peerPublicIP     string
 	peerURL          string
+	method           string
 	compatibility    int
 	peerPorts        []int
 	localPorts       []int
@@ -169,6 +181,9 @@ func (m *listener) Listen(providerID identity.Identity, serviceType string, chan
 			conns, err := config.start(ctx, config.peerIP(), config.peerPorts, config.localPorts)
 			if err != nil {
 				log.Err(err).Msg("Could not ping peer")
+				if config.method == nat.MethodHolePunching {
+					m.setPunchFailure(config.peerID)
+				}
 				cancel()
 				return
 			}
@@ -318,18 +333,22 @@ func (m *listener) providerStartConfigExchange(providerID identity.Identity, ser
 	}
 
 	if serviceType != "quic_scraping" {
-		publicIP, localPorts, portsRelease, start, err := m.prepareLocalPorts(providerID.Address, tracer)
+		prepared, err := m.prepareLocalPorts(providerID.Address, peerID, serviceType, tracer)
 		if err != nil {
 			return fmt.Errorf("could not prepare ports: %w", err)
 		}
 
-		p2pConnConfig.publicIP = publicIP
-		p2pConnConfig.localPorts = localPorts
-		p2pConnConfig.publicPorts = stunPorts(providerID, m.eventBus, localPorts...)
-		p2pConnConfig.upnpPortsRelease = portsRelease
-		p2pConnConfig.start = start
+		p2pConnConfig.method = prepared.method
+		p2pConnConfig.publicIP = prepared.publicIP
+		p2pConnConfig.localPorts = prepared.ports
+		p2pConnConfig.publicPorts = prepared.ports
+		if !prepared.relayed {
+			p2pConnConfig.publicPorts = stunPorts(providerID, m.eventBus, prepared.ports...)
+		}
+		p2pConnConfig.upnpPortsRelease = prepared.release
+		p2pConnConfig.start = prepared.start
 
-		config.PublicIP = publicIP
+		config.PublicIP = prepared.publicIP
 		config.Ports = intToInt32Slice(p2pConnConfig.publicPorts)
 	}
 
@@ -355,38 +374,59 @@ func (m *listener) providerStartConfigExchange(providerID identity.Identity, ser
 	return nil
 }
 
+type preparedPorts struct {
+	method   string
+	publicIP string
+	ports    []int
+	relayed  bool
+	release  func()
+	start    nat.StartPorts
+}
+
 // prepareLocalPorts acquires ports for p2p connections. It tries to acquire only
 // required ports count for actual p2p and service connections and fallback to
 // acquiring extra ports for nat pinger if provider is behind nat, port mapping failed
-// and no manual port forwarding is enabled.
-func (m *listener) prepareLocalPorts(id string, tracer *trace.Tracer) (string, []int, func(), nat.StartPorts, error) {
+// and no manual port forwarding is enabled. Hole punching is skipped for peers it has
+// recently failed with, so that those can be served through a relay.
+func (m *listener) prepareLocalPorts(id string, peerID identity.Identity, serviceType string, tracer *trace.Tracer) (preparedPorts, error) {
 	trace := tracer.StartStage("Provider P2P exchange (ports)")
 	defer tracer.EndStage(trace)
 
 	publicIP, err := m.ipResolver.GetPublicIP()
 	if err != nil {
-		return "", nil, nil, nil, fmt.Errorf("could not get public IP: %w", err)
+		return preparedPorts{}, fmt.Errorf("could not get public IP: %w", err)
 	}
 
-	for _, p := range nat.OrderedPortProviders() {
-		ports, release, start, err := p.Provider.PreparePorts()
-		if err == nil {
-			m.eventBus.Publish(nat.AppTopicNATTraversalMethod, nat.NATTraversalMethod{
-				Identity: id,
-				Method:   p.Method,
-				Success:  true,
-			})
-			return publicIP, ports, release, start, nil
+	for _, p := range nat.OrderedPortProviders(m.eventBus, m.relayer) {
+		if p.Method == nat.MethodHolePunching && m.punchFailed(peerID) {
+			log.Debug().Msgf("Skipping hole punching for peer %s, it has failed recently", peerID.Address)
+			continue
+		}
+		// Relay service must be reachable on its own, do not relay it through another relay.
+		if p.Method == nat.MethodRelay && serviceType == "relay" {
+			continue
+		}
+
+		prepared := preparedPorts{method: p.Method, publicIP: publicIP}
+		if rp, ok := p.Provider.(nat.RelayedPortProvider); ok {
+			prepared.relayed = true
+			prepared.publicIP, prepared.ports, prepared.release, prepared.start, err = rp.PrepareRelayedPorts(id)
+		} else {
+			prepared.ports, prepared.release, prepared.start, err = p.Provider.PreparePorts()
 		}
 
 		m.eventBus.Publish(nat.AppTopicNATTraversalMethod, nat.NATTraversalMethod{
 			Identity: id,
 			Method:   p.Method,
-			Success:  false,
+			Success:  err == nil,
 		})
+
+		if err == nil {
+			return prepared, nil
+		}
 	}
 
-	return "", nil, nil, nil, fmt.Errorf("failed to prepare local ports")
+	return preparedPorts{}, fmt.Errorf("failed to prepare local ports")
 }
 
 func (m *listener) providerAckConfigExchange(msg *nats_lib.Msg) (*p2pConnectConfig, error) {
@@ -422,6 +462,7 @@ func (m *listener) providerAckConfigExchange(msg *nats_lib.Msg) (*p2pConnectConf
 		peerPorts:        int32ToIntSlice(peerConfig.GetPorts()),
 		peerURL:          peerConfig.GetUrl(),
 		compatibility:    int(peerConfig.GetCompatibility()),
+		method:           config.method,
 		localPorts:       config.localPorts,
 		publicKey:        config.publicKey,
 		privateKey:       config.privateKey,
@@ -469,3 +510,22 @@ func (m *listener) deletePendingConfig(peerPubKey PublicKey) {
 	defer m.pendingConfigsMu.Unlock()
 	delete(m.pendingConfigs, peerPubKey)
 }
+
+func (m *listener) setPunchFailure(peerID identity.Identity) {
+	m.punchFailuresMu.Lock()
+	defer m.punchFailuresMu.Unlock()
+	m.punchFailures[peerID] = time.Now()
+}
+
+func (m *listener) punchFailed(peerID identity.Identity) bool {
+	m.punchFailuresMu.Lock()
+	defer m.punchFailuresMu.Unlock()
+
+	failedAt, ok := m.punchFailures[peerID]
+	if ok && time.Since(failedAt) > punchFailureTTL {
+		delete(m.punchFailures, peerID)
+		return false
+	}
+
+	return ok
+}
@@ -39,6 +39,8 @@ const (
 	TopicPaymentMessage = "p2p-payment-message"
 	// TopicPaymentInvoice is a payment invoices endpoint for p2p communication.
 	TopicPaymentInvoice = "p2p-payment-invoice"
+	// TopicPriceChange is a session price renegotiation endpoint for p2p communication.
+	TopicPriceChange = "p2p-price-change"
 )
 
 // Message represent message with data bytes.
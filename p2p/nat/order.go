@@ -23,6 +23,7 @@ import (
 	"github.com/rs/zerolog/log"
 
 	"github.com/mysteriumnetwork/node/config"
+	"github.com/mysteriumnetwork/node/eventbus"
 )
 
 // NamedPortProvider contains information of the NAT traversal method.
@@ -36,19 +37,30 @@ type PortProvider interface {
 	PreparePorts() (ports []int, release func(), start StartPorts, err error)
 }
 
-var traversalOptions map[string]func() PortProvider = map[string]func() PortProvider{
-	"manual":       NewManualPortProvider,
-	"upnp":         NewUPnPPortProvider,
-	"holepunching": NewNATHolePunchingPortProvider,
+// Traversal method names which need special handling.
+const (
+	MethodHolePunching = "holepunching"
+	MethodRelay        = "relay"
+)
+
+var traversalOptions = map[string]func(publisher eventbus.Publisher, relayer Relayer) PortProvider{
+	"manual":           func(eventbus.Publisher, Relayer) PortProvider { return NewManualPortProvider() },
+	"upnp":             func(p eventbus.Publisher, _ Relayer) PortProvider { return NewUPnPPortProvider(p) },
+	"pcp":              func(p eventbus.Publisher, _ Relayer) PortProvider { return NewPCPPortProvider(p) },
+	MethodHolePunching: func(eventbus.Publisher, Relayer) PortProvider { return NewNATHolePunchingPortProvider() },
+	MethodRelay:        func(_ eventbus.Publisher, r Relayer) PortProvider { return NewRelayPortProvider(r) },
 }
 
 // OrderedPortProviders returns a ordered list of the port providers.
-func OrderedPortProviders() (list []NamedPortProvider) {
+// Port mapping results are reported to the given publisher, relay
+// allocations are requested from the given relayer. Relay is paid by the
+// provider, so it is only used when listed in the traversal flag explicitly.
+func OrderedPortProviders(publisher eventbus.Publisher, relayer Relayer) (list []NamedPortProvider) {
 	methods := strings.Split(config.GetString(config.FlagTraversal), ",")
 
 	for _, m := range methods {
 		if t, ok := traversalOptions[m]; ok {
-			list = append(list, NamedPortProvider{Method: m, Provider: t()})
+			list = append(list, NamedPortProvider{Method: m, Provider: t(publisher, relayer)})
 		} else {
 			log.Warn().Msgf("Unsupported traversal method %s, ignoring it", m)
 		}
@@ -59,8 +71,9 @@ func OrderedPortProviders() (list []NamedPortProvider) {
 
 		return []NamedPortProvider{
 			{"manual", NewManualPortProvider()},
-			{"upnp", NewUPnPPortProvider()},
-			{"holepunching", NewNATHolePunchingPortProvider()},
+			{"upnp", NewUPnPPortProvider(publisher)},
+			{"pcp", NewPCPPortProvider(publisher)},
+			{MethodHolePunching, NewNATHolePunchingPortProvider()},
 		}
 	}
 
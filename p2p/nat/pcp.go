@@ -0,0 +1,93 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package nat
+
+import (
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/mysteriumnetwork/node/config"
+	"github.com/mysteriumnetwork/node/core/port"
+	"github.com/mysteriumnetwork/node/eventbus"
+	"github.com/mysteriumnetwork/node/nat/event"
+	"github.com/mysteriumnetwork/node/nat/pcp"
+)
+
+type pcpPort struct {
+	pool      *port.Pool
+	publisher eventbus.Publisher
+}
+
+// NewPCPPortProvider returns a new instance of the PCP and NAT-PMP port provider.
+func NewPCPPortProvider(publisher eventbus.Publisher) PortProvider {
+	udpPortRange, err := port.ParseRange(config.GetString(config.FlagUDPListenPorts))
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to parse UDP listen port range, using default value")
+
+		udpPortRange, err = port.ParseRange("10000:60000")
+		if err != nil {
+			panic(err) // This must never happen.
+		}
+	}
+
+	return &pcpPort{
+		pool:      port.NewFixedRangePool(udpPortRange),
+		publisher: publisher,
+	}
+}
+
+func (pp *pcpPort) PreparePorts() (ports []int, release func(), start StartPorts, err error) {
+	client, err := pcp.Discover()
+	if err != nil {
+		pp.publisher.Publish(event.AppTopicTraversal, event.BuildFailureEvent("", pcp.StageName, err))
+		return nil, nil, nil, err
+	}
+	portMapper := pcp.NewPortMapper(client, pp.publisher, pcp.DefaultLifetime)
+
+	localPorts, err := pp.pool.AcquireMultiple(requiredConnCount)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	var portsRelease []func()
+	releaseAll := func() {
+		for _, r := range portsRelease {
+			r()
+		}
+	}
+
+	for _, p := range localPorts {
+		portRelease, ok := portMapper.Map("", "UDP", p.Num(), "Myst node p2p port mapping")
+		if !ok {
+			releaseAll()
+			return nil, nil, nil, fmt.Errorf("failed to map port via PCP")
+		}
+
+		portsRelease = append(portsRelease, portRelease)
+		ports = append(ports, p.Num())
+	}
+
+	if err := checkAllPorts(ports); err != nil {
+		releaseAll()
+		log.Debug().Err(err).Msgf("Failed to check PCP ports %d globally", ports)
+		return nil, nil, nil, err
+	}
+
+	return ports, releaseAll, nil, nil
+}
@@ -0,0 +1,105 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package nat
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"time"
+)
+
+// relayStartTimeout is how long an allocated relay is kept waiting for the
+// peer to acknowledge the config exchange before it is released.
+const relayStartTimeout = time.Minute
+
+// ErrNoRelayer is returned when relay traversal is requested but no relayer is configured.
+var ErrNoRelayer = errors.New("relay traversal is not available")
+
+// Relay is a port forwarding allocation on a relay node.
+type Relay interface {
+	// Addr returns public address of the allocation on the relay node.
+	Addr() (ip string, ports []int)
+	// Start permits the peer to send through the relay and returns
+	// connections which reach the peer via the relay.
+	Start(ctx context.Context, peerIP string) ([]*net.UDPConn, error)
+	// Close releases the allocation.
+	Close() error
+}
+
+// Relayer allocates forwarding ports on relay nodes.
+type Relayer interface {
+	Relay(providerID string) (Relay, error)
+}
+
+// RelayedPortProvider is a PortProvider whose ports are reachable
+// on an address other than the public IP of the node.
+type RelayedPortProvider interface {
+	PortProvider
+	PrepareRelayedPorts(providerID string) (publicIP string, ports []int, release func(), start StartPorts, err error)
+}
+
+type relayPort struct {
+	relayer Relayer
+}
+
+// NewRelayPortProvider creates new instance of the relay port provider.
+func NewRelayPortProvider(relayer Relayer) PortProvider {
+	return &relayPort{relayer: relayer}
+}
+
+// PreparePorts always fails as relayed ports are useless without the relay address,
+// use PrepareRelayedPorts instead.
+func (rp *relayPort) PreparePorts() (ports []int, release func(), start StartPorts, err error) {
+	return nil, nil, nil, errors.New("relayed ports must be prepared with the relay address")
+}
+
+// PrepareRelayedPorts allocates ports on a relay node for the given provider.
+func (rp *relayPort) PrepareRelayedPorts(providerID string) (publicIP string, ports []int, release func(), start StartPorts, err error) {
+	if rp.relayer == nil {
+		return "", nil, nil, nil, ErrNoRelayer
+	}
+
+	relay, err := rp.relayer.Relay(providerID)
+	if err != nil {
+		return "", nil, nil, nil, err
+	}
+
+	var once sync.Once
+	release = func() {
+		once.Do(func() { relay.Close() })
+	}
+	// Config exchange might never be acknowledged, release the relay in that case.
+	timer := time.AfterFunc(relayStartTimeout, release)
+
+	start = func(ctx context.Context, peerIP string, _, _ []int) ([]*net.UDPConn, error) {
+		timer.Stop()
+
+		conns, err := relay.Start(ctx, peerIP)
+		if err != nil {
+			release()
+			return nil, err
+		}
+
+		return conns, nil
+	}
+
+	publicIP, ports = relay.Addr()
+	return publicIP, ports, release, start, nil
+}
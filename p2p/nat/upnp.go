@@ -34,7 +34,7 @@ type upnpPort struct {
 }
 
 // NewUPnPPortProvider returns a new instance of the UPnP port provider.
-func NewUPnPPortProvider() PortProvider {
+func NewUPnPPortProvider(publisher eventbus.Publisher) PortProvider {
 	udpPortRange, err := port.ParseRange(config.GetString(config.FlagUDPListenPorts))
 	if err != nil {
 		log.Warn().Err(err).Msg("Failed to parse UDP listen port range, using default value")
@@ -47,7 +47,7 @@ func NewUPnPPortProvider() PortProvider {
 
 	return &upnpPort{
 		pool:       port.NewFixedRangePool(udpPortRange),
-		portMapper: mapping.NewPortMapper(mapping.DefaultConfig(), eventbus.New()),
+		portMapper: mapping.NewPortMapper(mapping.DefaultConfig(), publisher),
 	}
 }
 
@@ -0,0 +1,433 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package p2p
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	// ErrStreamClosed indicates that stream was closed locally or channel was closed.
+	ErrStreamClosed = errors.New("p2p stream closed")
+
+	// ErrStreamReset indicates that peer aborted the stream.
+	ErrStreamReset = errors.New("p2p stream reset by peer")
+
+	// ErrStreamNotSupported indicates that channel does not support message streams.
+	ErrStreamNotSupported = errors.New("p2p streams are not supported")
+)
+
+const (
+	// streamWindowSize is the number of messages peer can send before it needs a window update.
+	streamWindowSize = 64
+
+	// topicStreamOpen prefixes the topic of the request which opens the stream.
+	// Peers without stream support reply with handler not found error.
+	topicStreamOpen = "p2p-stream-open:"
+	// Stream frames are marked with the reserved topics depending on which side opened the stream,
+	// so both peers can use their own stream ID sequences.
+	topicStreamFrameOpener   = "p2p-stream-frame-o"
+	topicStreamFrameAccepter = "p2p-stream-frame-a"
+
+	// Stream frame types are sent in message status code field.
+	streamFrameData   = 10
+	streamFrameWindow = 11
+	streamFrameClose  = 12
+	streamFrameReset  = 13
+)
+
+// Stream is a bidirectional message stream multiplexed over the p2p channel.
+type Stream interface {
+	// Send sends message to the peer. It blocks while the peer receive window is full.
+	Send(ctx context.Context, msg *Message) error
+
+	// Recv returns next message from the peer. It returns io.EOF once the peer closed its sending side.
+	Recv(ctx context.Context) (*Message, error)
+
+	// CloseSend closes the sending side of the stream. Peer receives io.EOF after reading all sent messages.
+	CloseSend() error
+
+	// Cancel aborts the stream in both directions.
+	Cancel()
+
+	// Done is closed once the stream is finished.
+	Done() <-chan struct{}
+}
+
+// StreamHandlerFunc is channel stream handler func signature.
+// Stream sending side is closed once the handler returns, returned error aborts the stream.
+type StreamHandlerFunc func(s Stream) error
+
+// ChannelStreamer is used to open and handle message streams.
+type ChannelStreamer interface {
+	// OpenStream opens a new stream to the peer stream handler registered for the given topic.
+	OpenStream(ctx context.Context, topic string) (Stream, error)
+
+	// HandleStream registers handler for streams opened by the peer on the given topic.
+	HandleStream(topic string, handler StreamHandlerFunc)
+}
+
+type streamKey struct {
+	id     uint64
+	opener bool
+}
+
+// msgStream implements Stream on top of the KCP channel.
+type msgStream struct {
+	ch     *channel
+	id     uint64
+	opener bool
+	topic  string
+
+	// recvCh is written and closed only from channel read loop.
+	recvCh  chan *Message
+	recvErr error
+
+	mu         sync.Mutex
+	credits    int
+	creditsCh  chan struct{}
+	consumed   int
+	sendClosed bool
+	recvClosed bool
+
+	once sync.Once
+	done chan struct{}
+	err  error
+}
+
+func newMsgStream(ch *channel, id uint64, opener bool, topic string) *msgStream {
+	return &msgStream{
+		ch:        ch,
+		id:        id,
+		opener:    opener,
+		topic:     topic,
+		recvCh:    make(chan *Message, streamWindowSize),
+		credits:   streamWindowSize,
+		creditsCh: make(chan struct{}, 1),
+		done:      make(chan struct{}),
+	}
+}
+
+func (s *msgStream) key() streamKey {
+	return streamKey{id: s.id, opener: s.opener}
+}
+
+// Send sends message to the peer.
+func (s *msgStream) Send(ctx context.Context, msg *Message) error {
+	for {
+		s.mu.Lock()
+		if s.sendClosed {
+			s.mu.Unlock()
+			return ErrStreamClosed
+		}
+		if s.credits > 0 {
+			s.credits--
+			s.mu.Unlock()
+			break
+		}
+		s.mu.Unlock()
+
+		select {
+		case <-s.creditsCh:
+		case <-s.done:
+			return s.err
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	select {
+	case <-s.done:
+		return s.err
+	default:
+	}
+	return s.ch.sendStreamFrame(s, streamFrameData, msg.Data, "")
+}
+
+// Recv returns next message from the peer.
+func (s *msgStream) Recv(ctx context.Context) (*Message, error) {
+	select {
+	case msg, ok := <-s.recvCh:
+		return s.received(msg, ok)
+	default:
+	}
+
+	select {
+	case msg, ok := <-s.recvCh:
+		return s.received(msg, ok)
+	case <-s.done:
+		// Messages received before the peer closed the stream are still delivered.
+		select {
+		case msg, ok := <-s.recvCh:
+			return s.received(msg, ok)
+		default:
+			return nil, s.err
+		}
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (s *msgStream) received(msg *Message, ok bool) (*Message, error) {
+	if !ok {
+		return nil, s.recvErr
+	}
+
+	s.mu.Lock()
+	s.consumed++
+	update := 0
+	if s.consumed >= streamWindowSize/2 {
+		update, s.consumed = s.consumed, 0
+	}
+	s.mu.Unlock()
+
+	if update > 0 {
+		data := make([]byte, 4)
+		binary.BigEndian.PutUint32(data, uint32(update))
+		if err := s.ch.sendStreamFrame(s, streamFrameWindow, data, ""); err != nil {
+			log.Warn().Err(err).Msgf("Could not send window update for stream %q", s.topic)
+		}
+	}
+	return msg, nil
+}
+
+// CloseSend closes the sending side of the stream.
+func (s *msgStream) CloseSend() error {
+	s.mu.Lock()
+	if s.sendClosed {
+		s.mu.Unlock()
+		return nil
+	}
+	s.sendClosed = true
+	finished := s.recvClosed
+	s.mu.Unlock()
+
+	err := s.ch.sendStreamFrame(s, streamFrameClose, nil, "")
+	if finished {
+		s.finish(ErrStreamClosed)
+	}
+	return err
+}
+
+// Cancel aborts the stream in both directions.
+func (s *msgStream) Cancel() {
+	s.reset("stream canceled")
+}
+
+func (s *msgStream) reset(reason string) {
+	select {
+	case <-s.done:
+		return
+	default:
+	}
+
+	if err := s.ch.sendStreamFrame(s, streamFrameReset, nil, reason); err != nil {
+		log.Debug().Err(err).Msgf("Could not send reset for stream %q", s.topic)
+	}
+	s.finish(ErrStreamClosed)
+}
+
+// Done is closed once the stream is finished.
+func (s *msgStream) Done() <-chan struct{} {
+	return s.done
+}
+
+func (s *msgStream) finish(err error) {
+	s.once.Do(func() {
+		s.mu.Lock()
+		s.sendClosed = true
+		s.mu.Unlock()
+
+		s.err = err
+		close(s.done)
+		s.ch.removeMsgStream(s)
+	})
+}
+
+// handleFrame processes frame from the peer. It is called only from channel read loop.
+func (s *msgStream) handleFrame(msg *transportMsg) {
+	switch msg.statusCode {
+	case streamFrameData:
+		if s.isRecvClosed() {
+			return
+		}
+		select {
+		case s.recvCh <- &Message{Data: msg.data}:
+		default:
+			log.Warn().Msgf("Peer exceeded receive window of stream %q, aborting", s.topic)
+			s.closeRecv(ErrStreamReset)
+			s.reset("receive window exceeded")
+		}
+	case streamFrameWindow:
+		if len(msg.data) != 4 {
+			return
+		}
+		s.mu.Lock()
+		s.credits += int(binary.BigEndian.Uint32(msg.data))
+		s.mu.Unlock()
+		select {
+		case s.creditsCh <- struct{}{}:
+		default:
+		}
+	case streamFrameClose:
+		s.closeRecv(io.EOF)
+		s.mu.Lock()
+		finished := s.sendClosed
+		s.mu.Unlock()
+		if finished {
+			s.finish(ErrStreamClosed)
+		}
+	case streamFrameReset:
+		err := fmt.Errorf("%w: %s", ErrStreamReset, msg.msg)
+		s.closeRecv(err)
+		s.finish(err)
+	}
+}
+
+func (s *msgStream) isRecvClosed() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.recvClosed
+}
+
+func (s *msgStream) closeRecv(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.recvClosed {
+		return
+	}
+	s.recvClosed = true
+	s.recvErr = err
+	close(s.recvCh)
+}
+
+// OpenStream opens a new stream to the peer stream handler registered for the given topic.
+func (c *channel) OpenStream(ctx context.Context, topic string) (Stream, error) {
+	c.msgStreamsMu.Lock()
+	c.nextMsgStreamID++
+	s := newMsgStream(c, c.nextMsgStreamID, true, topic)
+	c.msgStreams[s.key()] = s
+	c.msgStreamsMu.Unlock()
+
+	data := make([]byte, 8)
+	binary.BigEndian.PutUint64(data, s.id)
+	if _, err := c.Send(ctx, topicStreamOpen+topic, &Message{Data: data}); err != nil {
+		c.removeMsgStream(s)
+		return nil, fmt.Errorf("could not open stream %q: %w", topic, err)
+	}
+
+	return s, nil
+}
+
+// HandleStream registers handler for streams opened by the peer on the given topic.
+func (c *channel) HandleStream(topic string, handler StreamHandlerFunc) {
+	c.Handle(topicStreamOpen+topic, func(ctx Context) error {
+		data := ctx.Request().Data
+		if len(data) != 8 {
+			return errors.New("invalid stream open request")
+		}
+
+		s := newMsgStream(c, binary.BigEndian.Uint64(data), false, topic)
+		c.msgStreamsMu.Lock()
+		if _, exists := c.msgStreams[s.key()]; exists {
+			c.msgStreamsMu.Unlock()
+			return fmt.Errorf("stream %d already exists", s.id)
+		}
+		c.msgStreams[s.key()] = s
+		c.msgStreamsMu.Unlock()
+
+		go func() {
+			if err := handler(s); err != nil {
+				log.Debug().Err(err).Msgf("Stream %q handler failed", topic)
+				s.reset(err.Error())
+				return
+			}
+			if err := s.CloseSend(); err != nil {
+				log.Debug().Err(err).Msgf("Could not close stream %q", topic)
+			}
+		}()
+
+		return ctx.OK()
+	})
+}
+
+// handleStreamFrame forwards frame to the stream it belongs to.
+func (c *channel) handleStreamFrame(msg *transportMsg) {
+	// Frames sent by the opener belong to the stream opened by the peer.
+	key := streamKey{id: msg.id, opener: msg.topic == topicStreamFrameAccepter}
+
+	c.msgStreamsMu.Lock()
+	s, ok := c.msgStreams[key]
+	c.msgStreamsMu.Unlock()
+	if !ok {
+		log.Debug().Msgf("Stream %d not found, dropping frame", msg.id)
+		return
+	}
+
+	s.handleFrame(msg)
+}
+
+func (c *channel) sendStreamFrame(s *msgStream, frameType uint64, data []byte, reason string) error {
+	topic := topicStreamFrameAccepter
+	if s.opener {
+		topic = topicStreamFrameOpener
+	}
+
+	select {
+	case c.sendQueue <- &transportMsg{id: s.id, statusCode: frameType, topic: topic, msg: reason, data: data}:
+		return nil
+	case <-c.stop:
+		return ErrStreamClosed
+	}
+}
+
+func (c *channel) removeMsgStream(s *msgStream) {
+	c.msgStreamsMu.Lock()
+	defer c.msgStreamsMu.Unlock()
+
+	if c.msgStreams[s.key()] == s {
+		delete(c.msgStreams, s.key())
+	}
+}
+
+// closeMsgStreams finishes all streams once the channel is closed.
+func (c *channel) closeMsgStreams() {
+	c.msgStreamsMu.Lock()
+	streams := make([]*msgStream, 0, len(c.msgStreams))
+	for _, s := range c.msgStreams {
+		streams = append(streams, s)
+	}
+	c.msgStreamsMu.Unlock()
+
+	for _, s := range streams {
+		s.finish(ErrStreamClosed)
+	}
+}
+
+func isStreamFrame(msg *transportMsg) bool {
+	return msg.topic == topicStreamFrameOpener || msg.topic == topicStreamFrameAccepter
+}
@@ -0,0 +1,211 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package p2p
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChannel_Stream_Echo(t *testing.T) {
+	provider, consumer, err := createTestChannels()
+	require.NoError(t, err)
+	defer provider.Close()
+	defer consumer.Close()
+
+	provider.HandleStream("echo", func(s Stream) error {
+		for {
+			msg, err := s.Recv(context.Background())
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			if err := s.Send(context.Background(), msg); err != nil {
+				return err
+			}
+		}
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	s, err := consumer.OpenStream(ctx, "echo")
+	require.NoError(t, err)
+
+	// More messages than the window size are sent to exercise window updates.
+	count := 3 * streamWindowSize
+	go func() {
+		for i := 0; i < count; i++ {
+			if err := s.Send(ctx, &Message{Data: []byte(fmt.Sprint(i))}); err != nil {
+				return
+			}
+		}
+		s.CloseSend()
+	}()
+
+	for i := 0; i < count; i++ {
+		msg, err := s.Recv(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, fmt.Sprint(i), string(msg.Data))
+	}
+	_, err = s.Recv(ctx)
+	assert.Equal(t, io.EOF, err)
+
+	select {
+	case <-s.Done():
+	case <-ctx.Done():
+		t.Fatal("stream was not finished")
+	}
+}
+
+func TestChannel_Stream_FlowControl(t *testing.T) {
+	provider, consumer, err := createTestChannels()
+	require.NoError(t, err)
+	defer provider.Close()
+	defer consumer.Close()
+
+	var sent int32
+	provider.HandleStream("push", func(s Stream) error {
+		for i := 0; i < 2*streamWindowSize; i++ {
+			if err := s.Send(context.Background(), &Message{Data: []byte("notice")}); err != nil {
+				return err
+			}
+			atomic.AddInt32(&sent, 1)
+		}
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	s, err := consumer.OpenStream(ctx, "push")
+	require.NoError(t, err)
+
+	// Sender stops once the receive window is full.
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&sent) == streamWindowSize }, time.Second, 10*time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, int32(streamWindowSize), atomic.LoadInt32(&sent))
+
+	for i := 0; i < 2*streamWindowSize; i++ {
+		_, err := s.Recv(ctx)
+		require.NoError(t, err)
+	}
+	_, err = s.Recv(ctx)
+	assert.Equal(t, io.EOF, err)
+	assert.Equal(t, int32(2*streamWindowSize), atomic.LoadInt32(&sent))
+}
+
+func TestChannel_Stream_Cancel(t *testing.T) {
+	provider, consumer, err := createTestChannels()
+	require.NoError(t, err)
+	defer provider.Close()
+	defer consumer.Close()
+
+	handlerErr := make(chan error, 1)
+	provider.HandleStream("wait", func(s Stream) error {
+		_, err := s.Recv(context.Background())
+		handlerErr <- err
+		return err
+	})
+	provider.HandleStream("fail", func(s Stream) error {
+		return errors.New("no logs for you")
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	t.Run("Cancel is propagated to the peer", func(t *testing.T) {
+		s, err := consumer.OpenStream(ctx, "wait")
+		require.NoError(t, err)
+		s.Cancel()
+
+		select {
+		case err := <-handlerErr:
+			assert.ErrorIs(t, err, ErrStreamReset)
+		case <-ctx.Done():
+			t.Fatal("handler did not see stream reset")
+		}
+		assert.ErrorIs(t, s.Send(ctx, &Message{}), ErrStreamClosed)
+	})
+
+	t.Run("Handler error resets stream", func(t *testing.T) {
+		s, err := consumer.OpenStream(ctx, "fail")
+		require.NoError(t, err)
+
+		_, err = s.Recv(ctx)
+		assert.ErrorIs(t, err, ErrStreamReset)
+		assert.Contains(t, err.Error(), "no logs for you")
+	})
+
+	t.Run("Recv respects context", func(t *testing.T) {
+		s, err := consumer.OpenStream(ctx, "wait")
+		require.NoError(t, err)
+		defer s.Cancel()
+
+		recvCtx, recvCancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer recvCancel()
+		_, err = s.Recv(recvCtx)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+func TestChannel_Stream_HandlerNotFound(t *testing.T) {
+	provider, consumer, err := createTestChannels()
+	require.NoError(t, err)
+	defer provider.Close()
+	defer consumer.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err = consumer.OpenStream(ctx, "unknown")
+	assert.ErrorIs(t, err, ErrHandlerNotFound)
+	assert.Empty(t, consumer.(*channel).msgStreams)
+}
+
+func TestChannel_Stream_ClosedWithChannel(t *testing.T) {
+	provider, consumer, err := createTestChannels()
+	require.NoError(t, err)
+	defer provider.Close()
+
+	provider.HandleStream("wait", func(s Stream) error {
+		<-s.Done()
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	s, err := consumer.OpenStream(ctx, "wait")
+	require.NoError(t, err)
+
+	consumer.Close()
+	select {
+	case <-s.Done():
+	case <-ctx.Done():
+		t.Fatal("stream was not finished")
+	}
+	_, err = s.Recv(ctx)
+	assert.ErrorIs(t, err, ErrStreamClosed)
+}
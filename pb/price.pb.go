@@ -0,0 +1,214 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: pb/price.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type PriceChangeRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	SessionID         string                 `protobuf:"bytes,1,opt,name=sessionID,proto3" json:"sessionID,omitempty"`
+	PerGib            []byte                 `protobuf:"bytes,2,opt,name=PerGib,proto3" json:"PerGib,omitempty"`
+	PerHour           []byte                 `protobuf:"bytes,3,opt,name=PerHour,proto3" json:"PerHour,omitempty"`
+	BoundaryElapsedMs int64                  `protobuf:"varint,4,opt,name=boundaryElapsedMs,proto3" json:"boundaryElapsedMs,omitempty"`
+	BoundaryBytes     uint64                 `protobuf:"varint,5,opt,name=boundaryBytes,proto3" json:"boundaryBytes,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *PriceChangeRequest) Reset() {
+	*x = PriceChangeRequest{}
+	mi := &file_pb_price_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PriceChangeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceChangeRequest) ProtoMessage() {}
+
+func (x *PriceChangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_price_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceChangeRequest.ProtoReflect.Descriptor instead.
+func (*PriceChangeRequest) Descriptor() ([]byte, []int) {
+	return file_pb_price_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PriceChangeRequest) GetSessionID() string {
+	if x != nil {
+		return x.SessionID
+	}
+	return ""
+}
+
+func (x *PriceChangeRequest) GetPerGib() []byte {
+	if x != nil {
+		return x.PerGib
+	}
+	return nil
+}
+
+func (x *PriceChangeRequest) GetPerHour() []byte {
+	if x != nil {
+		return x.PerHour
+	}
+	return nil
+}
+
+func (x *PriceChangeRequest) GetBoundaryElapsedMs() int64 {
+	if x != nil {
+		return x.BoundaryElapsedMs
+	}
+	return 0
+}
+
+func (x *PriceChangeRequest) GetBoundaryBytes() uint64 {
+	if x != nil {
+		return x.BoundaryBytes
+	}
+	return 0
+}
+
+type PriceChangeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Accepted      bool                   `protobuf:"varint,1,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PriceChangeResponse) Reset() {
+	*x = PriceChangeResponse{}
+	mi := &file_pb_price_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PriceChangeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceChangeResponse) ProtoMessage() {}
+
+func (x *PriceChangeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_price_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceChangeResponse.ProtoReflect.Descriptor instead.
+func (*PriceChangeResponse) Descriptor() ([]byte, []int) {
+	return file_pb_price_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PriceChangeResponse) GetAccepted() bool {
+	if x != nil {
+		return x.Accepted
+	}
+	return false
+}
+
+func (x *PriceChangeResponse) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+var File_pb_price_proto protoreflect.FileDescriptor
+
+const file_pb_price_proto_rawDesc = "" +
+	"\n" +
+	"\x0epb/price.proto\x12\x02pb\"\xb8\x01\n" +
+	"\x12PriceChangeRequest\x12\x1c\n" +
+	"\tsessionID\x18\x01 \x01(\tR\tsessionID\x12\x16\n" +
+	"\x06PerGib\x18\x02 \x01(\fR\x06PerGib\x12\x18\n" +
+	"\aPerHour\x18\x03 \x01(\fR\aPerHour\x12,\n" +
+	"\x11boundaryElapsedMs\x18\x04 \x01(\x03R\x11boundaryElapsedMs\x12$\n" +
+	"\rboundaryBytes\x18\x05 \x01(\x04R\rboundaryBytes\"I\n" +
+	"\x13PriceChangeResponse\x12\x1a\n" +
+	"\baccepted\x18\x01 \x01(\bR\baccepted\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reasonB\x06Z\x04.;pbb\x06proto3"
+
+var (
+	file_pb_price_proto_rawDescOnce sync.Once
+	file_pb_price_proto_rawDescData []byte
+)
+
+func file_pb_price_proto_rawDescGZIP() []byte {
+	file_pb_price_proto_rawDescOnce.Do(func() {
+		file_pb_price_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_pb_price_proto_rawDesc), len(file_pb_price_proto_rawDesc)))
+	})
+	return file_pb_price_proto_rawDescData
+}
+
+var file_pb_price_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_pb_price_proto_goTypes = []any{
+	(*PriceChangeRequest)(nil),  // 0: pb.PriceChangeRequest
+	(*PriceChangeResponse)(nil), // 1: pb.PriceChangeResponse
+}
+var file_pb_price_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_pb_price_proto_init() }
+func file_pb_price_proto_init() {
+	if File_pb_price_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pb_price_proto_rawDesc), len(file_pb_price_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_pb_price_proto_goTypes,
+		DependencyIndexes: file_pb_price_proto_depIdxs,
+		MessageInfos:      file_pb_price_proto_msgTypes,
+	}.Build()
+	File_pb_price_proto = out.File
+	file_pb_price_proto_goTypes = nil
+	file_pb_price_proto_depIdxs = nil
+}
@@ -0,0 +1,326 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package pilvytis
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/asdine/storm/v3"
+	"github.com/rs/zerolog/log"
+
+	"github.com/mysteriumnetwork/payments/crypto"
+
+	"github.com/mysteriumnetwork/node/eventbus"
+	"github.com/mysteriumnetwork/node/identity"
+	pingpongEvent "github.com/mysteriumnetwork/node/session/pingpong/event"
+)
+
+const (
+	autoTopUpRulesBucket  = "pilvytis-auto-topup-rules"
+	autoTopUpOrdersBucket = "pilvytis-auto-topup-orders"
+
+	// autoTopUpPendingTimeout is how long an unpaid automatic order blocks creating another one.
+	autoTopUpPendingTimeout = 24 * time.Hour
+)
+
+// ErrAutoTopUpRuleNotFound is returned when identity has no automatic top-up rule.
+var ErrAutoTopUpRuleNotFound = errors.New("auto top-up rule not found")
+
+// AutoTopUpRule describes when and how balance of an identity is topped up automatically.
+type AutoTopUpRule struct {
+	Identity string `storm:"id"`
+	Gateway  string
+	// Threshold is a balance below which a top-up is made.
+	Threshold *big.Int
+	// Amount is MYST amount of a single top-up.
+	Amount *big.Int
+	// MonthlyMax limits MYST amount topped up per calendar month, zero means no limit.
+	MonthlyMax  *big.Int
+	PayCurrency string
+	Country     string
+	State       string
+	ProjectID   string
+	CallerData  json.RawMessage
+}
+
+// Validate checks if rule can be used for automatic top-ups.
+func (r AutoTopUpRule) Validate() error {
+	switch {
+	case r.Gateway == "":
+		return errors.New("gateway is required")
+	case r.Threshold == nil || r.Threshold.Sign() <= 0:
+		return errors.New("threshold must be positive")
+	case r.Amount == nil || r.Amount.Sign() <= 0:
+		return errors.New("amount must be positive")
+	case r.MonthlyMax != nil && r.MonthlyMax.Sign() < 0:
+		return errors.New("monthly maximum must not be negative")
+	case r.MonthlyMax != nil && r.MonthlyMax.Sign() > 0 && r.MonthlyMax.Cmp(r.Amount) < 0:
+		return errors.New("monthly maximum must not be less than amount")
+	}
+	return nil
+}
+
+// autoTopUpOrder is a payment order created by an automatic top-up.
+type autoTopUpOrder struct {
+	ID        string `storm:"id"`
+	Identity  string
+	Amount    *big.Int
+	Status    PaymentOrderStatus
+	CreatedAt time.Time
+}
+
+type autoTopUpStorage interface {
+	Store(bucket string, data interface{}) error
+	GetOneByField(bucket string, fieldName string, key interface{}, to interface{}) error
+	GetAllFrom(bucket string, data interface{}) error
+	Delete(bucket string, data interface{}) error
+}
+
+type orderIssuer interface {
+	CreatePaymentGatewayOrder(cgo GatewayOrderRequest) (*GatewayOrderResponse, error)
+}
+
+// AutoTopUp creates payment orders when consumer balance drops below the configured threshold.
+type AutoTopUp struct {
+	issuer    orderIssuer
+	storage   autoTopUpStorage
+	publisher eventbus.Publisher
+	now       func() time.Time
+
+	lock sync.Mutex
+	// limitNotified remembers identities notified about reached monthly limit.
+	limitNotified map[string]time.Month
+}
+
+// NewAutoTopUp returns a new automatic top-up.
+func NewAutoTopUp(issuer orderIssuer, storage autoTopUpStorage, publisher eventbus.Publisher) *AutoTopUp {
+	return &AutoTopUp{
+		issuer:        issuer,
+		storage:       storage,
+		publisher:     publisher,
+		now:           time.Now,
+		limitNotified: make(map[string]time.Month),
+	}
+}
+
+// Subscribe subscribes to balance and order changes.
+func (a *AutoTopUp) Subscribe(bus eventbus.Subscriber) error {
+	if err := bus.SubscribeAsync(pingpongEvent.AppTopicBalanceChanged, a.handleBalanceChange); err != nil {
+		return err
+	}
+	return bus.SubscribeAsync(AppTopicOrderUpdated, a.handleOrderUpdate)
+}
+
+// Rule returns automatic top-up rule of the given identity.
+func (a *AutoTopUp) Rule(id identity.Identity) (AutoTopUpRule, error) {
+	var rule AutoTopUpRule
+	err := a.storage.GetOneByField(autoTopUpRulesBucket, "Identity", id.Address, &rule)
+	if errors.Is(err, storm.ErrNotFound) {
+		return rule, ErrAutoTopUpRuleNotFound
+	}
+	return rule, err
+}
+
+// SetRule validates and stores automatic top-up rule of an identity.
+func (a *AutoTopUp) SetRule(rule AutoTopUpRule) error {
+	if err := rule.Validate(); err != nil {
+		return err
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	delete(a.limitNotified, rule.Identity)
+	return a.storage.Store(autoTopUpRulesBucket, &rule)
+}
+
+// RemoveRule disables automatic top-up of the given identity.
+func (a *AutoTopUp) RemoveRule(id identity.Identity) error {
+	err := a.storage.Delete(autoTopUpRulesBucket, &AutoTopUpRule{Identity: id.Address})
+	if errors.Is(err, storm.ErrNotFound) {
+		return ErrAutoTopUpRuleNotFound
+	}
+	return err
+}
+
+// ToppedUpThisMonth returns MYST amount of automatic top-ups made this month, failed ones excluded.
+func (a *AutoTopUp) ToppedUpThisMonth(id identity.Identity) (*big.Int, error) {
+	orders, err := a.orders(id)
+	if err != nil {
+		return nil, err
+	}
+	return a.monthlyTotal(orders), nil
+}
+
+func (a *AutoTopUp) handleBalanceChange(e pingpongEvent.AppEventBalanceChanged) {
+	if e.Current == nil {
+		return
+	}
+
+	rule, err := a.Rule(e.Identity)
+	if errors.Is(err, ErrAutoTopUpRuleNotFound) {
+		return
+	}
+	if err != nil {
+		log.Err(err).Str("identity", e.Identity.Address).Msg("Could not load auto top-up rule")
+		return
+	}
+	if e.Current.Cmp(rule.Threshold) >= 0 {
+		return
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if err := a.topUp(e.Identity, rule); err != nil {
+		log.Err(err).Str("identity", e.Identity.Address).Msg("Auto top-up failed")
+		a.publish(AppEventAutoTopUp{
+			Identity: e.Identity.Address,
+			Amount:   rule.Amount,
+			Status:   AutoTopUpStatusFailed,
+			Error:    err.Error(),
+		})
+	}
+}
+
+func (a *AutoTopUp) topUp(id identity.Identity, rule AutoTopUpRule) error {
+	orders, err := a.orders(id)
+	if err != nil {
+		return err
+	}
+
+	now := a.now()
+	for _, o := range orders {
+		if o.Status.Incomplete() && now.Sub(o.CreatedAt) < autoTopUpPendingTimeout {
+			return nil
+		}
+	}
+
+	total := a.monthlyTotal(orders)
+	if rule.MonthlyMax != nil && rule.MonthlyMax.Sign() > 0 && new(big.Int).Add(total, rule.Amount).Cmp(rule.MonthlyMax) > 0 {
+		if a.limitNotified[id.Address] != now.UTC().Month() {
+			a.limitNotified[id.Address] = now.UTC().Month()
+			log.Warn().Str("identity", id.Address).Msgf("Auto top-up skipped, monthly maximum %s reached", crypto.BigMystToDecimal(rule.MonthlyMax))
+			a.publish(AppEventAutoTopUp{
+				Identity: id.Address,
+				Amount:   rule.Amount,
+				Status:   AutoTopUpStatusLimitReached,
+			})
+		}
+		return nil
+	}
+
+	resp, err := a.issuer.CreatePaymentGatewayOrder(GatewayOrderRequest{
+		Identity:    id,
+		Gateway:     rule.Gateway,
+		MystAmount:  crypto.BigMystToDecimal(rule.Amount).String(),
+		PayCurrency: rule.PayCurrency,
+		Country:     rule.Country,
+		State:       rule.State,
+		ProjectID:   rule.ProjectID,
+		CallerData:  rule.CallerData,
+	})
+	if err != nil {
+		return fmt.Errorf("could not create payment order: %w", err)
+	}
+
+	order := autoTopUpOrder{
+		ID:        resp.ID,
+		Identity:  id.Address,
+		Amount:    rule.Amount,
+		Status:    resp.Status,
+		CreatedAt: now,
+	}
+	if err := a.storage.Store(autoTopUpOrdersBucket, &order); err != nil {
+		return fmt.Errorf("could not store auto top-up order: %w", err)
+	}
+
+	log.Info().Str("identity", id.Address).Str("order", resp.ID).Msgf("Auto top-up order for %s MYST created", crypto.BigMystToDecimal(rule.Amount))
+	a.publish(AppEventAutoTopUp{
+		Identity: id.Address,
+		OrderID:  resp.ID,
+		Amount:   rule.Amount,
+		Status:   AutoTopUpStatusCreated,
+	})
+	return nil
+}
+
+func (a *AutoTopUp) handleOrderUpdate(e AppEventOrderUpdated) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	var order autoTopUpOrder
+	if err := a.storage.GetOneByField(autoTopUpOrdersBucket, "ID", e.ID, &order); err != nil {
+		return
+	}
+
+	status, ok := e.Status.(PaymentOrderStatus)
+	if !ok || status == order.Status {
+		return
+	}
+	order.Status = status
+	if err := a.storage.Store(autoTopUpOrdersBucket, &order); err != nil {
+		log.Err(err).Str("order", order.ID).Msg("Could not update auto top-up order")
+		return
+	}
+
+	switch {
+	case status.Paid():
+		a.publish(AppEventAutoTopUp{Identity: order.Identity, OrderID: order.ID, Amount: order.Amount, Status: AutoTopUpStatusPaid})
+	case !status.Incomplete():
+		a.publish(AppEventAutoTopUp{Identity: order.Identity, OrderID: order.ID, Amount: order.Amount, Status: AutoTopUpStatusFailed})
+	}
+}
+
+func (a *AutoTopUp) orders(id identity.Identity) ([]autoTopUpOrder, error) {
+	var all []autoTopUpOrder
+	err := a.storage.GetAllFrom(autoTopUpOrdersBucket, &all)
+	if err != nil && !errors.Is(err, storm.ErrNotFound) {
+		return nil, fmt.Errorf("could not load auto top-up orders: %w", err)
+	}
+
+	var orders []autoTopUpOrder
+	for _, o := range all {
+		if o.Identity == id.Address {
+			orders = append(orders, o)
+		}
+	}
+	return orders, nil
+}
+
+func (a *AutoTopUp) monthlyTotal(orders []autoTopUpOrder) *big.Int {
+	now := a.now().UTC()
+	total := new(big.Int)
+	for _, o := range orders {
+		created := o.CreatedAt.UTC()
+		if created.Year() != now.Year() || created.Month() != now.Month() || o.Status == PaymentOrderStatusFailed {
+			continue
+		}
+		total.Add(total, o.Amount)
+	}
+	return total
+}
+
+func (a *AutoTopUp) publish(e AppEventAutoTopUp) {
+	a.publisher.Publish(AppTopicAutoTopUp, e)
+}
@@ -0,0 +1,235 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package pilvytis
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mysteriumnetwork/payments/crypto"
+
+	"github.com/mysteriumnetwork/node/core/location/locationstate"
+	"github.com/mysteriumnetwork/node/core/storage/boltdb"
+	"github.com/mysteriumnetwork/node/eventbus"
+	"github.com/mysteriumnetwork/node/identity"
+	"github.com/mysteriumnetwork/node/requests"
+	pingpongEvent "github.com/mysteriumnetwork/node/session/pingpong/event"
+)
+
+// fakePilvytis serves payment order endpoints of Pilvytis.
+type fakePilvytis struct {
+	*httptest.Server
+
+	mu     sync.Mutex
+	orders []GatewayOrderResponse
+}
+
+func newFakePilvytis() *fakePilvytis {
+	f := &fakePilvytis{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v2/payment/orders", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		json.NewEncoder(w).Encode(f.orders)
+	})
+	mux.HandleFunc("/api/v2/payment/test-gateway/orders", func(w http.ResponseWriter, r *http.Request) {
+		var req paymentOrderRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		order := GatewayOrderResponse{
+			ID:          fmt.Sprintf("order-%d", len(f.orders)+1),
+			Status:      PaymentOrderStatusNew,
+			GatewayName: "test-gateway",
+			ReceiveMYST: req.MystAmount,
+			PayCurrency: req.PayCurrency,
+		}
+		f.orders = append(f.orders, order)
+		json.NewEncoder(w).Encode(order)
+	})
+	f.Server = httptest.NewServer(mux)
+	return f
+}
+
+func (f *fakePilvytis) created() []GatewayOrderResponse {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]GatewayOrderResponse(nil), f.orders...)
+}
+
+func (f *fakePilvytis) setStatus(id string, status PaymentOrderStatus) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i := range f.orders {
+		if f.orders[i].ID == id {
+			f.orders[i].Status = status
+		}
+	}
+}
+
+type mockTopUpSigner struct{}
+
+func (mockTopUpSigner) Sign([]byte) (identity.Signature, error) {
+	return identity.SignatureHex("deadbeef"), nil
+}
+
+type mockTopUpLocation struct{}
+
+func (mockTopUpLocation) GetOrigin() locationstate.Location {
+	return locationstate.Location{Country: "LT"}
+}
+
+type mockTopUpChannels struct{}
+
+func (mockTopUpChannels) GetActiveChannelAddress(int64, common.Address) (common.Address, error) {
+	return common.HexToAddress("0xc"), nil
+}
+
+type mockTopUpIdentities struct {
+	id identity.Identity
+}
+
+func (m mockTopUpIdentities) GetIdentities() []identity.Identity {
+	return []identity.Identity{m.id}
+}
+
+func (m mockTopUpIdentities) IsUnlocked(string) bool {
+	return true
+}
+
+func myst(amount float64) *big.Int {
+	return crypto.FloatToBigMyst(amount)
+}
+
+func TestAutoTopUp(t *testing.T) {
+	server := newFakePilvytis()
+	defer server.Close()
+
+	storage, err := boltdb.NewStorage(t.TempDir())
+	require.NoError(t, err)
+	defer storage.Close()
+
+	id := identity.FromAddress("0x000000000000000000000000000000000000000a")
+	bus := eventbus.New()
+	api := NewAPI(requests.NewHTTPClient("0.0.0.0", time.Second), server.URL, func(identity.Identity) identity.Signer {
+		return mockTopUpSigner{}
+	}, mockTopUpLocation{}, mockTopUpChannels{})
+	tracker := NewStatusTracker(api, mockTopUpIdentities{id: id}, bus, 10*time.Millisecond)
+	go tracker.Track()
+	defer tracker.Stop()
+
+	var (
+		eventsMu sync.Mutex
+		events   []AppEventAutoTopUp
+	)
+	require.NoError(t, bus.Subscribe(AppTopicAutoTopUp, func(e AppEventAutoTopUp) {
+		eventsMu.Lock()
+		defer eventsMu.Unlock()
+		events = append(events, e)
+	}))
+	lastEvent := func() AppEventAutoTopUp {
+		eventsMu.Lock()
+		defer eventsMu.Unlock()
+		if len(events) == 0 {
+			return AppEventAutoTopUp{}
+		}
+		return events[len(events)-1]
+	}
+
+	topUp := NewAutoTopUp(NewOrderIssuer(api, tracker), storage, bus)
+	require.NoError(t, topUp.Subscribe(bus))
+
+	assert.Error(t, topUp.SetRule(AutoTopUpRule{Identity: id.Address, Gateway: "test-gateway", Threshold: myst(5), Amount: myst(10), MonthlyMax: myst(1)}))
+	require.NoError(t, topUp.SetRule(AutoTopUpRule{
+		Identity:    id.Address,
+		Gateway:     "test-gateway",
+		Threshold:   myst(5),
+		Amount:      myst(10),
+		MonthlyMax:  myst(25),
+		PayCurrency: "EUR",
+	}))
+
+	lowBalance := func() {
+		bus.Publish(pingpongEvent.AppTopicBalanceChanged, pingpongEvent.AppEventBalanceChanged{
+			Identity: id,
+			Previous: myst(6),
+			Current:  myst(4),
+		})
+	}
+
+	// balance above threshold does not top up
+	bus.Publish(pingpongEvent.AppTopicBalanceChanged, pingpongEvent.AppEventBalanceChanged{Identity: id, Previous: myst(7), Current: myst(6)})
+
+	lowBalance()
+	assert.Eventually(t, func() bool {
+		return lastEvent().Status == AutoTopUpStatusCreated
+	}, 2*time.Second, 10*time.Millisecond)
+	orders := server.created()
+	require.Len(t, orders, 1)
+	assert.Equal(t, "10", orders[0].ReceiveMYST)
+	assert.Equal(t, "EUR", orders[0].PayCurrency)
+	assert.Equal(t, "order-1", lastEvent().OrderID)
+
+	// pending order blocks another top-up
+	lowBalance()
+	time.Sleep(50 * time.Millisecond)
+	assert.Len(t, server.created(), 1)
+
+	server.setStatus("order-1", PaymentOrderStatusPaid)
+	assert.Eventually(t, func() bool {
+		return lastEvent().Status == AutoTopUpStatusPaid
+	}, 2*time.Second, 10*time.Millisecond)
+
+	lowBalance()
+	assert.Eventually(t, func() bool {
+		return len(server.created()) == 2 && lastEvent().OrderID == "order-2"
+	}, 2*time.Second, 10*time.Millisecond)
+	server.setStatus("order-2", PaymentOrderStatusPaid)
+	assert.Eventually(t, func() bool {
+		return lastEvent().Status == AutoTopUpStatusPaid
+	}, 2*time.Second, 10*time.Millisecond)
+
+	total, err := topUp.ToppedUpThisMonth(id)
+	require.NoError(t, err)
+	assert.Equal(t, myst(20), total)
+
+	// third top-up would exceed the monthly maximum
+	lowBalance()
+	assert.Eventually(t, func() bool {
+		return lastEvent().Status == AutoTopUpStatusLimitReached
+	}, 2*time.Second, 10*time.Millisecond)
+	assert.Len(t, server.created(), 2)
+
+	require.NoError(t, topUp.RemoveRule(id))
+	_, err = topUp.Rule(id)
+	assert.ErrorIs(t, err, ErrAutoTopUpRuleNotFound)
+}
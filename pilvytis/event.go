@@ -17,6 +17,8 @@
 
 package pilvytis
 
+import "math/big"
+
 // AppTopicOrderUpdated is an topic when the payment order is updated.
 const AppTopicOrderUpdated = "order_updated"
 
@@ -24,3 +26,23 @@ const AppTopicOrderUpdated = "order_updated"
 type AppEventOrderUpdated struct {
 	OrderSummary
 }
+
+// AppTopicAutoTopUp is a topic for automatic balance top-up events.
+const AppTopicAutoTopUp = "auto_topup"
+
+// Automatic top-up statuses.
+const (
+	AutoTopUpStatusCreated      = "created"
+	AutoTopUpStatusPaid         = "paid"
+	AutoTopUpStatusFailed       = "failed"
+	AutoTopUpStatusLimitReached = "limit_reached"
+)
+
+// AppEventAutoTopUp is the event payload for AppTopicAutoTopUp topic.
+type AppEventAutoTopUp struct {
+	Identity string
+	OrderID  string
+	Amount   *big.Int
+	Status   string
+	Error    string
+}
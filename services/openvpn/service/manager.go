@@ -56,6 +56,7 @@ type Manager struct {
 	natService      nat.NATService
 	ports           port.ServicePortSupplier
 	dnsProxy        *dns.Proxy
+	dnsHandler      *dns.ProviderHandler
 	bus             eventbus.EventBus
 	trafficFirewall firewall.IncomingTrafficFirewall
 	vpnNetwork      net.IPNet
@@ -82,8 +83,9 @@ func (m *Manager) Serve(instance *service.Instance) (err error) {
 	}
 
 	dnsPort := 11153
-	dnsHandler, err := dns.ResolveViaSystem()
+	m.dnsHandler, err = dns.NewProviderHandler()
 	if err == nil {
+		dnsHandler := m.dnsHandler.Handler
 		if instance.PolicyProvider().HasDNSRules() {
 			dnsHandler = dns.WhitelistAnswers(dnsHandler, m.trafficFirewall, instance.PolicyProvider())
 			removeRule, err := m.trafficFirewall.BlockIncomingTraffic(m.vpnNetwork)
@@ -163,6 +165,10 @@ func (m *Manager) Stop() error {
 		m.openvpnProcess.Stop()
 	}
 
+	if m.dnsHandler != nil {
+		m.dnsHandler.Stop()
+	}
+
 	if m.dnsProxy != nil {
 		if err := m.dnsProxy.Stop(); err != nil {
 			return fmt.Errorf("could not stop DNS proxy: %w", err)
@@ -30,6 +30,7 @@ import (
 	"github.com/mysteriumnetwork/node/services/openvpn"
 	openvpn_service "github.com/mysteriumnetwork/node/services/openvpn/service"
 	"github.com/mysteriumnetwork/node/services/quic"
+	"github.com/mysteriumnetwork/node/services/relay"
 	"github.com/mysteriumnetwork/node/services/scraping"
 	"github.com/mysteriumnetwork/node/services/wireguard"
 	wireguard_service "github.com/mysteriumnetwork/node/services/wireguard/service"
@@ -45,6 +46,7 @@ var JSONParsersByType = map[string]ServiceOptionsParser{
 	datatransfer.ServiceType: wireguard_service.ParseJSONOptions,
 	dvpn.ServiceType:         wireguard_service.ParseJSONOptions,
 	monitoring.ServiceType:   wireguard_service.ParseJSONOptions,
+	relay.ServiceType:        relay.ParseJSONOptions,
 }
 
 // ServiceOptionsParser parses request to service specific options
@@ -61,6 +63,7 @@ func Types() []string {
 		datatransfer.ServiceType,
 		dvpn.ServiceType,
 		monitoring.ServiceType,
+		relay.ServiceType,
 	}
 }
 
@@ -83,6 +86,8 @@ func TypeConfiguredOptions(serviceType string) (service.Options, error) {
 		return wireguard_service.GetOptions(), nil
 	case monitoring.ServiceType:
 		return wireguard_service.GetOptions(), nil
+	case relay.ServiceType:
+		return relay.GetOptions(), nil
 	default:
 		return nil, errors.Errorf("unknown service type: %q", serviceType)
 	}
@@ -0,0 +1,177 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package relay
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net"
+	"sync"
+	"sync/atomic"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/mysteriumnetwork/node/core/port"
+)
+
+// allocation is a set of lanes forwarding datagrams of a single session.
+// Each lane forwards between the owner, which has bound it with the session
+// token, and the peer whose IP the owner has permitted.
+type allocation struct {
+	token []byte
+	lanes []*lane
+	once  sync.Once
+}
+
+func newAllocation(ports port.ServicePortSupplier, count int) (*allocation, error) {
+	token, err := newToken()
+	if err != nil {
+		return nil, fmt.Errorf("could not generate allocation token: %w", err)
+	}
+
+	portList, err := ports.AcquireMultiple(count)
+	if err != nil {
+		return nil, fmt.Errorf("could not acquire ports: %w", err)
+	}
+
+	a := &allocation{token: token}
+	for _, p := range portList {
+		conn, err := net.ListenUDP("udp4", &net.UDPAddr{Port: p.Num()})
+		if err != nil {
+			a.close()
+			return nil, fmt.Errorf("could not listen on port %d: %w", p.Num(), err)
+		}
+		a.lanes = append(a.lanes, &lane{conn: conn, token: token})
+	}
+
+	for _, l := range a.lanes {
+		go l.serve()
+	}
+
+	return a, nil
+}
+
+func (a *allocation) ports() (ports []int) {
+	for _, l := range a.lanes {
+		ports = append(ports, l.conn.LocalAddr().(*net.UDPAddr).Port)
+	}
+	return ports
+}
+
+// stats returns bytes sent to and received from the owner over all lanes.
+func (a *allocation) stats() (up, down uint64) {
+	for _, l := range a.lanes {
+		up += atomic.LoadUint64(&l.toOwner)
+		down += atomic.LoadUint64(&l.fromOwner)
+	}
+	return up, down
+}
+
+func (a *allocation) close() {
+	a.once.Do(func() {
+		for _, l := range a.lanes {
+			l.conn.Close()
+		}
+	})
+}
+
+type lane struct {
+	conn  *net.UDPConn
+	token []byte
+
+	mu        sync.Mutex
+	owner     *net.UDPAddr
+	peer      *net.UDPAddr
+	permitted net.IP
+
+	toOwner, fromOwner uint64
+}
+
+func (l *lane) serve() {
+	buf := make([]byte, maxDatagramSize)
+	for {
+		n, addr, err := l.conn.ReadFromUDP(buf)
+		if err != nil {
+			return
+		}
+
+		if kind, token, payload, ok := parseControlPacket(buf[:n]); ok {
+			if subtle.ConstantTimeCompare(token, l.token) == 1 {
+				l.control(kind, payload, addr)
+			}
+			continue
+		}
+
+		dst, fromOwner := l.route(addr)
+		if dst == nil {
+			continue
+		}
+
+		if _, err := l.conn.WriteToUDP(buf[:n], dst); err != nil {
+			log.Trace().Err(err).Msgf("Failed to forward datagram to %s", dst)
+			continue
+		}
+
+		if fromOwner {
+			atomic.AddUint64(&l.fromOwner, uint64(n))
+		} else {
+			atomic.AddUint64(&l.toOwner, uint64(n))
+		}
+	}
+}
+
+func (l *lane) control(kind byte, payload []byte, addr *net.UDPAddr) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	switch kind {
+	case packetBind:
+		l.owner = addr
+	case packetPermit:
+		if l.owner == nil || !sameAddr(l.owner, addr) {
+			return
+		}
+		if len(payload) != net.IPv4len && len(payload) != net.IPv6len {
+			return
+		}
+		l.permitted = net.IP(append([]byte(nil), payload...))
+		l.peer = nil
+	}
+}
+
+// route returns the destination of a datagram received from addr.
+func (l *lane) route(addr *net.UDPAddr) (dst *net.UDPAddr, fromOwner bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.owner != nil && sameAddr(l.owner, addr) {
+		return l.peer, true
+	}
+
+	if l.permitted == nil || !l.permitted.Equal(addr.IP) {
+		return nil, false
+	}
+
+	// Follow the peer if its NAT rebinds the mapping.
+	l.peer = addr
+	return l.owner, false
+}
+
+func sameAddr(a, b *net.UDPAddr) bool {
+	return a.Port == b.Port && a.IP.Equal(b.IP)
+}
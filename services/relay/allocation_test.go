@@ -0,0 +1,76 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package relay
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parseControlPacket(t *testing.T) {
+	token := make([]byte, tokenSize)
+	token[0] = 1
+
+	kind, parsedToken, payload, ok := parseControlPacket(controlPacket(packetPermit, token, net.IPv4(1, 2, 3, 4).To4()))
+	assert.True(t, ok)
+	assert.Equal(t, packetPermit, kind)
+	assert.Equal(t, token, parsedToken)
+	assert.Equal(t, []byte{1, 2, 3, 4}, payload)
+
+	_, _, _, ok = parseControlPacket([]byte("MRLY"))
+	assert.False(t, ok)
+
+	_, _, _, ok = parseControlPacket(controlPacket(7, token, nil))
+	assert.False(t, ok)
+}
+
+func Test_lane_route(t *testing.T) {
+	owner := &net.UDPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 1000}
+	peer := &net.UDPAddr{IP: net.IPv4(20, 0, 0, 1), Port: 2000}
+	stranger := &net.UDPAddr{IP: net.IPv4(30, 0, 0, 1), Port: 3000}
+	l := &lane{}
+
+	// Nothing is forwarded before the owner binds and permits the peer.
+	dst, _ := l.route(peer)
+	assert.Nil(t, dst)
+
+	l.control(packetBind, nil, owner)
+	l.control(packetPermit, peer.IP.To4(), stranger)
+	dst, _ = l.route(peer)
+	assert.Nil(t, dst, "only owner can permit peers")
+
+	l.control(packetPermit, peer.IP.To4(), owner)
+	dst, fromOwner := l.route(peer)
+	assert.Equal(t, owner, dst)
+	assert.False(t, fromOwner)
+
+	dst, fromOwner = l.route(owner)
+	assert.Equal(t, peer, dst)
+	assert.True(t, fromOwner)
+
+	dst, _ = l.route(stranger)
+	assert.Nil(t, dst)
+
+	// Peer is followed when its NAT mapping changes.
+	rebound := &net.UDPAddr{IP: peer.IP, Port: 2001}
+	l.route(rebound)
+	dst, _ = l.route(owner)
+	assert.Equal(t, rebound, dst)
+}
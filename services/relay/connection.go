@@ -0,0 +1,139 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package relay
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/mysteriumnetwork/node/core/connection"
+	"github.com/mysteriumnetwork/node/core/connection/connectionstate"
+)
+
+// Connection binds to a relay allocation and hands its tunnel over to the relayer.
+type Connection struct {
+	tunnels   *tunnelRegistry
+	keepAlive time.Duration
+
+	mu      sync.Mutex
+	key     int
+	tunnel  *Tunnel
+	stateCh chan connectionstate.State
+}
+
+var _ connection.Connection = &Connection{}
+
+// State returns connection state channel.
+func (c *Connection) State() <-chan connectionstate.State {
+	return c.stateCh
+}
+
+// Statistics returns bytes transferred through the relay.
+func (c *Connection) Statistics() (connectionstate.Statistics, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.tunnel == nil {
+		return connectionstate.Statistics{At: time.Now()}, nil
+	}
+
+	sent, received := c.tunnel.Stats()
+	return connectionstate.Statistics{
+		At:            time.Now(),
+		BytesSent:     sent,
+		BytesReceived: received,
+	}, nil
+}
+
+// Reconnect restarts a connection with a new options.
+func (c *Connection) Reconnect(ctx context.Context, options connection.ConnectOptions) error {
+	return fmt.Errorf("not supported")
+}
+
+// Start implements the connection.Connection interface
+func (c *Connection) Start(ctx context.Context, options connection.ConnectOptions) error {
+	var cfg ServiceConfig
+	if err := json.Unmarshal(options.SessionConfig, &cfg); err != nil {
+		return fmt.Errorf("could not parse relay config: %w", err)
+	}
+
+	c.stateCh <- connectionstate.Connecting
+
+	tunnel, err := NewTunnel(cfg, c.keepAlive)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	c.key = options.Params.ProxyPort
+	c.tunnel = tunnel
+	c.mu.Unlock()
+
+	c.tunnels.put(c.key, tunnel)
+	c.stateCh <- connectionstate.Connected
+	return nil
+}
+
+// Stop implements the connection.Connection interface
+func (c *Connection) Stop() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.tunnel == nil {
+		return
+	}
+
+	c.stateCh <- connectionstate.Disconnecting
+	c.tunnels.delete(c.key)
+	c.tunnel.Close()
+	c.tunnel = nil
+	c.stateCh <- connectionstate.NotConnected
+	close(c.stateCh)
+}
+
+// GetConfig returns the consumer configuration for session creation
+func (c *Connection) GetConfig() (connection.ConsumerConfig, error) {
+	return nil, nil
+}
+
+type tunnelRegistry struct {
+	mu      sync.Mutex
+	tunnels map[int]*Tunnel
+}
+
+func (r *tunnelRegistry) put(key int, t *Tunnel) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tunnels[key] = t
+}
+
+func (r *tunnelRegistry) get(key int) (*Tunnel, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	t, ok := r.tunnels[key]
+	return t, ok
+}
+
+func (r *tunnelRegistry) delete(key int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.tunnels, key)
+}
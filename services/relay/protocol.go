@@ -0,0 +1,74 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package relay
+
+import (
+	"bytes"
+	"crypto/rand"
+)
+
+// ServiceConfig is the session configuration passed from the relay to the node using it.
+type ServiceConfig struct {
+	IP    string `json:"ip"`
+	Ports []int  `json:"ports"`
+	Token []byte `json:"token"`
+}
+
+// Control packets are exchanged between the relay and the node owning the allocation,
+// everything else received on the allocated ports is forwarded.
+//
+// Format: magic (4 bytes) | kind (1 byte) | token (16 bytes) | payload.
+const (
+	packetBind   byte = 1 // Registers sender as the owner of the lane, also used as keep-alive.
+	packetPermit byte = 2 // Permits the peer IP from payload to send through the lane.
+
+	tokenSize       = 16
+	headerSize      = 4 + 1 + tokenSize
+	maxDatagramSize = 64 * 1024
+)
+
+var packetMagic = []byte("MRLY")
+
+func newToken() ([]byte, error) {
+	token := make([]byte, tokenSize)
+	if _, err := rand.Read(token); err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+func controlPacket(kind byte, token, payload []byte) []byte {
+	packet := make([]byte, 0, headerSize+len(payload))
+	packet = append(packet, packetMagic...)
+	packet = append(packet, kind)
+	packet = append(packet, token...)
+	return append(packet, payload...)
+}
+
+func parseControlPacket(packet []byte) (kind byte, token, payload []byte, ok bool) {
+	if len(packet) < headerSize || !bytes.Equal(packet[:len(packetMagic)], packetMagic) {
+		return 0, nil, nil, false
+	}
+
+	kind = packet[len(packetMagic)]
+	if kind != packetBind && kind != packetPermit {
+		return 0, nil, nil, false
+	}
+
+	return kind, packet[len(packetMagic)+1 : headerSize], packet[headerSize:], true
+}
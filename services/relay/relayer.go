@@ -0,0 +1,153 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package relay
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/mysteriumnetwork/node/core/connection"
+	"github.com/mysteriumnetwork/node/core/connection/connectionstate"
+	"github.com/mysteriumnetwork/node/core/discovery/proposal"
+	"github.com/mysteriumnetwork/node/identity"
+	"github.com/mysteriumnetwork/node/p2p/nat"
+)
+
+// DefaultKeepAlive is the interval of refreshing relay bindings through NATs.
+const DefaultKeepAlive = 15 * time.Second
+
+type proposalRepository interface {
+	Proposals(filter *proposal.Filter) ([]proposal.PricedServiceProposal, error)
+}
+
+// Relayer allocates relays for the p2p listener. It connects to the relay
+// service with the lowest latency as a consumer, so the relay gets paid
+// through the regular session invoices. The provider identity needs
+// a consumer balance to cover them, relaying fails otherwise.
+type Relayer struct {
+	manager   connection.MultiManager
+	proposals proposalRepository
+	hermes    func() (common.Address, error)
+	tunnels   *tunnelRegistry
+	keepAlive time.Duration
+
+	mu   sync.Mutex
+	keys map[int]struct{}
+}
+
+var _ nat.Relayer = &Relayer{}
+
+// NewRelayer creates new instance of the Relayer. Relay connections are
+// established with the given manager, which must create them with NewConnection.
+func NewRelayer(manager connection.MultiManager, proposals proposalRepository, hermes func() (common.Address, error), keepAlive time.Duration) *Relayer {
+	return &Relayer{
+		manager:   manager,
+		proposals: proposals,
+		hermes:    hermes,
+		tunnels:   &tunnelRegistry{tunnels: make(map[int]*Tunnel)},
+		keepAlive: keepAlive,
+		keys:      make(map[int]struct{}),
+	}
+}
+
+// NewConnection creates a new relay connection.
+func (r *Relayer) NewConnection() (connection.Connection, error) {
+	return &Connection{
+		tunnels:   r.tunnels,
+		keepAlive: r.keepAlive,
+		stateCh:   make(chan connectionstate.State, 10),
+	}, nil
+}
+
+// Relay connects to a relay service and allocates forwarding ports on it.
+func (r *Relayer) Relay(providerID string) (nat.Relay, error) {
+	hermesID, err := r.hermes()
+	if err != nil {
+		return nil, fmt.Errorf("could not get hermes: %w", err)
+	}
+
+	key := r.acquireKey()
+	release := func() error {
+		defer r.releaseKey(key)
+		return r.manager.Disconnect(key)
+	}
+
+	filter := &proposal.Filter{
+		ServiceType:        ServiceType,
+		ExcludeUnsupported: true,
+	}
+	lookup := connection.FilteredProposals(filter, proposal.SortTypeLatency, r.proposals)
+	params := connection.ConnectParams{
+		DisableKillSwitch: true,
+		ProxyPort:         key,
+	}
+	if err := r.manager.Connect(identity.FromAddress(providerID), hermesID, lookup, params); err != nil {
+		release()
+		if errors.Is(err, connection.ErrInsufficientBalance) {
+			return nil, fmt.Errorf("relay is paid from the balance of %s, top it up to use relay traversal: %w", providerID, err)
+		}
+		return nil, fmt.Errorf("could not connect to relay: %w", err)
+	}
+
+	tunnel, ok := r.tunnels.get(key)
+	if !ok {
+		release()
+		return nil, errors.New("relay connection has no tunnel")
+	}
+
+	return &relayAllocation{Tunnel: tunnel, release: release}, nil
+}
+
+// acquireKey returns the lowest key not used by active relay connections.
+func (r *Relayer) acquireKey() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := 1
+	for {
+		if _, ok := r.keys[key]; !ok {
+			r.keys[key] = struct{}{}
+			return key
+		}
+		key++
+	}
+}
+
+func (r *Relayer) releaseKey(key int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.keys, key)
+}
+
+type relayAllocation struct {
+	*Tunnel
+	release func() error
+	once    sync.Once
+}
+
+// Close ends the relay session.
+func (a *relayAllocation) Close() (err error) {
+	a.once.Do(func() {
+		err = a.release()
+	})
+	return err
+}
@@ -0,0 +1,67 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package relay
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mysteriumnetwork/node/core/connection"
+	"github.com/mysteriumnetwork/node/core/discovery/proposal"
+	"github.com/mysteriumnetwork/node/identity"
+)
+
+type mockMultiManager struct {
+	connection.MultiManager
+	connectErr   error
+	disconnected []int
+}
+
+func (m *mockMultiManager) Connect(identity.Identity, common.Address, connection.ProposalLookup, connection.ConnectParams) error {
+	return m.connectErr
+}
+
+func (m *mockMultiManager) Disconnect(n int) error {
+	m.disconnected = append(m.disconnected, n)
+	return nil
+}
+
+type mockProposals struct{}
+
+func (mockProposals) Proposals(*proposal.Filter) ([]proposal.PricedServiceProposal, error) {
+	return nil, nil
+}
+
+func Test_Relayer_ReportsInsufficientProviderBalance(t *testing.T) {
+	manager := &mockMultiManager{connectErr: connection.ErrInsufficientBalance}
+	relayer := NewRelayer(manager, mockProposals{}, func() (common.Address, error) {
+		return common.Address{}, nil
+	}, DefaultKeepAlive)
+
+	_, err := relayer.Relay("0x1")
+	assert.ErrorIs(t, err, connection.ErrInsufficientBalance)
+	assert.Contains(t, err.Error(), "paid from the balance of 0x1")
+	assert.Equal(t, []int{1}, manager.disconnected)
+
+	// Key of the failed relay is reused.
+	_, err = relayer.Relay("0x1")
+	assert.Error(t, err)
+	assert.Equal(t, []int{1, 1}, manager.disconnected)
+}
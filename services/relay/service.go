@@ -0,0 +1,127 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package relay
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/mysteriumnetwork/node/core/ip"
+	"github.com/mysteriumnetwork/node/core/port"
+	"github.com/mysteriumnetwork/node/core/service"
+	"github.com/mysteriumnetwork/node/eventbus"
+	"github.com/mysteriumnetwork/node/p2p"
+	"github.com/mysteriumnetwork/node/session/event"
+)
+
+// laneCount is the number of lanes allocated per session,
+// one for the p2p channel and one for the service connection.
+const laneCount = 2
+
+// NewManager creates new instance of Relay service
+func NewManager(ipResolver ip.Resolver, ports port.ServicePortSupplier, publisher eventbus.Publisher, statsInterval time.Duration) *Manager {
+	return &Manager{
+		ipResolver:    ipResolver,
+		ports:         ports,
+		publisher:     publisher,
+		statsInterval: statsInterval,
+		done:          make(chan struct{}),
+	}
+}
+
+// Manager represents entrypoint for Relay service
+type Manager struct {
+	ipResolver    ip.Resolver
+	ports         port.ServicePortSupplier
+	publisher     eventbus.Publisher
+	statsInterval time.Duration
+
+	done chan struct{}
+	once sync.Once
+}
+
+// ProvideConfig allocates forwarding ports for the session and provides them in the session configuration.
+func (m *Manager) ProvideConfig(sessionID string, _ json.RawMessage, _ p2p.ServiceConn) (*service.ConfigParams, error) {
+	publicIP, err := m.ipResolver.GetPublicIP()
+	if err != nil {
+		return nil, fmt.Errorf("could not get public IP: %w", err)
+	}
+
+	a, err := newAllocation(m.ports, laneCount)
+	if err != nil {
+		return nil, err
+	}
+
+	stop := make(chan struct{})
+	go m.publishStats(sessionID, a, stop)
+
+	return &service.ConfigParams{
+		SessionServiceConfig: ServiceConfig{
+			IP:    publicIP,
+			Ports: a.ports(),
+			Token: a.token,
+		},
+		SessionDestroyCallback: func() {
+			close(stop)
+			a.close()
+		},
+	}, nil
+}
+
+// publishStats reports forwarded bytes so that the session is invoiced for them.
+func (m *Manager) publishStats(sessionID string, a *allocation, stop <-chan struct{}) {
+	ticker := time.NewTicker(m.statsInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			up, down := a.stats()
+			m.publisher.Publish(event.AppTopicDataTransferred, event.AppEventDataTransferred{
+				ID:   sessionID,
+				Up:   up,
+				Down: down,
+			})
+		case <-stop:
+			log.Info().Msgf("Stopped publishing relay statistics for session %s", sessionID)
+			return
+		case <-m.done:
+			return
+		}
+	}
+}
+
+// Serve starts service - does block
+func (m *Manager) Serve(instance *service.Instance) error {
+	log.Info().Msg("Relay service started successfully")
+	<-m.done
+	return nil
+}
+
+// Stop stops service
+func (m *Manager) Stop() error {
+	m.once.Do(func() {
+		close(m.done)
+	})
+	log.Info().Msg("Relay service stopped")
+	return nil
+}
@@ -0,0 +1,234 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package relay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Tunnel connects local p2p connections to a relay allocation. Datagrams pass
+// through a loopback proxy per lane, which keeps the relay binding alive and
+// counts transferred bytes for the session statistics.
+type Tunnel struct {
+	ip    string
+	ports []int
+	token []byte
+	lanes []*tunnelLane
+
+	done chan struct{}
+	once sync.Once
+}
+
+type tunnelLane struct {
+	relay *net.UDPConn
+	local *net.UDPConn
+
+	mu  sync.Mutex
+	app *net.UDPAddr
+
+	sent, received uint64
+}
+
+// NewTunnel binds to the relay allocation described by the given config.
+func NewTunnel(cfg ServiceConfig, keepAlive time.Duration) (*Tunnel, error) {
+	if len(cfg.Token) != tokenSize {
+		return nil, fmt.Errorf("invalid relay token size: %d", len(cfg.Token))
+	}
+	relayIP := net.ParseIP(cfg.IP)
+	if relayIP == nil {
+		return nil, fmt.Errorf("invalid relay IP: %q", cfg.IP)
+	}
+
+	t := &Tunnel{
+		ip:    cfg.IP,
+		ports: cfg.Ports,
+		token: cfg.Token,
+		done:  make(chan struct{}),
+	}
+
+	for _, p := range cfg.Ports {
+		relayConn, err := net.DialUDP("udp4", nil, &net.UDPAddr{IP: relayIP, Port: p})
+		if err != nil {
+			t.Close()
+			return nil, fmt.Errorf("could not dial relay port %d: %w", p, err)
+		}
+
+		localConn, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+		if err != nil {
+			relayConn.Close()
+			t.Close()
+			return nil, fmt.Errorf("could not listen on loopback: %w", err)
+		}
+
+		t.lanes = append(t.lanes, &tunnelLane{relay: relayConn, local: localConn})
+	}
+
+	t.bind()
+	for _, l := range t.lanes {
+		go l.forwardFromRelay()
+		go l.forwardToRelay()
+	}
+	go t.keepAlive(keepAlive)
+
+	return t, nil
+}
+
+// Addr returns public address of the relay allocation.
+func (t *Tunnel) Addr() (string, []int) {
+	return t.ip, t.ports
+}
+
+// Start permits the peer to send through the relay and returns connections
+// which reach the peer via the relay.
+func (t *Tunnel) Start(ctx context.Context, peerIP string) ([]*net.UDPConn, error) {
+	ip := net.ParseIP(peerIP).To4()
+	if ip == nil {
+		return nil, fmt.Errorf("invalid peer IP: %q", peerIP)
+	}
+
+	var conns []*net.UDPConn
+	for _, l := range t.lanes {
+		if _, err := l.relay.Write(controlPacket(packetPermit, t.token, ip)); err != nil {
+			closeAll(conns)
+			return nil, fmt.Errorf("could not permit peer on the relay: %w", err)
+		}
+
+		conn, err := net.DialUDP("udp4", nil, l.local.LocalAddr().(*net.UDPAddr))
+		if err != nil {
+			closeAll(conns)
+			return nil, fmt.Errorf("could not connect to relay proxy: %w", err)
+		}
+
+		l.mu.Lock()
+		l.app = conn.LocalAddr().(*net.UDPAddr)
+		l.mu.Unlock()
+
+		conns = append(conns, conn)
+	}
+
+	return conns, nil
+}
+
+// Stats returns bytes sent to and received from the relay over all lanes.
+func (t *Tunnel) Stats() (sent, received uint64) {
+	for _, l := range t.lanes {
+		sent += atomic.LoadUint64(&l.sent)
+		received += atomic.LoadUint64(&l.received)
+	}
+	return sent, received
+}
+
+// Close stops the tunnel.
+func (t *Tunnel) Close() error {
+	t.once.Do(func() {
+		close(t.done)
+		for _, l := range t.lanes {
+			l.relay.Close()
+			l.local.Close()
+		}
+	})
+	return nil
+}
+
+func (t *Tunnel) bind() {
+	for _, l := range t.lanes {
+		if _, err := l.relay.Write(controlPacket(packetBind, t.token, nil)); err != nil {
+			log.Debug().Err(err).Msg("Failed to bind relay lane")
+		}
+	}
+}
+
+func (t *Tunnel) keepAlive(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			t.bind()
+		case <-t.done:
+			return
+		}
+	}
+}
+
+func (l *tunnelLane) forwardFromRelay() {
+	buf := make([]byte, maxDatagramSize)
+	for {
+		n, err := l.relay.Read(buf)
+		if err != nil {
+			if isClosed(err) {
+				return
+			}
+			// Relay port might be unreachable for a moment, keep reading.
+			continue
+		}
+
+		l.mu.Lock()
+		app := l.app
+		l.mu.Unlock()
+		if app == nil {
+			continue
+		}
+
+		if _, err := l.local.WriteToUDP(buf[:n], app); err != nil {
+			continue
+		}
+		atomic.AddUint64(&l.received, uint64(n))
+	}
+}
+
+func (l *tunnelLane) forwardToRelay() {
+	buf := make([]byte, maxDatagramSize)
+	for {
+		n, addr, err := l.local.ReadFromUDP(buf)
+		if err != nil {
+			return
+		}
+
+		l.mu.Lock()
+		app := l.app
+		l.mu.Unlock()
+		if app == nil || !sameAddr(app, addr) {
+			continue
+		}
+
+		if _, err := l.relay.Write(buf[:n]); err != nil {
+			continue
+		}
+		atomic.AddUint64(&l.sent, uint64(n))
+	}
+}
+
+func isClosed(err error) bool {
+	return errors.Is(err, net.ErrClosed)
+}
+
+func closeAll(conns []*net.UDPConn) {
+	for _, c := range conns {
+		c.Close()
+	}
+}
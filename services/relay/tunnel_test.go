@@ -0,0 +1,89 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package relay
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mysteriumnetwork/node/core/port"
+)
+
+func Test_Tunnel_ForwardsThroughAllocation(t *testing.T) {
+	a, err := newAllocation(port.NewFixedRangePool(port.Range{Start: 41000, End: 41999}), laneCount)
+	require.NoError(t, err)
+	defer a.close()
+
+	tunnel, err := NewTunnel(ServiceConfig{IP: "127.0.0.1", Ports: a.ports(), Token: a.token}, 50*time.Millisecond)
+	require.NoError(t, err)
+	defer tunnel.Close()
+
+	conns, err := tunnel.Start(context.Background(), "127.0.0.1")
+	require.NoError(t, err)
+	require.Len(t, conns, laneCount)
+
+	for i, p := range a.ports() {
+		peer, err := net.DialUDP("udp4", nil, &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1), Port: p})
+		require.NoError(t, err)
+		defer peer.Close()
+
+		// Bind and permit packets race with the first datagrams, retry until forwarded.
+		assert.Eventually(t, func() bool {
+			if _, err := peer.Write([]byte("ping")); err != nil {
+				return false
+			}
+			return readString(conns[i]) == "ping"
+		}, 2*time.Second, 10*time.Millisecond)
+
+		_, err = conns[i].Write([]byte("pong"))
+		require.NoError(t, err)
+		assert.Equal(t, "pong", readString(peer))
+	}
+
+	sent, received := tunnel.Stats()
+	assert.Equal(t, uint64(4*laneCount), sent)
+	assert.GreaterOrEqual(t, received, uint64(4*laneCount))
+
+	assert.Eventually(t, func() bool {
+		up, down := a.stats()
+		return up == received && down == sent
+	}, time.Second, 10*time.Millisecond)
+}
+
+func Test_NewTunnel_RejectsInvalidConfig(t *testing.T) {
+	_, err := NewTunnel(ServiceConfig{IP: "127.0.0.1", Ports: []int{1}, Token: []byte{1}}, time.Second)
+	assert.Error(t, err)
+
+	_, err = NewTunnel(ServiceConfig{IP: "relay", Ports: []int{1}, Token: make([]byte, tokenSize)}, time.Second)
+	assert.Error(t, err)
+}
+
+func readString(conn *net.UDPConn) string {
+	buf := make([]byte, 16)
+	conn.SetReadDeadline(time.Now().Add(100 * time.Millisecond))
+	n, err := conn.Read(buf)
+	if err != nil {
+		return ""
+	}
+	return string(buf[:n])
+}
@@ -50,6 +50,7 @@ import (
 
 type settlementHistoryStorage interface {
 	Store(she SettlementHistoryEntry) error
+	List(filter SettlementHistoryFilter) ([]SettlementHistoryEntry, error)
 }
 
 type providerChannelStatusProvider interface {
@@ -112,6 +113,7 @@ type HermesPromiseSettler interface {
 	Withdraw(fromChainID int64, toChainID int64, providerID identity.Identity, hermesID, beneficiary common.Address, amount *big.Int) error
 	CheckLatestWithdrawal(chainID int64, providerID identity.Identity, hermesID common.Address) (*big.Int, string, error)
 	RetryWithdrawLatest(chainID int64, amountToWithdraw *big.Int, chid string, beneficiary common.Address, providerID identity.Identity) error
+	EstimateSettlement(chainID int64, providerID identity.Identity, hermesID ...common.Address) ([]SettlementEstimate, error)
 }
 
 // hermesPromiseSettler is responsible for settling the hermes promises.
@@ -171,6 +173,7 @@ type HermesPromiseSettlerConfig struct {
 	SettlementCheckInterval time.Duration
 	SettlementCheckTimeout  time.Duration
 	BalanceThreshold        float64
+	Scheduler               SettlementSchedulerConfig
 }
 
 var errFeeNotCovered = errors.New("fee not covered, cannot continue")
@@ -357,6 +360,11 @@ func (aps *hermesPromiseSettler) handleHermesPromiseReceived(apep event.AppEvent
 
 	log.Info().Msgf("Hermes %q promise state updated for provider %q", apep.HermesID.Hex(), id)
 
+	if aps.config.Scheduler.Enabled() && (channel.Channel.Stake == nil || channel.Channel.Stake.Sign() == 0) {
+		// Zero stake channels are settled by the scheduler once it is worth it.
+		return
+	}
+
 	needs, maxFee := aps.needsSettling(s, aps.config.BalanceThreshold, aps.config.MaxFeeThreshold, aps.config.MinAutoSettleAmount, aps.config.MaxUnSettledAmount, channel, apep.Promise.ChainID)
 	if needs {
 		log.Info().Msgf("Starting auto settle for provider %v", id)
@@ -1295,6 +1303,9 @@ func (aps *hermesPromiseSettler) setSettling(id identity.Identity, hermesID comm
 
 func (aps *hermesPromiseSettler) handleNodeStart() {
 	go aps.listenForSettlementRequests()
+	if aps.config.Scheduler.Enabled() {
+		go aps.runSettlementScheduler()
+	}
 
 	for _, v := range aps.ks.Accounts() {
 		addr := identity.FromAddress(v.Address.Hex())
@@ -1330,6 +1341,8 @@ type settlementState struct {
 	registered bool
 
 	settleInProgress map[common.Address]struct{}
+	// batchInProgress is set while the scheduler settles a batch of the provider channels.
+	batchInProgress bool
 }
 
 func (aps *hermesPromiseSettler) needsSettling(
@@ -829,12 +829,18 @@ func (mt *mockTransactor) GetQueueStatus(ID string) (registry.QueueResponse, err
 	return mt.queueToReturn, mt.queueError
 }
 
-type settlementHistoryStorageMock struct{}
+type settlementHistoryStorageMock struct {
+	entries []SettlementHistoryEntry
+}
 
 func (shsm *settlementHistoryStorageMock) Store(_ SettlementHistoryEntry) error {
 	return nil
 }
 
+func (shsm *settlementHistoryStorageMock) List(_ SettlementHistoryFilter) ([]SettlementHistoryEntry, error) {
+	return shsm.entries, nil
+}
+
 type mockPayAndSettler struct{}
 
 func (mpas *mockPayAndSettler) PayAndSettle(r []byte, em crypto.ExchangeMessage, providerID identity.Identity, sessionID string) <-chan error {
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"math/big"
 	"sync"
+	"time"
 
 	"github.com/asdine/storm/v3/codec/json"
 	"github.com/ethereum/go-ethereum/common"
@@ -31,7 +32,10 @@ import (
 	"go.etcd.io/bbolt"
 )
 
-const hermesPromiseBucketName = "hermes_promises"
+const (
+	hermesPromiseBucketName  = "hermes_promises"
+	hermesEarningsBucketName = "hermes_promise_earnings"
+)
 
 // ErrAttemptToOverwrite occurs when a promise with lower value is attempted to be overwritten on top of an existing promise.
 var ErrAttemptToOverwrite = errors.New("attempted to overwrite a promise with and equal or lower value")
@@ -58,6 +62,8 @@ type HermesPromise struct {
 	R           string
 	Revealed    bool
 	AgreementID *big.Int
+	// FirstPromiseAt is when the first promise of the channel was stored.
+	FirstPromiseAt time.Time
 }
 
 // Store stores the given promise.
@@ -78,12 +84,92 @@ func (aps *HermesPromiseStorage) Store(promise HermesPromise) error {
 		return ErrAttemptToOverwrite
 	}
 
+	if !previousPromise.FirstPromiseAt.IsZero() {
+		promise.FirstPromiseAt = previousPromise.FirstPromiseAt
+	} else if promise.FirstPromiseAt.IsZero() {
+		promise.FirstPromiseAt = time.Now().UTC()
+	}
+
 	if err := aps.bolt.SetValue(aps.getBucketName(promise.Promise.ChainID), promise.ChannelID, promise); err != nil {
 		return fmt.Errorf("could not store hermes promise: %w", err)
 	}
+
+	earned := new(big.Int).Set(promise.Promise.Amount)
+	if previousPromise.Promise.Amount != nil {
+		earned.Sub(earned, previousPromise.Promise.Amount)
+	}
+	if earned.Sign() > 0 {
+		if err := aps.addEarnings(promise.Identity, time.Now().UTC(), earned); err != nil {
+			return fmt.Errorf("could not store hermes promise earnings: %w", err)
+		}
+	}
 	return nil
 }
 
+// HermesEarnings is the amount promised to an identity during an hour.
+type HermesEarnings struct {
+	Identity identity.Identity
+	Hour     time.Time
+	Amount   *big.Int
+}
+
+func (aps *HermesPromiseStorage) addEarnings(id identity.Identity, at time.Time, amount *big.Int) error {
+	hour := at.Truncate(time.Hour)
+	key := fmt.Sprintf("%v_%v", id.Address, hour.Unix())
+
+	earnings := HermesEarnings{Identity: id, Hour: hour, Amount: new(big.Int)}
+	err := aps.bolt.GetValue(hermesEarningsBucketName, key, &earnings)
+	if err != nil && err.Error() != errBoltNotFound {
+		return err
+	}
+	if earnings.Amount == nil {
+		earnings.Amount = new(big.Int)
+	}
+	earnings.Amount.Add(earnings.Amount, amount)
+
+	return aps.bolt.SetValue(hermesEarningsBucketName, key, earnings)
+}
+
+// ListEarnings returns hourly amounts promised to the given identity since the hour of the given time.
+// Amounts are increments of the promises stored for each of the identity channels.
+func (aps *HermesPromiseStorage) ListEarnings(id identity.Identity, from time.Time) ([]HermesEarnings, error) {
+	aps.lock.Lock()
+	defer aps.lock.Unlock()
+
+	from = from.Truncate(time.Hour)
+	result := make([]HermesEarnings, 0)
+	aps.bolt.RLock()
+	defer aps.bolt.RUnlock()
+	err := aps.bolt.DB().Bolt.View(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket([]byte(hermesEarningsBucketName))
+		if bucket == nil {
+			return nil
+		}
+
+		return bucket.ForEach(func(k, v []byte) error {
+			if string(k) == "__storm_metadata" {
+				return nil
+			}
+
+			var entry HermesEarnings
+			if err := json.Codec.Unmarshal(v, &entry); err != nil {
+				return err
+			}
+			if entry.Identity != id || entry.Hour.Before(from) {
+				return nil
+			}
+
+			result = append(result, entry)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not list hermes promise earnings: %w", err)
+	}
+
+	return result, nil
+}
+
 func (aps *HermesPromiseStorage) shouldOverride(old, new HermesPromise) bool {
 	if old.Promise.Amount == nil {
 		return true
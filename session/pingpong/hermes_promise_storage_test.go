@@ -21,6 +21,7 @@ import (
 	"math/big"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/mysteriumnetwork/node/core/storage/boltdb"
@@ -45,21 +46,23 @@ func TestHermesPromiseStorage(t *testing.T) {
 	secondHermes := common.HexToAddress("0x000000acc2")
 
 	firstPromise := HermesPromise{
-		ChannelID:   "1",
-		Identity:    id,
-		HermesID:    firstHermes,
-		Promise:     crypto.Promise{Amount: big.NewInt(1), Fee: big.NewInt(1), ChainID: 1},
-		R:           "some r",
-		AgreementID: big.NewInt(123),
+		ChannelID:      "1",
+		Identity:       id,
+		HermesID:       firstHermes,
+		Promise:        crypto.Promise{Amount: big.NewInt(1), Fee: big.NewInt(1), ChainID: 1},
+		R:              "some r",
+		AgreementID:    big.NewInt(123),
+		FirstPromiseAt: time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC),
 	}
 
 	secondPromise := HermesPromise{
-		ChannelID:   "2",
-		Identity:    id,
-		HermesID:    secondHermes,
-		Promise:     crypto.Promise{Amount: big.NewInt(2), Fee: big.NewInt(2), ChainID: 1},
-		R:           "some other r",
-		AgreementID: big.NewInt(1234),
+		ChannelID:      "2",
+		Identity:       id,
+		HermesID:       secondHermes,
+		Promise:        crypto.Promise{Amount: big.NewInt(2), Fee: big.NewInt(2), ChainID: 1},
+		R:              "some other r",
+		AgreementID:    big.NewInt(1234),
+		FirstPromiseAt: time.Date(2026, time.January, 2, 0, 0, 0, 0, time.UTC),
 	}
 
 	// check if errors are wrapped correctly
@@ -102,6 +105,31 @@ func TestHermesPromiseStorage(t *testing.T) {
 	overwritingPromise.Promise.Amount = big.NewInt(0)
 	err = hermesStorage.Store(overwritingPromise)
 	assert.Equal(t, err, ErrAttemptToOverwrite)
+
+	// the time of the first promise is kept for the channel
+	nextPromise := firstPromise
+	nextPromise.Promise.Amount = big.NewInt(5)
+	nextPromise.FirstPromiseAt = time.Time{}
+	err = hermesStorage.Store(nextPromise)
+	assert.NoError(t, err)
+
+	promise, err = hermesStorage.Get(1, firstPromise.ChannelID)
+	assert.NoError(t, err)
+	assert.Equal(t, firstPromise.FirstPromiseAt, promise.FirstPromiseAt)
+
+	// promise increments are recorded as earnings
+	earnings, err := hermesStorage.ListEarnings(id, time.Now().Add(-time.Hour))
+	assert.NoError(t, err)
+	total := new(big.Int)
+	for _, e := range earnings {
+		assert.Equal(t, id, e.Identity)
+		total.Add(total, e.Amount)
+	}
+	assert.Equal(t, big.NewInt(7), total)
+
+	earnings, err = hermesStorage.ListEarnings(identity.FromAddress("0x1"), time.Now().Add(-time.Hour))
+	assert.NoError(t, err)
+	assert.Empty(t, earnings)
 }
 
 func TestHermesPromiseStorageDelete(t *testing.T) {
@@ -119,12 +147,13 @@ func TestHermesPromiseStorageDelete(t *testing.T) {
 	firstHermes := common.HexToAddress("0x000000acc1")
 
 	firstPromise := HermesPromise{
-		ChannelID:   "1",
-		Identity:    id,
-		HermesID:    firstHermes,
-		Promise:     crypto.Promise{Amount: big.NewInt(1), Fee: big.NewInt(1), ChainID: 1},
-		R:           "some r",
-		AgreementID: big.NewInt(123),
+		ChannelID:      "1",
+		Identity:       id,
+		HermesID:       firstHermes,
+		Promise:        crypto.Promise{Amount: big.NewInt(1), Fee: big.NewInt(1), ChainID: 1},
+		R:              "some r",
+		AgreementID:    big.NewInt(123),
+		FirstPromiseAt: time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC),
 	}
 
 	// should error since no such promise
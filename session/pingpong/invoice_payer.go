@@ -80,6 +80,7 @@ type InvoicePayer struct {
 
 	lastInvoice crypto.Invoice
 	deps        InvoicePayerDeps
+	prices      *PriceSchedule
 
 	dataTransferred     DataTransferred
 	dataTransferredLock sync.Mutex
@@ -113,8 +114,9 @@ type InvoicePayerDeps struct {
 // NewInvoicePayer returns a new instance of exchange message tracker.
 func NewInvoicePayer(ipd InvoicePayerDeps) *InvoicePayer {
 	return &InvoicePayer{
-		stop: make(chan struct{}),
-		deps: ipd,
+		stop:   make(chan struct{}),
+		deps:   ipd,
+		prices: NewPriceSchedule(ipd.AgreedPrice),
 		lastInvoice: crypto.Invoice{
 			AgreementID:    new(big.Int),
 			AgreementTotal: new(big.Int),
@@ -182,7 +184,7 @@ func (ip *InvoicePayer) isInvoiceOK(invoice crypto.Invoice) error {
 	transferred := ip.getDataTransferred()
 	transferred.Up += ip.deps.DataLeeway.Bytes()
 
-	shouldBe := CalculatePaymentAmount(ip.deps.TimeTracker.Elapsed(), transferred, ip.deps.AgreedPrice)
+	shouldBe := ip.prices.Amount(ip.deps.TimeTracker.Elapsed(), transferred)
 	estimatedTolerance := estimateInvoiceTolerance(ip.deps.TimeTracker.Elapsed(), transferred)
 
 	upperBound, _ := new(big.Float).Mul(new(big.Float).SetInt(shouldBe), big.NewFloat(estimatedTolerance)).Int(nil)
@@ -335,6 +337,20 @@ func (ip *InvoicePayer) getDataTransferred() DataTransferred {
 	return ip.dataTransferred
 }
 
+// PriceBoundary returns the current point of the session as seen by the consumer.
+func (ip *InvoicePayer) PriceBoundary() market.PriceBoundary {
+	return market.PriceBoundary{
+		Elapsed: ip.deps.TimeTracker.Elapsed(),
+		Bytes:   ip.getDataTransferred().sum(),
+	}
+}
+
+// ChangePrice switches session price at the agreed boundary. Data and time consumed before it stay paid with the previous price.
+func (ip *InvoicePayer) ChangePrice(price market.Price, at market.PriceBoundary) {
+	ip.prices.Switch(price, at)
+	log.Info().Msgf("Session price changed to %s", price.String())
+}
+
 // SetSessionID updates invoice payer dependencies to set session ID once session established.
 func (ip *InvoicePayer) SetSessionID(sessionID string) {
 	ip.sessionIDLock.Lock()
@@ -329,6 +329,7 @@ func TestInvoicePayer_isInvoiceOK(t *testing.T) {
 					AgreedPrice: tt.fields.price,
 					Peer:        tt.fields.peer,
 				},
+				prices: NewPriceSchedule(tt.fields.price),
 			}
 			if err := emt.isInvoiceOK(tt.invoice); (err != nil) != tt.wantErr {
 				t.Errorf("InvoicePayer.isInvoiceOK() error = %v, wantErr %v", err, tt.wantErr)
@@ -124,6 +124,7 @@ type InvoiceTracker struct {
 	invoicesSent                   map[string]sentInvoice
 	invoiceLock                    sync.Mutex
 	deps                           InvoiceTrackerDeps
+	prices                         *PriceSchedule
 
 	dataTransferred     DataTransferred
 	dataTransferredLock sync.Mutex
@@ -178,6 +179,7 @@ func NewInvoiceTracker(
 		},
 		stop:                           make(chan struct{}),
 		deps:                           itd,
+		prices:                         NewPriceSchedule(itd.AgreedPrice),
 		maxNotReceivedExchangeMessages: calculateMaxNotReceivedExchangeMessageCount(itd.ChargePeriodLeeway, itd.ChargePeriod),
 		maxNotSentExchangeMessages:     calculateMaxNotSentExchangeMessageCount(itd.ChargePeriodLeeway, itd.ChargePeriod),
 		invoicesSent:                   make(map[string]sentInvoice),
@@ -262,7 +264,7 @@ func (it *InvoiceTracker) handleExchangeMessage(em crypto.ExchangeMessage) error
 	it.resetNotSentExchangeMessageCount()
 
 	// incase of zero payment, we'll just skip going to the hermes
-	if it.deps.AgreedPrice.IsFree() {
+	if it.prices.Price().IsFree() {
 		return nil
 	}
 
@@ -354,7 +356,7 @@ func (it *InvoiceTracker) sendInvoicesWhenNeeded(interval time.Duration) {
 			return
 		case <-time.After(interval):
 			currentlyElapsed := it.deps.TimeTracker.Elapsed()
-			shouldBe := CalculatePaymentAmount(currentlyElapsed, it.getDataTransferred(), it.deps.AgreedPrice)
+			shouldBe := it.prices.Amount(currentlyElapsed, it.getDataTransferred())
 			lastEM := it.getLastExchangeMessage()
 			diff := safeSub(shouldBe, lastEM.AgreementTotal)
 			if diff.Cmp(it.deps.MaxNotPaidInvoice) >= 0 && currentlyElapsed-it.lastInvoiceSent > it.invoiceDebounceRate {
@@ -404,6 +406,20 @@ func (it *InvoiceTracker) updateTimer() {
 	log.Debug().Int64("change_period (ms)", it.deps.ChargePeriod.Milliseconds()).Msg("Max charge period increased")
 }
 
+// PriceBoundary returns the current point of the session as seen by the provider.
+func (it *InvoiceTracker) PriceBoundary() market.PriceBoundary {
+	return market.PriceBoundary{
+		Elapsed: it.deps.TimeTracker.Elapsed(),
+		Bytes:   it.getDataTransferred().sum(),
+	}
+}
+
+// ChangePrice switches session price at the agreed boundary. Data and time consumed before it stay billed with the previous price.
+func (it *InvoiceTracker) ChangePrice(price market.Price, at market.PriceBoundary) {
+	it.prices.Switch(price, at)
+	log.Info().Msgf("Session %s price changed to %s", it.deps.SessionID, price.String())
+}
+
 // WaitFirstInvoice waits for a first invoice to be paid.
 func (it *InvoiceTracker) WaitFirstInvoice(wait time.Duration) error {
 	timeout := time.After(wait)
@@ -492,7 +508,7 @@ func (it *InvoiceTracker) sendInvoice(isCritical bool) error {
 		return ErrExchangeWaitTimeout
 	}
 
-	shouldBe := CalculatePaymentAmount(it.deps.TimeTracker.Elapsed(), it.getDataTransferred(), it.deps.AgreedPrice)
+	shouldBe := it.prices.Amount(it.deps.TimeTracker.Elapsed(), it.getDataTransferred())
 
 	lastEm := it.getLastExchangeMessage()
 	if lastEm.AgreementTotal.Cmp(big.NewInt(0)) == 0 && shouldBe.Cmp(big.NewInt(0)) == 1 {
@@ -720,6 +720,7 @@ func TestInvoiceTracker_receiveExchangeMessageOrTimeout(t *testing.T) {
 				lastExchangeMessage: tt.fields.lastExchangeMessage,
 				agreementID:         tt.fields.AgreementID,
 				deps:                deps,
+				prices:              NewPriceSchedule(deps.AgreedPrice),
 				invoicesSent:        tt.fields.invoicesSent,
 			}
 			if err := it.handleExchangeMessage(*tt.em); (err != nil) != tt.wantErr {
@@ -22,6 +22,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/mysteriumnetwork/node/identity"
+	"github.com/mysteriumnetwork/node/session/pingpong"
 )
 
 // NoopHermesPromiseSettler doesn't do much.
@@ -62,3 +63,8 @@ func (n *NoopHermesPromiseSettler) CheckLatestWithdrawal(chainID int64, provider
 func (n *NoopHermesPromiseSettler) RetryWithdrawLatest(chainID int64, amountToWithdraw *big.Int, chid string, beneficiary common.Address, providerID identity.Identity) error {
 	return nil
 }
+
+// EstimateSettlement does absolutely nothing.
+func (n *NoopHermesPromiseSettler) EstimateSettlement(chainID int64, providerID identity.Identity, hermesID ...common.Address) ([]pingpong.SettlementEstimate, error) {
+	return nil, nil
+}
@@ -0,0 +1,95 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package pingpong
+
+import (
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/mysteriumnetwork/node/market"
+)
+
+// PriceSchedule keeps the price agreed for a session and the amount accumulated under previous prices.
+// It allows to change the price mid-session without resetting the agreement total.
+type PriceSchedule struct {
+	lock sync.Mutex
+
+	price market.Price
+	// charged is the amount for the periods which were billed with previous prices.
+	charged *big.Int
+	// since marks the boundary from which current price is applied.
+	since market.PriceBoundary
+}
+
+// NewPriceSchedule creates price schedule starting with the given price.
+func NewPriceSchedule(price market.Price) *PriceSchedule {
+	return &PriceSchedule{
+		price:   price,
+		charged: new(big.Int),
+	}
+}
+
+// Price returns current price.
+func (ps *PriceSchedule) Price() market.Price {
+	ps.lock.Lock()
+	defer ps.lock.Unlock()
+
+	return ps.price
+}
+
+// Switch applies the given price from the boundary agreed by both session peers.
+// The amount charged up to the boundary depends only on the boundary itself,
+// so the peers keep equal totals even if their own counters differ.
+func (ps *PriceSchedule) Switch(price market.Price, at market.PriceBoundary) {
+	ps.lock.Lock()
+	defer ps.lock.Unlock()
+
+	if at.Elapsed < ps.since.Elapsed {
+		at.Elapsed = ps.since.Elapsed
+	}
+	if at.Bytes < ps.since.Bytes {
+		at.Bytes = ps.since.Bytes
+	}
+
+	ps.charged = new(big.Int).Add(ps.charged, ps.currentAmount(at.Elapsed, at.Bytes))
+	ps.price = price
+	ps.since = at
+}
+
+// Amount calculates total payment amount for the whole session.
+func (ps *PriceSchedule) Amount(elapsed time.Duration, transferred DataTransferred) *big.Int {
+	ps.lock.Lock()
+	defer ps.lock.Unlock()
+
+	return new(big.Int).Add(ps.charged, ps.currentAmount(elapsed, transferred.sum()))
+}
+
+func (ps *PriceSchedule) currentAmount(elapsed time.Duration, bytes uint64) *big.Int {
+	period := elapsed - ps.since.Elapsed
+	if period < 0 {
+		period = 0
+	}
+
+	var transferred DataTransferred
+	if bytes > ps.since.Bytes {
+		transferred.Up = bytes - ps.since.Bytes
+	}
+
+	return CalculatePaymentAmount(period, transferred, ps.price)
+}
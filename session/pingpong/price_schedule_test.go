@@ -0,0 +1,50 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package pingpong
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mysteriumnetwork/node/datasize"
+	"github.com/mysteriumnetwork/node/market"
+)
+
+func TestPriceSchedule_Amount(t *testing.T) {
+	gib := datasize.GiB.Bytes()
+	ps := NewPriceSchedule(*market.NewPrice(3600, 100))
+
+	assert.Equal(t, big.NewInt(3600+100), ps.Amount(time.Hour, DataTransferred{Up: gib}))
+
+	// Price is halved after the first hour and first GiB.
+	ps.Switch(*market.NewPrice(1800, 50), market.PriceBoundary{Elapsed: time.Hour, Bytes: gib})
+	assert.Equal(t, *market.NewPrice(1800, 50), ps.Price())
+	assert.Equal(t, big.NewInt(3600+100), ps.Amount(time.Hour, DataTransferred{Up: gib}))
+	assert.Equal(t, big.NewInt(3600+100+1800+50), ps.Amount(2*time.Hour, DataTransferred{Up: gib, Down: gib}))
+
+	// Switching to free price keeps the amount charged so far.
+	ps.Switch(*market.NewPrice(0, 0), market.PriceBoundary{Elapsed: 2 * time.Hour, Bytes: 2 * gib})
+	assert.Equal(t, big.NewInt(3600+100+1800+50), ps.Amount(10*time.Hour, DataTransferred{Up: 5 * gib, Down: 5 * gib}))
+
+	// Boundary before the previous one does not bill anything twice.
+	ps.Switch(*market.NewPrice(3600, 100), market.PriceBoundary{Elapsed: time.Hour})
+	assert.Equal(t, big.NewInt(3600+100+1800+50), ps.Amount(2*time.Hour, DataTransferred{Up: 2 * gib}))
+}
@@ -0,0 +1,309 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package pingpong
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rs/zerolog/log"
+
+	"github.com/mysteriumnetwork/node/identity"
+	"github.com/mysteriumnetwork/payments/units"
+)
+
+// SettlementSchedulerConfig configures fee aware settlement scheduling.
+type SettlementSchedulerConfig struct {
+	// CheckInterval is how often channels are evaluated, zero disables the scheduler.
+	CheckInterval time.Duration
+	// TargetFeeRatio is the max share of unsettled earnings which can be paid in fees.
+	TargetFeeRatio float64
+	// MaxUnsettledAge forces a settlement once earnings stay unsettled for this long.
+	MaxUnsettledAge time.Duration
+}
+
+// Enabled checks if the scheduler decides when to settle zero stake channels.
+func (c SettlementSchedulerConfig) Enabled() bool {
+	return c.CheckInterval > 0
+}
+
+// Settlement decision reasons.
+const (
+	SettleReasonNothingToSettle  = "nothing_to_settle"
+	SettleReasonInProgress       = "settlement_in_progress"
+	SettleReasonFeesExceedAmount = "fees_exceed_unsettled_amount"
+	SettleReasonMaxAmount        = "max_unsettled_amount_reached"
+	SettleReasonFeeRatio         = "fee_ratio_below_target"
+	SettleReasonMaxAge           = "max_unsettled_age_reached"
+	SettleReasonWaiting          = "fee_ratio_above_target"
+)
+
+// SettlementEstimate describes what settling a hermes channel now would cost.
+type SettlementEstimate struct {
+	ChainID        int64
+	ProviderID     identity.Identity
+	HermesID       common.Address
+	Unsettled      *big.Int
+	TransactorFee  *big.Int
+	HermesFee      *big.Int
+	TotalFee       *big.Int
+	Payout         *big.Int
+	FeeRatio       float64
+	UnsettledSince time.Time
+	Settle         bool
+	Reason         string
+}
+
+// EstimateSettlement returns what settling the given hermes channels now would cost and whether the scheduler would do it.
+// All known hermeses are estimated if none are given.
+func (aps *hermesPromiseSettler) EstimateSettlement(chainID int64, providerID identity.Identity, hermesIDs ...common.Address) ([]SettlementEstimate, error) {
+	if len(hermesIDs) == 0 {
+		known, err := aps.addressProvider.GetKnownHermeses(chainID)
+		if err != nil {
+			return nil, fmt.Errorf("could not get known hermeses: %w", err)
+		}
+		hermesIDs = known
+	}
+
+	fees, err := aps.transactor.FetchSettleFees(chainID)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch settlement fees: %w", err)
+	}
+
+	estimates := make([]SettlementEstimate, 0, len(hermesIDs))
+	for _, hermesID := range hermesIDs {
+		channel, ok := aps.channelProvider.Get(chainID, providerID, hermesID)
+		if !ok {
+			channel, err = aps.channelProvider.Fetch(chainID, providerID, hermesID)
+			if err != nil {
+				if errors.Is(err, ErrNotFound) {
+					continue
+				}
+				return nil, fmt.Errorf("could not get channel with hermes %s: %w", hermesID.Hex(), err)
+			}
+		}
+
+		estimate, err := aps.estimateChannel(chainID, channel, fees.Fee)
+		if err != nil {
+			return nil, err
+		}
+		estimates = append(estimates, estimate)
+	}
+	return estimates, nil
+}
+
+// unsettledSince returns since when the channel has unsettled earnings.
+// It is derived from the stored promises and the settlement history, so it survives node restarts:
+// earnings are unsettled since the first promise of the channel or since its last successful settlement, whichever is later.
+func (aps *hermesPromiseSettler) unsettledSince(channel HermesChannel, now time.Time) (time.Time, error) {
+	if channel.UnsettledBalance().Sign() <= 0 {
+		return time.Time{}, nil
+	}
+
+	since := channel.lastPromise.FirstPromiseAt
+	history, err := aps.settlementHistoryStorage.List(SettlementHistoryFilter{
+		ProviderID: &channel.Identity,
+		HermesID:   &channel.HermesID,
+	})
+	if err != nil {
+		return time.Time{}, fmt.Errorf("could not get settlement history: %w", err)
+	}
+	// History is ordered from the latest entry.
+	for _, entry := range history {
+		if entry.Error != "" {
+			continue
+		}
+		if entry.Time.After(since) {
+			since = entry.Time
+		}
+		break
+	}
+
+	if since.IsZero() || since.After(now) {
+		return now, nil
+	}
+	return since, nil
+}
+
+func (aps *hermesPromiseSettler) estimateChannel(chainID int64, channel HermesChannel, transactorFee *big.Int) (SettlementEstimate, error) {
+	unsettled := channel.UnsettledBalance()
+	since, err := aps.unsettledSince(channel, time.Now())
+	if err != nil {
+		return SettlementEstimate{}, err
+	}
+
+	estimate := SettlementEstimate{
+		ChainID:        chainID,
+		ProviderID:     channel.Identity,
+		HermesID:       channel.HermesID,
+		Unsettled:      unsettled,
+		TransactorFee:  new(big.Int).Set(transactorFee),
+		HermesFee:      new(big.Int),
+		Payout:         new(big.Int),
+		UnsettledSince: since,
+	}
+
+	if unsettled.Sign() > 0 {
+		hermesFee, err := aps.bc.CalculateHermesFee(chainID, channel.HermesID, unsettled)
+		if err != nil {
+			return SettlementEstimate{}, fmt.Errorf("could not calculate hermes fee: %w", err)
+		}
+		estimate.HermesFee = hermesFee
+	}
+
+	estimate.TotalFee = new(big.Int).Add(estimate.TransactorFee, estimate.HermesFee)
+	if payout := new(big.Int).Sub(unsettled, estimate.TotalFee); payout.Sign() > 0 {
+		estimate.Payout = payout
+	}
+	if unsettled.Sign() > 0 {
+		estimate.FeeRatio, _ = new(big.Float).Quo(new(big.Float).SetInt(estimate.TotalFee), new(big.Float).SetInt(unsettled)).Float64()
+	}
+
+	estimate.Settle, estimate.Reason = aps.settlementDecision(estimate)
+	return estimate, nil
+}
+
+func (aps *hermesPromiseSettler) settlementDecision(estimate SettlementEstimate) (bool, string) {
+	switch {
+	case estimate.Unsettled.Sign() <= 0:
+		return false, SettleReasonNothingToSettle
+	case aps.isSettling(estimate.ProviderID, estimate.HermesID):
+		return false, SettleReasonInProgress
+	case estimate.Payout.Sign() <= 0:
+		return false, SettleReasonFeesExceedAmount
+	case estimate.Unsettled.Cmp(units.FloatEthToBigIntWei(aps.config.MaxUnSettledAmount)) > 0:
+		return true, SettleReasonMaxAmount
+	case estimate.FeeRatio <= aps.config.Scheduler.TargetFeeRatio:
+		return true, SettleReasonFeeRatio
+	case aps.config.Scheduler.MaxUnsettledAge > 0 && time.Since(estimate.UnsettledSince) >= aps.config.Scheduler.MaxUnsettledAge:
+		return true, SettleReasonMaxAge
+	}
+	return false, SettleReasonWaiting
+}
+
+// runSettlementScheduler periodically settles channels of registered providers once it is worth it.
+func (aps *hermesPromiseSettler) runSettlementScheduler() {
+	log.Info().Msg("Starting settlement scheduler")
+	defer log.Info().Msg("Stopped settlement scheduler")
+
+	for {
+		select {
+		case <-aps.stop:
+			return
+		case <-time.After(aps.config.Scheduler.CheckInterval):
+		}
+
+		for _, providerID := range aps.registeredProviders() {
+			aps.scheduleSettlement(aps.chainID(), providerID)
+		}
+	}
+}
+
+// scheduleSettlement settles all channels of the provider which are worth settling in a single batch.
+// A new batch is not started while the previous one of the provider is still being settled.
+func (aps *hermesPromiseSettler) scheduleSettlement(chainID int64, providerID identity.Identity) {
+	estimates, err := aps.EstimateSettlement(chainID, providerID)
+	if err != nil {
+		log.Error().Err(err).Msgf("Could not estimate settlement for provider %s", providerID.Address)
+		return
+	}
+
+	var batch []common.Address
+	for _, estimate := range estimates {
+		log.Debug().
+			Str("provider", providerID.Address).
+			Str("hermes", estimate.HermesID.Hex()).
+			Str("unsettled", estimate.Unsettled.String()).
+			Str("fee", estimate.TotalFee.String()).
+			Float64("fee_ratio", estimate.FeeRatio).
+			Time("unsettled_since", estimate.UnsettledSince).
+			Bool("settle", estimate.Settle).
+			Str("reason", estimate.Reason).
+			Msg("Settlement scheduler decision")
+		if estimate.Settle {
+			batch = append(batch, estimate.HermesID)
+		}
+	}
+	if len(batch) == 0 {
+		return
+	}
+
+	if !aps.startBatch(providerID) {
+		log.Debug().Msgf("Settlement batch for provider %s is still in progress", providerID.Address)
+		return
+	}
+	log.Info().Msgf("Scheduling settlement batch for provider %s with %d hermes(es)", providerID.Address, len(batch))
+	go func() {
+		defer aps.finishBatch(providerID)
+		aps.settleBatch(chainID, providerID, batch)
+	}()
+}
+
+// settleBatch settles the hermes channels of a batch one after another.
+// Transactor settles a single hermes channel per transaction, so a failure of one channel does not stop the rest of the batch.
+func (aps *hermesPromiseSettler) settleBatch(chainID int64, providerID identity.Identity, batch []common.Address) {
+	var settled, failed int
+	for _, hermesID := range batch {
+		if err := aps.forceSettle(false, chainID, providerID, hermesID); err != nil {
+			log.Error().Err(err).Msgf("Scheduled settlement with hermes %s failed for provider %s", hermesID.Hex(), providerID.Address)
+			failed++
+			continue
+		}
+		settled++
+	}
+	log.Info().Msgf("Settlement batch for provider %s finished: %d settled, %d failed", providerID.Address, settled, failed)
+}
+
+// startBatch marks the provider as settling a batch, returns false if a batch is already in progress.
+func (aps *hermesPromiseSettler) startBatch(id identity.Identity) bool {
+	aps.lock.Lock()
+	defer aps.lock.Unlock()
+
+	v := aps.currentState[id]
+	if v.batchInProgress {
+		return false
+	}
+	v.batchInProgress = true
+	aps.currentState[id] = v
+	return true
+}
+
+func (aps *hermesPromiseSettler) finishBatch(id identity.Identity) {
+	aps.lock.Lock()
+	defer aps.lock.Unlock()
+
+	v := aps.currentState[id]
+	v.batchInProgress = false
+	aps.currentState[id] = v
+}
+
+func (aps *hermesPromiseSettler) registeredProviders() []identity.Identity {
+	aps.lock.RLock()
+	defer aps.lock.RUnlock()
+
+	var providers []identity.Identity
+	for id, s := range aps.currentState {
+		if s.registered {
+			providers = append(providers, id)
+		}
+	}
+	return providers
+}
@@ -0,0 +1,153 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package pingpong
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mysteriumnetwork/node/identity"
+	"github.com/mysteriumnetwork/payments/client"
+	"github.com/mysteriumnetwork/payments/crypto"
+)
+
+func TestPromiseSettler_settlementDecision(t *testing.T) {
+	settler := &hermesPromiseSettler{
+		currentState: map[identity.Identity]settlementState{
+			mockID: {settleInProgress: map[common.Address]struct{}{}},
+		},
+		config: HermesPromiseSettlerConfig{
+			MaxUnSettledAmount: 20,
+			Scheduler: SettlementSchedulerConfig{
+				CheckInterval:   time.Hour,
+				TargetFeeRatio:  0.02,
+				MaxUnsettledAge: time.Hour,
+			},
+		},
+	}
+	eth := big.NewInt(1_000_000_000_000_000_000)
+
+	tests := []struct {
+		name     string
+		estimate SettlementEstimate
+		settle   bool
+		reason   string
+	}{
+		{
+			name:     "nothing to settle",
+			estimate: SettlementEstimate{Unsettled: big.NewInt(0), Payout: big.NewInt(0)},
+			reason:   SettleReasonNothingToSettle,
+		},
+		{
+			name:     "fees exceed amount",
+			estimate: SettlementEstimate{Unsettled: big.NewInt(10), Payout: big.NewInt(0), FeeRatio: 1.5, UnsettledSince: time.Now().Add(-2 * time.Hour)},
+			reason:   SettleReasonFeesExceedAmount,
+		},
+		{
+			name:     "max amount reached",
+			estimate: SettlementEstimate{Unsettled: new(big.Int).Mul(eth, big.NewInt(21)), Payout: eth, FeeRatio: 0.5, UnsettledSince: time.Now()},
+			settle:   true,
+			reason:   SettleReasonMaxAmount,
+		},
+		{
+			name:     "fee ratio below target",
+			estimate: SettlementEstimate{Unsettled: eth, Payout: eth, FeeRatio: 0.01, UnsettledSince: time.Now()},
+			settle:   true,
+			reason:   SettleReasonFeeRatio,
+		},
+		{
+			name:     "max age reached",
+			estimate: SettlementEstimate{Unsettled: eth, Payout: eth, FeeRatio: 0.1, UnsettledSince: time.Now().Add(-2 * time.Hour)},
+			settle:   true,
+			reason:   SettleReasonMaxAge,
+		},
+		{
+			name:     "waiting for better fee ratio",
+			estimate: SettlementEstimate{Unsettled: eth, Payout: eth, FeeRatio: 0.1, UnsettledSince: time.Now()},
+			reason:   SettleReasonWaiting,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.estimate.ProviderID = mockID
+			tt.estimate.HermesID = hermesID
+			settle, reason := settler.settlementDecision(tt.estimate)
+			assert.Equal(t, tt.settle, settle)
+			assert.Equal(t, tt.reason, reason)
+		})
+	}
+
+	settler.currentState[mockID].settleInProgress[hermesID] = struct{}{}
+	settle, reason := settler.settlementDecision(SettlementEstimate{ProviderID: mockID, HermesID: hermesID, Unsettled: eth, Payout: eth})
+	assert.False(t, settle)
+	assert.Equal(t, SettleReasonInProgress, reason)
+}
+
+func TestPromiseSettler_unsettledSince(t *testing.T) {
+	history := &settlementHistoryStorageMock{}
+	settler := &hermesPromiseSettler{settlementHistoryStorage: history}
+	now := time.Now()
+	firstPromiseAt := now.Add(-3 * time.Hour)
+	unsettled := NewHermesChannel("1", mockID, hermesID, client.ProviderChannel{Settled: big.NewInt(5)}, HermesPromise{
+		Promise:        crypto.Promise{Amount: big.NewInt(10)},
+		FirstPromiseAt: firstPromiseAt,
+	}, beneficiaryID)
+
+	settled := unsettled
+	settled.Channel.Settled = big.NewInt(10)
+	since, err := settler.unsettledSince(settled, now)
+	assert.NoError(t, err)
+	assert.True(t, since.IsZero())
+
+	// Without settlements earnings are unsettled since the first promise.
+	since, err = settler.unsettledSince(unsettled, now)
+	assert.NoError(t, err)
+	assert.Equal(t, firstPromiseAt, since)
+
+	// The latest successful settlement resets the age, failed ones are ignored.
+	history.entries = []SettlementHistoryEntry{
+		{Time: now.Add(-time.Minute), Error: "failed"},
+		{Time: now.Add(-time.Hour)},
+		{Time: now.Add(-2 * time.Hour)},
+	}
+	since, err = settler.unsettledSince(unsettled, now)
+	assert.NoError(t, err)
+	assert.Equal(t, now.Add(-time.Hour), since)
+
+	// Promises stored before the first promise time was recorded count from now.
+	unsettled.lastPromise.FirstPromiseAt = time.Time{}
+	history.entries = nil
+	since, err = settler.unsettledSince(unsettled, now)
+	assert.NoError(t, err)
+	assert.Equal(t, now, since)
+}
+
+func TestPromiseSettler_batchInProgress(t *testing.T) {
+	settler := &hermesPromiseSettler{currentState: make(map[identity.Identity]settlementState)}
+
+	assert.True(t, settler.startBatch(mockID))
+	assert.False(t, settler.startBatch(mockID))
+	assert.True(t, settler.startBatch(identity.FromAddress("0x2")))
+
+	settler.finishBatch(mockID)
+	assert.True(t, settler.startBatch(mockID))
+}
@@ -21,38 +21,128 @@ import (
 	"bufio"
 	"errors"
 	"fmt"
+	"net"
 	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/rs/zerolog/log"
+
+	"github.com/mysteriumnetwork/node/supervisor/config"
+	"github.com/mysteriumnetwork/node/supervisor/protocol"
+)
+
+const (
+	// probeTimeout limits how long we wait for the structured protocol probe.
+	// Supervisors which do not speak the structured protocol either answer it with a legacy error or not at all.
+	probeTimeout = 3 * time.Second
+	// commandTimeout limits how long a single command may take.
+	commandTimeout = time.Minute
+)
+
+var (
+	lastRequestID uint64
+	dial          = connect
 )
 
 // Command executes supervisor command.
+// Errors reported by the supervisor are returned as *protocol.Error.
+// Falls back to legacy text commands if the supervisor does not support the structured protocol.
 func Command(args ...string) (result string, err error) {
-	cmdLine := strings.Join(args, " ")
-	log.Trace().Msgf("Supervisor command invoked: %q", cmdLine)
-	conn, err := connect()
+	if len(args) == 0 {
+		return "", errors.New("command is required")
+	}
+	log.Trace().Msgf("Supervisor command invoked: %q", strings.Join(args, " "))
+
+	token, err := config.ReadToken()
+	if err != nil {
+		return "", err
+	}
+
+	conn, err := dial()
 	if err != nil {
 		return "", err
 	}
 	defer conn.Close()
 
-	_, err = fmt.Fprintln(conn, cmdLine)
+	scanner := bufio.NewScanner(conn)
+	if !probe(conn, scanner, token) {
+		log.Debug().Msg("Supervisor does not support the structured protocol, using legacy commands")
+		return legacyCommand(args...)
+	}
+
+	conn.SetDeadline(time.Now().Add(commandTimeout))
+	return request(conn, scanner, token, args[0], args[1:]...)
+}
+
+// probe checks whether the supervisor answers structured frames.
+// The probe is a ping, so it is harmless to repeat it as a legacy command afterwards.
+func probe(conn net.Conn, scanner *bufio.Scanner, token string) bool {
+	conn.SetDeadline(time.Now().Add(probeTimeout))
+	_, err := request(conn, scanner, token, "ping")
+	var protoErr *protocol.Error
+	return err == nil || errors.As(err, &protoErr)
+}
+
+func request(conn net.Conn, scanner *bufio.Scanner, token, command string, args ...string) (string, error) {
+	req := protocol.Request{
+		Version: protocol.Version,
+		ID:      atomic.AddUint64(&lastRequestID, 1),
+		Command: command,
+		Args:    args,
+		Token:   token,
+	}
+	if err := protocol.WriteFrame(conn, req); err != nil {
+		return "", err
+	}
+
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return "", fmt.Errorf("could not read supervisor response: %w", err)
+		}
+		return "", errors.New("supervisor closed connection without response")
+	}
+
+	resp := protocol.Response{}
+	if err := protocol.ParseFrame(scanner.Bytes(), &resp); err != nil {
+		return "", err
+	}
+	if resp.ID != req.ID {
+		return "", fmt.Errorf("unexpected response id %d, expected %d", resp.ID, req.ID)
+	}
+	if resp.Error != nil {
+		return "", resp.Error
+	}
+	return resp.Result, nil
+}
+
+// legacyCommand executes the command using plain text protocol on a new connection.
+func legacyCommand(args ...string) (result string, err error) {
+	conn, err := dial()
 	if err != nil {
 		return "", err
 	}
+	defer conn.Close()
+
+	conn.SetDeadline(time.Now().Add(commandTimeout))
+	if _, err := fmt.Fprintln(conn, strings.Join(args, " ")); err != nil {
+		return "", err
+	}
 
 	scanner := bufio.NewScanner(conn)
-	scanner.Scan()
-	line := string(scanner.Bytes())
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return "", fmt.Errorf("could not read supervisor response: %w", err)
+		}
+		return "", errors.New("supervisor closed connection without response")
+	}
+	line := scanner.Text()
 	parts := strings.SplitN(line, ": ", 2)
-	status := parts[0]
-	if status == "ok" {
+	if parts[0] == "ok" {
 		if len(parts) > 1 {
 			result = parts[1]
 		}
 		return result, nil
 	}
-
-	message := strings.TrimPrefix(line, "error: ")
-	return "", errors.New(message)
+	return "", errors.New(strings.TrimPrefix(line, "error: "))
 }
@@ -0,0 +1,106 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package client
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mysteriumnetwork/node/supervisor/protocol"
+)
+
+// fakeSupervisor serves every dialed connection with the given handler.
+func fakeSupervisor(t *testing.T, handle func(line string) (reply string, ok bool)) {
+	dial = func() (net.Conn, error) {
+		server, client := net.Pipe()
+		go func() {
+			defer server.Close()
+			scan := bufio.NewScanner(server)
+			for scan.Scan() {
+				reply, ok := handle(scan.Text())
+				if !ok {
+					continue
+				}
+				fmt.Fprintln(server, reply)
+			}
+		}()
+		return client, nil
+	}
+	t.Cleanup(func() { dial = connect })
+}
+
+func TestCommand_Structured(t *testing.T) {
+	fakeSupervisor(t, func(line string) (string, bool) {
+		req := protocol.Request{}
+		if err := protocol.ParseFrame([]byte(line), &req); err != nil {
+			return "error: unexpected legacy command", true
+		}
+		resp := protocol.Response{Version: protocol.Version, ID: req.ID}
+		switch req.Command {
+		case "ping":
+			resp.Result = "pong"
+		case "wg-down":
+			resp.Error = protocol.NewError(protocol.ErrCodeCommandFailed, "-iface is required")
+		}
+		b := &strings.Builder{}
+		protocol.WriteFrame(b, resp)
+		return strings.TrimSpace(b.String()), true
+	})
+
+	_, err := Command("wg-down")
+	assert.Equal(t, &protocol.Error{Code: protocol.ErrCodeCommandFailed, Message: "-iface is required"}, err)
+}
+
+func TestCommand_FallsBackToLegacySupervisor(t *testing.T) {
+	var legacy []string
+	fakeSupervisor(t, func(line string) (string, bool) {
+		legacy = append(legacy, line)
+		cmd := strings.Split(line, " ")[0]
+		if cmd == "version" {
+			return "ok: 1.0.0", true
+		}
+		return "error: unknown command: " + cmd, true
+	})
+
+	result, err := Command("version")
+	require.NoError(t, err)
+	assert.Equal(t, "1.0.0", result)
+	assert.Equal(t, "version", legacy[len(legacy)-1])
+}
+
+func TestCommand_FallsBackWhenSupervisorDoesNotAnswerFrames(t *testing.T) {
+	fakeSupervisor(t, func(line string) (string, bool) {
+		if protocol.IsFrame([]byte(line)) {
+			return "", false
+		}
+		return "ok: pong", true
+	})
+
+	start := time.Now()
+	result, err := Command("ping")
+	require.NoError(t, err)
+	assert.Equal(t, "pong", result)
+	assert.Less(t, time.Since(start), commandTimeout)
+}
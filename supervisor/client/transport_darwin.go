@@ -19,13 +19,12 @@ package client
 
 import (
 	"fmt"
-	"io"
 	"net"
 )
 
 const sock = "/var/run/myst.sock"
 
-func connect() (io.ReadWriteCloser, error) {
+func connect() (net.Conn, error) {
 	conn, err := net.Dial("unix", sock)
 	if err != nil {
 		return nil, fmt.Errorf("could not connect to the supervisor socket %s: %w", sock, err)
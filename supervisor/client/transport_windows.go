@@ -19,7 +19,7 @@ package client
 
 import (
 	"fmt"
-	"io"
+	"net"
 	"time"
 
 	"github.com/Microsoft/go-winio"
@@ -27,7 +27,7 @@ import (
 
 const sock = `\\.\pipe\mystpipe`
 
-func connect() (io.ReadWriteCloser, error) {
+func connect() (net.Conn, error) {
 	timeout := 5 * time.Second
 	conn, err := winio.DialPipe(sock, &timeout)
 	if err != nil {
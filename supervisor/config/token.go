@@ -0,0 +1,76 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package config
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const tokenFile = "myst_supervisor.token"
+
+// NewToken generates a random shared secret for supervisor clients.
+func NewToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("could not generate token: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// WriteToken stores the shared secret so that only the given user can read it.
+func WriteToken(token, uid string) error {
+	path, err := tokenPath()
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, []byte(token), 0600); err != nil {
+		return fmt.Errorf("could not write %q: %w", path, err)
+	}
+	return restrictToken(path, uid)
+}
+
+// ReadToken reads the shared secret written by the supervisor.
+// Returns empty token if the supervisor did not write one.
+func ReadToken() (string, error) {
+	path, err := tokenPath()
+	if err != nil {
+		return "", err
+	}
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("could not read %q: %w", path, err)
+	}
+	return strings.TrimSpace(string(b)), nil
+}
+
+func tokenPath() (string, error) {
+	dir, err := configDir()
+	if err != nil {
+		return "", fmt.Errorf("could not determine config dir: %w", err)
+	}
+	return filepath.Join(dir, tokenFile), nil
+}
@@ -0,0 +1,37 @@
+//go:build !windows
+
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+)
+
+func restrictToken(path, uid string) error {
+	numUid, err := strconv.Atoi(uid)
+	if err != nil {
+		return fmt.Errorf("failed to parse uid %s: %w", uid, err)
+	}
+	if err := os.Chown(path, numUid, -1); err != nil {
+		return fmt.Errorf("failed to chown supervisor token to uid %s: %w", uid, err)
+	}
+	return nil
+}
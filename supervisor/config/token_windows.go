@@ -0,0 +1,54 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package config
+
+import (
+	"fmt"
+
+	"golang.org/x/sys/windows"
+)
+
+// restrictToken replaces the token file DACL so that only SYSTEM, Administrators and the given user can access it.
+// uid is the security identifier (SID) of the user in a string format.
+func restrictToken(path, uid string) error {
+	if _, err := windows.StringToSid(uid); err != nil {
+		return fmt.Errorf("failed to parse uid %s: %w", uid, err)
+	}
+	sddl := fmt.Sprintf("D:P(A;;GA;;;SY)(A;;GA;;;BA)(A;;GR;;;%s)", uid)
+	sd, err := windows.SecurityDescriptorFromString(sddl)
+	if err != nil {
+		return fmt.Errorf("failed to build supervisor token security descriptor: %w", err)
+	}
+	dacl, _, err := sd.DACL()
+	if err != nil {
+		return fmt.Errorf("failed to get supervisor token DACL: %w", err)
+	}
+	err = windows.SetNamedSecurityInfo(
+		path,
+		windows.SE_FILE_OBJECT,
+		windows.DACL_SECURITY_INFORMATION|windows.PROTECTED_DACL_SECURITY_INFORMATION,
+		nil,
+		nil,
+		dacl,
+		nil,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to restrict supervisor token to uid %s: %w", uid, err)
+	}
+	return nil
+}
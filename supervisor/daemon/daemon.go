@@ -19,6 +19,7 @@ package daemon
 
 import (
 	"bufio"
+	"crypto/subtle"
 	"encoding/base64"
 	"encoding/json"
 	"errors"
@@ -33,106 +34,173 @@ import (
 	"github.com/mysteriumnetwork/node/metadata"
 	"github.com/mysteriumnetwork/node/router/network"
 	"github.com/mysteriumnetwork/node/services/wireguard/wgcfg"
+	"github.com/mysteriumnetwork/node/supervisor/config"
 	"github.com/mysteriumnetwork/node/supervisor/daemon/transport"
 	"github.com/mysteriumnetwork/node/supervisor/daemon/wireguard"
+	"github.com/mysteriumnetwork/node/supervisor/protocol"
 )
 
 // Daemon - supervisor process.
 type Daemon struct {
-	monitor       *wireguard.Monitor
-	tequilapiPort uint16
+	monitor             *wireguard.Monitor
+	tequilapiPort       uint16
+	token               string
+	allowLegacyCommands bool
+}
+
+// Options for the supervisor daemon.
+type Options struct {
+	// AllowLegacyCommands accepts plain text commands from clients which were not verified by peer credentials.
+	// Legacy commands carry no token, so they are rejected by default on platforms without peer credentials.
+	AllowLegacyCommands bool
 }
 
 // New creates a new daemon.
-func New() Daemon {
-	return Daemon{monitor: wireguard.NewMonitor(), tequilapiPort: defaultPort}
+func New(options Options) Daemon {
+	return Daemon{monitor: wireguard.NewMonitor(), tequilapiPort: defaultPort, allowLegacyCommands: options.AllowLegacyCommands}
 }
 
 // Start supervisor daemon. Blocks.
 func (d *Daemon) Start(options transport.Options) error {
+	token, err := config.NewToken()
+	if err != nil {
+		return err
+	}
+	if err := config.WriteToken(token, options.Uid); err != nil {
+		return fmt.Errorf("could not write supervisor token: %w", err)
+	}
+	d.token = token
 	return transport.Start(d.dialog, options)
 }
 
+var errUnknownCommand = errors.New("unknown command")
+
 // dialog talks to the client via established connection.
-func (d *Daemon) dialog(conn io.ReadWriter) {
+// Structured protocol frames and legacy text commands are accepted on the same connection,
+// legacy text commands only from peers verified by the transport unless explicitly allowed.
+func (d *Daemon) dialog(conn io.ReadWriter, peer transport.Peer) {
 	scan := bufio.NewScanner(conn)
 	answer := responder{conn}
 	for scan.Scan() {
 		line := scan.Bytes()
+		if protocol.IsFrame(line) {
+			if !d.handleFrame(conn, line) {
+				return
+			}
+			continue
+		}
+
 		log.Debug().Msgf("> %s", line)
+		if !peer.Verified && !d.allowLegacyCommands {
+			answer.err(errors.New("legacy commands are not allowed, use the structured protocol"))
+			continue
+		}
 		cmd := strings.Split(string(line), " ")
 		op := strings.ToLower(cmd[0])
-		switch op {
-		case commandVersion:
-			answer.ok(metadata.VersionAsString())
-		case commandBye:
+		if op == commandBye {
 			answer.ok("bye")
 			return
-		case commandPing:
-			answer.ok("pong")
-		case commandWgUp:
-			up, err := d.wgUp(cmd...)
-			if err != nil {
-				log.Err(err).Msgf("%s failed", commandWgUp)
-				answer.err(err)
-			} else {
-				answer.ok(up)
-			}
-		case commandWgDown:
-			err := d.wgDown(cmd...)
-			if err != nil {
-				log.Err(err).Msgf("%s failed", commandWgDown)
-				answer.err(err)
-			} else {
-				answer.ok()
-			}
-		case commandWgStats:
-			stats, err := d.wgStats(cmd...)
-			if err != nil {
-				log.Err(err).Msgf("%s failed", commandWgStats)
-				answer.err(err)
-			} else {
-				answer.ok(stats)
-			}
-		case commandKill:
-			if err := d.killMyst(); err != nil {
-				log.Err(err).Msgf("%s failed", commandKill)
-				answer.err(err)
-			} else {
-				answer.ok()
-			}
-		case commandTequilapiSetPort:
-			if err := d.setTequilapiPort(cmd); err != nil {
-				log.Err(err).Msgf("%s failed", commandTequilapiSetPort)
-				answer.err(err)
-			} else {
-				answer.ok()
-			}
-		case commandDiscoverGateway:
-			t := &network.RoutingTable{}
-			gw, err := t.DiscoverGateway()
-			if err != nil {
-				log.Err(err).Msgf("%s failed", commandDiscoverGateway)
-				answer.err(err)
-			} else {
-				answer.ok(gw.String())
-			}
-		case commandExcludeRoute:
-			if err := d.excludeRoute(cmd...); err != nil {
-				log.Err(err).Msgf("%s failed", commandDiscoverGateway)
-				answer.err(err)
-			} else {
-				answer.ok()
-			}
-		case commandDeleteRoute:
-			if err := d.deleteRoute(cmd...); err != nil {
-				log.Err(err).Msgf("%s failed", commandDiscoverGateway)
-				answer.err(err)
-			} else {
-				answer.ok()
-			}
 		}
+		result, err := d.execute(op, cmd)
+		if err != nil {
+			answer.err(err)
+		} else if result != "" {
+			answer.ok(result)
+		} else {
+			answer.ok()
+		}
+	}
+}
+
+// handleFrame handles a single structured request. Returns false if the connection should be closed.
+func (d *Daemon) handleFrame(w io.Writer, line []byte) bool {
+	log.Debug().Msg("> structured request")
+	req := protocol.Request{}
+	resp := protocol.Response{Version: protocol.Version}
+	reply := func(r protocol.Response) {
+		if err := protocol.WriteFrame(w, r); err != nil {
+			log.Err(err).Msgf("Could not send response for request %d", r.ID)
+		}
+	}
+
+	if err := protocol.ParseFrame(line, &req); err != nil {
+		resp.Error = protocol.NewError(protocol.ErrCodeBadRequest, "%v", err)
+		reply(resp)
+		return true
+	}
+	resp.ID = req.ID
+	if req.Version != protocol.Version {
+		resp.Error = protocol.NewError(protocol.ErrCodeUnsupportedVersion, "protocol version %d is not supported, expected %d", req.Version, protocol.Version)
+		reply(resp)
+		return true
+	}
+	if d.token != "" && subtle.ConstantTimeCompare([]byte(req.Token), []byte(d.token)) != 1 {
+		log.Warn().Msgf("Rejected unauthorized request %d", req.ID)
+		resp.Error = protocol.NewError(protocol.ErrCodeUnauthorized, "invalid token")
+		reply(resp)
+		return true
+	}
+
+	op := strings.ToLower(req.Command)
+	log.Debug().Msgf("> [%d] %s", req.ID, op)
+	if op == commandBye {
+		resp.Result = "bye"
+		reply(resp)
+		return false
+	}
+
+	result, err := d.execute(op, append([]string{op}, req.Args...))
+	switch {
+	case errors.Is(err, errUnknownCommand):
+		resp.Error = protocol.NewError(protocol.ErrCodeUnknownCommand, "%v", err)
+	case err != nil:
+		resp.Error = protocol.NewError(protocol.ErrCodeCommandFailed, "%v", err)
+	default:
+		resp.Result = result
+	}
+	log.Debug().Msgf("< [%d] %s", resp.ID, resp.Result)
+	reply(resp)
+	return true
+}
+
+// execute runs the given command, cmd holds the command name followed by its arguments.
+func (d *Daemon) execute(op string, cmd []string) (string, error) {
+	var result string
+	var err error
+	switch op {
+	case commandVersion:
+		return metadata.VersionAsString(), nil
+	case commandPing:
+		return "pong", nil
+	case commandWgUp:
+		result, err = d.wgUp(cmd...)
+	case commandWgDown:
+		err = d.wgDown(cmd...)
+	case commandWgStats:
+		result, err = d.wgStats(cmd...)
+	case commandKill:
+		err = d.killMyst()
+	case commandTequilapiSetPort:
+		err = d.setTequilapiPort(cmd)
+	case commandDiscoverGateway:
+		t := &network.RoutingTable{}
+		var gw net.IP
+		gw, err = t.DiscoverGateway()
+		if err == nil {
+			result = gw.String()
+		}
+	case commandExcludeRoute:
+		err = d.excludeRoute(cmd...)
+	case commandDeleteRoute:
+		err = d.deleteRoute(cmd...)
+	default:
+		return "", fmt.Errorf("%w: %s", errUnknownCommand, op)
+	}
+	if err != nil {
+		log.Err(err).Msgf("%s failed", op)
+		return "", err
 	}
+	return result, nil
 }
 
 func (d *Daemon) excludeRoute(args ...string) error {
@@ -0,0 +1,117 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package daemon
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mysteriumnetwork/node/supervisor/daemon/transport"
+	"github.com/mysteriumnetwork/node/supervisor/protocol"
+)
+
+func startDialog(t *testing.T, d *Daemon, peer transport.Peer) (net.Conn, *bufio.Scanner) {
+	server, client := net.Pipe()
+	go func() {
+		d.dialog(server, peer)
+		server.Close()
+	}()
+	t.Cleanup(func() { client.Close() })
+	return client, bufio.NewScanner(client)
+}
+
+func request(t *testing.T, conn net.Conn, scan *bufio.Scanner, req protocol.Request) protocol.Response {
+	require.NoError(t, protocol.WriteFrame(conn, req))
+	require.True(t, scan.Scan())
+	resp := protocol.Response{}
+	require.NoError(t, protocol.ParseFrame(scan.Bytes(), &resp))
+	return resp
+}
+
+func TestDaemon_LegacyCommands(t *testing.T) {
+	d := New(Options{})
+	d.token = "secret"
+
+	conn, scan := startDialog(t, &d, transport.Peer{Verified: true})
+	_, err := fmt.Fprintln(conn, "ping")
+	require.NoError(t, err)
+	require.True(t, scan.Scan())
+	assert.Equal(t, "ok: pong", scan.Text())
+
+	_, err = fmt.Fprintln(conn, "wg-down")
+	require.NoError(t, err)
+	require.True(t, scan.Scan())
+	assert.Equal(t, "error: -iface is required", scan.Text())
+}
+
+func TestDaemon_LegacyCommandsAcceptedForUnverifiedPeerWhenAllowed(t *testing.T) {
+	d := New(Options{AllowLegacyCommands: true})
+	d.token = "secret"
+
+	conn, scan := startDialog(t, &d, transport.Peer{})
+	_, err := fmt.Fprintln(conn, "ping")
+	require.NoError(t, err)
+	require.True(t, scan.Scan())
+	assert.Equal(t, "ok: pong", scan.Text())
+}
+
+func TestDaemon_LegacyCommandsRejectedForUnverifiedPeerByDefault(t *testing.T) {
+	d := New(Options{})
+	d.token = "secret"
+
+	conn, scan := startDialog(t, &d, transport.Peer{})
+	_, err := fmt.Fprintln(conn, "ping")
+	require.NoError(t, err)
+	require.True(t, scan.Scan())
+	assert.Equal(t, "error: legacy commands are not allowed, use the structured protocol", scan.Text())
+}
+
+func TestDaemon_StructuredCommands(t *testing.T) {
+	d := New(Options{})
+	d.token = "secret"
+	conn, scan := startDialog(t, &d, transport.Peer{})
+
+	resp := request(t, conn, scan, protocol.Request{Version: protocol.Version, ID: 1, Command: "ping", Token: "secret"})
+	assert.Equal(t, protocol.Response{Version: protocol.Version, ID: 1, Result: "pong"}, resp)
+
+	resp = request(t, conn, scan, protocol.Request{Version: protocol.Version, ID: 2, Command: "ping", Token: "wrong"})
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, uint64(2), resp.ID)
+	assert.Equal(t, protocol.ErrCodeUnauthorized, resp.Error.Code)
+
+	resp = request(t, conn, scan, protocol.Request{Version: protocol.Version + 1, ID: 3, Command: "ping", Token: "secret"})
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, protocol.ErrCodeUnsupportedVersion, resp.Error.Code)
+
+	resp = request(t, conn, scan, protocol.Request{Version: protocol.Version, ID: 4, Command: "fly", Token: "secret"})
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, protocol.ErrCodeUnknownCommand, resp.Error.Code)
+
+	resp = request(t, conn, scan, protocol.Request{Version: protocol.Version, ID: 5, Command: "wg-down", Token: "secret"})
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, &protocol.Error{Code: protocol.ErrCodeCommandFailed, Message: "-iface is required"}, resp.Error)
+
+	resp = request(t, conn, scan, protocol.Request{Version: protocol.Version, ID: 6, Command: "bye", Token: "secret"})
+	assert.Equal(t, "bye", resp.Result)
+	assert.False(t, scan.Scan())
+}
@@ -20,7 +20,13 @@ package transport
 import "io"
 
 // handlerFunc talks to a connected client.
-type handlerFunc func(conn io.ReadWriter)
+type handlerFunc func(conn io.ReadWriter, peer Peer)
+
+// Peer describes a connected client.
+type Peer struct {
+	// Verified is set when the client was authorized using OS provided peer credentials.
+	Verified bool
+}
 
 // Options for transport.
 type Options struct {
@@ -62,7 +62,7 @@ func Start(handle handlerFunc, options Options) error {
 		go func() {
 			peer := conn.RemoteAddr().Network()
 			log.Debug().Msgf("Client connected: %s", peer)
-			handle(conn)
+			handle(conn, Peer{})
 			if err := conn.Close(); err != nil {
 				log.Err(err).Msgf("Error closing connection for: %v", peer)
 			}
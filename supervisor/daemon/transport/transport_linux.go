@@ -24,6 +24,7 @@ import (
 	"strconv"
 
 	"github.com/rs/zerolog/log"
+	"golang.org/x/sys/unix"
 )
 
 const sock = "/run/myst.sock"
@@ -62,7 +63,18 @@ func Start(handle handlerFunc, options Options) error {
 		go func() {
 			peer := conn.RemoteAddr().Network()
 			log.Debug().Msgf("Client connected: %s", peer)
-			handle(conn)
+			peerUid, err := peerCredentials(conn)
+			if err != nil {
+				log.Err(err).Msg("Could not get client credentials, dropping connection")
+				conn.Close()
+				return
+			}
+			if peerUid != 0 && peerUid != numUid {
+				log.Warn().Msgf("Client with uid %d is not allowed to use supervisor, dropping connection", peerUid)
+				conn.Close()
+				return
+			}
+			handle(conn, Peer{Verified: true})
 			if err := conn.Close(); err != nil {
 				log.Err(err).Msgf("Error closing connection for: %v", peer)
 			}
@@ -70,3 +82,28 @@ func Start(handle handlerFunc, options Options) error {
 		}()
 	}
 }
+
+// peerCredentials returns uid of the process on the other end of the unix socket (SO_PEERCRED).
+func peerCredentials(conn net.Conn) (int, error) {
+	unixConn, ok := conn.(*net.UnixConn)
+	if !ok {
+		return 0, fmt.Errorf("unexpected connection type %T", conn)
+	}
+	raw, err := unixConn.SyscallConn()
+	if err != nil {
+		return 0, fmt.Errorf("could not get raw connection: %w", err)
+	}
+
+	var cred *unix.Ucred
+	var credErr error
+	err = raw.Control(func(fd uintptr) {
+		cred, credErr = unix.GetsockoptUcred(int(fd), unix.SOL_SOCKET, unix.SO_PEERCRED)
+	})
+	if err != nil {
+		return 0, fmt.Errorf("could not access socket: %w", err)
+	}
+	if credErr != nil {
+		return 0, fmt.Errorf("could not get peer credentials: %w", credErr)
+	}
+	return int(cred.Uid), nil
+}
@@ -102,7 +102,7 @@ func listenPipe(handle handlerFunc) error {
 		go func() {
 			peer := conn.RemoteAddr().Network()
 			log.Debug().Msgf("Client connected: %s", peer)
-			handle(conn)
+			handle(conn, Peer{})
 			if err := conn.Close(); err != nil {
 				log.Err(err).Msgf("Error closing connection for: %s", peer)
 			}
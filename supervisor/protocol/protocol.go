@@ -0,0 +1,112 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package protocol
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Version of the structured supervisor protocol.
+const Version = 1
+
+// frameStart is the first byte of every structured frame.
+// Legacy text commands never start with it, which allows both protocols to share a socket.
+const frameStart = '{'
+
+// Request is a single command sent to the supervisor.
+type Request struct {
+	Version int      `json:"v"`
+	ID      uint64   `json:"id"`
+	Command string   `json:"cmd"`
+	Args    []string `json:"args,omitempty"`
+	Token   string   `json:"token,omitempty"`
+}
+
+// Response is the supervisor answer to a Request with the same ID.
+type Response struct {
+	Version int    `json:"v"`
+	ID      uint64 `json:"id"`
+	Result  string `json:"result,omitempty"`
+	Error   *Error `json:"error,omitempty"`
+}
+
+// ErrorCode classifies supervisor errors.
+type ErrorCode string
+
+const (
+	// ErrCodeBadRequest is returned when the request frame can not be parsed.
+	ErrCodeBadRequest ErrorCode = "bad_request"
+	// ErrCodeUnsupportedVersion is returned when the request protocol version is not supported.
+	ErrCodeUnsupportedVersion ErrorCode = "unsupported_version"
+	// ErrCodeUnauthorized is returned when the client failed authentication.
+	ErrCodeUnauthorized ErrorCode = "unauthorized"
+	// ErrCodeUnknownCommand is returned for commands the supervisor does not know.
+	ErrCodeUnknownCommand ErrorCode = "unknown_command"
+	// ErrCodeCommandFailed is returned when the command was accepted but failed to execute.
+	ErrCodeCommandFailed ErrorCode = "command_failed"
+)
+
+// Error is a typed supervisor error.
+type Error struct {
+	Code    ErrorCode `json:"code"`
+	Message string    `json:"message"`
+}
+
+// Error returns error message.
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
+
+// NewError creates a new typed error.
+func NewError(code ErrorCode, format string, args ...interface{}) *Error {
+	return &Error{Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+// IsFrame checks whether the given line is a structured frame rather than a legacy text command.
+func IsFrame(line []byte) bool {
+	line = bytes.TrimSpace(line)
+	return len(line) > 0 && line[0] == frameStart
+}
+
+// WriteFrame encodes v as a single newline terminated frame.
+func WriteFrame(w io.Writer, v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("could not encode frame: %w", err)
+	}
+	// encoding/json escapes control characters, so the encoded value never contains a newline.
+	b = append(b, '\n')
+	if _, err := w.Write(b); err != nil {
+		return fmt.Errorf("could not write frame: %w", err)
+	}
+	return nil
+}
+
+// ParseFrame decodes a single frame into v.
+func ParseFrame(line []byte, v interface{}) error {
+	if !IsFrame(line) {
+		return fmt.Errorf("not a protocol frame")
+	}
+	if err := json.Unmarshal(line, v); err != nil {
+		return fmt.Errorf("could not decode frame: %w", err)
+	}
+	return nil
+}
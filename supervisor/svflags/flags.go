@@ -32,6 +32,7 @@ var (
 	FlagLogFilePath = flag.String("log-path", "", "Supervisor log file path")
 	FlagLogLevel    = flag.String("log-level", zerolog.InfoLevel.String(), "Logging level")
 	FlagWinService  = flag.Bool("winservice", false, "Run via service manager instead of standalone (windows only).")
+	FlagAllowLegacy = flag.Bool("allow-legacy-commands", false, "Accept plain text commands without a token from clients which can not be verified by peer credentials (non-linux only, insecure)")
 )
 
 // Parse parses supervisor flags.
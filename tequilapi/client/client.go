@@ -29,6 +29,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/mysteriumnetwork/go-rest/apierror"
+	"github.com/mysteriumnetwork/node/config"
 	"github.com/mysteriumnetwork/node/identity"
 	"github.com/mysteriumnetwork/node/tequilapi/contract"
 	"github.com/mysteriumnetwork/payments/exchange"
@@ -452,6 +453,14 @@ func (client *Client) ProposalsNATCompatible() ([]contract.ProposalDTO, error) {
 	return client.proposals(queryParams)
 }
 
+// ProposalsByQuery returns proposals matching the given proposal query, which we can connect to
+func (client *Client) ProposalsByQuery(query string) ([]contract.ProposalDTO, error) {
+	queryParams := url.Values{}
+	queryParams.Add("nat_compatibility", contract.AutoNATType)
+	queryParams.Add("query", query)
+	return client.proposals(queryParams)
+}
+
 func (client *Client) proposals(query url.Values) ([]contract.ProposalDTO, error) {
 	response, err := client.http.Get("proposals", query)
 	if err != nil {
@@ -624,6 +633,18 @@ func (client *Client) NATType() (status contract.NATTypeDTO, err error) {
 	return status, err
 }
 
+// Diagnostics runs node diagnostics and returns the report.
+func (client *Client) Diagnostics() (report contract.DiagnosticsReportDTO, err error) {
+	response, err := client.http.Get("diagnostics", nil)
+	if err != nil {
+		return report, err
+	}
+	defer response.Body.Close()
+
+	err = parseResponseJSON(response, &report)
+	return report, err
+}
+
 // filterSessionsByType removes all sessions of irrelevant types
 func filterSessionsByType(serviceType string, sessions contract.SessionListResponse) contract.SessionListResponse {
 	matches := 0
@@ -931,6 +952,41 @@ func (client *Client) OrderInvoice(address identity.Identity, orderID string) ([
 	return io.ReadAll(resp.Body)
 }
 
+// AutoTopUpGet returns automatic balance top-up rule of the given identity.
+func (client *Client) AutoTopUpGet(id identity.Identity) (contract.AutoTopUpRuleResponse, error) {
+	resp, err := client.http.Get(fmt.Sprintf("v2/identities/%s/auto-topup", id.Address), nil)
+	if err != nil {
+		return contract.AutoTopUpRuleResponse{}, err
+	}
+	defer resp.Body.Close()
+
+	var res contract.AutoTopUpRuleResponse
+	return res, parseResponseJSON(resp, &res)
+}
+
+// AutoTopUpSet sets automatic balance top-up rule of the given identity.
+func (client *Client) AutoTopUpSet(id identity.Identity, rule contract.AutoTopUpRuleRequest) (contract.AutoTopUpRuleResponse, error) {
+	resp, err := client.http.Put(fmt.Sprintf("v2/identities/%s/auto-topup", id.Address), rule)
+	if err != nil {
+		return contract.AutoTopUpRuleResponse{}, err
+	}
+	defer resp.Body.Close()
+
+	var res contract.AutoTopUpRuleResponse
+	return res, parseResponseJSON(resp, &res)
+}
+
+// AutoTopUpRemove disables automatic balance top-up of the given identity.
+func (client *Client) AutoTopUpRemove(id identity.Identity) error {
+	resp, err := client.http.Delete(fmt.Sprintf("v2/identities/%s/auto-topup", id.Address), nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
 // PaymentOrderGateways returns all possible gateways and their data.
 func (client *Client) PaymentOrderGateways(optionsCurrency exchange.Currency) ([]contract.GatewaysResponse, error) {
 	query := url.Values{}
@@ -983,6 +1039,26 @@ func (client *Client) FetchConfig() (map[string]interface{}, error) {
 	return config, err
 }
 
+// DiffConfig - validate user config changes and return changes of effective config without applying them.
+func (client *Client) DiffConfig(data map[string]interface{}) ([]config.Change, error) {
+	req := struct {
+		Data map[string]interface{} `json:"data"`
+	}{
+		Data: data,
+	}
+	resp, err := client.http.Post("config/user?dry_run=true", req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var res struct {
+		Changes []config.Change `json:"changes"`
+	}
+	err = parseResponseJSON(resp, &res)
+	return res.Changes, err
+}
+
 // SetConfig - set user config.
 func (client *Client) SetConfig(data map[string]interface{}) error {
 	req := struct {
@@ -1003,3 +1079,66 @@ func (client *Client) SetConfig(data map[string]interface{}) error {
 
 	return nil
 }
+
+// BackupCreate returns node backup encrypted with the given passphrase.
+func (client *Client) BackupCreate(passphrase string) ([]byte, error) {
+	resp, err := client.http.Post("backup", contract.BackupCreateRequest{Passphrase: passphrase})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	return io.ReadAll(resp.Body)
+}
+
+// BackupRestore stages node backup to be restored on the next node start.
+func (client *Client) BackupRestore(passphrase string, archive []byte) (contract.BackupManifestDTO, error) {
+	resp, err := client.http.Post("backup/restore", contract.BackupRestoreRequest{Passphrase: passphrase, Archive: archive})
+	if err != nil {
+		return contract.BackupManifestDTO{}, err
+	}
+	defer resp.Body.Close()
+
+	var res contract.BackupManifestDTO
+	return res, parseResponseJSON(resp, &res)
+}
+
+// ProviderLists returns favourite and blocked providers of the given consumer.
+func (client *Client) ProviderLists(consumerID identity.Identity) (contract.ProviderListsDTO, error) {
+	resp, err := client.http.Get(fmt.Sprintf("identities/%s/provider-lists", consumerID.Address), nil)
+	if err != nil {
+		return contract.ProviderListsDTO{}, err
+	}
+	defer resp.Body.Close()
+
+	var res contract.ProviderListsDTO
+	return res, parseResponseJSON(resp, &res)
+}
+
+// ProviderFavouriteSet adds or removes provider from favourites of the given consumer.
+func (client *Client) ProviderFavouriteSet(consumerID identity.Identity, providerID string, favourite bool) (contract.ProviderListsDTO, error) {
+	return client.setProviderList(consumerID, "favourites", providerID, favourite)
+}
+
+// ProviderBlockedSet blocks or unblocks provider for the given consumer.
+func (client *Client) ProviderBlockedSet(consumerID identity.Identity, providerID string, blocked bool) (contract.ProviderListsDTO, error) {
+	return client.setProviderList(consumerID, "blocked", providerID, blocked)
+}
+
+func (client *Client) setProviderList(consumerID identity.Identity, list, providerID string, present bool) (contract.ProviderListsDTO, error) {
+	path := fmt.Sprintf("identities/%s/provider-lists/%s/%s", consumerID.Address, list, providerID)
+	var resp *http.Response
+	var err error
+	if present {
+		resp, err = client.http.Put(path, nil)
+	} else {
+		resp, err = client.http.Delete(path, nil)
+	}
+	if err != nil {
+		return contract.ProviderListsDTO{}, err
+	}
+	defer resp.Body.Close()
+
+	var res contract.ProviderListsDTO
+	return res, parseResponseJSON(resp, &res)
+}
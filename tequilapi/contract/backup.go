@@ -0,0 +1,95 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package contract
+
+import (
+	"time"
+
+	"github.com/mysteriumnetwork/go-rest/apierror"
+
+	"github.com/mysteriumnetwork/node/core/backup"
+)
+
+// BackupCreateRequest request used to create node backup.
+// swagger:model BackupCreateRequest
+type BackupCreateRequest struct {
+	// Passphrase used to encrypt the backup
+	Passphrase string `json:"passphrase"`
+}
+
+// Validate validates backup create request.
+func (r BackupCreateRequest) Validate() *apierror.APIError {
+	v := apierror.NewValidator()
+	if r.Passphrase == "" {
+		v.Required("passphrase")
+	}
+	return v.Err()
+}
+
+// BackupRestoreRequest request used to restore node backup.
+// swagger:model BackupRestoreRequest
+type BackupRestoreRequest struct {
+	// Passphrase the backup was encrypted with
+	Passphrase string `json:"passphrase"`
+
+	// Base64 encoded backup archive
+	Archive []byte `json:"archive"`
+}
+
+// Validate validates backup restore request.
+func (r BackupRestoreRequest) Validate() *apierror.APIError {
+	v := apierror.NewValidator()
+	if r.Passphrase == "" {
+		v.Required("passphrase")
+	}
+	if len(r.Archive) == 0 {
+		v.Required("archive")
+	}
+	return v.Err()
+}
+
+// BackupManifestDTO describes contents of node backup.
+// swagger:model BackupManifestDTO
+type BackupManifestDTO struct {
+	FormatVersion int                  `json:"format_version"`
+	NodeVersion   string               `json:"node_version"`
+	CreatedAt     time.Time            `json:"created_at"`
+	Files         []BackupFileEntryDTO `json:"files"`
+}
+
+// BackupFileEntryDTO describes a single file of node backup.
+// swagger:model BackupFileEntryDTO
+type BackupFileEntryDTO struct {
+	Path   string `json:"path"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// NewBackupManifestDTO maps backup manifest to DTO.
+func NewBackupManifestDTO(m backup.Manifest) BackupManifestDTO {
+	files := make([]BackupFileEntryDTO, 0, len(m.Files))
+	for _, f := range m.Files {
+		files = append(files, BackupFileEntryDTO{Path: f.Path, Size: f.Size, SHA256: f.SHA256})
+	}
+	return BackupManifestDTO{
+		FormatVersion: m.FormatVersion,
+		NodeVersion:   m.NodeVersion,
+		CreatedAt:     m.CreatedAt,
+		Files:         files,
+	}
+}
@@ -39,6 +39,9 @@ func NewConnectionInfoDTO(session connectionstate.Status) ConnectionInfoDTO {
 		Status:     string(session.State),
 		ConsumerID: session.ConsumerID.Address,
 		SessionID:  string(session.SessionID),
+
+		Failovers:         session.Failovers,
+		StandbyProviderID: session.StandbyProviderID,
 	}
 	if session.HermesID != emptyAddress {
 		response.HermesID = session.HermesID.Hex()
@@ -68,6 +71,14 @@ type ConnectionInfoDTO struct {
 
 	// example: 4cfb0324-daf6-4ad8-448b-e61fe0a1f918
 	SessionID string `json:"session_id,omitempty"`
+
+	// Number of times the connection was switched to a standby provider.
+	// example: 1
+	Failovers int `json:"failovers,omitempty"`
+
+	// Provider kept ready for a failover.
+	// example: 0x71ccbdee7f6afe85a5bc7106323518518cd23b94
+	StandbyProviderID string `json:"standby_provider_id,omitempty"`
 }
 
 // NewConnectionDTO maps to API connection.
@@ -181,6 +192,9 @@ type ConnectionCreateFilter struct {
 	IPType                  string   `json:"ip_type,omitempty"`
 	IncludeMonitoringFailed bool     `json:"include_monitoring_failed,omitempty"`
 	SortBy                  string   `json:"sort_by,omitempty"`
+	// proposal query, e.g. country in (DE, NL) and quality >= 2
+	// example: country in (DE, NL) and quality >= 2
+	Query string `json:"query,omitempty"`
 }
 
 // Validate validates fields in request.
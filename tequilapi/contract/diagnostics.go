@@ -0,0 +1,69 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package contract
+
+import (
+	"time"
+
+	"github.com/mysteriumnetwork/node/core/diagnostics"
+)
+
+// DiagnosticsCheckDTO is an outcome of a single diagnostics check.
+// swagger:model DiagnosticsCheckDTO
+type DiagnosticsCheckDTO struct {
+	// example: nat_type
+	Name string `json:"name"`
+	// example: warning
+	Status string `json:"status"`
+	// example: NAT type is Symmetric
+	Message string `json:"message"`
+	// example: Forward UDP ports to this host or enable UPnP on the router
+	Remediation string `json:"remediation,omitempty"`
+	DurationMs  int64  `json:"duration_ms"`
+}
+
+// DiagnosticsReportDTO is a machine readable node diagnostics report.
+// swagger:model DiagnosticsReportDTO
+type DiagnosticsReportDTO struct {
+	// Worst status of all checks.
+	// example: ok
+	Status     string                `json:"status"`
+	StartedAt  time.Time             `json:"started_at"`
+	DurationMs int64                 `json:"duration_ms"`
+	Checks     []DiagnosticsCheckDTO `json:"checks"`
+}
+
+// NewDiagnosticsReportDTO maps diagnostics report to DTO.
+func NewDiagnosticsReportDTO(report diagnostics.Report) DiagnosticsReportDTO {
+	dto := DiagnosticsReportDTO{
+		Status:     string(report.Status),
+		StartedAt:  report.StartedAt,
+		DurationMs: report.Duration.Milliseconds(),
+		Checks:     make([]DiagnosticsCheckDTO, 0, len(report.Checks)),
+	}
+	for _, check := range report.Checks {
+		dto.Checks = append(dto.Checks, DiagnosticsCheckDTO{
+			Name:        check.Name,
+			Status:      string(check.Status),
+			Message:     check.Message,
+			Remediation: check.Remediation,
+			DurationMs:  check.Duration.Milliseconds(),
+		})
+	}
+	return dto
+}
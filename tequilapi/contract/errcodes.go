@@ -49,6 +49,7 @@ const (
 	ErrCodePaymentListCurrencies = "err_payment_list_currencies"
 	ErrCodePaymentGetOptions     = "err_payment_get_order_options"
 	ErrCodePaymentListGateways   = "err_payment_list_gateways"
+	ErrCodePaymentAutoTopUp      = "err_payment_auto_topup"
 
 	// Referral
 
@@ -57,7 +58,8 @@ const (
 
 	// Config
 
-	ErrCodeConfigSave = "err_config_save"
+	ErrCodeConfigSave    = "err_config_save"
+	ErrCodeConfigInvalid = "err_config_invalid"
 
 	// Connection
 
@@ -91,6 +93,7 @@ const (
 	ErrCodeProposalsPrices         = "err_proposals_prices"
 	ErrCodeProposalsPresets        = "err_proposals_presets"
 	ErrCodeProposalsServiceType    = "err_proposals_service_type"
+	ErrCodeProposalsQueryInvalid   = "err_proposals_query_invalid"
 
 	// Service
 
@@ -107,6 +110,7 @@ const (
 	ErrCodeSessionListPaginate = "err_session_list_paginate"
 	ErrCodeSessionStats        = "err_session_stats"
 	ErrCodeSessionStatsDaily   = "err_session_stats_daily"
+	ErrCodeSessionPrice        = "err_session_price"
 
 	// Transactor
 
@@ -133,11 +137,13 @@ const (
 	ErrCodeHermesFee                       = "err_hermes_fee"
 	ErrCodeHermesSettle                    = "err_hermes_settle"
 	ErrCodeHermesSettleAsync               = "err_hermes_settle_async"
+	ErrCodeHermesSettleEstimate            = "err_hermes_settle_estimate"
 	ErrCodeUILocalVersions                 = "err_ui_local_versions"
 	ErrCodeUISwitchVersion                 = "err_ui_switch_version"
 	ErrCodeUIDownload                      = "err_ui_download"
 	ErrCodeUIBundledVersion                = "err_ui_bundled_version"
 	ErrCodeUIUsedVersion                   = "err_ui_used_version"
+	ErrCodeSSEQueryInvalid                 = "err_sse_query_invalid"
 	ErrorCodeProviderSessions              = "err_provider_sessions"
 	ErrorCodeProviderTransferredData       = "err_provider_transferred_data"
 	ErrorCodeProviderSessionsCount         = "err_provider_sessions_count"
@@ -149,4 +155,7 @@ const (
 	ErrorCodeProviderActivityStats         = "err_provider_activity_stats"
 	ErrorCodeLatestReleaseInformation      = "err_latest_release_information"
 	ErrorCodeProviderServiceEarnings       = "err_provider_service_earnings"
+	ErrCodeBackupCreate                    = "err_backup_create"
+	ErrCodeBackupRestore                   = "err_backup_restore"
+	ErrCodeProviderLists                   = "err_provider_lists"
 )
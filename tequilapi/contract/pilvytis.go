@@ -19,6 +19,12 @@ package contract
 
 import (
 	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/mysteriumnetwork/go-rest/apierror"
+	"github.com/mysteriumnetwork/payments/crypto"
+	"github.com/shopspring/decimal"
 
 	"github.com/mysteriumnetwork/node/identity"
 
@@ -173,3 +179,108 @@ func (o *PaymentOrderRequest) GatewayOrderRequest(identity identity.Identity, ga
 		CallerData:  o.CallerData,
 	}
 }
+
+// AutoTopUpRuleRequest holds automatic balance top-up rule
+// swagger:model AutoTopUpRuleRequest
+type AutoTopUpRuleRequest struct {
+	// Payment gateway used to create orders
+	// example: coingate
+	Gateway string `json:"gateway"`
+
+	// MYST balance below which a top-up is made
+	// example: 2.5
+	Threshold string `json:"threshold"`
+
+	// MYST amount of a single top-up
+	// example: 10
+	Amount string `json:"amount"`
+
+	// MYST amount topped up per calendar month at most, 0 means no limit
+	// example: 50
+	MonthlyMax string `json:"monthly_max,omitempty"`
+
+	// example: EUR
+	PayCurrency string `json:"pay_currency"`
+
+	// example: US
+	Country string `json:"country"`
+
+	// example: MO
+	State string `json:"state"`
+
+	// example: mysteriumvpn, mystnodes
+	ProjectID string `json:"project_id"`
+
+	// example: {}
+	CallerData json.RawMessage `json:"gateway_caller_data,omitempty"`
+}
+
+// Validate validates automatic top-up rule request.
+func (r AutoTopUpRuleRequest) Validate() *apierror.APIError {
+	v := apierror.NewValidator()
+	if r.Gateway == "" {
+		v.Required("gateway")
+	}
+	for field, value := range map[string]string{"threshold": r.Threshold, "amount": r.Amount} {
+		if amount, err := decimal.NewFromString(value); err != nil || !amount.IsPositive() {
+			v.Invalid(field, fmt.Sprintf("'%s' should be a positive MYST amount", field))
+		}
+	}
+	if r.MonthlyMax != "" {
+		if amount, err := decimal.NewFromString(r.MonthlyMax); err != nil || amount.IsNegative() {
+			v.Invalid("monthly_max", "'monthly_max' should be a non-negative MYST amount")
+		}
+	}
+	return v.Err()
+}
+
+// AutoTopUpRule converts request to the automatic top-up rule of the given identity.
+func (r AutoTopUpRuleRequest) AutoTopUpRule(id identity.Identity) pilvytis.AutoTopUpRule {
+	monthlyMax := decimal.Zero
+	if r.MonthlyMax != "" {
+		monthlyMax = decimal.RequireFromString(r.MonthlyMax)
+	}
+	return pilvytis.AutoTopUpRule{
+		Identity:    id.Address,
+		Gateway:     r.Gateway,
+		Threshold:   crypto.DecimalToBigMyst(decimal.RequireFromString(r.Threshold)),
+		Amount:      crypto.DecimalToBigMyst(decimal.RequireFromString(r.Amount)),
+		MonthlyMax:  crypto.DecimalToBigMyst(monthlyMax),
+		PayCurrency: r.PayCurrency,
+		Country:     r.Country,
+		State:       r.State,
+		ProjectID:   r.ProjectID,
+		CallerData:  r.CallerData,
+	}
+}
+
+// AutoTopUpRuleResponse holds automatic balance top-up rule and its usage
+// swagger:model AutoTopUpRuleResponse
+type AutoTopUpRuleResponse struct {
+	Gateway           string          `json:"gateway"`
+	Threshold         Tokens          `json:"threshold"`
+	Amount            Tokens          `json:"amount"`
+	MonthlyMax        Tokens          `json:"monthly_max"`
+	ToppedUpThisMonth Tokens          `json:"topped_up_this_month"`
+	PayCurrency       string          `json:"pay_currency"`
+	Country           string          `json:"country"`
+	State             string          `json:"state"`
+	ProjectID         string          `json:"project_id"`
+	CallerData        json.RawMessage `json:"gateway_caller_data,omitempty"`
+}
+
+// NewAutoTopUpRuleResponse creates automatic top-up rule response.
+func NewAutoTopUpRuleResponse(rule pilvytis.AutoTopUpRule, toppedUp *big.Int) AutoTopUpRuleResponse {
+	return AutoTopUpRuleResponse{
+		Gateway:           rule.Gateway,
+		Threshold:         NewTokens(rule.Threshold),
+		Amount:            NewTokens(rule.Amount),
+		MonthlyMax:        NewTokens(rule.MonthlyMax),
+		ToppedUpThisMonth: NewTokens(toppedUp),
+		PayCurrency:       rule.PayCurrency,
+		Country:           rule.Country,
+		State:             rule.State,
+		ProjectID:         rule.ProjectID,
+		CallerData:        rule.CallerData,
+	}
+}
@@ -19,6 +19,7 @@ package contract
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/mysteriumnetwork/node/core/discovery/proposal"
 	"github.com/mysteriumnetwork/node/market"
@@ -52,6 +53,9 @@ func NewProposalDTO(p proposal.PricedServiceProposal) ProposalDTO {
 			PerGiB:        p.Price.PricePerGiB.Uint64(),
 			PerGiBTokens:  NewTokens(p.Price.PricePerGiB),
 		},
+		Stale:      p.SnapshotAt != nil,
+		SnapshotAt: p.SnapshotAt,
+		Favourite:  p.Favourite,
 	}
 }
 
@@ -105,6 +109,18 @@ type ProposalDTO struct {
 
 	// Quality of the service.
 	Quality Quality `json:"quality"`
+
+	// Set if discovery is unreachable and the proposal comes from the local snapshot.
+	// example: true
+	Stale bool `json:"stale,omitempty"`
+
+	// Time the local snapshot was taken at, only set for stale proposals.
+	// example: 2026-10-18T10:00:00Z
+	SnapshotAt *time.Time `json:"snapshot_at,omitempty"`
+
+	// Set if the consumer given in the request marked the provider as a favourite.
+	// example: true
+	Favourite bool `json:"favourite,omitempty"`
 }
 
 // Price represents the service price.
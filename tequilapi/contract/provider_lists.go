@@ -0,0 +1,42 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package contract
+
+import (
+	"github.com/mysteriumnetwork/node/core/discovery/proposal"
+)
+
+// ProviderListsDTO holds providers a consumer marked as favourite or blocked.
+// swagger:model ProviderListsDTO
+type ProviderListsDTO struct {
+	// Providers which are tried first when connecting
+	// example: ["0x0000000000000000000000000000000000000001"]
+	Favourites []string `json:"favourites"`
+
+	// Providers which are never connected to unless asked for explicitly
+	// example: ["0x0000000000000000000000000000000000000002"]
+	Blocked []string `json:"blocked"`
+}
+
+// NewProviderListsDTO maps provider lists to DTO.
+func NewProviderListsDTO(l proposal.ProviderLists) ProviderListsDTO {
+	return ProviderListsDTO{
+		Favourites: l.Favourites,
+		Blocked:    l.Blocked,
+	}
+}
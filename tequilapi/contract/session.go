@@ -29,6 +29,33 @@ import (
 	"github.com/mysteriumnetwork/node/tequilapi/utils"
 )
 
+// SessionPriceRequest request used to continue an ongoing provider session with another price.
+// swagger:model SessionPriceRequest
+type SessionPriceRequest struct {
+	// price per hour of the session
+	// example: 500000000000000000
+	PricePerHour *big.Int `json:"price_per_hour"`
+	// price per GiB of the session
+	// example: 1000000000000000000
+	PricePerGiB *big.Int `json:"price_per_gib"`
+}
+
+// Validate validates session price request.
+func (r SessionPriceRequest) Validate() *apierror.APIError {
+	v := apierror.NewValidator()
+	if r.PricePerHour == nil {
+		v.Required("price_per_hour")
+	} else if r.PricePerHour.Sign() < 0 {
+		v.Invalid("price_per_hour", "Price can not be negative")
+	}
+	if r.PricePerGiB == nil {
+		v.Required("price_per_gib")
+	} else if r.PricePerGiB.Sign() < 0 {
+		v.Invalid("price_per_gib", "Price can not be negative")
+	}
+	return v.Err()
+}
+
 // NewSessionQuery creates session query with default values.
 func NewSessionQuery() SessionQuery {
 	return SessionQuery{}
@@ -257,6 +257,72 @@ type SettlementDTO struct {
 	Error string `json:"error"`
 }
 
+// NewSettlementEstimateResponse maps settlement estimates to API response.
+func NewSettlementEstimateResponse(chainID int64, providerID string, estimates []pingpong.SettlementEstimate) SettlementEstimateResponse {
+	items := make([]SettlementEstimateDTO, len(estimates))
+	for i, e := range estimates {
+		items[i] = SettlementEstimateDTO{
+			HermesID:      e.HermesID.Hex(),
+			Unsettled:     NewTokens(e.Unsettled),
+			TransactorFee: NewTokens(e.TransactorFee),
+			HermesFee:     NewTokens(e.HermesFee),
+			TotalFee:      NewTokens(e.TotalFee),
+			Payout:        NewTokens(e.Payout),
+			FeeRatio:      e.FeeRatio,
+			WouldSettle:   e.Settle,
+			Reason:        e.Reason,
+		}
+		if !e.UnsettledSince.IsZero() {
+			items[i].UnsettledSince = e.UnsettledSince.Format(time.RFC3339)
+		}
+	}
+
+	return SettlementEstimateResponse{
+		ChainID:    chainID,
+		ProviderID: providerID,
+		Items:      items,
+	}
+}
+
+// SettlementEstimateResponse describes what settling provider channels now would cost.
+// swagger:model SettlementEstimateResponse
+type SettlementEstimateResponse struct {
+	// example: 137
+	ChainID int64 `json:"chain_id"`
+
+	// example: 0x0000000000000000000000000000000000000001
+	ProviderID string `json:"provider_id"`
+
+	Items []SettlementEstimateDTO `json:"items"`
+}
+
+// SettlementEstimateDTO describes what settling a single hermes channel now would cost.
+// swagger:model SettlementEstimateDTO
+type SettlementEstimateDTO struct {
+	// example: 0x0000000000000000000000000000000000000001
+	HermesID string `json:"hermes_id"`
+
+	Unsettled     Tokens `json:"unsettled"`
+	TransactorFee Tokens `json:"transactor_fee"`
+	HermesFee     Tokens `json:"hermes_fee"`
+	TotalFee      Tokens `json:"total_fee"`
+	Payout        Tokens `json:"payout"`
+
+	// Share of unsettled earnings paid in fees.
+	// example: 0.015
+	FeeRatio float64 `json:"fee_ratio"`
+
+	// example: 2019-06-06T11:04:43.910035Z
+	UnsettledSince string `json:"unsettled_since,omitempty"`
+
+	// Whether the settlement scheduler would settle this channel now.
+	// example: true
+	WouldSettle bool `json:"would_settle"`
+
+	// example: fee_ratio_below_target
+	Reason string `json:"reason"`
+}
+
 // SettleRequest represents the request to settle hermes promises
 // swagger:model SettleRequestDTO
 type SettleRequest struct {
@@ -0,0 +1,149 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package endpoints
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mysteriumnetwork/go-rest/apierror"
+
+	"github.com/mysteriumnetwork/node/core/backup"
+	"github.com/mysteriumnetwork/node/tequilapi/contract"
+	"github.com/mysteriumnetwork/node/tequilapi/utils"
+)
+
+type nodeBackup interface {
+	Create(passphrase string) ([]byte, backup.Manifest, error)
+	Stage(passphrase string, sealed []byte) (backup.Manifest, error)
+}
+
+type backupEndpoint struct {
+	backup nodeBackup
+}
+
+// CreateBackup creates encrypted node backup.
+// swagger:operation POST /backup Backup createBackup
+//
+//	---
+//	summary: Create node backup
+//	description: Creates a passphrase encrypted archive with identity keys, database, user config and UI credentials.
+//	parameters:
+//	  - in: body
+//	    name: body
+//	    schema:
+//	      $ref: "#/definitions/BackupCreateRequest"
+//	produces:
+//	  - application/octet-stream
+//	responses:
+//	  200:
+//	    description: Backup archive (binary)
+//	  400:
+//	    description: Failed to parse or request validation failed
+//	    schema:
+//	      "$ref": "#/definitions/APIError"
+//	  500:
+//	    description: Internal server error
+//	    schema:
+//	      "$ref": "#/definitions/APIError"
+func (e *backupEndpoint) CreateBackup(c *gin.Context) {
+	var req contract.BackupCreateRequest
+	if err := json.NewDecoder(c.Request.Body).Decode(&req); err != nil {
+		c.Error(apierror.ParseFailed())
+		return
+	}
+	if err := req.Validate(); err != nil {
+		c.Error(err)
+		return
+	}
+
+	archive, _, err := e.backup.Create(req.Passphrase)
+	if err != nil {
+		c.Error(apierror.Internal("Failed to create backup: "+err.Error(), contract.ErrCodeBackupCreate))
+		return
+	}
+
+	c.Header("Content-Disposition", `attachment; filename="myst-backup.bin"`)
+	c.Data(http.StatusOK, "application/octet-stream", archive)
+}
+
+// RestoreBackup stages node backup to be restored on the next node start.
+// swagger:operation POST /backup/restore Backup restoreBackup
+//
+//	---
+//	summary: Restore node backup
+//	description: Validates the backup and restores it on the next node start. Backups older than promises held by the node are refused.
+//	parameters:
+//	  - in: body
+//	    name: body
+//	    schema:
+//	      $ref: "#/definitions/BackupRestoreRequest"
+//	responses:
+//	  202:
+//	    description: Backup will be restored on the next node start
+//	    schema:
+//	      "$ref": "#/definitions/BackupManifestDTO"
+//	  400:
+//	    description: Failed to parse or request validation failed
+//	    schema:
+//	      "$ref": "#/definitions/APIError"
+//	  409:
+//	    description: Node has newer promises than the backup
+//	    schema:
+//	      "$ref": "#/definitions/APIError"
+//	  422:
+//	    description: Backup can not be restored
+//	    schema:
+//	      "$ref": "#/definitions/APIError"
+func (e *backupEndpoint) RestoreBackup(c *gin.Context) {
+	var req contract.BackupRestoreRequest
+	if err := json.NewDecoder(c.Request.Body).Decode(&req); err != nil {
+		c.Error(apierror.ParseFailed())
+		return
+	}
+	if err := req.Validate(); err != nil {
+		c.Error(err)
+		return
+	}
+
+	manifest, err := e.backup.Stage(req.Passphrase, req.Archive)
+	if errors.Is(err, backup.ErrStalePromises) {
+		c.Error(apierror.Conflict("Failed to restore backup: "+err.Error(), contract.ErrCodeBackupRestore, "archive"))
+		return
+	}
+	if err != nil {
+		c.Error(apierror.Unprocessable("Failed to restore backup: "+err.Error(), contract.ErrCodeBackupRestore))
+		return
+	}
+
+	c.Status(http.StatusAccepted)
+	utils.WriteAsJSON(contract.NewBackupManifestDTO(manifest), c.Writer)
+}
+
+// AddRoutesForBackup attaches node backup endpoints to router.
+func AddRoutesForBackup(b nodeBackup) func(*gin.Engine) error {
+	e := &backupEndpoint{backup: b}
+	return func(g *gin.Engine) error {
+		group := g.Group("/backup")
+		group.POST("", e.CreateBackup)
+		group.POST("/restore", e.RestoreBackup)
+		return nil
+	}
+}
@@ -0,0 +1,81 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package endpoints
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mysteriumnetwork/go-rest/apierror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mysteriumnetwork/node/core/backup"
+	"github.com/mysteriumnetwork/node/tequilapi/contract"
+)
+
+func TestBackup(t *testing.T) {
+	dir := t.TempDir()
+	paths := backup.Paths{
+		Data:     dir,
+		Keystore: filepath.Join(dir, "keystore"),
+		Storage:  filepath.Join(dir, "db"),
+	}
+	require.NoError(t, os.MkdirAll(paths.Keystore, 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(paths.Keystore, "UTC--key"), []byte("key"), 0600))
+
+	g := gin.New()
+	g.Use(apierror.ErrorHandler)
+	require.NoError(t, AddRoutesForBackup(backup.NewBackup(paths, nil))(g))
+
+	resp := httptest.NewRecorder()
+	g.ServeHTTP(resp, httptest.NewRequest(http.MethodPost, "/backup", strings.NewReader(`{}`)))
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+
+	resp = httptest.NewRecorder()
+	g.ServeHTTP(resp, httptest.NewRequest(http.MethodPost, "/backup", strings.NewReader(`{"passphrase": "secret"}`)))
+	require.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "application/octet-stream", resp.Header().Get("Content-Type"))
+	archive := resp.Body.Bytes()
+
+	restore := func(passphrase string) *httptest.ResponseRecorder {
+		body, err := json.Marshal(contract.BackupRestoreRequest{Passphrase: passphrase, Archive: archive})
+		require.NoError(t, err)
+		resp := httptest.NewRecorder()
+		g.ServeHTTP(resp, httptest.NewRequest(http.MethodPost, "/backup/restore", bytes.NewReader(body)))
+		return resp
+	}
+
+	resp = restore("wrong")
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+
+	resp = restore("secret")
+	require.Equal(t, http.StatusAccepted, resp.Code)
+	var manifest contract.BackupManifestDTO
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &manifest))
+	assert.Equal(t, backup.FormatVersion, manifest.FormatVersion)
+	require.Len(t, manifest.Files, 1)
+	assert.Equal(t, "keystore/UTC--key", manifest.Files[0].Path)
+}
@@ -19,6 +19,7 @@ package endpoints
 
 import (
 	"encoding/json"
+	"errors"
 	"reflect"
 
 	"github.com/gin-gonic/gin"
@@ -37,6 +38,8 @@ type configProvider interface {
 	SetUser(key string, value interface{})
 	RemoveUser(key string)
 	SaveUserConfig() error
+	DiffUser(changes map[string]interface{}) ([]config.Change, error)
+	Schema() []config.FlagSchema
 }
 
 // swagger:model configPayload
@@ -45,6 +48,17 @@ type configPayload struct {
 	Data map[string]interface{} `json:"data"`
 }
 
+// swagger:model configDiffPayload
+type configDiffPayload struct {
+	// Changes of effective configuration values which would be made by the request.
+	Changes []config.Change `json:"changes"`
+}
+
+// swagger:model configSchemaPayload
+type configSchemaPayload struct {
+	Flags []config.FlagSchema `json:"flags"`
+}
+
 type configAPI struct {
 	config configProvider
 }
@@ -125,21 +139,40 @@ func (api *configAPI) GetUserConfig(c *gin.Context) {
 	utils.WriteAsJSON(res, c.Writer)
 }
 
+// GetConfigSchema returns schema of configuration values
+// swagger:operation GET /config/schema Configuration getConfigSchema
+//
+//	---
+//	summary: Returns configuration schema
+//	description: Returns type, allowed range and whether restart is required for every configuration flag
+//	responses:
+//	  200:
+//	    description: Configuration schema
+//	    schema:
+//	      "$ref": "#/definitions/configSchemaPayload"
+func (api *configAPI) GetConfigSchema(c *gin.Context) {
+	utils.WriteAsJSON(configSchemaPayload{Flags: api.config.Schema()}, c.Writer)
+}
+
 // SetUserConfig sets and returns current configuration
 // swagger:operation POST /config/user Configuration serUserConfig
 //
 //	---
 //	summary: Sets and returns user configuration
-//	description: For keys present in the payload, it will set or remove the user config values (if the key is null). Changes are persisted to the config file.
+//	description: For keys present in the payload, it will set or remove the user config values (if the key is null). Changes are validated against the configuration schema, keys not backed by a flag are rejected, and persisted to the config file. Values which do not require restart are applied immediately. PUT is an alias of this endpoint.
 //	parameters:
 //	  - in: body
 //	    name: body
 //	    description: configuration keys/values
 //	    schema:
 //	      $ref: "#/definitions/configPayload"
+//	  - in: query
+//	    name: dry_run
+//	    description: Only validate the payload and return changes of effective configuration without applying them
+//	    type: boolean
 //	responses:
 //	  200:
-//	    description: User configuration
+//	    description: User configuration or, if dry_run is set, changes of effective configuration
 //	    schema:
 //	      "$ref": "#/definitions/configPayload"
 //	  400:
@@ -159,7 +192,28 @@ func (api *configAPI) SetUserConfig(c *gin.Context) {
 	}
 	for k, v := range req.Data {
 		if isNil(v) {
-			log.Debug().Msgf("Clearing user config value: %q", v)
+			req.Data[k] = nil
+		}
+	}
+
+	changes, err := api.config.DiffUser(req.Data)
+	if err != nil {
+		var verr *config.ValidationError
+		if errors.As(err, &verr) {
+			c.Error(apierror.BadRequestField(verr.Error(), contract.ErrCodeConfigInvalid, verr.Key))
+			return
+		}
+		c.Error(apierror.BadRequest(err.Error(), contract.ErrCodeConfigInvalid))
+		return
+	}
+	if c.Query("dry_run") == "true" {
+		utils.WriteAsJSON(configDiffPayload{Changes: changes}, c.Writer)
+		return
+	}
+
+	for k, v := range req.Data {
+		if v == nil {
+			log.Debug().Msgf("Clearing user config value: %q", k)
 			api.config.RemoveUser(k)
 		} else {
 			log.Debug().Msgf("Setting user config value: %q = %q", k, v)
@@ -196,6 +250,8 @@ func AddRoutesForConfig(
 		g.GET("/default", api.GetDefaultConfig)
 		g.GET("/user", api.GetUserConfig)
 		g.POST("/user", api.SetUserConfig)
+		g.PUT("/user", api.SetUserConfig)
+		g.GET("/schema", api.GetConfigSchema)
 		g.GET("/ui/features", api.GetUiFeatures)
 	}
 	return nil
@@ -31,6 +31,7 @@ import (
 	"github.com/mysteriumnetwork/node/config"
 	"github.com/mysteriumnetwork/node/core/connection"
 	"github.com/mysteriumnetwork/node/core/discovery/proposal"
+	"github.com/mysteriumnetwork/node/core/discovery/query"
 	"github.com/mysteriumnetwork/node/core/quality"
 	"github.com/mysteriumnetwork/node/eventbus"
 	"github.com/mysteriumnetwork/node/identity"
@@ -162,6 +163,13 @@ func (ce *ConnectionEndpoint) Create(c *gin.Context) {
 		return
 	}
 
+	q, err := query.Parse(cr.Filter.Query)
+	if err != nil {
+		ce.publisher.Publish(quality.AppTopicConnectionEvents, cr.Event(quality.StageValidateRequest, err.Error()))
+		c.Error(apierror.BadRequestField("Invalid proposal query: "+err.Error(), contract.ErrCodeProposalsQueryInvalid, "filter.query"))
+		return
+	}
+
 	consumerID := identity.FromAddress(cr.ConsumerID)
 	status, err := ce.identityRegistry.GetRegistrationStatus(config.GetInt64(config.FlagChainID), consumerID)
 	if err != nil {
@@ -201,6 +209,8 @@ func (ce *ConnectionEndpoint) Create(c *gin.Context) {
 		IPType:                  cr.Filter.IPType,
 		IncludeMonitoringFailed: cr.Filter.IncludeMonitoringFailed,
 		AccessPolicy:            "all",
+		Query:                   q,
+		ConsumerID:              consumerID.Address,
 	}
 	proposalLookup := connection.FilteredProposals(f, cr.Filter.SortBy, ce.proposalRepository)
 
@@ -0,0 +1,61 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package endpoints
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/mysteriumnetwork/node/core/diagnostics"
+	"github.com/mysteriumnetwork/node/tequilapi/contract"
+	"github.com/mysteriumnetwork/node/tequilapi/utils"
+)
+
+type diagnosticsRunner interface {
+	Run(ctx context.Context) diagnostics.Report
+}
+
+type diagnosticsEndpoint struct {
+	diagnostics diagnosticsRunner
+}
+
+// Diagnostics runs node diagnostics checks.
+// swagger:operation GET /diagnostics Diagnostics diagnostics
+//
+//	---
+//	summary: Runs node diagnostics
+//	description: Runs connectivity and health checks of the node and returns a report with remediation hints
+//	responses:
+//	  200:
+//	    description: Diagnostics report
+//	    schema:
+//	      "$ref": "#/definitions/DiagnosticsReportDTO"
+func (de *diagnosticsEndpoint) Diagnostics(c *gin.Context) {
+	report := de.diagnostics.Run(c.Request.Context())
+	utils.WriteAsJSON(contract.NewDiagnosticsReportDTO(report), c.Writer)
+}
+
+// AddRoutesForDiagnostics adds diagnostics routes to given router
+func AddRoutesForDiagnostics(diagnostics diagnosticsRunner) func(*gin.Engine) error {
+	de := &diagnosticsEndpoint{diagnostics: diagnostics}
+	return func(e *gin.Engine) error {
+		e.GET("/diagnostics", de.Diagnostics)
+		return nil
+	}
+}
@@ -19,6 +19,9 @@ package endpoints
 
 import (
 	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
 	"strings"
 
 	"github.com/mysteriumnetwork/go-rest/apierror"
@@ -49,18 +52,27 @@ type paymentLocationFallback interface {
 	GetOrigin() locationstate.Location
 }
 
+type autoTopUp interface {
+	Rule(id identity.Identity) (pilvytis.AutoTopUpRule, error)
+	SetRule(rule pilvytis.AutoTopUpRule) error
+	RemoveRule(id identity.Identity) error
+	ToppedUpThisMonth(id identity.Identity) (*big.Int, error)
+}
+
 type pilvytisEndpoint struct {
 	api api
 	pt  paymentsIssuer
 	lf  paymentLocationFallback
+	atu autoTopUp
 }
 
 // NewPilvytisEndpoint returns pilvytis endpoints.
-func NewPilvytisEndpoint(pil api, pt paymentsIssuer, lf paymentLocationFallback) *pilvytisEndpoint {
+func NewPilvytisEndpoint(pil api, pt paymentsIssuer, lf paymentLocationFallback, atu autoTopUp) *pilvytisEndpoint {
 	return &pilvytisEndpoint{
 		api: pil,
 		pt:  pt,
 		lf:  lf,
+		atu: atu,
 	}
 }
 
@@ -294,9 +306,153 @@ func (e *pilvytisEndpoint) GetRegistrationPaymentStatus(c *gin.Context) {
 	utils.WriteAsJSON(contract.NewRegistrationPaymentResponse(resp), c.Writer)
 }
 
+// GetAutoTopUp returns automatic balance top-up rule of an identity.
+//
+// swagger:operation GET /v2/identities/{id}/auto-topup Order getAutoTopUp
+//
+//	---
+//	summary: Get automatic top-up rule
+//	description: Returns automatic balance top-up rule of an identity and MYST amount topped up this month.
+//	parameters:
+//	- name: id
+//	  in: path
+//	  description: Identity for which to get the rule
+//	  type: string
+//	  required: true
+//	responses:
+//	  200:
+//	    description: Automatic top-up rule
+//	    schema:
+//	      "$ref": "#/definitions/AutoTopUpRuleResponse"
+//	  404:
+//	    description: Automatic top-up is not configured
+//	    schema:
+//	      "$ref": "#/definitions/APIError"
+//	  500:
+//	    description: Internal server error
+//	    schema:
+//	      "$ref": "#/definitions/APIError"
+func (e *pilvytisEndpoint) GetAutoTopUp(c *gin.Context) {
+	id := identity.FromAddress(c.Param("id"))
+	rule, err := e.atu.Rule(id)
+	if errors.Is(err, pilvytis.ErrAutoTopUpRuleNotFound) {
+		c.Error(apierror.NotFound("Automatic top-up is not configured"))
+		return
+	}
+	if err != nil {
+		c.Error(apierror.Internal("Failed to get automatic top-up rule: "+err.Error(), contract.ErrCodePaymentAutoTopUp))
+		return
+	}
+
+	e.writeAutoTopUp(c, rule)
+}
+
+// SetAutoTopUp sets automatic balance top-up rule of an identity.
+//
+// swagger:operation PUT /v2/identities/{id}/auto-topup Order setAutoTopUp
+//
+//	---
+//	summary: Set automatic top-up rule
+//	description: Creates a payment order with the given gateway whenever identity balance drops below the threshold.
+//	parameters:
+//	- name: id
+//	  in: path
+//	  description: Identity for which to set the rule
+//	  type: string
+//	  required: true
+//	- in: body
+//	  name: body
+//	  description: Automatic top-up rule
+//	  schema:
+//	    $ref: "#/definitions/AutoTopUpRuleRequest"
+//	responses:
+//	  200:
+//	    description: Automatic top-up rule
+//	    schema:
+//	      "$ref": "#/definitions/AutoTopUpRuleResponse"
+//	  400:
+//	    description: Failed to parse or request validation failed
+//	    schema:
+//	      "$ref": "#/definitions/APIError"
+//	  500:
+//	    description: Internal server error
+//	    schema:
+//	      "$ref": "#/definitions/APIError"
+func (e *pilvytisEndpoint) SetAutoTopUp(c *gin.Context) {
+	var req contract.AutoTopUpRuleRequest
+	if err := json.NewDecoder(c.Request.Body).Decode(&req); err != nil {
+		c.Error(apierror.ParseFailed())
+		return
+	}
+	if err := req.Validate(); err != nil {
+		c.Error(err)
+		return
+	}
+
+	rule := req.AutoTopUpRule(identity.FromAddress(c.Param("id")))
+	if err := rule.Validate(); err != nil {
+		c.Error(apierror.BadRequest(err.Error(), contract.ErrCodePaymentAutoTopUp))
+		return
+	}
+	if err := e.atu.SetRule(rule); err != nil {
+		c.Error(apierror.Internal("Failed to set automatic top-up rule: "+err.Error(), contract.ErrCodePaymentAutoTopUp))
+		return
+	}
+
+	e.writeAutoTopUp(c, rule)
+}
+
+// RemoveAutoTopUp disables automatic balance top-up of an identity.
+//
+// swagger:operation DELETE /v2/identities/{id}/auto-topup Order removeAutoTopUp
+//
+//	---
+//	summary: Remove automatic top-up rule
+//	description: Disables automatic balance top-up of an identity.
+//	parameters:
+//	- name: id
+//	  in: path
+//	  description: Identity for which to remove the rule
+//	  type: string
+//	  required: true
+//	responses:
+//	  202:
+//	    description: Automatic top-up disabled
+//	  404:
+//	    description: Automatic top-up is not configured
+//	    schema:
+//	      "$ref": "#/definitions/APIError"
+//	  500:
+//	    description: Internal server error
+//	    schema:
+//	      "$ref": "#/definitions/APIError"
+func (e *pilvytisEndpoint) RemoveAutoTopUp(c *gin.Context) {
+	err := e.atu.RemoveRule(identity.FromAddress(c.Param("id")))
+	if errors.Is(err, pilvytis.ErrAutoTopUpRuleNotFound) {
+		c.Error(apierror.NotFound("Automatic top-up is not configured"))
+		return
+	}
+	if err != nil {
+		c.Error(apierror.Internal("Failed to remove automatic top-up rule: "+err.Error(), contract.ErrCodePaymentAutoTopUp))
+		return
+	}
+
+	c.Status(http.StatusAccepted)
+}
+
+func (e *pilvytisEndpoint) writeAutoTopUp(c *gin.Context, rule pilvytis.AutoTopUpRule) {
+	toppedUp, err := e.atu.ToppedUpThisMonth(identity.FromAddress(rule.Identity))
+	if err != nil {
+		c.Error(apierror.Internal("Failed to get automatic top-ups: "+err.Error(), contract.ErrCodePaymentAutoTopUp))
+		return
+	}
+
+	utils.WriteAsJSON(contract.NewAutoTopUpRuleResponse(rule, toppedUp), c.Writer)
+}
+
 // AddRoutesForPilvytis adds the pilvytis routers to the given router.
-func AddRoutesForPilvytis(pilvytis api, pt paymentsIssuer, lf paymentLocationFallback) func(*gin.Engine) error {
-	pil := NewPilvytisEndpoint(pilvytis, pt, lf)
+func AddRoutesForPilvytis(pilvytis api, pt paymentsIssuer, lf paymentLocationFallback, atu autoTopUp) func(*gin.Engine) error {
+	pil := NewPilvytisEndpoint(pilvytis, pt, lf, atu)
 	return func(e *gin.Engine) error {
 		idGroupV2 := e.Group("/v2/identities")
 		{
@@ -305,6 +461,9 @@ func AddRoutesForPilvytis(pilvytis api, pt paymentsIssuer, lf paymentLocationFal
 			idGroupV2.GET("/:id/payment-order/:order_id/invoice", pil.GetPaymentGatewayOrderInvoice)
 			idGroupV2.GET("/:id/payment-order", pil.GetPaymentGatewayOrders)
 			idGroupV2.GET("/:id/registration-payment", pil.GetRegistrationPaymentStatus)
+			idGroupV2.GET("/:id/auto-topup", pil.GetAutoTopUp)
+			idGroupV2.PUT("/:id/auto-topup", pil.SetAutoTopUp)
+			idGroupV2.DELETE("/:id/auto-topup", pil.RemoveAutoTopUp)
 		}
 		e.GET("/v2/payment-order-gateways", pil.GetPaymentGateways)
 		return nil
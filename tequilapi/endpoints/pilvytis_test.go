@@ -18,17 +18,23 @@
 package endpoints
 
 import (
+	"encoding/json"
 	"fmt"
+	"math/big"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/gin-gonic/gin"
+	"github.com/mysteriumnetwork/go-rest/apierror"
 	"github.com/mysteriumnetwork/payments/exchange"
+	"github.com/stretchr/testify/require"
 
 	"github.com/mysteriumnetwork/node/core/location/locationstate"
 	"github.com/mysteriumnetwork/node/identity"
 	"github.com/mysteriumnetwork/node/pilvytis"
+	"github.com/mysteriumnetwork/node/tequilapi/contract"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 )
@@ -113,7 +119,7 @@ func TestGetRegistrationPaymentStatus(t *testing.T) {
 	mock := &mockPilvytis{
 		identity: identity,
 	}
-	handler := NewPilvytisEndpoint(mock, &mockPilvytisIssuer{}, &mockPilvytisLocation{}).GetRegistrationPaymentStatus
+	handler := NewPilvytisEndpoint(mock, &mockPilvytisIssuer{}, &mockPilvytisLocation{}, nil).GetRegistrationPaymentStatus
 
 	resp := httptest.NewRecorder()
 	req, err := http.NewRequest(
@@ -135,3 +141,74 @@ func TestGetRegistrationPaymentStatus(t *testing.T) {
 		resp.Body.String(),
 	)
 }
+
+type mockAutoTopUp struct {
+	rules map[string]pilvytis.AutoTopUpRule
+}
+
+func (mock *mockAutoTopUp) Rule(id identity.Identity) (pilvytis.AutoTopUpRule, error) {
+	rule, ok := mock.rules[id.Address]
+	if !ok {
+		return rule, pilvytis.ErrAutoTopUpRuleNotFound
+	}
+	return rule, nil
+}
+
+func (mock *mockAutoTopUp) SetRule(rule pilvytis.AutoTopUpRule) error {
+	mock.rules[rule.Identity] = rule
+	return nil
+}
+
+func (mock *mockAutoTopUp) RemoveRule(id identity.Identity) error {
+	delete(mock.rules, id.Address)
+	return nil
+}
+
+func (mock *mockAutoTopUp) ToppedUpThisMonth(id identity.Identity) (*big.Int, error) {
+	return big.NewInt(0), nil
+}
+
+func TestAutoTopUp(t *testing.T) {
+	id := "0x000000000000000000000000000000000000000b"
+	g := gin.New()
+	g.Use(apierror.ErrorHandler)
+	require.NoError(t, AddRoutesForPilvytis(&mockPilvytis{}, &mockPilvytisIssuer{}, &mockPilvytisLocation{}, &mockAutoTopUp{rules: map[string]pilvytis.AutoTopUpRule{}})(g))
+
+	resp := httptest.NewRecorder()
+	g.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, fmt.Sprintf("/v2/identities/%s/auto-topup", id), nil))
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+
+	resp = httptest.NewRecorder()
+	g.ServeHTTP(resp, httptest.NewRequest(http.MethodPut, fmt.Sprintf("/v2/identities/%s/auto-topup", id), strings.NewReader(
+		`{"gateway": "coingate", "threshold": "0", "amount": "10"}`,
+	)))
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+
+	resp = httptest.NewRecorder()
+	g.ServeHTTP(resp, httptest.NewRequest(http.MethodPut, fmt.Sprintf("/v2/identities/%s/auto-topup", id), strings.NewReader(
+		`{"gateway": "coingate", "threshold": "2.5", "amount": "10", "monthly_max": "5"}`,
+	)))
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+
+	resp = httptest.NewRecorder()
+	g.ServeHTTP(resp, httptest.NewRequest(http.MethodPut, fmt.Sprintf("/v2/identities/%s/auto-topup", id), strings.NewReader(
+		`{"gateway": "coingate", "threshold": "2.5", "amount": "10", "monthly_max": "50", "pay_currency": "EUR"}`,
+	)))
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	resp = httptest.NewRecorder()
+	g.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, fmt.Sprintf("/v2/identities/%s/auto-topup", id), nil))
+	assert.Equal(t, http.StatusOK, resp.Code)
+	var rule contract.AutoTopUpRuleResponse
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &rule))
+	assert.Equal(t, "coingate", rule.Gateway)
+	assert.Equal(t, "2.5", rule.Threshold.Ether)
+	assert.Equal(t, "10", rule.Amount.Ether)
+	assert.Equal(t, "50", rule.MonthlyMax.Ether)
+	assert.Equal(t, "0", rule.ToppedUpThisMonth.Ether)
+	assert.Equal(t, "EUR", rule.PayCurrency)
+
+	resp = httptest.NewRecorder()
+	g.ServeHTTP(resp, httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/v2/identities/%s/auto-topup", id), nil))
+	assert.Equal(t, http.StatusAccepted, resp.Code)
+}
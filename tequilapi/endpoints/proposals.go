@@ -24,8 +24,10 @@ import (
 
 	"github.com/mysteriumnetwork/go-rest/apierror"
 	"github.com/mysteriumnetwork/node/core/discovery/proposal"
+	"github.com/mysteriumnetwork/node/core/discovery/query"
 	"github.com/mysteriumnetwork/node/core/location"
 	"github.com/mysteriumnetwork/node/core/quality"
+	"github.com/mysteriumnetwork/node/identity"
 	"github.com/mysteriumnetwork/node/market"
 	"github.com/mysteriumnetwork/node/nat"
 	"github.com/mysteriumnetwork/node/services/datatransfer"
@@ -112,17 +114,35 @@ func NewProposalsEndpoint(proposalRepository proposalRepository, pricer priceAPI
 //	    name: nat_compatibility
 //	    description: Pick nodes compatible with NAT of specified type. Specify "auto" to probe NAT.
 //	    type: string
+//	  - in: query
+//	    name: query
+//	    description: 'Proposal query, e.g. country in (DE, NL) and not isp ~ "hosting" and quality >= 2'
+//	    type: string
+//	  - in: query
+//	    name: consumer_id
+//	    description: Consumer identity whose blocked providers are excluded and favourite ones are marked.
+//	    type: string
 //	responses:
 //	  200:
 //	    description: List of proposals
 //	    schema:
 //	      "$ref": "#/definitions/ListProposalsResponse"
+//	  400:
+//	    description: Invalid proposal query
+//	    schema:
+//	      "$ref": "#/definitions/APIError"
 //	  500:
 //	    description: Internal server error
 //	    schema:
 //	      "$ref": "#/definitions/APIError"
 func (pe *proposalsEndpoint) List(c *gin.Context) {
 	req := c.Request
+	q, err := query.Parse(req.URL.Query().Get("query"))
+	if err != nil {
+		c.Error(apierror.BadRequestField("Invalid proposal query: "+err.Error(), contract.ErrCodeProposalsQueryInvalid, "query"))
+		return
+	}
+
 	presetID, _ := strconv.Atoi(req.URL.Query().Get("preset_id"))
 	compatibilityMinQuery := req.URL.Query().Get("compatibility_min")
 	compatibilityMin := 2
@@ -163,6 +183,8 @@ func (pe *proposalsEndpoint) List(c *gin.Context) {
 		QualityMin:              qualityMin,
 		ExcludeUnsupported:      true,
 		IncludeMonitoringFailed: includeMonitoringFailed,
+		Query:                   q,
+		ConsumerID:              identity.FromAddress(req.URL.Query().Get("consumer_id")).Address,
 	})
 	if err != nil {
 		c.Error(apierror.Internal("Proposal query failed: "+err.Error(), contract.ErrCodeProposalsQuery))
@@ -30,6 +30,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"golang.org/x/net/context"
 
+	"github.com/mysteriumnetwork/go-rest/apierror"
 	"github.com/mysteriumnetwork/node/core/discovery/proposal"
 	"github.com/mysteriumnetwork/node/core/location/locationstate"
 	"github.com/mysteriumnetwork/node/market"
@@ -127,7 +128,7 @@ func TestProposalsEndpointListByNodeId(t *testing.T) {
             "proposals": [
                 {
                     "format": "service-proposal/v3",
-                    "compatibility": 2,
+                    "compatibility": 3,
                     "provider_id": "0xProviderId",
                     "service_type": "testprotocol",
                     "location": {
@@ -199,7 +200,7 @@ func TestProposalsEndpointAcceptsAccessPolicyParams(t *testing.T) {
             "proposals": [
                 {
                     "format": "service-proposal/v3",
-                    "compatibility": 2,
+                    "compatibility": 3,
                     "provider_id": "0xProviderId",
                     "service_type": "testprotocol",
                     "location": {
@@ -335,7 +336,7 @@ func TestProposalsEndpointFilterByPresetID(t *testing.T) {
             "proposals": [
                 {
                     "format": "service-proposal/v3",
-                    "compatibility": 2,
+                    "compatibility": 3,
                     "provider_id": "0xProviderId",
                     "service_type": "testprotocol",
                     "location": {
@@ -367,7 +368,7 @@ func TestProposalsEndpointFilterByPresetID(t *testing.T) {
                 },
                 {
                     "format": "service-proposal/v3",
-                    "compatibility": 2,
+                    "compatibility": 3,
                     "provider_id": "other_provider",
                     "service_type": "testprotocol",
                     "location": {
@@ -454,3 +455,30 @@ func setPricingBounds(v url.Values) {
 	v.Add("price_hour_max", fmt.Sprintf("%v", priceHourMax))
 	v.Add("price_gib_max", fmt.Sprintf("%v", priceGiBMax))
 }
+
+func TestProposalsEndpointAcceptsQuery(t *testing.T) {
+	repository := &mockProposalRepository{
+		proposals: serviceProposals,
+	}
+	path := "/proposals"
+	endpoint := NewProposalsEndpoint(repository, nil, nil, &mockFilterPresetRepository{}, mockedNATProber)
+	g := gin.Default()
+	g.Use(apierror.ErrorHandler)
+	g.GET(path, endpoint.List)
+
+	req, err := http.NewRequest(http.MethodGet, path+"?"+url.Values{"query": {`country in (DE, NL) and quality >= 2`}}.Encode(), nil)
+	assert.Nil(t, err)
+	resp := httptest.NewRecorder()
+	g.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, `country in (DE, NL) and quality >= 2`, repository.recordedFilter.Query.String())
+
+	req, err = http.NewRequest(http.MethodGet, path+"?"+url.Values{"query": {`country >= DE`}}.Encode(), nil)
+	assert.Nil(t, err)
+	resp = httptest.NewRecorder()
+	g.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Contains(t, resp.Body.String(), "err_proposals_query_invalid")
+}
@@ -0,0 +1,248 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package endpoints
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gin-gonic/gin"
+	"github.com/mysteriumnetwork/go-rest/apierror"
+
+	"github.com/mysteriumnetwork/node/core/discovery/proposal"
+	"github.com/mysteriumnetwork/node/identity"
+	"github.com/mysteriumnetwork/node/tequilapi/contract"
+	"github.com/mysteriumnetwork/node/tequilapi/utils"
+)
+
+type providerLists interface {
+	Get(consumerID string) (proposal.ProviderLists, error)
+	SetFavourite(consumerID, providerID string, favourite bool) error
+	SetBlocked(consumerID, providerID string, blocked bool) error
+}
+
+type providerListsEndpoint struct {
+	lists providerLists
+}
+
+// GetProviderLists returns favourite and blocked providers of a consumer.
+// swagger:operation GET /identities/{id}/provider-lists Identity getProviderLists
+//
+//	---
+//	summary: Get provider lists
+//	description: Returns providers the consumer marked as favourite or blocked.
+//	parameters:
+//	- name: id
+//	  in: path
+//	  description: Consumer identity
+//	  type: string
+//	  required: true
+//	responses:
+//	  200:
+//	    description: Provider lists
+//	    schema:
+//	      "$ref": "#/definitions/ProviderListsDTO"
+//	  500:
+//	    description: Internal server error
+//	    schema:
+//	      "$ref": "#/definitions/APIError"
+func (e *providerListsEndpoint) GetProviderLists(c *gin.Context) {
+	e.writeLists(c, identity.FromAddress(c.Param("id")).Address)
+}
+
+// AddFavouriteProvider marks provider as a favourite of a consumer.
+// swagger:operation PUT /identities/{id}/provider-lists/favourites/{provider_id} Identity addFavouriteProvider
+//
+//	---
+//	summary: Add favourite provider
+//	description: Marks provider as a favourite one, favourite providers are tried first when connecting.
+//	parameters:
+//	- name: id
+//	  in: path
+//	  description: Consumer identity
+//	  type: string
+//	  required: true
+//	- name: provider_id
+//	  in: path
+//	  description: Provider identity
+//	  type: string
+//	  required: true
+//	responses:
+//	  200:
+//	    description: Provider lists
+//	    schema:
+//	      "$ref": "#/definitions/ProviderListsDTO"
+//	  400:
+//	    description: Invalid provider identity
+//	    schema:
+//	      "$ref": "#/definitions/APIError"
+//	  500:
+//	    description: Internal server error
+//	    schema:
+//	      "$ref": "#/definitions/APIError"
+func (e *providerListsEndpoint) AddFavouriteProvider(c *gin.Context) {
+	e.update(c, func(consumerID, providerID string) error {
+		return e.lists.SetFavourite(consumerID, providerID, true)
+	})
+}
+
+// RemoveFavouriteProvider removes provider from favourites of a consumer.
+// swagger:operation DELETE /identities/{id}/provider-lists/favourites/{provider_id} Identity removeFavouriteProvider
+//
+//	---
+//	summary: Remove favourite provider
+//	description: Removes provider from favourite ones.
+//	parameters:
+//	- name: id
+//	  in: path
+//	  description: Consumer identity
+//	  type: string
+//	  required: true
+//	- name: provider_id
+//	  in: path
+//	  description: Provider identity
+//	  type: string
+//	  required: true
+//	responses:
+//	  200:
+//	    description: Provider lists
+//	    schema:
+//	      "$ref": "#/definitions/ProviderListsDTO"
+//	  400:
+//	    description: Invalid provider identity
+//	    schema:
+//	      "$ref": "#/definitions/APIError"
+//	  500:
+//	    description: Internal server error
+//	    schema:
+//	      "$ref": "#/definitions/APIError"
+func (e *providerListsEndpoint) RemoveFavouriteProvider(c *gin.Context) {
+	e.update(c, func(consumerID, providerID string) error {
+		return e.lists.SetFavourite(consumerID, providerID, false)
+	})
+}
+
+// BlockProvider blocks provider for a consumer.
+// swagger:operation PUT /identities/{id}/provider-lists/blocked/{provider_id} Identity blockProvider
+//
+//	---
+//	summary: Block provider
+//	description: Blocks provider, blocked providers are not listed nor connected to unless asked for explicitly.
+//	parameters:
+//	- name: id
+//	  in: path
+//	  description: Consumer identity
+//	  type: string
+//	  required: true
+//	- name: provider_id
+//	  in: path
+//	  description: Provider identity
+//	  type: string
+//	  required: true
+//	responses:
+//	  200:
+//	    description: Provider lists
+//	    schema:
+//	      "$ref": "#/definitions/ProviderListsDTO"
+//	  400:
+//	    description: Invalid provider identity
+//	    schema:
+//	      "$ref": "#/definitions/APIError"
+//	  500:
+//	    description: Internal server error
+//	    schema:
+//	      "$ref": "#/definitions/APIError"
+func (e *providerListsEndpoint) BlockProvider(c *gin.Context) {
+	e.update(c, func(consumerID, providerID string) error {
+		return e.lists.SetBlocked(consumerID, providerID, true)
+	})
+}
+
+// UnblockProvider unblocks provider for a consumer.
+// swagger:operation DELETE /identities/{id}/provider-lists/blocked/{provider_id} Identity unblockProvider
+//
+//	---
+//	summary: Unblock provider
+//	description: Unblocks provider, including the ones blocked automatically after failed connects.
+//	parameters:
+//	- name: id
+//	  in: path
+//	  description: Consumer identity
+//	  type: string
+//	  required: true
+//	- name: provider_id
+//	  in: path
+//	  description: Provider identity
+//	  type: string
+//	  required: true
+//	responses:
+//	  200:
+//	    description: Provider lists
+//	    schema:
+//	      "$ref": "#/definitions/ProviderListsDTO"
+//	  400:
+//	    description: Invalid provider identity
+//	    schema:
+//	      "$ref": "#/definitions/APIError"
+//	  500:
+//	    description: Internal server error
+//	    schema:
+//	      "$ref": "#/definitions/APIError"
+func (e *providerListsEndpoint) UnblockProvider(c *gin.Context) {
+	e.update(c, func(consumerID, providerID string) error {
+		return e.lists.SetBlocked(consumerID, providerID, false)
+	})
+}
+
+func (e *providerListsEndpoint) update(c *gin.Context, change func(consumerID, providerID string) error) {
+	providerID := c.Param("provider_id")
+	if !common.IsHexAddress(providerID) {
+		c.Error(apierror.BadRequestField("Invalid provider identity", contract.ErrCodeProviderLists, "provider_id"))
+		return
+	}
+
+	consumerID := identity.FromAddress(c.Param("id")).Address
+	if err := change(consumerID, identity.FromAddress(providerID).Address); err != nil {
+		c.Error(apierror.Internal("Failed to update provider lists: "+err.Error(), contract.ErrCodeProviderLists))
+		return
+	}
+
+	e.writeLists(c, consumerID)
+}
+
+func (e *providerListsEndpoint) writeLists(c *gin.Context, consumerID string) {
+	lists, err := e.lists.Get(consumerID)
+	if err != nil {
+		c.Error(apierror.Internal("Failed to get provider lists: "+err.Error(), contract.ErrCodeProviderLists))
+		return
+	}
+
+	utils.WriteAsJSON(contract.NewProviderListsDTO(lists), c.Writer)
+}
+
+// AddRoutesForProviderLists attaches consumer provider lists endpoints to router.
+func AddRoutesForProviderLists(lists providerLists) func(*gin.Engine) error {
+	e := &providerListsEndpoint{lists: lists}
+	return func(g *gin.Engine) error {
+		group := g.Group("/identities/:id/provider-lists")
+		group.GET("", e.GetProviderLists)
+		group.PUT("/favourites/:provider_id", e.AddFavouriteProvider)
+		group.DELETE("/favourites/:provider_id", e.RemoveFavouriteProvider)
+		group.PUT("/blocked/:provider_id", e.BlockProvider)
+		group.DELETE("/blocked/:provider_id", e.UnblockProvider)
+		return nil
+	}
+}
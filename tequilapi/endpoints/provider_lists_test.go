@@ -0,0 +1,72 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package endpoints
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mysteriumnetwork/go-rest/apierror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mysteriumnetwork/node/core/discovery/proposal"
+	"github.com/mysteriumnetwork/node/core/storage/boltdb"
+)
+
+func TestProviderLists(t *testing.T) {
+	bolt, err := boltdb.NewStorage(t.TempDir())
+	require.NoError(t, err)
+	defer bolt.Close()
+
+	g := gin.New()
+	g.Use(apierror.ErrorHandler)
+	g.GET("/identities/:id", func(c *gin.Context) {})
+	require.NoError(t, AddRoutesForProviderLists(proposal.NewProviderListStorage(bolt))(g))
+
+	serve := func(method, path string) *httptest.ResponseRecorder {
+		resp := httptest.NewRecorder()
+		g.ServeHTTP(resp, httptest.NewRequest(method, path, nil))
+		return resp
+	}
+	consumer := "/identities/0x000000000000000000000000000000000000000C/provider-lists"
+
+	resp := serve(http.MethodGet, consumer)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.JSONEq(t, `{"favourites": [], "blocked": []}`, resp.Body.String())
+
+	resp = serve(http.MethodPut, consumer+"/favourites/not-an-address")
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+
+	resp = serve(http.MethodPut, consumer+"/favourites/0x000000000000000000000000000000000000000A")
+	assert.Equal(t, http.StatusOK, resp.Code)
+	resp = serve(http.MethodPut, consumer+"/blocked/0x000000000000000000000000000000000000000b")
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.JSONEq(t, `{
+		"favourites": ["0x000000000000000000000000000000000000000a"],
+		"blocked": ["0x000000000000000000000000000000000000000b"]
+	}`, resp.Body.String())
+
+	resp = serve(http.MethodDelete, consumer+"/favourites/0x000000000000000000000000000000000000000a")
+	assert.Equal(t, http.StatusOK, resp.Code)
+	resp = serve(http.MethodDelete, consumer+"/blocked/0x000000000000000000000000000000000000000b")
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.JSONEq(t, `{"favourites": [], "blocked": []}`, resp.Body.String())
+}
@@ -18,6 +18,9 @@
 package endpoints
 
 import (
+	"encoding/json"
+	"errors"
+	"net/http"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -25,6 +28,9 @@ import (
 	"github.com/go-openapi/strfmt/conv"
 	"github.com/mysteriumnetwork/go-rest/apierror"
 	"github.com/mysteriumnetwork/node/consumer/session"
+	"github.com/mysteriumnetwork/node/core/service"
+	"github.com/mysteriumnetwork/node/market"
+	node_session "github.com/mysteriumnetwork/node/session"
 	"github.com/mysteriumnetwork/node/tequilapi/contract"
 	"github.com/mysteriumnetwork/node/tequilapi/utils"
 	"github.com/vcraescu/go-paginator/adapter"
@@ -182,3 +188,84 @@ func AddRoutesForSessions(sessionStorage sessionStorage) func(*gin.Engine) error
 		return nil
 	}
 }
+
+type sessionPriceProposer interface {
+	ProposePrice(id node_session.ID, price market.Price) error
+}
+
+type sessionPriceEndpoint struct {
+	proposer sessionPriceProposer
+}
+
+// swagger:operation PUT /sessions/{id}/price Session sessionPrice
+//
+//	---
+//	summary: Changes price of an ongoing provider session
+//	description: Proposes the price to the consumer of the session. The price is switched only if the consumer accepts it.
+//	parameters:
+//	  - in: path
+//	    name: id
+//	    description: Session ID
+//	    type: string
+//	    required: true
+//	  - in: body
+//	    name: body
+//	    schema:
+//	      $ref: "#/definitions/SessionPriceRequest"
+//	responses:
+//	  200:
+//	    description: Consumer accepted the price
+//	  400:
+//	    description: Failed to parse or request validation failed
+//	    schema:
+//	      "$ref": "#/definitions/APIError"
+//	  404:
+//	    description: Session not found
+//	    schema:
+//	      "$ref": "#/definitions/APIError"
+//	  422:
+//	    description: Consumer rejected the price
+//	    schema:
+//	      "$ref": "#/definitions/APIError"
+//	  500:
+//	    description: Internal server error
+//	    schema:
+//	      "$ref": "#/definitions/APIError"
+func (endpoint *sessionPriceEndpoint) ChangePrice(c *gin.Context) {
+	var req contract.SessionPriceRequest
+	if err := json.NewDecoder(c.Request.Body).Decode(&req); err != nil {
+		c.Error(apierror.ParseFailed())
+		return
+	}
+	if err := req.Validate(); err != nil {
+		c.Error(err)
+		return
+	}
+
+	err := endpoint.proposer.ProposePrice(node_session.ID(c.Param("id")), market.Price{
+		PricePerHour: req.PricePerHour,
+		PricePerGiB:  req.PricePerGiB,
+	})
+	switch {
+	case errors.Is(err, service.ErrorSessionNotExists):
+		c.Error(apierror.NotFound("Session not found"))
+		return
+	case errors.Is(err, service.ErrorPriceRejected):
+		c.Error(apierror.Unprocessable("Price was not accepted: "+err.Error(), contract.ErrCodeSessionPrice))
+		return
+	case err != nil:
+		c.Error(apierror.Internal("Could not change session price: "+err.Error(), contract.ErrCodeSessionPrice))
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// AddRoutesForSessionPrice attaches provider session price endpoint to router
+func AddRoutesForSessionPrice(proposer sessionPriceProposer) func(*gin.Engine) error {
+	endpoint := &sessionPriceEndpoint{proposer: proposer}
+	return func(e *gin.Engine) error {
+		e.PUT("/sessions/:id/price", endpoint.ChangePrice)
+		return nil
+	}
+}
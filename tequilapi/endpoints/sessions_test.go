@@ -20,8 +20,10 @@ package endpoints
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -30,7 +32,9 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/mysteriumnetwork/node/consumer/session"
+	"github.com/mysteriumnetwork/node/core/service"
 	"github.com/mysteriumnetwork/node/identity"
+	"github.com/mysteriumnetwork/node/market"
 	node_session "github.com/mysteriumnetwork/node/session"
 )
 
@@ -269,3 +273,64 @@ func (ssm *sessionStorageMock) StatsByDay(filter *session.Filter) (map[time.Time
 	ssm.calledWithFilter = filter
 	return ssm.statsByDayToReturn, ssm.errToReturn
 }
+
+func Test_SessionPriceEndpoint_ChangePrice(t *testing.T) {
+	for name, tc := range map[string]struct {
+		body      string
+		err       error
+		wantCode  int
+		wantPrice *market.Price
+	}{
+		"accepted": {
+			body:      `{"price_per_hour": 100, "price_per_gib": 200}`,
+			wantCode:  http.StatusOK,
+			wantPrice: market.NewPrice(100, 200),
+		},
+		"missing price": {
+			body:     `{"price_per_hour": 100}`,
+			wantCode: http.StatusBadRequest,
+		},
+		"unknown session": {
+			body:      `{"price_per_hour": 100, "price_per_gib": 200}`,
+			err:       service.ErrorSessionNotExists,
+			wantCode:  http.StatusNotFound,
+			wantPrice: market.NewPrice(100, 200),
+		},
+		"rejected by consumer": {
+			body:      `{"price_per_hour": 100, "price_per_gib": 200}`,
+			err:       fmt.Errorf("%w: too expensive", service.ErrorPriceRejected),
+			wantCode:  http.StatusUnprocessableEntity,
+			wantPrice: market.NewPrice(100, 200),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			proposer := &sessionPriceProposerMock{errToReturn: tc.err}
+			req, err := http.NewRequest(http.MethodPut, "/sessions/session-1/price", strings.NewReader(tc.body))
+			assert.NoError(t, err)
+
+			resp := httptest.NewRecorder()
+			g := summonTestGin()
+			assert.NoError(t, AddRoutesForSessionPrice(proposer)(g))
+			g.ServeHTTP(resp, req)
+
+			assert.Equal(t, tc.wantCode, resp.Code)
+			assert.Equal(t, tc.wantPrice, proposer.calledWithPrice)
+			if tc.wantPrice != nil {
+				assert.Equal(t, node_session.ID("session-1"), proposer.calledWithID)
+			}
+		})
+	}
+}
+
+type sessionPriceProposerMock struct {
+	errToReturn error
+
+	calledWithID    node_session.ID
+	calledWithPrice *market.Price
+}
+
+func (m *sessionPriceProposerMock) ProposePrice(id node_session.ID, price market.Price) error {
+	m.calledWithID = id
+	m.calledWithPrice = &price
+	return m.errToReturn
+}
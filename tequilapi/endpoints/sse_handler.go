@@ -18,13 +18,17 @@
 package endpoints
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"math/big"
 	"net/http"
+	"strconv"
+	"strings"
 	"sync"
 
 	"github.com/gin-gonic/gin"
+	"github.com/mysteriumnetwork/go-rest/apierror"
 	"github.com/mysteriumnetwork/node/core/connection/connectionstate"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog/log"
@@ -54,17 +58,35 @@ const (
 	ServiceStatusEvent EventType = "service-status"
 	// StateChangeEvent represents the state change
 	StateChangeEvent EventType = "state-change"
+	// StatePatchEvent represents the state change as JSON patch operations
+	StatePatchEvent EventType = "state-patch"
 )
 
+// sseTopics lists the state sections clients can subscribe to.
+var sseTopics = map[string]bool{
+	"service_info":   true,
+	"sessions":       true,
+	"sessions_stats": true,
+	"consumer":       true,
+	"identities":     true,
+	"channels":       true,
+}
+
+// sseHistorySize is the number of state changes kept for resuming clients.
+const sseHistorySize = 128
+
 // Handler represents an sse handler
 type Handler struct {
-	clients       map[chan string]struct{}
-	newClients    chan chan string
-	deadClients   chan chan string
-	messages      chan string
+	clients       map[*sseClient]struct{}
+	newClients    chan *sseClient
+	deadClients   chan *sseClient
+	messages      chan stateUpdate
 	stopOnce      sync.Once
 	stopChan      chan struct{}
 	stateProvider stateProvider
+
+	historyMu sync.Mutex
+	history   *stateHistory
 }
 
 type stateProvider interface {
@@ -72,15 +94,61 @@ type stateProvider interface {
 	GetConnection(string) stateEvent.Connection
 }
 
+// sseClient is a single subscriber. Clients without topics and deltas get
+// the whole state on every change, as they always did.
+type sseClient struct {
+	messages chan string
+	topics   map[string]bool
+	delta    bool
+	// after is the last change already sent within the initial messages.
+	after uint64
+}
+
+func (c *sseClient) legacy() bool {
+	return !c.delta && len(c.topics) == 0
+}
+
+func (c *sseClient) subscribed(topic string) bool {
+	return len(c.topics) == 0 || c.topics[topic]
+}
+
+func (c *sseClient) filterOps(ops []patchOp) []patchOp {
+	var res []patchOp
+	for _, op := range ops {
+		if c.subscribed(op.topic()) {
+			res = append(res, op)
+		}
+	}
+	return res
+}
+
+func (c *sseClient) filterSections(sections map[string]interface{}) map[string]interface{} {
+	res := make(map[string]interface{}, len(sections))
+	for topic, section := range sections {
+		if c.subscribed(topic) {
+			res[topic] = section
+		}
+	}
+	return res
+}
+
+// stateUpdate is a state change broadcast to all clients.
+type stateUpdate struct {
+	full     string
+	change   stateChange
+	sections map[string]interface{}
+}
+
 // NewSSEHandler returns a new instance of handler
 func NewSSEHandler(stateProvider stateProvider) *Handler {
 	return &Handler{
-		clients:       make(map[chan string]struct{}),
-		newClients:    make(chan (chan string)),
-		deadClients:   make(chan (chan string)),
-		messages:      make(chan string, 20),
+		clients:       make(map[*sseClient]struct{}),
+		newClients:    make(chan *sseClient),
+		deadClients:   make(chan *sseClient),
+		messages:      make(chan stateUpdate, 20),
 		stopChan:      make(chan struct{}),
 		stateProvider: stateProvider,
+		history:       newStateHistory(sseHistorySize),
 	}
 }
 
@@ -94,11 +162,24 @@ func (h *Handler) Subscribe(bus eventbus.Subscriber) error {
 	return err
 }
 
-// Sub subscribes a user to sse
+// Sub subscribes a user to sse.
+//
+// By default every state change is sent as a whole state. Clients may limit
+// the stream to some state sections with the "topics" query parameter and ask
+// for JSON patch deltas with "delta=true". Such messages carry increasing IDs
+// and a reconnecting client resumes from the Last-Event-ID header (or the
+// "last_event_id" query parameter) while the changes are still buffered.
+// IDs from a previous node run get the whole state.
 func (h *Handler) Sub(c *gin.Context) {
 	resp := c.Writer
 	req := c.Request
 
+	client, err := newSSEClient(req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
 	f, ok := resp.(http.Flusher)
 	if !ok {
 		resp.WriteHeader(http.StatusBadRequest)
@@ -114,33 +195,41 @@ func (h *Handler) Sub(c *gin.Context) {
 	resp.Header().Set("Cache-Control", "no-cache,no-transform")
 	resp.Header().Set("Connection", "keep-alive")
 
-	messageChan := make(chan string, 1)
-	err := h.sendInitialState(messageChan)
-	if err != nil {
-		resp.WriteHeader(http.StatusBadRequest)
-		resp.Header().Set("Content-type", "application/json; charset=utf-8")
-		writeErr := json.NewEncoder(resp).Encode(err)
-		if writeErr != nil {
-			http.Error(resp, "Http response write error", http.StatusInternalServerError)
+	if client.legacy() {
+		err = h.sendInitialState(client.messages)
+		if err != nil {
+			resp.WriteHeader(http.StatusBadRequest)
+			resp.Header().Set("Content-type", "application/json; charset=utf-8")
+			writeErr := json.NewEncoder(resp).Encode(err)
+			if writeErr != nil {
+				http.Error(resp, "Http response write error", http.StatusInternalServerError)
+			}
 		}
-	}
 
-	h.newClients <- messageChan
+		if !h.register(client) {
+			return
+		}
+	} else if !h.resume(client, lastEventID(req)) {
+		return
+	}
 
 	defer func() {
-		h.deadClients <- messageChan
+		select {
+		case h.deadClients <- client:
+		case <-h.stopChan:
+		}
 	}()
 
 	for {
 		select {
 		case <-req.Context().Done():
 			return
-		case msg, open := <-messageChan:
+		case msg, open := <-client.messages:
 			if !open {
 				return
 			}
 
-			_, err := fmt.Fprintf(resp, "data: %s\n\n", msg)
+			_, err := fmt.Fprint(resp, msg)
 			if err != nil {
 				log.Error().Err(err).Msg("failed to print data in response")
 				return
@@ -153,6 +242,104 @@ func (h *Handler) Sub(c *gin.Context) {
 	}
 }
 
+func newSSEClient(req *http.Request) (*sseClient, error) {
+	client := &sseClient{
+		topics: make(map[string]bool),
+	}
+
+	for _, param := range req.URL.Query()["topics"] {
+		for _, topic := range strings.Split(param, ",") {
+			topic = strings.TrimSpace(topic)
+			if topic == "" {
+				continue
+			}
+			if !sseTopics[topic] {
+				return nil, apierror.BadRequestField("Unknown topic: "+topic, contract.ErrCodeSSEQueryInvalid, "topics")
+			}
+			client.topics[topic] = true
+		}
+	}
+
+	if delta := req.URL.Query().Get("delta"); delta != "" {
+		enabled, err := strconv.ParseBool(delta)
+		if err != nil {
+			return nil, apierror.BadRequestField("Invalid delta value", contract.ErrCodeSSEQueryInvalid, "delta")
+		}
+		client.delta = enabled
+	}
+
+	if client.legacy() {
+		client.messages = make(chan string, 1)
+	} else {
+		// Room for a whole history replay on resume.
+		client.messages = make(chan string, sseHistorySize+1)
+	}
+	return client, nil
+}
+
+func lastEventID(req *http.Request) string {
+	if id := req.Header.Get("Last-Event-ID"); id != "" {
+		return id
+	}
+	return req.URL.Query().Get("last_event_id")
+}
+
+func (h *Handler) register(client *sseClient) bool {
+	select {
+	case h.newClients <- client:
+		return true
+	case <-h.stopChan:
+		return false
+	}
+}
+
+// resume queues the initial messages for a topic or delta client and
+// registers it. Both happen under the history lock, so no change gets
+// lost or duplicated in between.
+func (h *Handler) resume(client *sseClient, lastID string) bool {
+	h.historyMu.Lock()
+	defer h.historyMu.Unlock()
+
+	if h.history.sections == nil {
+		sections, err := stateSections(mapState(h.stateProvider.GetState()))
+		if err != nil {
+			log.Error().Err(err).Msg("Could not map state for SSE")
+			return false
+		}
+		h.history.record(sections)
+	}
+
+	resumed := false
+	if id, ok := h.history.parseEventID(lastID); ok && client.delta {
+		if changes, ok := h.history.since(id); ok {
+			for _, change := range changes {
+				if ops := client.filterOps(change.ops); len(ops) > 0 {
+					client.messages <- formatSSE(h.history.eventID(change.id), Event{Type: StatePatchEvent, Payload: ops})
+				}
+			}
+			resumed = true
+		}
+	}
+	if !resumed {
+		client.messages <- formatSSE(h.history.eventID(h.history.lastID), Event{
+			Type:    StateChangeEvent,
+			Payload: client.filterSections(h.history.sections),
+		})
+	}
+	client.after = h.history.lastID
+
+	return h.register(client)
+}
+
+func formatSSE(id string, e Event) string {
+	data, err := json.Marshal(e)
+	if err != nil {
+		log.Error().Err(err).Msg("Could not marshal SSE message")
+		return ""
+	}
+	return fmt.Sprintf("id: %s\ndata: %s\n\n", id, data)
+}
+
 func (h *Handler) sendInitialState(messageChan chan string) error {
 	res, err := json.Marshal(Event{
 		Type:    StateChangeEvent,
@@ -162,14 +349,14 @@ func (h *Handler) sendInitialState(messageChan chan string) error {
 		return err
 	}
 
-	messageChan <- string(res)
+	messageChan <- fmt.Sprintf("data: %s\n\n", res)
 	return nil
 }
 
 func (h *Handler) serve() {
 	defer func() {
 		for k := range h.clients {
-			close(k)
+			close(k.messages)
 		}
 	}()
 
@@ -180,31 +367,98 @@ func (h *Handler) serve() {
 		case s := <-h.newClients:
 			h.clients[s] = struct{}{}
 		case s := <-h.deadClients:
-			delete(h.clients, s)
-			close(s)
-		case msg := <-h.messages:
+			if _, ok := h.clients[s]; ok {
+				delete(h.clients, s)
+				close(s.messages)
+			}
+		case update := <-h.messages:
 			for s := range h.clients {
-				// non-locking send to each client
-				select {
-				case s <- msg:
-				default:
-				}
+				h.deliver(s, update)
 			}
 		}
 	}
 }
 
+func (h *Handler) deliver(client *sseClient, update stateUpdate) {
+	if client.legacy() {
+		// non-locking send to each client
+		select {
+		case client.messages <- update.full:
+		default:
+		}
+		return
+	}
+
+	if update.change.id <= client.after {
+		return
+	}
+	ops := client.filterOps(update.change.ops)
+	if len(ops) == 0 {
+		return
+	}
+
+	var msg string
+	if client.delta {
+		msg = formatSSE(h.history.eventID(update.change.id), Event{Type: StatePatchEvent, Payload: ops})
+	} else {
+		msg = formatSSE(h.history.eventID(update.change.id), Event{Type: StateChangeEvent, Payload: client.filterSections(update.sections)})
+	}
+
+	select {
+	case client.messages <- msg:
+	default:
+		// A skipped change would corrupt the client state, so it is
+		// disconnected instead and resumes from the history.
+		delete(h.clients, client)
+		close(client.messages)
+	}
+}
+
 func (h *Handler) stop() {
 	h.stopOnce.Do(func() { close(h.stopChan) })
 }
 
-func (h *Handler) send(e Event) {
-	marshaled, err := json.Marshal(e)
+func (h *Handler) publishState(res stateRes) {
+	full, err := json.Marshal(Event{
+		Type:    StateChangeEvent,
+		Payload: res,
+	})
 	if err != nil {
 		log.Error().Err(err).Msg("Could not marshal SSE message")
 		return
 	}
-	h.messages <- string(marshaled)
+	sections, err := stateSections(res)
+	if err != nil {
+		log.Error().Err(err).Msg("Could not map state for SSE")
+		return
+	}
+
+	h.historyMu.Lock()
+	defer h.historyMu.Unlock()
+
+	update := stateUpdate{
+		full:     fmt.Sprintf("data: %s\n\n", full),
+		change:   h.history.record(sections),
+		sections: sections,
+	}
+	select {
+	case h.messages <- update:
+	case <-h.stopChan:
+	}
+}
+
+// stateSections decodes the state into generic JSON values keyed by topic.
+// Numbers are kept as json.Number, so big integers survive the round trip.
+func stateSections(res stateRes) (map[string]interface{}, error) {
+	raw, err := json.Marshal(res)
+	if err != nil {
+		return nil, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var sections map[string]interface{}
+	return sections, dec.Decode(&sections)
 }
 
 // ConsumeNodeEvent consumes the node state event
@@ -306,8 +560,5 @@ func identityChannel(address string, channels []pingpong.HermesChannel) *pingpon
 
 // ConsumeStateEvent consumes the state change event
 func (h *Handler) ConsumeStateEvent(event stateEvent.State) {
-	h.send(Event{
-		Type:    StateChangeEvent,
-		Payload: mapState(event),
-	})
+	h.publishState(mapState(event))
 }
@@ -24,12 +24,14 @@ import (
 	"math/big"
 	"net"
 	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/gin-gonic/gin"
+	"github.com/mysteriumnetwork/go-rest/apierror"
 	"github.com/mysteriumnetwork/node/identity"
 	"github.com/stretchr/testify/assert"
 
@@ -38,6 +40,7 @@ import (
 	stateEvent "github.com/mysteriumnetwork/node/core/state/event"
 	"github.com/mysteriumnetwork/node/identity/registry"
 	"github.com/mysteriumnetwork/node/session/pingpong/event"
+	"github.com/mysteriumnetwork/node/tequilapi/contract"
 )
 
 type mockStateProvider struct {
@@ -83,8 +86,8 @@ func TestHandler_ConsumeNodeEvent_Starts(t *testing.T) {
 	h.ConsumeNodeEvent(me)
 
 	// without starting, this would block forever
-	h.newClients <- make(chan string)
-	h.newClients <- make(chan string)
+	h.newClients <- &sseClient{messages: make(chan string)}
+	h.newClients <- &sseClient{messages: make(chan string)}
 
 	h.stop()
 }
@@ -289,3 +292,129 @@ func TestHandler_SendsInitialAndFollowingStates(t *testing.T) {
 
 	<-serveExit
 }
+
+type sseFrame struct {
+	id   string
+	data string
+}
+
+func connectSSE(t *testing.T, ctx context.Context, url, lastEventID string) <-chan sseFrame {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	assert.NoError(t, err)
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+
+	frames := make(chan sseFrame, 10)
+	go func() {
+		defer resp.Body.Close()
+		reader := bufio.NewReader(resp.Body)
+		var frame sseFrame
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+			line = strings.TrimSpace(line)
+			switch {
+			case strings.HasPrefix(line, "id: "):
+				frame.id = strings.TrimPrefix(line, "id: ")
+			case strings.HasPrefix(line, "data: "):
+				frame.data = strings.TrimPrefix(line, "data: ")
+			case line == "":
+				frames <- frame
+				frame = sseFrame{}
+			}
+		}
+	}()
+	return frames
+}
+
+func nextFrame(t *testing.T, frames <-chan sseFrame) sseFrame {
+	select {
+	case frame := <-frames:
+		return frame
+	case <-time.After(2 * time.Second):
+		t.Fatal("no SSE message received")
+		return sseFrame{}
+	}
+}
+
+func TestHandler_DeltaStreamResumes(t *testing.T) {
+	msp := &mockStateProvider{stateToReturn: stateEvent.State{Connections: make(map[string]stateEvent.Connection)}}
+	h := NewSSEHandler(msp)
+	go h.serve()
+	defer h.stop()
+
+	router := gin.Default()
+	router.GET("/events", h.Sub)
+	server := httptest.NewServer(router)
+	defer server.Close()
+	url := server.URL + "/events?topics=identities&delta=true"
+
+	ctx, cancel := context.WithCancel(context.Background())
+	frames := connectSSE(t, ctx, url, "")
+
+	frame := nextFrame(t, frames)
+	assert.Equal(t, h.history.eventID(1), frame.id)
+	assert.JSONEq(t, `{"type": "state-change", "payload": {"identities": []}}`, frame.data)
+
+	state := msp.GetState()
+	state.Identities = []stateEvent.Identity{{Address: "0x1", Balance: big.NewInt(50)}}
+	h.ConsumeStateEvent(state)
+
+	frame = nextFrame(t, frames)
+	assert.Equal(t, h.history.eventID(2), frame.id)
+	assert.Contains(t, frame.data, `"type":"state-patch"`)
+	assert.Contains(t, frame.data, `{"op":"add","path":"/identities/0","value":{`)
+
+	// consumer section is not subscribed, only the balance change below is delivered
+	state.Connections = map[string]stateEvent.Connection{"1": {Session: connectionstate.Status{State: connectionstate.Connecting}}}
+	h.ConsumeStateEvent(state)
+	state.Identities = []stateEvent.Identity{{Address: "0x1", Balance: big.NewInt(60)}}
+	h.ConsumeStateEvent(state)
+
+	frame = nextFrame(t, frames)
+	assert.Equal(t, h.history.eventID(4), frame.id)
+	assert.JSONEq(t, `{"type": "state-patch", "payload": [
+		{"op": "replace", "path": "/identities/0/balance", "value": 60},
+		{"op": "replace", "path": "/identities/0/balance_tokens/ether", "value": "0.00000000000000006"},
+		{"op": "replace", "path": "/identities/0/balance_tokens/wei", "value": "60"}
+	]}`, frame.data)
+	cancel()
+
+	state.Identities = []stateEvent.Identity{{Address: "0x1", Balance: big.NewInt(70)}}
+	h.ConsumeStateEvent(state)
+
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	frames = connectSSE(t, ctx, url, h.history.eventID(4))
+	frame = nextFrame(t, frames)
+	assert.Equal(t, h.history.eventID(5), frame.id)
+	assert.Contains(t, frame.data, `{"op":"replace","path":"/identities/0/balance","value":70}`)
+
+	for _, lastID := range []string{h.history.eventID(100), "previousrun-4", "4"} {
+		frames = connectSSE(t, ctx, url, lastID)
+		frame = nextFrame(t, frames)
+		assert.Equal(t, h.history.eventID(5), frame.id)
+		assert.Contains(t, frame.data, `"type":"state-change"`)
+		assert.Contains(t, frame.data, `"balance":70`)
+	}
+}
+
+func TestHandler_RejectsUnknownTopic(t *testing.T) {
+	h := NewSSEHandler(&mockStateProvider{})
+
+	router := gin.New()
+	router.Use(apierror.ErrorHandler)
+	router.GET("/events", h.Sub)
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/events?topics=sessions,unknown", nil)
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Contains(t, resp.Body.String(), contract.ErrCodeSSEQueryInvalid)
+}
@@ -0,0 +1,128 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package endpoints
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// stateChange is a numbered set of patch operations between two states.
+type stateChange struct {
+	id  uint64
+	ops []patchOp
+}
+
+// stateHistory numbers state changes and keeps the latest ones in a bounded
+// ring buffer, so that reconnecting clients can catch up with patches
+// instead of a whole state.
+//
+// Change numbers start over with every node run, so event IDs are prefixed
+// with an epoch of the history. IDs of another epoch are unknown.
+type stateHistory struct {
+	epoch    string
+	changes  []stateChange
+	start    int
+	count    int
+	lastID   uint64
+	sections map[string]interface{}
+}
+
+func newStateHistory(size int) *stateHistory {
+	return &stateHistory{
+		epoch:   strconv.FormatInt(time.Now().UnixNano(), 36),
+		changes: make([]stateChange, size),
+	}
+}
+
+// eventID returns the SSE event ID of the given change.
+func (sh *stateHistory) eventID(id uint64) string {
+	return fmt.Sprintf("%s-%d", sh.epoch, id)
+}
+
+// parseEventID returns the change of the given SSE event ID, false if it
+// is malformed or belongs to another epoch.
+func (sh *stateHistory) parseEventID(eventID string) (uint64, bool) {
+	epoch, n, ok := strings.Cut(eventID, "-")
+	if !ok || epoch != sh.epoch {
+		return 0, false
+	}
+	id, err := strconv.ParseUint(n, 10, 64)
+	return id, err == nil
+}
+
+// record stores the new state and returns the change against the previous
+// one. Unchanged states do not consume an ID and are reported with ID 0.
+func (sh *stateHistory) record(sections map[string]interface{}) stateChange {
+	if sh.sections == nil {
+		sh.sections = sections
+		sh.lastID++
+		sh.push(stateChange{id: sh.lastID})
+		return stateChange{id: sh.lastID}
+	}
+
+	ops := diffJSON(nil, "", sh.sections, sections)
+	sh.sections = sections
+	if len(ops) == 0 {
+		return stateChange{}
+	}
+
+	sh.lastID++
+	change := stateChange{id: sh.lastID, ops: ops}
+	sh.push(change)
+	return change
+}
+
+func (sh *stateHistory) push(change stateChange) {
+	if len(sh.changes) == 0 {
+		return
+	}
+
+	idx := (sh.start + sh.count) % len(sh.changes)
+	sh.changes[idx] = change
+	if sh.count < len(sh.changes) {
+		sh.count++
+	} else {
+		sh.start = (sh.start + 1) % len(sh.changes)
+	}
+}
+
+// since returns all changes after the given ID, false if some of them
+// are no longer kept or the ID is unknown.
+func (sh *stateHistory) since(id uint64) ([]stateChange, bool) {
+	if id > sh.lastID {
+		return nil, false
+	}
+	if id == sh.lastID {
+		return nil, true
+	}
+	if sh.count == 0 || sh.changes[sh.start].id > id+1 {
+		return nil, false
+	}
+
+	changes := make([]stateChange, 0, sh.lastID-id)
+	for i := 0; i < sh.count; i++ {
+		change := sh.changes[(sh.start+i)%len(sh.changes)]
+		if change.id > id {
+			changes = append(changes, change)
+		}
+	}
+	return changes, true
+}
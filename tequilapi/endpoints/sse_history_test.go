@@ -0,0 +1,69 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package endpoints
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStateHistory(t *testing.T) {
+	history := newStateHistory(3)
+
+	assert.Equal(t, uint64(1), history.record(map[string]interface{}{"a": "0"}).id)
+	for i := 1; i <= 4; i++ {
+		change := history.record(map[string]interface{}{"a": strconv.Itoa(i)})
+		assert.Equal(t, uint64(i+1), change.id)
+		assert.Equal(t, []patchOp{{Op: "replace", Path: "/a", Value: strconv.Itoa(i)}}, change.ops)
+	}
+	assert.Equal(t, stateChange{}, history.record(map[string]interface{}{"a": "4"}))
+
+	changes, ok := history.since(2)
+	assert.True(t, ok)
+	assert.Len(t, changes, 3)
+	assert.Equal(t, uint64(3), changes[0].id)
+	assert.Equal(t, uint64(5), changes[2].id)
+
+	changes, ok = history.since(5)
+	assert.True(t, ok)
+	assert.Empty(t, changes)
+
+	_, ok = history.since(1)
+	assert.False(t, ok, "changes are no longer kept")
+	_, ok = history.since(6)
+	assert.False(t, ok, "unknown ID")
+}
+
+func TestStateHistory_EventID(t *testing.T) {
+	history := newStateHistory(3)
+
+	id, ok := history.parseEventID(history.eventID(42))
+	assert.True(t, ok)
+	assert.Equal(t, uint64(42), id)
+
+	previous := newStateHistory(3)
+	previous.epoch = "previous"
+	_, ok = history.parseEventID(previous.eventID(42))
+	assert.False(t, ok, "ID of another epoch")
+	_, ok = history.parseEventID("42")
+	assert.False(t, ok, "ID without epoch")
+	_, ok = history.parseEventID(history.epoch + "-x")
+	assert.False(t, ok, "malformed ID")
+}
@@ -0,0 +1,130 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package endpoints
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// patchOp is a single JSON patch (RFC 6902) operation.
+type patchOp struct {
+	Op    string
+	Path  string
+	Value interface{}
+}
+
+// MarshalJSON omits the value of remove operations, null is a valid value otherwise.
+func (op patchOp) MarshalJSON() ([]byte, error) {
+	if op.Op == "remove" {
+		return json.Marshal(struct {
+			Op   string `json:"op"`
+			Path string `json:"path"`
+		}{op.Op, op.Path})
+	}
+	return json.Marshal(struct {
+		Op    string      `json:"op"`
+		Path  string      `json:"path"`
+		Value interface{} `json:"value"`
+	}{op.Op, op.Path, op.Value})
+}
+
+// topic returns the top level state section the operation changes.
+func (op patchOp) topic() string {
+	path := strings.TrimPrefix(op.Path, "/")
+	if i := strings.IndexByte(path, '/'); i >= 0 {
+		path = path[:i]
+	}
+	return unescapePointer(path)
+}
+
+// diffJSON appends operations turning decoded JSON value a into b. Arrays are
+// compared element by element, so removals happen from the tail to keep
+// indexes of the following operations valid.
+func diffJSON(ops []patchOp, path string, a, b interface{}) []patchOp {
+	switch av := a.(type) {
+	case map[string]interface{}:
+		bv, ok := b.(map[string]interface{})
+		if !ok {
+			break
+		}
+		for _, key := range sortedKeys(av) {
+			if _, exists := bv[key]; !exists {
+				ops = append(ops, patchOp{Op: "remove", Path: path + "/" + escapePointer(key)})
+			}
+		}
+		for _, key := range sortedKeys(bv) {
+			keyPath := path + "/" + escapePointer(key)
+			if old, exists := av[key]; exists {
+				ops = diffJSON(ops, keyPath, old, bv[key])
+			} else {
+				ops = append(ops, patchOp{Op: "add", Path: keyPath, Value: bv[key]})
+			}
+		}
+		return ops
+	case []interface{}:
+		bv, ok := b.([]interface{})
+		if !ok {
+			break
+		}
+		common := len(av)
+		if len(bv) < common {
+			common = len(bv)
+		}
+		for i := 0; i < common; i++ {
+			ops = diffJSON(ops, path+"/"+strconv.Itoa(i), av[i], bv[i])
+		}
+		for i := len(av) - 1; i >= len(bv); i-- {
+			ops = append(ops, patchOp{Op: "remove", Path: path + "/" + strconv.Itoa(i)})
+		}
+		for i := len(av); i < len(bv); i++ {
+			ops = append(ops, patchOp{Op: "add", Path: path + "/" + strconv.Itoa(i), Value: bv[i]})
+		}
+		return ops
+	}
+
+	if !reflect.DeepEqual(a, b) {
+		ops = append(ops, patchOp{Op: "replace", Path: path, Value: b})
+	}
+	return ops
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+var (
+	pointerEscaper   = strings.NewReplacer("~", "~0", "/", "~1")
+	pointerUnescaper = strings.NewReplacer("~1", "/", "~0", "~")
+)
+
+func escapePointer(s string) string {
+	return pointerEscaper.Replace(s)
+}
+
+func unescapePointer(s string) string {
+	return pointerUnescaper.Replace(s)
+}
@@ -0,0 +1,79 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package endpoints
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func decodeJSON(t *testing.T, s string) interface{} {
+	var v interface{}
+	assert.NoError(t, json.Unmarshal([]byte(s), &v))
+	return v
+}
+
+func TestDiffJSON(t *testing.T) {
+	tests := map[string]struct {
+		a, b string
+		ops  string
+	}{
+		"equal": {
+			a:   `{"a": [1, {"b": null}]}`,
+			b:   `{"a": [1, {"b": null}]}`,
+			ops: `null`,
+		},
+		"object keys": {
+			a:   `{"a": 1, "b": 2, "c/d": 3}`,
+			b:   `{"a": 1, "c/d": 4, "e~": null}`,
+			ops: `[{"op": "remove", "path": "/b"}, {"op": "replace", "path": "/c~1d", "value": 4}, {"op": "add", "path": "/e~0", "value": null}]`,
+		},
+		"array grows": {
+			a:   `{"a": [1, 2]}`,
+			b:   `{"a": [1, 3, {"x": 1}]}`,
+			ops: `[{"op": "replace", "path": "/a/1", "value": 3}, {"op": "add", "path": "/a/2", "value": {"x": 1}}]`,
+		},
+		"array shrinks": {
+			a:   `{"a": [1, 2, 3]}`,
+			b:   `{"a": [1]}`,
+			ops: `[{"op": "remove", "path": "/a/2"}, {"op": "remove", "path": "/a/1"}]`,
+		},
+		"type change": {
+			a:   `{"a": [1]}`,
+			b:   `{"a": {"0": 1}}`,
+			ops: `[{"op": "replace", "path": "/a", "value": {"0": 1}}]`,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			ops := diffJSON(nil, "", decodeJSON(t, tt.a), decodeJSON(t, tt.b))
+			res, err := json.Marshal(ops)
+			assert.NoError(t, err)
+			assert.JSONEq(t, tt.ops, string(res))
+		})
+	}
+}
+
+func TestPatchOp_Topic(t *testing.T) {
+	assert.Equal(t, "identities", patchOp{Path: "/identities/0/balance"}.topic())
+	assert.Equal(t, "sessions_stats", patchOp{Path: "/sessions_stats"}.topic())
+	assert.Equal(t, "a/b", patchOp{Path: "/a~1b/c"}.topic())
+}
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"math/big"
 	"net/http"
+	"strings"
 
 	"github.com/mysteriumnetwork/go-rest/apierror"
 	"github.com/shopspring/decimal"
@@ -67,6 +68,7 @@ type promiseSettler interface {
 	SettleIntoStake(chainID int64, providerID identity.Identity, hermesID ...common.Address) error
 	GetHermesFee(chainID int64, id common.Address) (uint16, error)
 	Withdraw(fromChainID int64, toChainID int64, providerID identity.Identity, hermesID, beneficiary common.Address, amount *big.Int) error
+	EstimateSettlement(chainID int64, providerID identity.Identity, hermesID ...common.Address) ([]pingpong.SettlementEstimate, error)
 }
 
 type addressProvider interface {
@@ -293,6 +295,70 @@ func (te *transactorEndpoint) SettleAsync(c *gin.Context) {
 	c.Status(http.StatusAccepted)
 }
 
+// swagger:operation GET /transactor/settle/estimate SettlementEstimate
+//
+//	---
+//	summary: Estimates settlement costs
+//	description: Returns what settling the provider channels now would cost and whether the settlement scheduler would settle them. Nothing is settled.
+//	parameters:
+//	- in: query
+//	  name: provider_id
+//	  description: Provider identity
+//	  type: string
+//	  required: true
+//	- in: query
+//	  name: hermes_ids
+//	  description: Comma separated hermes addresses, all known hermeses are used if empty
+//	  type: string
+//	- in: query
+//	  name: chain_id
+//	  description: Chain ID
+//	  type: integer
+//	responses:
+//	  200:
+//	    description: Settlement estimates
+//	    schema:
+//	      "$ref": "#/definitions/SettlementEstimateResponse"
+//	  400:
+//	    description: Failed to parse or request validation failed
+//	    schema:
+//	      "$ref": "#/definitions/APIError"
+//	  500:
+//	    description: Internal server error
+//	    schema:
+//	      "$ref": "#/definitions/APIError"
+func (te *transactorEndpoint) SettlementEstimate(c *gin.Context) {
+	providerID := c.Query("provider_id")
+	if !common.IsHexAddress(providerID) {
+		c.Error(apierror.BadRequestField("'provider_id' is invalid", apierror.ValidateErrInvalidVal, "provider_id"))
+		return
+	}
+
+	chainID := config.GetInt64(config.FlagChainID)
+	if qcid, err := cast.ToInt64E(c.Query("chain_id")); err == nil {
+		chainID = qcid
+	}
+
+	var hermesIDs []common.Address
+	if q := c.Query("hermes_ids"); q != "" {
+		for _, h := range strings.Split(q, ",") {
+			if !common.IsHexAddress(h) {
+				c.Error(apierror.BadRequestField("'hermes_ids' is invalid", apierror.ValidateErrInvalidVal, "hermes_ids"))
+				return
+			}
+			hermesIDs = append(hermesIDs, common.HexToAddress(h))
+		}
+	}
+
+	estimates, err := te.promiseSettler.EstimateSettlement(chainID, identity.FromAddress(providerID), hermesIDs...)
+	if err != nil {
+		utils.ForwardError(c, err, apierror.Internal("Could not estimate settlement: "+err.Error(), contract.ErrCodeHermesSettleEstimate))
+		return
+	}
+
+	utils.WriteAsJSON(contract.NewSettlementEstimateResponse(chainID, providerID, estimates), c.Writer)
+}
+
 func (te *transactorEndpoint) settle(request *http.Request, settler func(int64, identity.Identity, ...common.Address) error) error {
 	req := contract.SettleRequest{}
 
@@ -905,6 +971,7 @@ func AddRoutesForTransactor(
 			transGroup.GET("/fees", te.TransactorFees)
 			transGroup.POST("/settle/sync", te.SettleSync)
 			transGroup.POST("/settle/async", te.SettleAsync)
+			transGroup.GET("/settle/estimate", te.SettlementEstimate)
 			transGroup.GET("/settle/history", te.SettlementHistory)
 			transGroup.POST("/stake/increase/sync", te.SettleIntoStakeSync)
 			transGroup.POST("/stake/increase/async", te.SettleIntoStakeAsync)
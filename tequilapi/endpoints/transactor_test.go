@@ -155,6 +155,56 @@ func Test_SettleAsync_OK(t *testing.T) {
 	assert.Equal(t, "", resp.Body.String())
 }
 
+func Test_SettlementEstimate(t *testing.T) {
+	router := summonTestGin()
+
+	hermesID := common.HexToAddress("0xbe180c8CA53F280C7BE8669596fF7939d933AA10")
+	settler := &mockSettler{
+		estimatesToReturn: []pingpong.SettlementEstimate{{
+			HermesID:       hermesID,
+			Unsettled:      big.NewInt(1000),
+			TransactorFee:  big.NewInt(10),
+			HermesFee:      big.NewInt(5),
+			TotalFee:       big.NewInt(15),
+			Payout:         big.NewInt(985),
+			FeeRatio:       0.015,
+			UnsettledSince: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+			Settle:         true,
+			Reason:         pingpong.SettleReasonFeeRatio,
+		}},
+	}
+	err := AddRoutesForTransactor(mockIdentityRegistryInstance, nil, nil, settler, &settlementHistoryProviderMock{}, &mockAddressProvider{}, nil, nil, nil)(router)
+	assert.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/transactor/settle/estimate?chain_id=137&provider_id=0x0000000000000000000000000000000000000001&hermes_ids="+hermesID.Hex(), nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, []common.Address{hermesID}, settler.capturedEstimateIDs)
+	assert.JSONEq(t, `{
+		"chain_id": 137,
+		"provider_id": "0x0000000000000000000000000000000000000001",
+		"items": [{
+			"hermes_id": "0xbe180c8CA53F280C7BE8669596fF7939d933AA10",
+			"unsettled": {"wei": "1000", "ether": "0.000000000000001", "human": "0"},
+			"transactor_fee": {"wei": "10", "ether": "0.00000000000000001", "human": "0"},
+			"hermes_fee": {"wei": "5", "ether": "0.000000000000000005", "human": "0"},
+			"total_fee": {"wei": "15", "ether": "0.000000000000000015", "human": "0"},
+			"payout": {"wei": "985", "ether": "0.000000000000000985", "human": "0"},
+			"fee_ratio": 0.015,
+			"unsettled_since": "2026-01-02T03:04:05Z",
+			"would_settle": true,
+			"reason": "fee_ratio_below_target"
+		}]
+	}`, resp.Body.String())
+
+	req = httptest.NewRequest(http.MethodGet, "/transactor/settle/estimate?provider_id=nope", nil)
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+}
+
 func Test_SettleAsync_ReturnsError(t *testing.T) {
 	mockResponse := ""
 	server := newTestTransactorServer(http.StatusAccepted, mockResponse)
@@ -513,6 +563,9 @@ type mockSettler struct {
 
 	capturedToChainID   int64
 	capturedFromChainID int64
+
+	estimatesToReturn   []pingpong.SettlementEstimate
+	capturedEstimateIDs []common.Address
 }
 
 func (ms *mockSettler) ForceSettle(_ int64, _ identity.Identity, _ ...common.Address) error {
@@ -531,6 +584,11 @@ func (ms *mockSettler) GetHermesFee(_ int64, _ common.Address) (uint16, error) {
 	return ms.feeToReturn, ms.feeErrorToReturn
 }
 
+func (ms *mockSettler) EstimateSettlement(_ int64, _ identity.Identity, hermesIDs ...common.Address) ([]pingpong.SettlementEstimate, error) {
+	ms.capturedEstimateIDs = hermesIDs
+	return ms.estimatesToReturn, ms.errToReturn
+}
+
 func (ms *mockSettler) Withdraw(fromChainID int64, toChainID int64, providerID identity.Identity, hermesID, beneficiary common.Address, amount *big.Int) error {
 	ms.capturedToChainID = toChainID
 	ms.capturedFromChainID = fromChainID
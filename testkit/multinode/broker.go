@@ -0,0 +1,320 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package multinode
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Broker is an in-memory NATS server which implements the subset of the client
+// protocol used by the node: PUB, SUB, UNSUB and PING. Like the network broker
+// it accepts subjects signed by node identities, but it only strips the
+// signature prefix without verifying it. Headers, queue groups and clustering
+// are not supported.
+type Broker struct {
+	listener net.Listener
+
+	mu      sync.Mutex
+	clients map[*brokerClient]struct{}
+	wg      sync.WaitGroup
+}
+
+// NewBroker starts a broker listening on a random local port.
+func NewBroker() (*Broker, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("could not listen for broker connections: %w", err)
+	}
+
+	b := &Broker{
+		listener: listener,
+		clients:  make(map[*brokerClient]struct{}),
+	}
+
+	b.wg.Add(1)
+	go b.serve()
+
+	return b, nil
+}
+
+// Address returns the broker address in the form expected by node contacts.
+func (b *Broker) Address() string {
+	return "nats://" + b.listener.Addr().String()
+}
+
+// Close stops the broker and disconnects all clients.
+func (b *Broker) Close() error {
+	err := b.listener.Close()
+
+	b.mu.Lock()
+	for c := range b.clients {
+		c.conn.Close()
+	}
+	b.mu.Unlock()
+
+	b.wg.Wait()
+	return err
+}
+
+func (b *Broker) serve() {
+	defer b.wg.Done()
+
+	for {
+		conn, err := b.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		c := &brokerClient{
+			broker: b,
+			conn:   conn,
+			subs:   make(map[string]*brokerSub),
+		}
+
+		b.mu.Lock()
+		b.clients[c] = struct{}{}
+		b.mu.Unlock()
+
+		b.wg.Add(1)
+		go func() {
+			defer b.wg.Done()
+			c.serve()
+
+			b.mu.Lock()
+			delete(b.clients, c)
+			b.mu.Unlock()
+		}()
+	}
+}
+
+func (b *Broker) publish(subject, reply string, payload []byte) {
+	b.mu.Lock()
+	clients := make([]*brokerClient, 0, len(b.clients))
+	for c := range b.clients {
+		clients = append(clients, c)
+	}
+	b.mu.Unlock()
+
+	for _, c := range clients {
+		c.deliver(subject, reply, payload)
+	}
+}
+
+type brokerSub struct {
+	subject   string
+	sid       string
+	delivered int
+	max       int
+}
+
+type brokerClient struct {
+	broker *Broker
+	conn   net.Conn
+
+	writeMu sync.Mutex
+	subsMu  sync.Mutex
+	subs    map[string]*brokerSub
+}
+
+func (c *brokerClient) serve() {
+	defer c.conn.Close()
+
+	info := fmt.Sprintf(`{"server_id":"testkit","server_name":"testkit","version":"2.10.0","proto":1,"headers":false,"max_payload":%d}`, 8*1024*1024)
+	if err := c.write("INFO " + info + "\r\n"); err != nil {
+		return
+	}
+
+	reader := bufio.NewReader(c.conn)
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+
+		op, args, _ := strings.Cut(line, " ")
+		fields := strings.Fields(args)
+
+		switch strings.ToUpper(op) {
+		case "CONNECT":
+		case "PING":
+			err = c.write("PONG\r\n")
+		case "PONG":
+		case "SUB":
+			err = c.subscribe(fields)
+		case "UNSUB":
+			err = c.unsubscribe(fields)
+		case "PUB":
+			err = c.publish(reader, fields)
+		default:
+			err = fmt.Errorf("unknown protocol operation %q", op)
+		}
+
+		if err != nil {
+			c.write(fmt.Sprintf("-ERR '%s'\r\n", err))
+			return
+		}
+	}
+}
+
+func (c *brokerClient) subscribe(fields []string) error {
+	// SUB <subject> [queue group] <sid>
+	if len(fields) < 2 {
+		return fmt.Errorf("invalid SUB arguments")
+	}
+
+	sub := &brokerSub{subject: unsignedSubject(fields[0]), sid: fields[len(fields)-1]}
+
+	c.subsMu.Lock()
+	c.subs[sub.sid] = sub
+	c.subsMu.Unlock()
+
+	return nil
+}
+
+func (c *brokerClient) unsubscribe(fields []string) error {
+	// UNSUB <sid> [max_msgs]
+	if len(fields) < 1 {
+		return fmt.Errorf("invalid UNSUB arguments")
+	}
+
+	c.subsMu.Lock()
+	defer c.subsMu.Unlock()
+
+	sub, ok := c.subs[fields[0]]
+	if !ok {
+		return nil
+	}
+
+	if len(fields) > 1 {
+		max, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return fmt.Errorf("invalid UNSUB max messages: %w", err)
+		}
+		if sub.delivered < max {
+			sub.max = max
+			return nil
+		}
+	}
+
+	delete(c.subs, sub.sid)
+	return nil
+}
+
+func (c *brokerClient) publish(reader *bufio.Reader, fields []string) error {
+	// PUB <subject> [reply-to] <#bytes>
+	if len(fields) < 2 || len(fields) > 3 {
+		return fmt.Errorf("invalid PUB arguments")
+	}
+
+	size, err := strconv.Atoi(fields[len(fields)-1])
+	if err != nil {
+		return fmt.Errorf("invalid PUB size: %w", err)
+	}
+
+	payload := make([]byte, size+2)
+	if _, err := io.ReadFull(reader, payload); err != nil {
+		return err
+	}
+
+	reply := ""
+	if len(fields) == 3 {
+		reply = fields[1]
+	}
+
+	c.broker.publish(unsignedSubject(fields[0]), reply, payload[:size])
+	return nil
+}
+
+func (c *brokerClient) deliver(subject, reply string, payload []byte) {
+	var sids []string
+
+	c.subsMu.Lock()
+	for sid, sub := range c.subs {
+		if !subjectMatches(sub.subject, subject) {
+			continue
+		}
+
+		sids = append(sids, sid)
+		sub.delivered++
+		if sub.max > 0 && sub.delivered >= sub.max {
+			delete(c.subs, sid)
+		}
+	}
+	c.subsMu.Unlock()
+
+	for _, sid := range sids {
+		var msg string
+		if reply == "" {
+			msg = fmt.Sprintf("MSG %s %s %d\r\n", subject, sid, len(payload))
+		} else {
+			msg = fmt.Sprintf("MSG %s %s %s %d\r\n", subject, sid, reply, len(payload))
+		}
+
+		c.write(msg + string(payload) + "\r\n")
+	}
+}
+
+func (c *brokerClient) write(data string) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	_, err := io.WriteString(c.conn, data)
+	return err
+}
+
+// unsignedSubject strips the "signed.<signature>.<timestamp>." prefix added by
+// nats.SignedSubject.
+func unsignedSubject(subject string) string {
+	if !strings.HasPrefix(subject, "signed.") {
+		return subject
+	}
+
+	parts := strings.SplitN(subject, ".", 4)
+	if len(parts) < 4 {
+		return subject
+	}
+	return parts[3]
+}
+
+// subjectMatches reports whether subject matches the subscription pattern,
+// where "*" matches a single token and ">" matches all remaining tokens.
+func subjectMatches(pattern, subject string) bool {
+	patternTokens := strings.Split(pattern, ".")
+	subjectTokens := strings.Split(subject, ".")
+
+	for i, token := range patternTokens {
+		if token == ">" {
+			return len(subjectTokens) > i
+		}
+		if i >= len(subjectTokens) {
+			return false
+		}
+		if token != "*" && token != subjectTokens[i] {
+			return false
+		}
+	}
+
+	return len(patternTokens) == len(subjectTokens)
+}
@@ -0,0 +1,71 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package multinode
+
+import (
+	"testing"
+	"time"
+
+	nats_lib "github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubjectMatches(t *testing.T) {
+	tests := []struct {
+		pattern string
+		subject string
+		want    bool
+	}{
+		{"a.b.c", "a.b.c", true},
+		{"a.b.c", "a.b", false},
+		{"a.*.c", "a.b.c", true},
+		{"a.*", "a.b.c", false},
+		{"a.>", "a.b.c", true},
+		{"a.>", "a", false},
+		{"_INBOX.abc.*", "_INBOX.abc.1", true},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, subjectMatches(tt.pattern, tt.subject), "%s ~ %s", tt.pattern, tt.subject)
+	}
+}
+
+func TestBroker_RequestReply(t *testing.T) {
+	broker, err := NewBroker()
+	require.NoError(t, err)
+	defer broker.Close()
+
+	responder, err := nats_lib.Connect(broker.Address())
+	require.NoError(t, err)
+	defer responder.Close()
+
+	_, err = responder.Subscribe("ping", func(msg *nats_lib.Msg) {
+		msg.Respond(append([]byte("pong:"), msg.Data...))
+	})
+	require.NoError(t, err)
+	require.NoError(t, responder.Flush())
+
+	requester, err := nats_lib.Connect(broker.Address())
+	require.NoError(t, err)
+	defer requester.Close()
+
+	reply, err := requester.Request("ping", []byte("1"), 5*time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, "pong:1", string(reply.Data))
+}
@@ -0,0 +1,112 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package multinode
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/mysteriumnetwork/node/communication/nats"
+	"github.com/mysteriumnetwork/node/core/discovery/brokerdiscovery"
+	"github.com/mysteriumnetwork/node/eventbus"
+	"github.com/mysteriumnetwork/node/market"
+)
+
+// Discovery is an HTTP stand-in for the discovery API. It collects proposals
+// announced over the broker and serves them to consumers.
+type Discovery struct {
+	server     *httptest.Server
+	connection nats.Connection
+	repository *brokerdiscovery.Repository
+	storage    *brokerdiscovery.ProposalStorage
+}
+
+// NewDiscovery starts a discovery stand-in listening to proposals on the given broker.
+func NewDiscovery(broker *Broker) (*Discovery, error) {
+	connection, err := connectBroker(broker)
+	if err != nil {
+		return nil, err
+	}
+
+	storage := brokerdiscovery.NewStorage(eventbus.New())
+	repository := brokerdiscovery.NewRepository(connection, storage, time.Minute, time.Second)
+	if err := repository.Start(); err != nil {
+		connection.Close()
+		return nil, fmt.Errorf("could not start proposal repository: %w", err)
+	}
+
+	d := &Discovery{
+		connection: connection,
+		repository: repository,
+		storage:    storage,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/proposals", d.proposals)
+	d.server = httptest.NewServer(mux)
+
+	return d, nil
+}
+
+// URL returns the base URL of the discovery API.
+func (d *Discovery) URL() string {
+	return d.server.URL
+}
+
+// Proposals returns the proposals currently announced by providers.
+func (d *Discovery) Proposals() []market.ServiceProposal {
+	return d.storage.Proposals()
+}
+
+// Close stops the discovery API.
+func (d *Discovery) Close() {
+	d.server.Close()
+	d.repository.Stop()
+	d.connection.Close()
+}
+
+func (d *Discovery) proposals(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	providerIDs := query["provider_id"]
+	serviceType := query.Get("service_type")
+
+	result := make([]market.ServiceProposal, 0)
+	for _, p := range d.storage.Proposals() {
+		if serviceType != "" && p.ServiceType != serviceType {
+			continue
+		}
+		if len(providerIDs) > 0 && !containsFold(providerIDs, p.ProviderID) {
+			continue
+		}
+		result = append(result, p)
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+func containsFold(list []string, s string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,110 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package multinode
+
+import (
+	"errors"
+	"math/big"
+	"net"
+
+	"github.com/ethereum/go-ethereum/common"
+	paymentClient "github.com/mysteriumnetwork/payments/client"
+	"github.com/mysteriumnetwork/payments/observer"
+
+	"github.com/mysteriumnetwork/node/communication/nats"
+	"github.com/mysteriumnetwork/node/identity"
+	"github.com/mysteriumnetwork/node/market"
+	"github.com/mysteriumnetwork/node/session/pingpong"
+)
+
+var errNoObserver = errors.New("observer is not available in the test network")
+
+func connectBroker(broker *Broker) (nats.Connection, error) {
+	serverURL, err := nats.ParseServerURL(broker.Address())
+	if err != nil {
+		return nil, err
+	}
+
+	return nats.NewBrokerConnector((&net.Dialer{}).DialContext, nil).Connect(serverURL)
+}
+
+// blockchain answers the registry lookups of the address provider with the
+// single channel implementation of the test network.
+type blockchain struct {
+	channelImplementation common.Address
+}
+
+func (bc *blockchain) GetHermes(chainID int64, registryID, hermesID common.Address) (paymentClient.Hermes, error) {
+	return paymentClient.Hermes{Operator: hermesID, ImplVer: big.NewInt(1), Stake: new(big.Int)}, nil
+}
+
+func (bc *blockchain) GetChannelImplementationByVersion(chainID int64, registryID common.Address, version *big.Int) (common.Address, error) {
+	return bc.channelImplementation, nil
+}
+
+// hermesStatus reports the network hermes as active and free of charge.
+type hermesStatus struct{}
+
+func (hermesStatus) GetHermesStatus(chainID int64, registryAddress common.Address, hermesID common.Address) (pingpong.HermesStatus, error) {
+	return pingpong.HermesStatus{HermesID: hermesID, ChainID: chainID, IsActive: true}, nil
+}
+
+// hermesURL points every hermes lookup to the network hermes.
+type hermesURL string
+
+func (u hermesURL) GetHermesURL(chainID int64, address common.Address) (string, error) {
+	return string(u), nil
+}
+
+// noObserver fails all lookups, nodes fall back to their local data.
+type noObserver struct{}
+
+func (noObserver) GetHermeses(f *observer.HermesFilter) (observer.HermesesResponse, error) {
+	return nil, errNoObserver
+}
+
+func (noObserver) GetHermesData(chainId int64, hermesAddress common.Address) (*observer.HermesResponse, error) {
+	return nil, errNoObserver
+}
+
+// fixedPrice agrees to any price and quotes the given one.
+type fixedPrice struct {
+	price market.Price
+}
+
+func (p fixedPrice) GetCurrentPrice(nodeType string, country string, serviceType string) (market.Price, error) {
+	return p.price, nil
+}
+
+func (p fixedPrice) IsPriceValid(in market.Price, nodeType string, country string, serviceType string) bool {
+	return true
+}
+
+// anyBalance lets consumers connect regardless of their balance.
+type anyBalance struct{}
+
+func (anyBalance) Validate(chainID int64, consumerID identity.Identity, p market.Price) error {
+	return nil
+}
+
+// noRoutes leaves the routing table of the test host untouched.
+type noRoutes struct{}
+
+func (noRoutes) ExcludeIP(net.IP) error        { return nil }
+func (noRoutes) RemoveExcludedIP(net.IP) error { return nil }
+func (noRoutes) Clean() error                  { return nil }
@@ -0,0 +1,200 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package multinode
+
+import (
+	"crypto/ecdsa"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/mysteriumnetwork/payments/crypto"
+
+	"github.com/mysteriumnetwork/node/session/pingpong"
+)
+
+// Hermes is an HTTP stand-in for the hermes payment hub. It exchanges consumer
+// promises for provider promises signed with its own key, so its address is
+// used as the hermes ID by the nodes of the network.
+type Hermes struct {
+	server *httptest.Server
+	key    *ecdsa.PrivateKey
+
+	mu               sync.Mutex
+	consumerPromised map[string]*big.Int
+	providerEarned   map[string]*big.Int
+	revealed         map[string]bool
+}
+
+// NewHermes starts a hermes stand-in with a freshly generated operator key.
+func NewHermes() (*Hermes, error) {
+	key, err := ethcrypto.GenerateKey()
+	if err != nil {
+		return nil, fmt.Errorf("could not generate hermes key: %w", err)
+	}
+
+	h := &Hermes{
+		key:              key,
+		consumerPromised: make(map[string]*big.Int),
+		providerEarned:   make(map[string]*big.Int),
+		revealed:         make(map[string]bool),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/request_promise", h.requestPromise)
+	mux.HandleFunc("/reveal_r", h.revealR)
+	h.server = httptest.NewServer(mux)
+
+	return h, nil
+}
+
+// Address returns the hermes ID.
+func (h *Hermes) Address() common.Address {
+	return ethcrypto.PubkeyToAddress(h.key.PublicKey)
+}
+
+// URL returns the base URL of the hermes API.
+func (h *Hermes) URL() string {
+	return h.server.URL
+}
+
+// Earned returns the total amount promised to the given provider.
+func (h *Hermes) Earned(providerID string) *big.Int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if earned, ok := h.providerEarned[strings.ToLower(providerID)]; ok {
+		return new(big.Int).Set(earned)
+	}
+	return new(big.Int)
+}
+
+// Revealed reports whether the preimage for the given hashlock was revealed.
+func (h *Hermes) Revealed(hashlock []byte) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.revealed[hex.EncodeToString(hashlock)]
+}
+
+// Close stops the hermes API.
+func (h *Hermes) Close() {
+	h.server.Close()
+}
+
+// SignHash signs the given hash with the hermes operator key.
+func (h *Hermes) SignHash(_ accounts.Account, hash []byte) ([]byte, error) {
+	return ethcrypto.Sign(hash, h.key)
+}
+
+func (h *Hermes) requestPromise(w http.ResponseWriter, r *http.Request) {
+	var req pingpong.RequestPromise
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeHermesError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	em := req.ExchangeMessage
+	consumer, err := em.RecoverConsumerIdentity()
+	if err != nil {
+		writeHermesError(w, http.StatusBadRequest, err)
+		return
+	}
+	if !em.IsMessageValid(consumer) || !em.Promise.IsPromiseValid(consumer) {
+		writeHermesError(w, http.StatusBadRequest, fmt.Errorf("invalid exchange message signature"))
+		return
+	}
+	if !strings.EqualFold(em.HermesID, h.Address().Hex()) {
+		writeHermesError(w, http.StatusBadRequest, fmt.Errorf("exchange message is for hermes %s", em.HermesID))
+		return
+	}
+
+	providerChannel, err := crypto.GenerateProviderChannelID(em.Provider, h.Address().Hex())
+	if err != nil {
+		writeHermesError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	h.mu.Lock()
+	consumerChannel := hex.EncodeToString(em.Promise.ChannelID)
+	promised, ok := h.consumerPromised[consumerChannel]
+	if !ok {
+		promised = new(big.Int)
+	}
+	if em.Promise.Amount.Cmp(promised) < 0 {
+		h.mu.Unlock()
+		writeHermesError(w, http.StatusBadRequest, fmt.Errorf("promise amount %v is lower than already promised %v", em.Promise.Amount, promised))
+		return
+	}
+
+	provider := strings.ToLower(em.Provider)
+	earned, ok := h.providerEarned[provider]
+	if !ok {
+		earned = new(big.Int)
+	}
+	earned = new(big.Int).Add(earned, new(big.Int).Sub(em.Promise.Amount, promised))
+	h.consumerPromised[consumerChannel] = em.Promise.Amount
+	h.providerEarned[provider] = earned
+	h.mu.Unlock()
+
+	promise, err := crypto.CreatePromise(providerChannel, em.ChainID, earned, req.TransactorFee, hex.EncodeToString(em.Promise.Hashlock), h, h.Address())
+	if err != nil {
+		writeHermesError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, promise)
+}
+
+func (h *Hermes) revealR(w http.ResponseWriter, r *http.Request) {
+	var req pingpong.RevealObject
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeHermesError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	preimage, err := hex.DecodeString(strings.TrimPrefix(req.R, "0x"))
+	if err != nil {
+		writeHermesError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	h.mu.Lock()
+	h.revealed[hex.EncodeToString(ethcrypto.Keccak256(preimage))] = true
+	h.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, pingpong.RevealSuccess{Message: "R revealed"})
+}
+
+func writeHermesError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, pingpong.HermesErrorResponse{ErrorMessage: err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
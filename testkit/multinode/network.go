@@ -0,0 +1,128 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+// Package multinode boots provider and consumer nodes inside a single test
+// process. Nodes are wired from the same components as the real node, while
+// the broker, hermes, transactor and discovery are replaced by in-process
+// stand-ins, so connect, payment and settlement flows can be exercised with
+// `go test` without docker or a blockchain.
+package multinode
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	paymentClient "github.com/mysteriumnetwork/payments/client"
+
+	"github.com/mysteriumnetwork/node/config"
+	"github.com/mysteriumnetwork/node/market"
+	"github.com/mysteriumnetwork/node/p2p"
+	"github.com/mysteriumnetwork/node/router"
+	"github.com/mysteriumnetwork/node/services/noop"
+)
+
+// ChainID is the chain used by the test network.
+const ChainID int64 = 80002
+
+// Network holds the shared services of a test network.
+type Network struct {
+	Broker     *Broker
+	Hermes     *Hermes
+	Transactor *Transactor
+	Discovery  *Discovery
+
+	// Price is quoted by providers and agreed to by consumers.
+	Price market.Price
+
+	t         testing.TB
+	addresses map[int64]paymentClient.SmartContractAddresses
+}
+
+// NewNetwork starts the shared services of a test network. Everything is torn
+// down when the test finishes.
+func NewNetwork(t testing.TB) *Network {
+	t.Helper()
+
+	configure()
+
+	broker, err := NewBroker()
+	if err != nil {
+		t.Fatalf("could not start broker: %v", err)
+	}
+	t.Cleanup(func() { broker.Close() })
+
+	hermes, err := NewHermes()
+	if err != nil {
+		t.Fatalf("could not start hermes: %v", err)
+	}
+	t.Cleanup(hermes.Close)
+
+	transactor := NewTransactor(hermes.Address(), big.NewInt(1000))
+	t.Cleanup(transactor.Close)
+
+	discovery, err := NewDiscovery(broker)
+	if err != nil {
+		t.Fatalf("could not start discovery: %v", err)
+	}
+	t.Cleanup(discovery.Close)
+
+	return &Network{
+		Broker:     broker,
+		Hermes:     hermes,
+		Transactor: transactor,
+		Discovery:  discovery,
+		Price: market.Price{
+			PricePerHour: big.NewInt(360_000_000_000_000_000),
+			PricePerGiB:  big.NewInt(100_000_000_000_000_000),
+		},
+		t: t,
+		addresses: map[int64]paymentClient.SmartContractAddresses{
+			ChainID: {
+				Registry:                    common.HexToAddress("0x0000000000000000000000000000000000000001"),
+				Myst:                        common.HexToAddress("0x0000000000000000000000000000000000000002"),
+				ActiveChannelImplementation: common.HexToAddress("0x0000000000000000000000000000000000000003"),
+				ActiveHermes:                hermes.Address(),
+				KnownHermeses:               []common.Address{hermes.Address()},
+			},
+		},
+	}
+}
+
+func (n *Network) addressProvider() *paymentClient.MultiChainAddressProvider {
+	return paymentClient.NewMultiChainAddressProvider(
+		paymentClient.NewMultiChainAddressKeeper(n.addresses),
+		&blockchain{channelImplementation: n.addresses[ChainID].ActiveChannelImplementation},
+	)
+}
+
+// configure sets the process wide configuration the nodes read at runtime.
+// Traversal is limited to hole punching, which works over loopback, and
+// routing changes are disabled.
+func configure() {
+	config.Current.SetDefault(config.FlagChainID.Name, ChainID)
+	config.Current.SetDefault(config.FlagChain1ChainID.Name, ChainID)
+	config.Current.SetDefault(config.FlagChain2ChainID.Name, ChainID)
+	config.Current.SetDefault(config.FlagTraversal.Name, "holepunching")
+	config.Current.SetDefault(config.FlagUDPListenPorts.Name, "10000:60000")
+	config.Current.SetDefault(config.FlagSTUNservers.Name, []string{})
+	config.Current.SetDefault(config.FlagKeepConnectedOnFail.Name, false)
+
+	router.DefaultRouter = noRoutes{}
+	p2p.RegisterContactUnserializer()
+	noop.Bootstrap()
+}
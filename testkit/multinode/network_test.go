@@ -0,0 +1,69 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package multinode
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mysteriumnetwork/node/core/connection/connectionstate"
+	"github.com/mysteriumnetwork/node/services/noop"
+)
+
+func TestNetwork_ConnectPayAndSettle(t *testing.T) {
+	if testing.Short() {
+		t.Skip("multi node flow is skipped in short mode")
+	}
+
+	network := NewNetwork(t)
+	provider := network.NewProvider()
+	consumer := network.NewConsumer()
+
+	_, err := provider.StartService(noop.ServiceType)
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		return len(network.Discovery.Proposals()) == 1
+	}, 10*time.Second, 50*time.Millisecond, "proposal was not announced")
+
+	require.NoError(t, consumer.Connect(provider, noop.ServiceType))
+	assert.Equal(t, connectionstate.Connected, consumer.Connection.Status().State)
+
+	require.Eventually(t, func() bool {
+		promise, err := provider.Promise()
+		return err == nil && promise.Promise.Amount.Sign() > 0 && network.Hermes.Revealed(promise.Promise.Hashlock)
+	}, 30*time.Second, 100*time.Millisecond, "provider was not paid")
+
+	require.NoError(t, consumer.Connection.Disconnect())
+
+	require.Eventually(t, func() bool {
+		promise, err := provider.Promise()
+		return err == nil && promise.Promise.Amount.Cmp(network.Hermes.Earned(provider.ID.Address)) == 0
+	}, 10*time.Second, 100*time.Millisecond, "provider promise does not match hermes earnings")
+
+	promise, err := provider.Promise()
+	require.NoError(t, err)
+
+	require.NoError(t, provider.Settle())
+	settled := network.Transactor.Settled()
+	require.Len(t, settled, 1)
+	assert.Equal(t, promise.Promise.Amount, settled[0].Amount)
+	assert.Equal(t, provider.ID.Address, settled[0].ProviderID)
+}
@@ -0,0 +1,311 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package multinode
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/mysteriumnetwork/payments/crypto"
+
+	"github.com/mysteriumnetwork/node/communication/nats"
+	"github.com/mysteriumnetwork/node/core/connection"
+	"github.com/mysteriumnetwork/node/core/discovery"
+	"github.com/mysteriumnetwork/node/core/discovery/apidiscovery"
+	"github.com/mysteriumnetwork/node/core/discovery/brokerdiscovery"
+	"github.com/mysteriumnetwork/node/core/discovery/proposal"
+	"github.com/mysteriumnetwork/node/core/ip"
+	"github.com/mysteriumnetwork/node/core/location"
+	"github.com/mysteriumnetwork/node/core/node/event"
+	"github.com/mysteriumnetwork/node/core/policy/localcopy"
+	"github.com/mysteriumnetwork/node/core/port"
+	"github.com/mysteriumnetwork/node/core/service"
+	"github.com/mysteriumnetwork/node/core/storage/boltdb"
+	"github.com/mysteriumnetwork/node/eventbus"
+	"github.com/mysteriumnetwork/node/identity"
+	"github.com/mysteriumnetwork/node/identity/registry"
+	"github.com/mysteriumnetwork/node/market"
+	"github.com/mysteriumnetwork/node/market/mysterium"
+	"github.com/mysteriumnetwork/node/p2p"
+	"github.com/mysteriumnetwork/node/requests"
+	"github.com/mysteriumnetwork/node/services/noop"
+	"github.com/mysteriumnetwork/node/session/connectivity"
+	"github.com/mysteriumnetwork/node/session/pingpong"
+)
+
+// Node holds the components shared by providers and consumers.
+type Node struct {
+	ID       identity.Identity
+	EventBus eventbus.EventBus
+
+	network    *Network
+	keystore   *identity.Keystore
+	signer     identity.SignerFactory
+	storage    *boltdb.Bolt
+	broker     nats.Connection
+	ipResolver ip.Resolver
+	httpClient *requests.HTTPClient
+}
+
+func (n *Network) newNode() *Node {
+	n.t.Helper()
+
+	dir := n.t.TempDir()
+
+	ks := identity.NewKeystoreFilesystem(dir, keystore.NewKeyStore(dir, keystore.LightScryptN, keystore.LightScryptP))
+	account, err := ks.NewAccount("")
+	if err != nil {
+		n.t.Fatalf("could not create identity: %v", err)
+	}
+	if err := ks.Unlock(account, ""); err != nil {
+		n.t.Fatalf("could not unlock identity: %v", err)
+	}
+
+	storage, err := boltdb.NewStorage(dir)
+	if err != nil {
+		n.t.Fatalf("could not open storage: %v", err)
+	}
+	n.t.Cleanup(func() { storage.Close() })
+
+	broker, err := connectBroker(n.Broker)
+	if err != nil {
+		n.t.Fatalf("could not connect to broker: %v", err)
+	}
+	n.t.Cleanup(broker.Close)
+
+	return &Node{
+		ID:         identity.FromAddress(account.Address.Hex()),
+		EventBus:   eventbus.New(),
+		network:    n,
+		keystore:   ks,
+		signer:     func(id identity.Identity) identity.Signer { return identity.NewSigner(ks, id) },
+		storage:    storage,
+		broker:     broker,
+		ipResolver: ip.NewResolverMock("127.0.0.1"),
+		httpClient: requests.NewHTTPClient("", 10*time.Second),
+	}
+}
+
+// Provider is a node offering services to consumers and collecting payments
+// through the network hermes.
+type Provider struct {
+	*Node
+
+	Services *service.Manager
+
+	promises   *pingpong.HermesPromiseStorage
+	transactor *registry.Transactor
+}
+
+// NewProvider boots a provider node with the noop service registered.
+func (n *Network) NewProvider() *Provider {
+	n.t.Helper()
+
+	node := n.newNode()
+	addressProvider := n.addressProvider()
+
+	promises := pingpong.NewHermesPromiseStorage(node.storage)
+	transactor := registry.NewTransactor(node.httpClient, n.Transactor.URL(), addressProvider, node.signer, node.EventBus, nil, time.Minute)
+
+	promiseHandler := pingpong.NewHermesPromiseHandler(pingpong.HermesPromiseHandlerDeps{
+		HermesPromiseStorage: promises,
+		FeeProvider:          transactor,
+		Encryption:           node.keystore,
+		EventBus:             node.EventBus,
+		HermesURLGetter:      hermesURL(n.Hermes.URL()),
+		HermesCallerFactory: func(url string) pingpong.HermesHTTPRequester {
+			return pingpong.NewHermesCaller(node.httpClient, url)
+		},
+		Signer: node.signer,
+		Chains: []int64{ChainID},
+	})
+	if err := promiseHandler.Subscribe(node.EventBus); err != nil {
+		n.t.Fatalf("could not subscribe promise handler: %v", err)
+	}
+
+	invoices := pingpong.NewProviderInvoiceStorage(pingpong.NewInvoiceStorage(node.storage))
+	sessions := service.NewSessionPool(node.EventBus)
+	pricer := fixedPrice{price: n.Price}
+
+	newSessionManager := func(instance *service.Instance, channel p2p.Channel) *service.SessionManager {
+		paymentEngineFactory := pingpong.InvoiceFactoryCreator(
+			channel, time.Second, time.Second,
+			pingpong.PromiseWaitTimeout, invoices,
+			pingpong.DefaultHermesFailureCount,
+			3000,
+			big.NewInt(3_000_000_000_000_000),
+			big.NewInt(30_000_000_000_000_000),
+			hermesStatus{},
+			node.EventBus,
+			promiseHandler,
+			addressProvider,
+			noObserver{},
+		)
+		return service.NewSessionManager(
+			instance,
+			sessions,
+			paymentEngineFactory,
+			node.EventBus,
+			channel,
+			service.DefaultConfig(),
+			pricer,
+		)
+	}
+
+	serviceRegistry := service.NewRegistry()
+	serviceRegistry.Register(noop.ServiceType, func(service.Options) (service.Service, error) {
+		return noop.NewManager(), nil
+	})
+
+	proposalRegistry := brokerdiscovery.NewRegistry(node.broker)
+	identityRegistry := &registry.FakeRegistry{RegistrationStatus: registry.Registered}
+
+	services := service.NewManager(
+		serviceRegistry,
+		func() service.Discovery {
+			return discovery.NewService(identityRegistry, proposalRegistry, time.Minute, node.signer, node.EventBus)
+		},
+		node.EventBus,
+		localcopy.NewOracle(node.httpClient, "", time.Hour, false),
+		localcopy.NewRepository(),
+		p2p.NewListener(node.broker, node.signer, identity.NewVerifierSigned(), node.ipResolver, node.EventBus, nil),
+		newSessionManager,
+		connectivity.NewStatusStorage(),
+		location.NewStaticResolver("DE", "Berlin", "residential", node.ipResolver),
+	)
+
+	node.EventBus.Publish(event.AppTopicNode, event.Payload{Status: event.StatusStarted})
+	n.t.Cleanup(func() {
+		services.Kill()
+		node.EventBus.Publish(event.AppTopicNode, event.Payload{Status: event.StatusStopped})
+	})
+
+	return &Provider{
+		Node:       node,
+		Services:   services,
+		promises:   promises,
+		transactor: transactor,
+	}
+}
+
+// StartService starts a service of the given type and announces its proposal.
+func (p *Provider) StartService(serviceType string) (service.ID, error) {
+	return p.Services.Start(p.ID, serviceType, nil, nil)
+}
+
+// Promise returns the latest promise the provider received from the network hermes.
+func (p *Provider) Promise() (pingpong.HermesPromise, error) {
+	channelID, err := crypto.GenerateProviderChannelID(p.ID.Address, p.network.Hermes.Address().Hex())
+	if err != nil {
+		return pingpong.HermesPromise{}, err
+	}
+
+	return p.promises.Get(ChainID, channelID)
+}
+
+// Settle submits the latest hermes promise for settlement to the transactor.
+func (p *Provider) Settle() error {
+	latest, err := p.Promise()
+	if err != nil {
+		return fmt.Errorf("could not get latest promise: %w", err)
+	}
+
+	promise := latest.Promise
+	promise.R, err = hex.DecodeString(latest.R)
+	if err != nil {
+		return fmt.Errorf("could not decode promise preimage: %w", err)
+	}
+
+	_, err = p.transactor.SettleAndRebalance(latest.HermesID.Hex(), p.ID.Address, promise)
+	return err
+}
+
+// Consumer is a node connecting to providers and paying for their services.
+type Consumer struct {
+	*Node
+
+	Connection connection.Manager
+
+	proposals *mysterium.MysteriumAPI
+}
+
+// NewConsumer boots a consumer node able to use the noop service.
+func (n *Network) NewConsumer() *Consumer {
+	n.t.Helper()
+
+	node := n.newNode()
+
+	connections := connection.NewRegistry()
+	connections.Register(noop.ServiceType, noop.NewConnection)
+
+	portRange, err := port.ParseRange("10000:60000")
+	if err != nil {
+		n.t.Fatalf("could not parse port range: %v", err)
+	}
+
+	verifierFactory := func(id identity.Identity) identity.Verifier {
+		return identity.NewVerifierIdentity(id)
+	}
+	brokerConnector := nats.NewBrokerConnector(requests.NewDialer("").DialContext, nil)
+
+	manager := connection.NewManager(
+		pingpong.ExchangeFactoryFunc(
+			node.keystore,
+			node.signer,
+			pingpong.NewConsumerTotalsStorage(node.EventBus),
+			n.addressProvider(),
+			node.EventBus,
+			0,
+		),
+		connections.CreateConnection,
+		node.EventBus,
+		node.ipResolver,
+		location.NewCache(location.NewStaticResolver("LT", "Vilnius", "residential", node.ipResolver), node.EventBus, time.Minute),
+		connection.DefaultConfig(),
+		time.Second,
+		anyBalance{},
+		p2p.NewDialer(brokerConnector, node.signer, verifierFactory, node.ipResolver, port.NewFixedRangePool(portRange), node.EventBus),
+		func() {},
+		func() {},
+		fixedPrice{price: n.Price},
+	)
+	n.t.Cleanup(func() { manager.Disconnect() })
+
+	return &Consumer{
+		Node:       node,
+		Connection: manager,
+		proposals:  mysterium.NewClient(node.httpClient, n.Discovery.URL()),
+	}
+}
+
+// Connect connects to the given provider service through the network hermes.
+func (c *Consumer) Connect(provider *Provider, serviceType string) error {
+	repository := apidiscovery.NewRepository(c.proposals)
+	lookup := func() (*proposal.PricedServiceProposal, error) {
+		p, err := repository.Proposal(market.ProposalID{ProviderID: provider.ID.Address, ServiceType: serviceType})
+		if err != nil {
+			return nil, err
+		}
+		return &proposal.PricedServiceProposal{ServiceProposal: *p, Price: c.network.Price}, nil
+	}
+
+	return c.Connection.Connect(c.ID, c.network.Hermes.Address(), lookup, connection.ConnectParams{})
+}
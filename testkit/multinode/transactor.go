@@ -0,0 +1,151 @@
+/*
+ * Copyright (C) 2026 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package multinode
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/mysteriumnetwork/payments/crypto"
+
+	"github.com/mysteriumnetwork/node/identity/registry"
+)
+
+// Transactor is an HTTP stand-in for the transactor. It quotes a fixed
+// settlement fee and accepts settlements of promises signed by the network
+// hermes, recording them instead of submitting transactions.
+type Transactor struct {
+	server *httptest.Server
+	fee    *big.Int
+	hermes common.Address
+
+	mu      sync.Mutex
+	settled []registry.PromiseSettlementRequest
+}
+
+// NewTransactor starts a transactor stand-in accepting promises of the given hermes.
+func NewTransactor(hermes common.Address, fee *big.Int) *Transactor {
+	t := &Transactor{
+		fee:    fee,
+		hermes: hermes,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/fee/", t.fees)
+	mux.HandleFunc("/identity/settle_and_rebalance", t.settle)
+	t.server = httptest.NewServer(mux)
+
+	return t
+}
+
+// URL returns the base URL of the transactor API.
+func (t *Transactor) URL() string {
+	return t.server.URL
+}
+
+// Settled returns the settlements accepted so far.
+func (t *Transactor) Settled() []registry.PromiseSettlementRequest {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return append([]registry.PromiseSettlementRequest(nil), t.settled...)
+}
+
+// Close stops the transactor API.
+func (t *Transactor) Close() {
+	t.server.Close()
+}
+
+func (t *Transactor) fees(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, registry.FeesResponse{
+		Fee:        t.fee,
+		ValidUntil: time.Now().Add(time.Hour),
+	})
+}
+
+func (t *Transactor) settle(w http.ResponseWriter, r *http.Request) {
+	var req registry.PromiseSettlementRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+		return
+	}
+
+	if err := t.validate(req); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+		return
+	}
+
+	t.mu.Lock()
+	t.settled = append(t.settled, req)
+	id := len(t.settled)
+	t.mu.Unlock()
+
+	writeJSON(w, http.StatusAccepted, registry.SettleResponse{ID: fmt.Sprintf("settle-%d", id)})
+}
+
+func (t *Transactor) validate(req registry.PromiseSettlementRequest) error {
+	if !strings.EqualFold(req.HermesID, t.hermes.Hex()) {
+		return fmt.Errorf("unknown hermes %s", req.HermesID)
+	}
+
+	expectedChannel, err := crypto.GenerateProviderChannelID(req.ProviderID, req.HermesID)
+	if err != nil {
+		return err
+	}
+	channelID, err := hex.DecodeString(strings.TrimPrefix(req.ChannelID, "0x"))
+	if err != nil {
+		return fmt.Errorf("invalid channel ID: %w", err)
+	}
+	if !bytes.Equal(channelID, common.FromHex(expectedChannel)) {
+		return fmt.Errorf("channel %s does not belong to provider %s", req.ChannelID, req.ProviderID)
+	}
+
+	preimage, err := hex.DecodeString(strings.TrimPrefix(req.Preimage, "0x"))
+	if err != nil {
+		return fmt.Errorf("invalid preimage: %w", err)
+	}
+	signature, err := hex.DecodeString(strings.TrimPrefix(req.Signature, "0x"))
+	if err != nil {
+		return fmt.Errorf("invalid signature: %w", err)
+	}
+
+	promise := crypto.Promise{
+		ChannelID: channelID,
+		ChainID:   req.ChainID,
+		Amount:    req.Amount,
+		Fee:       req.TransactorFee,
+		Hashlock:  ethcrypto.Keccak256(preimage),
+		R:         preimage,
+		Signature: signature,
+	}
+	if !promise.IsPromiseValid(t.hermes) {
+		return fmt.Errorf("promise is not signed by hermes %s", t.hermes.Hex())
+	}
+
+	return nil
+}